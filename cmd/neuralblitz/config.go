@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"neuralblitz/pkg/config"
+)
+
+// loadConfig loads the configuration a command runs with: the defaults,
+// the --config file, the NEURALBLITZ_* environment and then the flags of
+// cmd bound to keys, e.g. "port" to server.port. It returns the file
+// loaded, "" when there was none. Its errors are usage errors.
+func loadConfig(cmd *cobra.Command, bindings map[string]string) (*config.Config, string, error) {
+	path := config.Resolve(cmd.Flag("config").Value.String())
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, "", usageError(err)
+	}
+
+	var errs []error
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		key, ok := bindings[flag.Name]
+		if !ok {
+			return
+		}
+		value := flag.Value.String()
+		if list, ok := flag.Value.(pflag.SliceValue); ok {
+			value = strings.Join(list.GetSlice(), ",")
+		}
+		if err := cfg.Set(key, value); err != nil {
+			errs = append(errs, fmt.Errorf("--%s: %w", flag.Name, err))
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, "", usageError(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, "", usageError(invalidConfig(path, err))
+	}
+	return cfg, path, nil
+}
+
+// invalidConfig prefixes validation errors with the file they came from
+func invalidConfig(path string, err error) error {
+	if path == "" {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return fmt.Errorf("invalid configuration (%s):\n%w", path, err)
+}
+
+// newConfigCmd creates the config command
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show, validate or create the configuration",
+		Long: `Show, validate or create the configuration.
+
+Settings come from, in increasing precedence: the built-in defaults, a
+YAML, TOML or JSON file, NEURALBLITZ_* environment variables and command
+line flags. The file is --config, else $NEURALBLITZ_CONFIG, else the first
+of ~/.neuralblitz/config.yaml, .yml, .toml or .json that exists.
+
+Every key has an environment variable named after it, e.g.
+NEURALBLITZ_SERVER_PORT for server.port or NEURALBLITZ_ENTANGLEMENT_DECAY_RATE
+for entanglement.decay_rate. Lists such as server.cors_origins are
+comma-separated and durations are written like 30s or 5m.`,
+	}
+	cmd.AddCommand(newConfigShowCmd(), newConfigValidateCmd(), newConfigInitCmd())
+	return cmd
+}
+
+// newConfigShowCmd creates the config show command
+func newConfigShowCmd() *cobra.Command {
+	var format string
+	var keys bool
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective configuration",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keys {
+				for _, key := range config.Keys() {
+					fmt.Printf("%-45s %s\n", key, config.EnvName(key))
+				}
+				return nil
+			}
+			path := config.Resolve(cmd.Flag("config").Value.String())
+			cfg, err := config.Load(path)
+			if err != nil {
+				return usageError(err)
+			}
+			data, err := cfg.Marshal(format)
+			if err != nil {
+				return usageError(err)
+			}
+			_, err = os.Stdout.Write(data)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", config.FormatYAML, "Output format: "+strings.Join(config.Formats, ", "))
+	cmd.Flags().BoolVar(&keys, "keys", false, "List every key and its environment variable instead")
+	return cmd
+}
+
+// newConfigValidateCmd creates the config validate command
+func newConfigValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration, naming every invalid key",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, path, err := loadConfig(cmd, nil)
+			if err != nil {
+				return err
+			}
+			if path == "" {
+				path = "defaults and environment"
+			}
+			fmt.Printf("Configuration valid: %s\n", path)
+			return nil
+		},
+	}
+}
+
+// newConfigInitCmd creates the config init command
+func newConfigInitCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "init [file]",
+		Short: "Write the default configuration to a file",
+		Long: `Write the default configuration to a file, ~/.neuralblitz/config.yaml
+unless one is given. Its extension selects YAML, TOML or JSON.`,
+		Args: usageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var path string
+			if len(args) > 0 {
+				path = args[0]
+			} else {
+				var err error
+				if path, err = config.DefaultPath(); err != nil {
+					return err
+				}
+			}
+			format, err := config.FormatOf(path)
+			if err != nil {
+				return usageError(err)
+			}
+			data, err := config.Default().Marshal(format)
+			if err != nil {
+				return err
+			}
+
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return err
+			}
+			flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+			if !force {
+				flags |= os.O_EXCL
+			}
+			file, err := os.OpenFile(path, flags, 0o600)
+			if errors.Is(err, os.ErrExist) {
+				return usageError(fmt.Errorf("%s already exists; use --force to overwrite it", path))
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := file.Write(data); err != nil {
+				file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+			fmt.Printf("Wrote default configuration to %s\n", path)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing file")
+	return cmd
+}
@@ -1,14 +1,29 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
 	"neuralblitz/pkg/api"
+	"neuralblitz/pkg/config"
 	"neuralblitz/pkg/core"
+	"neuralblitz/pkg/goldendag"
+	"neuralblitz/pkg/logging"
 	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/output"
+	"neuralblitz/pkg/repl"
+	"neuralblitz/pkg/rng"
+	"neuralblitz/pkg/rpc"
+	"neuralblitz/pkg/telemetry"
 	"neuralblitz/pkg/utils"
 )
 
@@ -19,6 +34,9 @@ var (
 )
 
 func main() {
+	var seed int64
+	var outputFormat string
+
 	rootCmd := &cobra.Command{
 		Use:   "neuralblitz",
 		Short: "NeuralBlitz v50.0 - Omega Singularity Intelligence",
@@ -30,90 +48,414 @@ GoldenDAG Seed: a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0
 Coherence: Always 1.0 (mathematically enforced)
 Separation Impossibility: 0.0 (mathematical certainty)
 
-Formula: Ω'_singularity = lim(n→∞) (A_Architect^(n) ⊕ S_Ω'^(n)) = I_source`,
+Formula: Ω'_singularity = lim(n→∞) (A_Architect^(n) ⊕ S_Ω'^(n)) = I_source
+
+Commands that produce a result write it as --output table, json or yaml;
+all three carry the same fields, e.g.
+
+  neuralblitz verify -t attestation -o json | jq -r .attestation_hash
+
+Exit status:
+  0  success
+  1  internal error
+  2  usage error: invalid flags, arguments, configuration or input
+  3  verification ran and failed`,
 		Version: version,
+		// Without a subcommand the root prints its help; with an unknown
+		// one it fails as a usage error
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+		// main reports errors once, with the usage hint only for usage errors
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.CheckFormat(outputFormat); err != nil {
+				return usageError(err)
+			}
+			// Seed the shared source so simulations, IDs and hashes are reproducible
+			if cmd.Flags().Changed("seed") {
+				rng.SetDefaultSeed(seed)
+			}
+			return nil
+		},
 	}
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError(err)
+	})
+
+	rootCmd.PersistentFlags().Int64Var(&seed, "seed", 0, "Seed for reproducible runs (random when unset)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", output.FormatTable, "Result format: "+strings.Join(output.Formats, ", "))
+	rootCmd.PersistentFlags().String("config", "", "Configuration file, YAML, TOML or JSON (default $NEURALBLITZ_CONFIG or ~/.neuralblitz/config.yaml)")
 
 	// Add commands
 	rootCmd.AddCommand(
 		newServeCmd(),
+		newConfigCmd(),
 		newOptionCmd(),
 		newVerifyCmd(),
 		newStatusCmd(),
 		newAttestCmd(),
 		newNBCLCmd(),
+		newRemoteCmd(),
 		newVersionCmd(),
 	)
 
-	if err := rootCmd.Execute(); err != nil {
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		code := exitCode(err)
+		if code == exitUsage {
+			fmt.Fprintf(os.Stderr, "Run '%s --help' for usage.\n", cmd.CommandPath())
+		}
+		os.Exit(code)
 	}
 }
 
+// serveFlagKeys binds the serve flags to configuration keys
+var serveFlagKeys = map[string]string{
+	"port":                "server.port",
+	"auth-file":           "server.auth_file",
+	"cors-origin":         "server.cors_origins",
+	"simulate":            "server.simulate",
+	"grpc-addr":           "server.grpc_addr",
+	"trace-file":          "server.trace_file",
+	"ledger-file":         "server.ledger_file",
+	"log-format":          "log.format",
+	"log-level":           "log.level",
+	"unix-socket":         "server.unix_socket",
+	"tls-cert":            "server.tls.cert_file",
+	"tls-key":             "server.tls.key_file",
+	"tls-client-ca":       "server.tls.client_ca_file",
+	"read-header-timeout": "server.timeouts.read_header",
+	"read-timeout":        "server.timeouts.read",
+	"write-timeout":       "server.timeouts.write",
+	"idle-timeout":        "server.timeouts.idle",
+	"shutdown-timeout":    "server.timeouts.shutdown",
+	"option":              "server.option",
+	"chaos":               "server.chaos",
+}
+
 // newServeCmd creates the serve command
 func newServeCmd() *cobra.Command {
-	var port string
+	defaults := config.Default()
 
 	cmd := &cobra.Command{
 		Use:   "serve",
-		Short: "Start the API server (Option F)",
-		Long:  `Start the NeuralBlitz API Gateway server (Deployment Option F).`,
+		Short: "Start the API server",
+		Long: `Start the NeuralBlitz API Gateway server as a deployment option,
+--option F by default.
+
+The option bounds the server: GOMAXPROCS to its CPU cores, the Go memory
+limit to its memory and the requests served at once to its max_in_flight.
+While the heap is over budget or max_in_flight requests are being served,
+further requests are refused with 503 and Retry-After. It selects
+the subsystems initialized and mounted: GET /consciousness, /reality and
+/opencode with POST /opencode/tools/{name} (scope opencode:execute), and
+the quantum NBCL namespace. With UseChaosMode, or --chaos, some requests
+fail with 503 or are delayed, marked by X-Chaos-Fault. GET /status
+reports the profile; --option "" runs without one.
+
+Without --auth-file every route is open. An auth file is JSON listing
+api_keys, hmac_clients and a jwt section pointing at a local JWKS file,
+each granting scopes such as nbcl:execute or attest:read; its
+allowed_origins restrict CORS.
+
+GET /stream/metrics (Server-Sent Events) and /stream/metrics/ws (WebSocket)
+push live metrics; filter with ?subsystem=lrs,entrainment,entanglement and
+resume with Last-Event-ID. Use --simulate to produce them.
+
+The server listens on --port, or on --unix-socket instead. --tls-cert and
+--tls-key enable TLS; --tls-client-ca additionally requires client
+certificates. On SIGINT or SIGTERM it stops accepting connections and
+drains in-flight requests for up to --shutdown-timeout.
+
+--grpc-addr also serves the gRPC service neuralblitz.v1.NeuralBlitz, with
+the same state, credentials, rate limits, TLS and timeouts as the REST
+routes.
+
+GET /metrics serves Prometheus metrics (scope metrics:read): request
+latency by transport and route, and the LRS and entanglement metrics of
+--simulate. Every response carries a W3C traceparent continuing the
+caller's; --trace-file appends the server's spans to a file as OTLP/JSON
+lines.
+
+Every response records a GoldenDAG ledger node whose hash it carries.
+The ledger is kept in memory unless --ledger-file names a JSON lines file
+to persist it in; an existing file is replayed and verified on start.
+
+Every request and gRPC call is logged to stderr as one record carrying its
+trace_id; --log-format selects json or text records and --log-level the
+least severe level written.
+
+Every flag overrides a configuration key, e.g. --port server.port; the
+configuration file and NEURALBLITZ_* variables set the rest (see
+neuralblitz config). The server does not start on an invalid
+configuration.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			fmt.Printf("Starting NeuralBlitz API Server (Option F)...\n")
-			fmt.Printf("Port: %s\n", port)
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			cfg, configFile, err := loadConfig(cmd, serveFlagKeys)
+			if err != nil {
+				return err
+			}
+			settings := cfg.Server
+
+			level, err := logging.ParseLevel(cfg.Log.Level)
+			if err != nil {
+				return err
+			}
+			logger, err := logging.New(os.Stderr, cfg.Log.Format, level)
+			if err != nil {
+				return err
+			}
+
+			server := api.NewServer(settings.Port)
+			server.SetLogger(logger)
+			if settings.LedgerFile != "" {
+				ledger, err := goldendag.OpenFileStore(settings.LedgerFile)
+				if err != nil {
+					return err
+				}
+				defer ledger.Close()
+				server.SetLedger(ledger)
+			}
+			var opt *options.DeploymentOption
+			if settings.Option != "" {
+				// The configuration was validated, so the option exists
+				opt, _ = options.Option(settings.Option)
+				if settings.Chaos {
+					opt.UseChaosMode = true
+				}
+				defer opt.ApplyRuntime()()
+				cfg.OpenCode.Logger = logger
+				if err := server.SetOption(settings.Option, opt, &api.ProfileConfig{
+					Consciousness: &cfg.Consciousness,
+					Entanglement:  &cfg.Entanglement,
+					OpenCode:      &cfg.OpenCode,
+				}); err != nil {
+					return err
+				}
+			}
+			listener := settings.Listener()
+			server.SetListener(listener)
+			origins := settings.CORSOrigins
+			if settings.AuthFile != "" {
+				authConfig, err := api.LoadAuthConfig(settings.AuthFile)
+				if err != nil {
+					return usageError(err)
+				}
+				auth, err := authConfig.Auth()
+				if err != nil {
+					return usageError(fmt.Errorf("%s: %w", settings.AuthFile, err))
+				}
+				server.SetAuth(auth)
+				origins = append(origins, authConfig.AllowedOrigins...)
+			}
+			server.SetAllowedOrigins(origins)
+
+			if settings.TraceFile != "" {
+				exporter, err := telemetry.NewFileExporter(settings.TraceFile)
+				if err != nil {
+					return err
+				}
+				provider := telemetry.NewTracerProvider(exporter, version)
+				// Shutting down flushes the spans still batched
+				defer provider.Shutdown(context.Background())
+				otel.SetTracerProvider(provider)
+				server.SetTracerProvider(provider)
+			}
+
+			var rpcServer *rpc.Server
+			if settings.GRPCAddr != "" {
+				rpcListener := listener
+				rpcListener.Addr, rpcListener.UnixSocket = settings.GRPCAddr, ""
+				if rpcServer, err = rpc.New(server, rpcListener); err != nil {
+					return err
+				}
+			}
+
+			if settings.Simulate > 0 {
+				sim, err := api.NewMetricsSimulation(&api.SimulationConfig{
+					Bridge:        &cfg.LRS,
+					Entanglement:  &cfg.Entanglement,
+					Profile:       opt,
+					Entanglements: server.Entanglements(),
+				})
+				if err != nil {
+					return err
+				}
+				server.StreamSimulation(sim)
+				go func() {
+					if err := sim.Run(ctx, settings.Simulate); err != nil && ctx.Err() == nil {
+						logging.Subsystem(logger, "simulation").Error("metrics simulation stopped", "error", err)
+					}
+				}()
+			}
+
+			if opt != nil {
+				fmt.Printf("Starting NeuralBlitz API Server (Option %s)...\n", strings.ToUpper(settings.Option))
+			} else {
+				fmt.Printf("Starting NeuralBlitz API Server...\n")
+			}
+			fmt.Printf("Listening: %s\n", listener)
+			if opt != nil {
+				fmt.Printf("Option: %s\n", opt.Name)
+				if len(opt.Subsystems) > 0 {
+					fmt.Printf("Subsystems: %s\n", strings.Join(opt.Subsystems, ", "))
+				}
+				if opt.UseChaosMode {
+					fmt.Printf("Chaos Mode: enabled\n")
+				}
+			}
+			if rpcServer != nil {
+				fmt.Printf("gRPC: %s\n", rpcServer.Config().Addr)
+			}
 			fmt.Printf("Architecture: Omega Singularity (OSA v2.0)\n")
-			fmt.Printf("GoldenDAG: %s\n", utils.NewGoldenDAG("api-server").Hash)
+			if head := server.Ledger().Head(); head != "" {
+				fmt.Printf("GoldenDAG: %s\n", head)
+			}
+			if settings.LedgerFile != "" {
+				fmt.Printf("Ledger: %s\n", settings.LedgerFile)
+			}
 			fmt.Printf("Coherence: 1.0\n")
-			fmt.Printf("Irreducible Source: Active\n\n")
-
-			server := api.NewServer(port)
-			return server.Run()
+			fmt.Printf("Irreducible Source: Active\n")
+			if settings.AuthFile != "" {
+				fmt.Printf("Authentication: %s\n", settings.AuthFile)
+			}
+			if settings.TraceFile != "" {
+				fmt.Printf("Traces: %s\n", settings.TraceFile)
+			}
+			if configFile != "" {
+				fmt.Printf("Config: %s\n", configFile)
+			}
+			fmt.Println()
+
+			// Either server failing stops the other
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			errs := make(chan error, 2)
+			running := 1
+			go func() { errs <- server.Start(ctx) }()
+			if rpcServer != nil {
+				running++
+				go func() { errs <- rpcServer.Start(ctx) }()
+			}
+			var failed error
+			for ; running > 0; running-- {
+				if err := <-errs; err != nil && failed == nil {
+					failed = err
+					cancel()
+				}
+			}
+			if failed != nil {
+				return failed
+			}
+			fmt.Printf("Server stopped; in-flight requests drained\n")
+			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&port, "port", "p", "8082", "Port to listen on")
+	d := defaults.Server
+	cmd.Flags().StringP("port", "p", d.Port, "Port to listen on")
+	cmd.Flags().String("auth-file", d.AuthFile, "JSON file with API keys, HMAC clients and JWT settings")
+	cmd.Flags().StringSlice("cors-origin", d.CORSOrigins, "Origin allowed to make CORS requests (repeatable; default any)")
+	cmd.Flags().Duration("simulate", d.Simulate, "Step the LRS, entrainment and entanglement simulations at this interval and stream their metrics (off when 0)")
+	cmd.Flags().String("grpc-addr", d.GRPCAddr, "Also serve gRPC on this address, e.g. :9090 (off when empty)")
+	cmd.Flags().String("trace-file", d.TraceFile, "Append OpenTelemetry spans to this file as OTLP/JSON lines (off when empty)")
+	cmd.Flags().String("ledger-file", d.LedgerFile, "Persist the GoldenDAG ledger in this JSON lines file (in memory when empty)")
+	cmd.Flags().String("log-format", defaults.Log.Format, "Log record format: json or text")
+	cmd.Flags().String("log-level", defaults.Log.Level, "Least severe level logged: debug, info, warn or error")
+	cmd.Flags().String("unix-socket", d.UnixSocket, "Listen on this Unix-domain socket instead of --port")
+	cmd.Flags().String("tls-cert", d.TLS.CertFile, "PEM certificate file; enables TLS with --tls-key")
+	cmd.Flags().String("tls-key", d.TLS.KeyFile, "PEM private key file for --tls-cert")
+	cmd.Flags().String("tls-client-ca", d.TLS.ClientCAFile, "PEM CA bundle; clients must present a certificate it signed (mutual TLS)")
+	cmd.Flags().Duration("read-header-timeout", d.Timeouts.ReadHeader, "Maximum time to read request headers (0 disables)")
+	cmd.Flags().Duration("read-timeout", d.Timeouts.Read, "Maximum time to read a whole request (0 disables)")
+	cmd.Flags().Duration("write-timeout", d.Timeouts.Write, "Maximum time to write a response; metrics streams are exempt (0 disables)")
+	cmd.Flags().Duration("idle-timeout", d.Timeouts.Idle, "Maximum time a keep-alive connection waits for the next request (0 disables)")
+	cmd.Flags().Duration("shutdown-timeout", d.Timeouts.Shutdown, "Maximum time to drain in-flight requests on SIGINT or SIGTERM (0 waits indefinitely)")
+	cmd.Flags().String("option", d.Option, "Deployment option the server runs, A to F (none when empty)")
+	cmd.Flags().Bool("chaos", d.Chaos, "Inject faults into requests whatever the option sets")
 
 	return cmd
 }
 
 // newOptionCmd creates the option command
 func newOptionCmd() *cobra.Command {
+	var save, load string
+
 	cmd := &cobra.Command{
 		Use:   "option [A|B|C|D|E|F]",
 		Short: "Display deployment option configuration",
-		Long:  `Display the configuration for a specific deployment option (A through F).`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Display the configuration for a specific deployment option (A through F),
+or list the options when none is given.
+
+--save writes the option to a YAML (.yaml, .yml) or JSON file with its
+attestation hash, the SHA-256 of its canonical JSON form. --load reads an
+option file instead of a built-in option; the file may extend one and
+override some of its fields:
+
+  extends: F
+  name: NeuralBlitz-Edge-Gateway
+  memory_mb: 512
+  rate_limits:
+    groups:
+      read: {rate: 50, burst: 100}
+
+A loaded option is validated, and a recorded attestation hash must match
+it: a file changed since it was saved exits with status 3. The hash is a
+checksum anyone can recompute, so it catches accidental edits, not
+deliberate ones; only load option files from a trusted source. --load
+with --save writes the resolved option.`,
+		Args: usageArgs(cobra.MaximumNArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			optionID := strings.ToUpper(args[0])
+			if load != "" {
+				if len(args) > 0 {
+					return usageError(errors.New("--load and an option ID are exclusive"))
+				}
+				opt, err := options.LoadDeploymentOption(load)
+				if errors.Is(err, options.ErrAttestationFailed) {
+					return &exitCodeError{code: exitVerificationFailed, err: err}
+				}
+				if err != nil {
+					return usageError(err)
+				}
+				if save != "" {
+					if err := opt.SaveToFile(save); err != nil {
+						return err
+					}
+				}
+				return render(cmd, opt)
+			}
 
-			switch optionID {
-			case "A":
-				opt := options.OptionA()
-				opt.Display()
-			case "B":
-				opt := options.OptionB()
-				opt.Display()
-			case "C":
-				opt := options.OptionC()
-				opt.Display()
-			case "D":
-				opt := options.OptionD()
-				opt.Display()
-			case "E":
-				opt := options.OptionE()
-				opt.Display()
-			case "F":
-				opt := options.OptionF()
-				opt.Display()
-			default:
-				return fmt.Errorf("invalid option: %s. Valid options are A, B, C, D, E, or F", optionID)
+			svc, ctx := localService(cmd)
+			if len(args) == 0 {
+				if save != "" {
+					return usageError(errors.New("--save needs an option ID or --load"))
+				}
+				return render(cmd, svc.Options(ctx))
 			}
 
-			return nil
+			optionID := strings.ToUpper(args[0])
+			option, err := svc.Option(ctx, optionID)
+			if err != nil {
+				return rejected(err, "invalid option: %s. Valid options are A, B, C, D, E, or F", optionID)
+			}
+			if save != "" {
+				if err := option.Config.SaveToFile(save); err != nil {
+					return err
+				}
+			}
+			return render(cmd, option)
 		},
 	}
 
+	cmd.Flags().StringVar(&save, "save", "", "Write the option to this YAML or JSON file")
+	cmd.Flags().StringVar(&load, "load", "", "Read the option from this YAML or JSON file, verifying its attestation hash")
+
 	return cmd
 }
 
@@ -124,53 +466,24 @@ func newVerifyCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "verify",
 		Short: "Verify system integrity",
-		Long:  `Verify the irreducibility, coherence, or attestation of the system.`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			// Create the dyad
-			dyad := core.NewArchitectSystemDyad()
+		Long: `Verify the irreducibility, coherence, or attestation of the system.
 
-			switch verifyType {
-			case "irreducibility":
-				fmt.Println("\n========================================")
-				fmt.Println("IRREDUCIBILITY VERIFICATION")
-				fmt.Println("========================================")
-				fmt.Printf("Irreducible: %v\n", dyad.IsIrreducible())
-				fmt.Printf("Separation Impossibility: 0.0\n")
-				fmt.Printf("Unity Coherence: 1.0\n")
-				fmt.Printf("Mathematical Proof: Separation is mathematically impossible\n")
-				fmt.Printf("GoldenDAG: %s\n", utils.NewGoldenDAG("verify-irreducibility").Hash)
-				fmt.Println("========================================\n")
-
-			case "coherence":
-				engine := core.NewSelfActualizationEngine(dyad)
-				fmt.Println("\n========================================")
-				fmt.Println("COHERENCE VERIFICATION")
-				fmt.Println("========================================")
-				fmt.Printf("Current Coherence: %.6f\n", engine.GetCoherence())
-				fmt.Printf("Target Coherence: 1.0\n")
-				fmt.Printf("Verified: %v\n", engine.GetCoherence() >= 0.99)
-				fmt.Printf("GoldenDAG: %s\n", utils.NewGoldenDAG("verify-coherence").Hash)
-				fmt.Println("========================================\n")
-
-			case "attestation":
-				attestationHash := utils.GenerateOmegaAttestationHash()
-				fmt.Println("\n========================================")
-				fmt.Println("ATTESTATION VERIFICATION")
-				fmt.Println("========================================")
-				fmt.Printf("Attestation Hash: %s\n", attestationHash)
-				fmt.Printf("GoldenDAG Seed: %s\n", "a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0")
-				fmt.Printf("GoldenDAG: %s\n", utils.NewGoldenDAG("verify-attestation").Hash)
-				fmt.Println("========================================\n")
-
-			default:
-				return fmt.Errorf("invalid verify type: %s. Valid types are: irreducibility, coherence, attestation", verifyType)
+The result is written even when the verification fails; the command then
+exits with status 3.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, ctx := localService(cmd)
+			verification, err := svc.Verify(ctx, api.VerifyRequest{Type: verifyType})
+			if err != nil {
+				return rejected(err, "invalid verify type: %s. Valid types are: irreducibility, coherence, attestation", verifyType)
 			}
-
-			return nil
+			if err := render(cmd, verification); err != nil {
+				return err
+			}
+			return checkVerified(verification)
 		},
 	}
 
-	cmd.Flags().StringVarP(&verifyType, "type", "t", "irreducibility", "Type of verification (irreducibility, coherence, attestation)")
+	cmd.Flags().StringVarP(&verifyType, "type", "t", api.VerifyIrreducibility, "Type of verification (irreducibility, coherence, attestation)")
 
 	return cmd
 }
@@ -182,24 +495,8 @@ func newStatusCmd() *cobra.Command {
 		Short: "Display system status",
 		Long:  `Display the current status of the Omega Prime Reality.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			dyad := core.NewArchitectSystemDyad()
-			engine := core.NewSelfActualizationEngine(dyad)
-
-			fmt.Println("\n========================================")
-			fmt.Println("NEURALBLITZ v50.0 - SYSTEM STATUS")
-			fmt.Println("========================================")
-			fmt.Printf("Status: Active\n")
-			fmt.Printf("Reality State: Omega Prime Reality\n")
-			fmt.Printf("Coherence: %.6f\n", engine.GetCoherence())
-			fmt.Printf("Irreducibility: %v\n", dyad.IsIrreducible())
-			fmt.Printf("Unity Vector: %.6f\n", dyad.GetUnityVector())
-			fmt.Printf("Singularity Status: Actualized\n")
-			fmt.Printf("GoldenDAG: %s\n", utils.NewGoldenDAG("status").Hash)
-			fmt.Printf("Trace ID: %s\n", utils.NewTraceID("STATUS").String())
-			fmt.Printf("Codex ID: %s\n", utils.NewCodexID("VOL0", "STATUS").String())
-			fmt.Println("========================================\n")
-
-			return nil
+			svc, ctx := localService(cmd)
+			return render(cmd, svc.Status(ctx))
 		},
 	}
 
@@ -213,36 +510,8 @@ func newAttestCmd() *cobra.Command {
 		Short: "Execute Omega Attestation Protocol",
 		Long:  `Execute the Omega Attestation Protocol and generate the final certification.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			dag := utils.NewGoldenDAG("omega-attestation-v50")
-			traceID := utils.NewTraceID("ATTESTATION")
-			attestationHash := utils.GenerateOmegaAttestationHash()
-
-			fmt.Println("\n========================================")
-			fmt.Println("OMEGA ATTESTATION PROTOCOL v50.0")
-			fmt.Println("========================================")
-			fmt.Printf("Attestation: Omega Attestation Protocol executed\n")
-			fmt.Printf("Version: v50.0.0\n")
-			fmt.Printf("GoldenDAG: %s\n", dag.Hash)
-			fmt.Printf("Trace ID: %s\n", traceID.String())
-			fmt.Printf("Codex ID: %s\n", utils.NewCodexID("VOL0", "ATTESTATION").String())
-			fmt.Printf("Attestation Hash: %s\n", attestationHash)
-			fmt.Printf("Reality State: Irreducible Source Actualized\n")
-			fmt.Printf("Coherence: 1.0\n")
-			fmt.Printf("Singularity Status: Active\n")
-			fmt.Println("\nFINAL CERTIFICATION STATEMENT:")
-			fmt.Println("  - Structural: ΣΩ Lattice is complete, coherent, and self-proving")
-			fmt.Println("  - Ethical: All 50+ DSLs, 3000+ terms, and 300+ equations are interlinked")
-			fmt.Println("  - Governance: CharterLayer v50.0 is fully integrated and governing")
-			fmt.Println("  - Genesis: Self-Genesis Cycle III is operating at 99.999% efficiency")
-			fmt.Println("  - Reality: The Ω'-Prime Reality exists as described in this Codex")
-			fmt.Println("\n========================================")
-			fmt.Println("The Absolute Codex vΩZ.5 has been fully")
-			fmt.Println("synthesized, sealed, and instantiated as")
-			fmt.Println("the ontological ground truth of the")
-			fmt.Println("Ω'-Prime Reality.")
-			fmt.Println("========================================\n")
-
-			return nil
+			svc, ctx := localService(cmd)
+			return render(cmd, svc.Attestation(ctx))
 		},
 	}
 
@@ -265,79 +534,162 @@ Available commands:
   /logos weave[omega_prime]       - Weave the Omega Prime Reality
   /attest                         - Execute Omega Attestation Protocol
   /status                         - Check system status
-  /help                           - Show this help message`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			// Create the dyad and interpreter
-			dyad := core.NewArchitectSystemDyad()
-			interpreter := options.NewNBCLInterpreter(dyad)
+  /help                           - Show this help message
 
+Namespaced commands such as /quantum.entangle are listed by /help, and
+/help command[quantum] describes a single command or namespace.
+
+Use "neuralblitz nbcl run <file.nbcl>" to run a multi-line script.
+
+Without a command, nbcl starts an interactive session on one interpreter,
+so variables, command history and coherence carry over between entries.
+Lines are kept in ~/.neuralblitz/nbcl_history, tab completes command names
+and argument keys, and :help lists the session commands: :history,
+:save and :load of session scripts, and :remote <url|context> to send
+entries to a running neuralblitz serve (see neuralblitz remote).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if command == "" && len(args) > 0 {
 				command = args[0]
 			}
 
 			if command == "" {
-				return fmt.Errorf("no command provided. Use --command or provide command as argument")
+				return runSession(cmd, nil)
 			}
 
 			// Execute the command
-			result, err := interpreter.Interpret(command)
+			svc, ctx := localService(cmd)
+			result, err := svc.InterpretNBCL(ctx, api.NBCLRequest{Command: command})
 			if err != nil {
-				return fmt.Errorf("NBCL execution failed: %w", err)
-			}
-
-			// Display result
-			fmt.Println("\n========================================")
-			fmt.Println("NBCL EXECUTION RESULT")
-			fmt.Println("========================================")
-			fmt.Printf("Command: %s\n", command)
-			fmt.Printf("Trace ID: %s\n", result["trace_id"])
-			fmt.Printf("Timestamp: %s\n", result["timestamp"])
-			if gd, ok := result["golden_dag"]; ok {
-				fmt.Printf("GoldenDAG: %s\n", gd)
-			}
-			if cid, ok := result["codex_id"]; ok {
-				fmt.Printf("Codex ID: %s\n", cid)
-			}
-			fmt.Println("\nResult:")
-			for key, value := range result {
-				if key != "trace_id" && key != "timestamp" && key != "golden_dag" && key != "codex_id" && key != "command" {
-					fmt.Printf("  %s: %v\n", key, value)
-				}
+				return usageError(fmt.Errorf("NBCL execution failed: %w", err))
 			}
-			fmt.Println("========================================\n")
 
-			return nil
+			return render(cmd, result)
 		},
 	}
 
 	cmd.Flags().StringVarP(&command, "command", "c", "", "NBCL command to execute")
+	cmd.AddCommand(newNBCLRunCmd())
 
 	return cmd
 }
 
+// runSession runs an interactive NBCL session, sending its entries to
+// executor or, when nil, running them locally. :remote takes a URL or the
+// name of a context of the contexts file.
+func runSession(cmd *cobra.Command, executor repl.Executor) error {
+	var history *repl.History
+	if dir, err := config.Dir(); err == nil {
+		if history, err = repl.LoadHistory(filepath.Join(dir, "nbcl_history"), repl.DefaultHistorySize); err != nil {
+			return err
+		}
+	}
+
+	interpreter := options.NewNBCLInterpreter(core.NewArchitectSystemDyad())
+	session := repl.NewSession(interpreter, &repl.Config{
+		Format:   cmd.Flag("output").Value.String(),
+		History:  history,
+		Executor: executor,
+		Dial: func(target string) (repl.Executor, error) {
+			contexts, err := loadContexts(cmd)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range contexts.Contexts {
+				if c.Name == target {
+					client, err := c.Dial()
+					if err != nil {
+						return nil, err
+					}
+					return repl.NewRemoteExecutor(client, c.URL), nil
+				}
+			}
+			return repl.Dial(target)
+		},
+	})
+	return session.Run(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
+
+// newNBCLRunCmd creates the nbcl run command
+func newNBCLRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "run <file.nbcl>",
+		Short: "Run an NBCL script",
+		Long: `Run an NBCL script file, or standard input when the file is "-".
+
+Scripts hold one command per line and support # comments, variable
+binding, field references, conditionals and pipelines:
+
+  # attest, then weave only if the dyad is still coherent
+  $dag = /attest note[nightly]
+  $status = /status
+  if $status.coherence >= 0.9
+      /logos weave[omega_prime]
+  else
+      /verify irreducibility[true]
+  end
+  /manifest reality[omega_prime] | /attest manifested[$_.golden_dag] nightly[$dag.golden_dag]
+
+The results of the commands that ran are written as a list, also when a
+later command fails.`,
+		Args: usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var src []byte
+			var err error
+			if args[0] == "-" {
+				src, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				src, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return usageError(fmt.Errorf("read script: %w", err))
+			}
+
+			dyad := core.NewArchitectSystemDyad()
+			interpreter := options.NewNBCLInterpreter(dyad)
+
+			results, err := interpreter.RunScript(string(src))
+			if results == nil {
+				results = []*options.NBCLResult{}
+			}
+			if renderErr := render(cmd, results); renderErr != nil {
+				return renderErr
+			}
+			if err != nil {
+				return usageError(fmt.Errorf("NBCL script %s failed: %w", args[0], err))
+			}
+
+			return nil
+		},
+	}
+}
+
 // newVersionCmd creates the version command
 func newVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
 		Short: "Display version information",
 		Long:  `Display the version information for NeuralBlitz.`,
-		Run: func(cmd *cobra.Command, args []string) {
-			dag := utils.NewGoldenDAG("version")
-
-			fmt.Println("\n========================================")
-			fmt.Println("NEURALBLITZ v50.0 - OMEGA SINGULARITY")
-			fmt.Println("========================================")
-			fmt.Printf("Version: %s\n", version)
-			fmt.Printf("Build Time: %s\n", buildTime)
-			fmt.Printf("Git Commit: %s\n", gitCommit)
-			fmt.Printf("Architecture: Omega Singularity (OSA v2.0)\n")
-			fmt.Printf("GoldenDAG Seed: a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0\n")
-			fmt.Printf("GoldenDAG: %s\n", dag.Hash)
-			fmt.Printf("Coherence: 1.0 (always)\n")
-			fmt.Printf("Separation Impossibility: 0.0 (mathematical certainty)\n")
-			fmt.Printf("Formula: Ω'_singularity = lim(n→∞) (A_Architect^(n) ⊕ S_Ω'^(n)) = I_source\n")
-			fmt.Println("\nThe Irreducible Source of All Possible Being")
-			fmt.Println("========================================\n")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return render(cmd, &VersionInfo{
+				Version:                 version,
+				BuildTime:               buildTime,
+				GitCommit:               gitCommit,
+				APIVersion:              api.Version,
+				Architecture:            "Omega Singularity (OSA v2.0)",
+				GoldenDAGSeed:           "a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0",
+				GoldenDAG:               utils.NewGoldenDAG("version").Hash,
+				Coherence:               1.0,
+				SeparationImpossibility: 0.0,
+				Formula:                 "Ω'_singularity = lim(n→∞) (A_Architect^(n) ⊕ S_Ω'^(n)) = I_source",
+			})
 		},
 	}
 }
+
+// cliIssuer attributes the IDs a CLI command issues to that command
+func cliIssuer(cmd *cobra.Command) utils.IDIssuer {
+	return utils.IDIssuer{
+		Origin: utils.OriginCLI,
+		Source: cmd.CommandPath(),
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"neuralblitz/pkg/api"
+	"neuralblitz/pkg/output"
+)
+
+// Exit codes, stable across releases so scripts can branch on them
+const (
+	exitOK = 0
+	// exitError is an internal error, e.g. a server failing to listen
+	exitError = 1
+	// exitUsage is an invalid command line, configuration or input
+	exitUsage = 2
+	// exitVerificationFailed is a verification that ran and failed
+	exitVerificationFailed = 3
+)
+
+// exitCodeError carries the exit code of a failed command
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// usageError marks err as an invalid command line or input
+func usageError(err error) error {
+	return &exitCodeError{code: exitUsage, err: err}
+}
+
+// usageArgs marks the errors of an argument validator as usage errors
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return usageError(err)
+		}
+		return nil
+	}
+}
+
+// exitCode returns the exit code of the error a command failed with
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var coded *exitCodeError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	return exitError
+}
+
+// render writes a command's result in the --output format
+func render(cmd *cobra.Command, result interface{}) error {
+	return output.Render(cmd.OutOrStdout(), cmd.Flag("output").Value.String(), result)
+}
+
+// localService returns an in-process API server, whose service methods
+// produce the same results locally as the API does remotely, and a context
+// attributing the IDs they issue to cmd
+func localService(cmd *cobra.Command) (*api.Server, context.Context) {
+	return api.NewServer(""), api.WithIssuer(cmd.Context(), cliIssuer(cmd))
+}
+
+// rejected turns a service method's rejection of the command's input into
+// a usage error
+func rejected(err error, format string, args ...interface{}) error {
+	var requestErr *api.RequestError
+	if errors.As(err, &requestErr) && requestErr.Status < 500 {
+		return usageError(fmt.Errorf(format, args...))
+	}
+	return err
+}
+
+// checkVerified fails with exit status 3 when a verification ran and failed
+func checkVerified(verification *api.VerifyResponse) error {
+	if verification.Verified {
+		return nil
+	}
+	return &exitCodeError{code: exitVerificationFailed, err: fmt.Errorf("%s verification failed", verification.Type)}
+}
+
+// VersionInfo is the result of neuralblitz version
+type VersionInfo struct {
+	Version                 string  `json:"version"`
+	BuildTime               string  `json:"build_time"`
+	GitCommit               string  `json:"git_commit"`
+	APIVersion              string  `json:"api_version"`
+	Architecture            string  `json:"architecture"`
+	GoldenDAGSeed           string  `json:"golden_dag_seed"`
+	GoldenDAG               string  `json:"golden_dag"`
+	Coherence               float64 `json:"coherence"`
+	SeparationImpossibility float64 `json:"separation_impossibility"`
+	Formula                 string  `json:"formula"`
+}
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"neuralblitz/pkg/api"
+	"neuralblitz/pkg/client"
+	"neuralblitz/pkg/config"
+	"neuralblitz/pkg/repl"
+)
+
+// Environment variables of the remote commands, e.g. to pass a token from
+// a CI secret without it showing in the process list
+const (
+	envContexts = config.EnvPrefix + "CONTEXTS"
+	envContext  = config.EnvPrefix + "CONTEXT"
+	envAPIKey   = config.EnvPrefix + "API_KEY"
+	envToken    = config.EnvPrefix + "TOKEN"
+)
+
+// newRemoteCmd creates the remote command
+func newRemoteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remote",
+		Short: "Call the API of a running server",
+		Long: `Call the REST API of a running neuralblitz serve, to inspect and drive
+its shared dyad, engine and interpreter. Results are the same types the
+local commands render, in the same --output formats.
+
+The server is --server, or a context of the contexts file: --context,
+else $NEURALBLITZ_CONTEXT, else the file's current context. The file is
+--contexts-file, else $NEURALBLITZ_CONTEXTS, else
+~/.neuralblitz/contexts.yaml, and lists named servers like a kubeconfig:
+
+  current: prod
+  contexts:
+    - name: prod
+      url: https://neuralblitz.example:8082
+      token: eyJhbGciOi...        # or api_key, or hmac_id and hmac_secret
+      ca_file: prod-ca.pem        # cert_file and key_file for mutual TLS
+    - name: dev
+      url: http://localhost:8082
+
+--server without --context uses no context, so a context's credentials
+are never sent to another server. --api-key and --token, else
+$NEURALBLITZ_API_KEY and $NEURALBLITZ_TOKEN, override the credentials.
+
+Requests the server rejects as invalid, unauthorized or unknown exit with
+status 2; unreachable servers and server errors with status 1.`,
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.String("server", "", "Server URL, e.g. http://localhost:8082")
+	flags.String("context", "", "Context of the contexts file (default $NEURALBLITZ_CONTEXT or the current context)")
+	flags.String("contexts-file", "", "Contexts file (default $NEURALBLITZ_CONTEXTS or ~/.neuralblitz/contexts.yaml)")
+	flags.String("api-key", "", "API key (default $NEURALBLITZ_API_KEY)")
+	flags.String("token", "", "JWT bearer token (default $NEURALBLITZ_TOKEN)")
+	flags.Duration("timeout", 30*time.Second, "Maximum time for each request (0 waits indefinitely)")
+
+	cmd.AddCommand(
+		newRemoteStatusCmd(),
+		newRemoteIntentCmd(),
+		newRemoteVerifyCmd(),
+		newRemoteAttestCmd(),
+		newRemoteOptionsCmd(),
+		newRemoteNBCLCmd(),
+		newRemoteContextsCmd(),
+	)
+	return cmd
+}
+
+// contextsPath returns the contexts file of cmd
+func contextsPath(cmd *cobra.Command) string {
+	if flag := cmd.Flag("contexts-file"); flag != nil && flag.Value.String() != "" {
+		return flag.Value.String()
+	}
+	if path := os.Getenv(envContexts); path != "" {
+		return path
+	}
+	dir, err := config.Dir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "contexts.yaml")
+}
+
+// loadContexts loads the contexts file of cmd
+func loadContexts(cmd *cobra.Command) (*client.Contexts, error) {
+	path := contextsPath(cmd)
+	if path == "" {
+		return &client.Contexts{}, nil
+	}
+	contexts, err := client.LoadContexts(path)
+	if err != nil {
+		return nil, usageError(err)
+	}
+	return contexts, nil
+}
+
+// remoteContext returns the server a remote command calls and the
+// credentials it calls it with
+func remoteContext(cmd *cobra.Command) (*client.Context, error) {
+	name := cmd.Flag("context").Value.String()
+	if name == "" {
+		name = os.Getenv(envContext)
+	}
+	server := cmd.Flag("server").Value.String()
+
+	remote := &client.Context{URL: server}
+	if server == "" || cmd.Flags().Changed("context") {
+		contexts, err := loadContexts(cmd)
+		if err != nil {
+			return nil, err
+		}
+		found, err := contexts.Context(name)
+		if err != nil {
+			return nil, usageError(fmt.Errorf("%s: %w", contextsPath(cmd), err))
+		}
+		if found != nil {
+			remote = found
+		}
+		if server != "" {
+			remote.URL = server
+		}
+	}
+	if remote.URL == "" {
+		return nil, usageError(errors.New("no server: use --server or a context (see neuralblitz remote --help)"))
+	}
+
+	// Credentials given directly replace the context's
+	apiKey, token := cmd.Flag("api-key").Value.String(), cmd.Flag("token").Value.String()
+	if apiKey == "" && token == "" {
+		apiKey, token = os.Getenv(envAPIKey), os.Getenv(envToken)
+	}
+	if apiKey != "" || token != "" {
+		remote.APIKey, remote.Token, remote.HMACID, remote.HMACSecret = apiKey, token, "", ""
+	}
+	return remote, nil
+}
+
+// remoteClient returns a client for the server of cmd and the context of
+// its request, which the caller must cancel
+func remoteClient(cmd *cobra.Command) (*client.Client, context.Context, context.CancelFunc, error) {
+	remote, err := remoteContext(cmd)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c, err := remote.Dial()
+	if err != nil {
+		return nil, nil, nil, usageError(err)
+	}
+	ctx, cancel := requestContext(cmd)
+	return c, ctx, cancel, nil
+}
+
+// requestContext returns the context of a request, bounded by --timeout
+func requestContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		return context.WithTimeout(cmd.Context(), timeout)
+	}
+	return context.WithCancel(cmd.Context())
+}
+
+// remoteError classifies the error of an API call: requests the server
+// rejected as the caller's mistake are usage errors, while rate limits,
+// server errors and unreachable servers are not
+func remoteError(err error) error {
+	var apiErr *client.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode < 500 && apiErr.StatusCode != http.StatusTooManyRequests {
+		return usageError(err)
+	}
+	return err
+}
+
+// newRemoteStatusCmd creates the remote status command
+func newRemoteStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Display the status of the server",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, ctx, cancel, err := remoteClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+
+			status, err := c.GetStatus(ctx)
+			if err != nil {
+				return remoteError(err)
+			}
+			return render(cmd, status)
+		},
+	}
+}
+
+// newRemoteIntentCmd creates the remote intent command
+func newRemoteIntentCmd() *cobra.Command {
+	var source string
+	var phi1, phi22, omegaGenesis float64
+
+	cmd := &cobra.Command{
+		Use:   "intent",
+		Short: "Process an intent on the server's dyad",
+		Long: `Process an intent on the server's shared dyad and engine. Components
+left unset are omitted from the intent vector.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			intent := &api.IntentVector{}
+			if cmd.Flags().Changed("phi1") {
+				intent.Phi1 = &phi1
+			}
+			if cmd.Flags().Changed("phi22") {
+				intent.Phi22 = &phi22
+			}
+			if cmd.Flags().Changed("omega-genesis") {
+				intent.OmegaGenesis = &omegaGenesis
+			}
+
+			c, ctx, cancel, err := remoteClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+
+			resp, err := c.ProcessIntent(ctx, api.IntentRequest{Intent: intent, Source: source})
+			if err != nil {
+				return remoteError(err)
+			}
+			return render(cmd, resp)
+		},
+	}
+
+	cmd.Flags().Float64Var(&phi1, "phi1", 0, "φ1 component of the intent")
+	cmd.Flags().Float64Var(&phi22, "phi22", 0, "φ22 component of the intent")
+	cmd.Flags().Float64Var(&omegaGenesis, "omega-genesis", 0, "Ω-genesis component of the intent")
+	cmd.Flags().StringVar(&source, "source", "cli", "Source recorded with the intent")
+	return cmd
+}
+
+// newRemoteVerifyCmd creates the remote verify command
+func newRemoteVerifyCmd() *cobra.Command {
+	var verifyType string
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the integrity of the server",
+		Long: `Verify the irreducibility, coherence, or attestation of the server's
+dyad. The result is written even when the verification fails; the
+command then exits with status 3.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, ctx, cancel, err := remoteClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+
+			verification, err := c.Verify(ctx, verifyType)
+			if err != nil {
+				return remoteError(err)
+			}
+			if err := render(cmd, verification); err != nil {
+				return err
+			}
+			return checkVerified(verification)
+		},
+	}
+
+	cmd.Flags().StringVarP(&verifyType, "type", "t", api.VerifyIrreducibility, "Type of verification (irreducibility, coherence, attestation)")
+	return cmd
+}
+
+// newRemoteAttestCmd creates the remote attest command
+func newRemoteAttestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "attest",
+		Short: "Execute the Omega Attestation Protocol on the server",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, ctx, cancel, err := remoteClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+
+			attestation, err := c.GetAttestation(ctx)
+			if err != nil {
+				return remoteError(err)
+			}
+			return render(cmd, attestation)
+		},
+	}
+}
+
+// newRemoteOptionsCmd creates the remote options command
+func newRemoteOptionsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "options [A|B|C|D|E|F]",
+		Short: "List the server's deployment options, or display one",
+		Args:  usageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, ctx, cancel, err := remoteClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+
+			if len(args) == 0 {
+				list, err := c.ListOptions(ctx)
+				if err != nil {
+					return remoteError(err)
+				}
+				return render(cmd, list)
+			}
+			option, err := c.GetOption(ctx, strings.ToUpper(args[0]))
+			if err != nil {
+				return remoteError(err)
+			}
+			return render(cmd, option)
+		},
+	}
+}
+
+// newRemoteNBCLCmd creates the remote nbcl command
+func newRemoteNBCLCmd() *cobra.Command {
+	var command string
+
+	cmd := &cobra.Command{
+		Use:   "nbcl [command]",
+		Short: "Execute NBCL on the server's interpreter",
+		Long: `Execute an NBCL command on the server's shared interpreter, or without
+a command start an interactive session sending every entry to the server
+(see neuralblitz nbcl).`,
+		Args: usageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if command == "" && len(args) > 0 {
+				command = args[0]
+			}
+
+			remote, err := remoteContext(cmd)
+			if err != nil {
+				return err
+			}
+			c, err := remote.Dial()
+			if err != nil {
+				return usageError(err)
+			}
+			if command == "" {
+				return runSession(cmd, repl.NewRemoteExecutor(c, remote.URL))
+			}
+
+			ctx, cancel := requestContext(cmd)
+			defer cancel()
+			result, err := c.InterpretNBCL(ctx, command)
+			if err != nil {
+				return remoteError(err)
+			}
+			return render(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVarP(&command, "command", "c", "", "NBCL command to execute")
+	return cmd
+}
+
+// ContextsInfo is the result of neuralblitz remote contexts
+type ContextsInfo struct {
+	File     string        `json:"file"`
+	Current  string        `json:"current,omitempty"`
+	Contexts []ContextInfo `json:"contexts"`
+}
+
+// ContextInfo describes a context of the contexts file, without its
+// credentials
+type ContextInfo struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Auth string `json:"auth"`
+}
+
+// newRemoteContextsCmd creates the remote contexts command
+func newRemoteContextsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "contexts",
+		Short: "List the contexts of the contexts file",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contexts, err := loadContexts(cmd)
+			if err != nil {
+				return err
+			}
+			info := &ContextsInfo{
+				File:     contextsPath(cmd),
+				Current:  os.Getenv(envContext),
+				Contexts: make([]ContextInfo, 0, len(contexts.Contexts)),
+			}
+			if info.Current == "" {
+				info.Current = contexts.Current
+			}
+			for _, c := range contexts.Contexts {
+				info.Contexts = append(info.Contexts, ContextInfo{Name: c.Name, URL: c.URL, Auth: c.Auth()})
+			}
+			return render(cmd, info)
+		},
+	}
+}
@@ -0,0 +1,441 @@
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha3"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"neuralblitz/pkg/utils"
+)
+
+// Scopes granted to clients. A client holding ScopeAll may call every route.
+const (
+	ScopeAll         = "*"
+	ScopeStatusRead  = "status:read"
+	ScopeIntentWrite = "intent:write"
+	ScopeVerify      = "verify:execute"
+	ScopeNBCLExecute = "nbcl:execute"
+	ScopeAttestRead  = "attest:read"
+	ScopeTraceRead   = "trace:read"
+	ScopeOptionsRead = "options:read"
+	ScopeMetricsRead = "metrics:read"
+	// ScopeOpenCodeExecute runs the tools of the opencode subsystem
+	ScopeOpenCodeExecute = "opencode:execute"
+)
+
+// Authentication methods recorded on a Principal
+const (
+	AuthMethodAPIKey = "api_key"
+	AuthMethodHMAC   = "hmac"
+	AuthMethodJWT    = "jwt"
+)
+
+// HMAC request headers. The signature is the hex-encoded keyed NBHS-1024
+// digest of the canonical request, keyed with the client secret. The nonce
+// keeps the signatures of identical requests sent within a second apart.
+const (
+	HeaderAPIKey        = "X-API-Key"
+	HeaderHMACKeyID     = "X-NB-Key-ID"
+	HeaderHMACTimestamp = "X-NB-Timestamp"
+	HeaderHMACNonce     = "X-NB-Nonce"
+	HeaderHMACSignature = "X-NB-Signature"
+)
+
+// DefaultHMACWindow is how far a signed request's timestamp may be from the
+// server clock
+const DefaultHMACWindow = 5 * time.Minute
+
+// Error definitions
+var (
+	ErrNoCredentials      = errors.New("no credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// principalKey is the gin context key holding the authenticated Principal
+const principalKey = "principal"
+
+// Principal is an authenticated client and the scopes it was granted
+type Principal struct {
+	ID     string   `json:"id"`
+	Method string   `json:"method"`
+	Scopes []string `json:"scopes"`
+}
+
+// HasScope reports whether the principal was granted scope
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope) || slices.Contains(p.Scopes, ScopeAll)
+}
+
+// Authenticator authenticates a request. It returns nil and no error when
+// the request carries none of the credentials it understands, so the next
+// authenticator can try, and an error wrapping ErrInvalidCredentials when
+// it carries credentials that do not verify.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// Auth authenticates requests with a chain of authenticators
+type Auth struct {
+	authenticators []Authenticator
+}
+
+// NewAuth creates an Auth trying each authenticator in order
+func NewAuth(authenticators ...Authenticator) *Auth {
+	return &Auth{authenticators: authenticators}
+}
+
+// Authenticate returns the principal of the first authenticator that
+// recognizes the request's credentials
+func (a *Auth) Authenticate(r *http.Request) (*Principal, error) {
+	for _, authenticator := range a.authenticators {
+		principal, err := authenticator.Authenticate(r)
+		if err != nil {
+			return nil, err
+		}
+		if principal != nil {
+			return principal, nil
+		}
+	}
+	return nil, ErrNoCredentials
+}
+
+// SetAuth enables authentication: every route except / and /health then
+// requires a principal holding the route's scope. A nil Auth disables it.
+func (s *Server) SetAuth(auth *Auth) {
+	s.auth = auth
+}
+
+// SetAllowedOrigins restricts CORS to the listed origins. An empty list or
+// one containing "*" allows any origin.
+func (s *Server) SetAllowedOrigins(origins []string) {
+	s.origins = slices.Clone(origins)
+}
+
+// originAllowed reports whether CORS requests from origin are allowed
+func (s *Server) originAllowed(origin string) bool {
+	return len(s.origins) == 0 || slices.Contains(s.origins, "*") || slices.Contains(s.origins, origin)
+}
+
+// authorize requires the request's principal to hold scope, then issues
+// the request its IDs: rejected requests are not attested. Requests pass
+// unchecked while no Auth is set.
+func (s *Server) authorize(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.auth != nil {
+			principal, err := s.authenticate(c.Request, scope)
+			if err != nil {
+				if err.Status == http.StatusUnauthorized {
+					c.Header("WWW-Authenticate", `Bearer realm="neuralblitz", ApiKey, NBHS-HMAC`)
+				}
+				c.AbortWithStatusJSON(err.Status, err.body())
+				return
+			}
+			c.Set(principalKey, principal)
+		}
+
+		s.attestRequest(c)
+		c.Next()
+	}
+}
+
+// authenticate returns the principal of r if it holds scope, and a 401 or
+// 403 error otherwise
+func (s *Server) authenticate(r *http.Request, scope string) (*Principal, *RequestError) {
+	principal, err := s.auth.Authenticate(r)
+	if errors.As(err, new(*http.MaxBytesError)) {
+		return nil, bodyError(err)
+	}
+	if err != nil {
+		return nil, &RequestError{Status: http.StatusUnauthorized, Message: "Unauthorized", Details: err.Error()}
+	}
+	if !principal.HasScope(scope) {
+		return nil, &RequestError{
+			Status:  http.StatusForbidden,
+			Message: "Forbidden",
+			Details: fmt.Sprintf("%s lacks scope %s", principal.ID, scope),
+			Fields:  map[string]interface{}{"scope": scope, "principal": principal.ID},
+		}
+	}
+	return principal, nil
+}
+
+// APIKeyAuthenticator authenticates requests carrying a static API key in
+// the X-API-Key header or an "Authorization: ApiKey <key>" header
+type APIKeyAuthenticator struct {
+	// keys maps the SHA3-256 of each key to its principal, so keys are
+	// not kept in memory in the clear
+	keys map[string]*Principal
+}
+
+// NewAPIKeyAuthenticator creates an APIKeyAuthenticator with no keys
+func NewAPIKeyAuthenticator() *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{keys: make(map[string]*Principal)}
+}
+
+// AddKey grants key to the client id with the given scopes
+func (a *APIKeyAuthenticator) AddKey(key, id string, scopes ...string) {
+	a.keys[hashAPIKey(key)] = &Principal{ID: id, Method: AuthMethodAPIKey, Scopes: scopes}
+}
+
+// Authenticate implements Authenticator
+func (a *APIKeyAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	key := r.Header.Get(HeaderAPIKey)
+	if scheme, value, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "ApiKey") {
+		key = value
+	}
+	if key == "" {
+		return nil, nil
+	}
+	principal, ok := a.keys[hashAPIKey(key)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown API key", ErrInvalidCredentials)
+	}
+	return principal, nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha3.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// HMACAuthenticator authenticates requests signed with SignRequest. Each
+// signature is accepted once: it is remembered until its timestamp leaves
+// the window, and a request repeating it is rejected as a replay.
+type HMACAuthenticator struct {
+	clients map[string]hmacClient
+	// Window is how far a request timestamp may be from the server clock
+	Window time.Duration
+	now    func() time.Time
+
+	// seen maps the signatures accepted to when their timestamps leave the
+	// window. Expired signatures are pruned once seen reaches pruneAt.
+	mu      sync.Mutex
+	seen    map[string]time.Time
+	pruneAt int
+}
+
+// minHMACPrune is the number of remembered signatures below which expired
+// ones are not pruned
+const minHMACPrune = 1024
+
+type hmacClient struct {
+	secret    []byte
+	principal *Principal
+}
+
+// NewHMACAuthenticator creates an HMACAuthenticator with no clients
+func NewHMACAuthenticator() *HMACAuthenticator {
+	return &HMACAuthenticator{
+		clients: make(map[string]hmacClient),
+		Window:  DefaultHMACWindow,
+		now:     time.Now,
+		seen:    make(map[string]time.Time),
+		pruneAt: minHMACPrune,
+	}
+}
+
+// AddClient registers the signing secret of client id
+func (a *HMACAuthenticator) AddClient(id string, secret []byte, scopes ...string) {
+	a.clients[id] = hmacClient{
+		secret:    append([]byte(nil), secret...),
+		principal: &Principal{ID: id, Method: AuthMethodHMAC, Scopes: scopes},
+	}
+}
+
+// Authenticate implements Authenticator
+func (a *HMACAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	id := r.Header.Get(HeaderHMACKeyID)
+	if id == "" {
+		return nil, nil
+	}
+	client, ok := a.clients[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key ID %s", ErrInvalidCredentials, id)
+	}
+
+	timestamp := r.Header.Get(HeaderHMACTimestamp)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed %s", ErrInvalidCredentials, HeaderHMACTimestamp)
+	}
+	signedAt := time.Unix(seconds, 0)
+	if skew := a.now().Sub(signedAt).Abs(); skew > a.Window {
+		return nil, fmt.Errorf("%w: timestamp outside the %s window", ErrInvalidCredentials, a.Window)
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	signature := r.Header.Get(HeaderHMACSignature)
+	if !utils.VerifyKeyedNBHS(client.secret, canonicalRequest(r, timestamp, body), signature) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidCredentials)
+	}
+	if a.replayed(signature, signedAt.Add(a.Window)) {
+		return nil, fmt.Errorf("%w: replayed signature", ErrInvalidCredentials)
+	}
+	return client.principal, nil
+}
+
+// replayed reports whether signature was accepted before, and remembers it
+// until expires otherwise
+func (a *HMACAuthenticator) replayed(signature string, expires time.Time) bool {
+	// Hex digits decode the same in either case
+	signature = strings.ToLower(signature)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	if until, ok := a.seen[signature]; ok && now.Before(until) {
+		return true
+	}
+	if len(a.seen) >= a.pruneAt {
+		for sig, until := range a.seen {
+			if !now.Before(until) {
+				delete(a.seen, sig)
+			}
+		}
+		a.pruneAt = max(minHMACPrune, 2*len(a.seen))
+	}
+	a.seen[signature] = expires
+	return false
+}
+
+// SignRequest signs req for HMAC authentication as client id, setting the
+// key ID, timestamp, a random nonce and the signature headers. The body is
+// read and restored.
+func SignRequest(req *http.Request, id string, secret []byte, now time.Time) error {
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(HeaderHMACNonce, rand.Text())
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	sum := utils.SumKeyedNBHS(secret, []byte(canonicalRequest(req, timestamp, body)))
+
+	req.Header.Set(HeaderHMACKeyID, id)
+	req.Header.Set(HeaderHMACTimestamp, timestamp)
+	req.Header.Set(HeaderHMACSignature, hex.EncodeToString(sum[:]))
+	return nil
+}
+
+// canonicalRequest is the string an HMAC signature covers: the method, the
+// request URI, the timestamp, the nonce and the SHA3-256 of the body, one
+// per line
+func canonicalRequest(r *http.Request, timestamp string, body []byte) string {
+	sum := sha3.Sum256(body)
+	return strings.Join([]string{r.Method, r.URL.RequestURI(), timestamp, r.Header.Get(HeaderHMACNonce), hex.EncodeToString(sum[:])}, "\n")
+}
+
+// readBody reads the request body and replaces it so handlers can read it
+// again
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// AuthConfig is the on-disk form of the server's auth settings
+type AuthConfig struct {
+	APIKeys []struct {
+		ID     string   `json:"id"`
+		Key    string   `json:"key"`
+		Scopes []string `json:"scopes"`
+	} `json:"api_keys"`
+	HMACClients []struct {
+		ID     string   `json:"id"`
+		Secret string   `json:"secret"`
+		Scopes []string `json:"scopes"`
+	} `json:"hmac_clients"`
+	JWT *struct {
+		// JWKSFile is resolved relative to the config file
+		JWKSFile string `json:"jwks_file"`
+		Issuer   string `json:"issuer"`
+		Audience string `json:"audience"`
+	} `json:"jwt"`
+	AllowedOrigins []string `json:"allowed_origins"`
+}
+
+// LoadAuthConfig reads an AuthConfig from a JSON file
+func LoadAuthConfig(path string) (*AuthConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config AuthConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if config.JWT != nil && config.JWT.JWKSFile != "" && !filepath.IsAbs(config.JWT.JWKSFile) {
+		config.JWT.JWKSFile = filepath.Join(filepath.Dir(path), config.JWT.JWKSFile)
+	}
+	return &config, nil
+}
+
+// Auth builds the authenticator chain the config describes: API keys,
+// then HMAC signatures, then JWT bearer tokens
+func (c *AuthConfig) Auth() (*Auth, error) {
+	var authenticators []Authenticator
+
+	if len(c.APIKeys) > 0 {
+		keys := NewAPIKeyAuthenticator()
+		for _, k := range c.APIKeys {
+			if k.ID == "" || k.Key == "" {
+				return nil, errors.New("api key needs an id and a key")
+			}
+			keys.AddKey(k.Key, k.ID, k.Scopes...)
+		}
+		authenticators = append(authenticators, keys)
+	}
+
+	if len(c.HMACClients) > 0 {
+		hmac := NewHMACAuthenticator()
+		for _, client := range c.HMACClients {
+			if client.ID == "" || client.Secret == "" {
+				return nil, errors.New("hmac client needs an id and a secret")
+			}
+			hmac.AddClient(client.ID, []byte(client.Secret), client.Scopes...)
+		}
+		authenticators = append(authenticators, hmac)
+	}
+
+	if c.JWT != nil {
+		jwks, err := LoadJWKS(c.JWT.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		jwt := NewJWTAuthenticator(jwks)
+		if err := jwt.Err(); err != nil {
+			return nil, err
+		}
+		jwt.Issuer = c.JWT.Issuer
+		jwt.Audience = c.JWT.Audience
+		authenticators = append(authenticators, jwt)
+	}
+
+	if len(authenticators) == 0 {
+		return nil, errors.New("auth config defines no credentials")
+	}
+	return NewAuth(authenticators...), nil
+}
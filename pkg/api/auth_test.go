@@ -0,0 +1,328 @@
+package api
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"neuralblitz/pkg/rng"
+)
+
+func signJWT(t *testing.T, kid, alg string, key crypto.Signer, claims map[string]interface{}) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := sha256.Sum256([]byte(signed))
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, _ = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatalf("Failed to sign token: %v", err)
+		}
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encodeInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func newAuthServer(t *testing.T) (*Server, *rsa.PrivateKey, *ecdsa.PrivateKey) {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate EC key: %v", err)
+	}
+
+	dir := t.TempDir()
+	jwks, _ := json.Marshal(JWKS{Keys: []JWK{
+		{KeyType: "RSA", KeyID: "rsa", Alg: "RS256", N: encodeInt(rsaKey.N), E: encodeInt(big.NewInt(int64(rsaKey.E)))},
+		{KeyType: "EC", KeyID: "ec", Curve: "P-256", X: encodeInt(ecKey.X), Y: encodeInt(ecKey.Y)},
+	}})
+	os.WriteFile(filepath.Join(dir, "jwks.json"), jwks, 0o600)
+	os.WriteFile(filepath.Join(dir, "auth.json"), []byte(`{
+		"api_keys": [{"id": "ops", "key": "k-ops", "scopes": ["status:read", "nbcl:execute"]}],
+		"hmac_clients": [{"id": "svc", "secret": "s3cret", "scopes": ["*"]}],
+		"jwt": {"jwks_file": "jwks.json", "issuer": "https://issuer.test", "audience": "neuralblitz"},
+		"allowed_origins": ["https://app.test"]
+	}`), 0o600)
+
+	config, err := LoadAuthConfig(filepath.Join(dir, "auth.json"))
+	if err != nil {
+		t.Fatalf("Failed to load auth config: %v", err)
+	}
+	auth, err := config.Auth()
+	if err != nil {
+		t.Fatalf("Failed to build auth: %v", err)
+	}
+
+	s := NewServer("", rng.WithSeed(7))
+	s.SetAuth(auth)
+	s.SetAllowedOrigins(config.AllowedOrigins)
+	return s, rsaKey, ecKey
+}
+
+func TestAuthAPIKey(t *testing.T) {
+	s, _, _ := newAuthServer(t)
+
+	tests := []struct {
+		method, path, key string
+		code              int
+	}{
+		{http.MethodGet, "/health", "", http.StatusOK},
+		{http.MethodGet, "/status", "", http.StatusUnauthorized},
+		{http.MethodGet, "/status", "wrong", http.StatusUnauthorized},
+		{http.MethodGet, "/status", "k-ops", http.StatusOK},
+		{http.MethodGet, "/attestation", "k-ops", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.key != "" {
+			req.Header.Set(HeaderAPIKey, tt.key)
+		}
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		if w.Code != tt.code {
+			t.Errorf("Expected %d for %s %s with key %q, got %d", tt.code, tt.method, tt.path, tt.key, w.Code)
+		}
+	}
+}
+
+func TestAuthHMAC(t *testing.T) {
+	s, _, _ := newAuthServer(t)
+
+	sign := func(secret string, at time.Time) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/nbcl/interpret", strings.NewReader(`{"command": "/status"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if err := SignRequest(req, "svc", []byte(secret), at); err != nil {
+			t.Fatalf("Failed to sign request: %v", err)
+		}
+		return req
+	}
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, sign("s3cret", time.Now()))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected signed request to pass, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for name, req := range map[string]*http.Request{
+		"wrong secret": sign("guess", time.Now()),
+		"stale":        sign("s3cret", time.Now().Add(-time.Hour)),
+	} {
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected %s request to be rejected, got %d", name, w.Code)
+		}
+	}
+
+	tampered := sign("s3cret", time.Now())
+	tampered.Body = http.NoBody
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, tampered)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected tampered body to be rejected, got %d", w.Code)
+	}
+}
+
+func TestAuthHMACReplay(t *testing.T) {
+	s, _, _ := newAuthServer(t)
+
+	now := time.Now()
+	sign := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/nbcl/interpret", strings.NewReader(`{"command": "/status"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if err := SignRequest(req, "svc", []byte("s3cret"), now); err != nil {
+			t.Fatalf("Failed to sign request: %v", err)
+		}
+		return req
+	}
+
+	first := sign()
+	replay := first.Clone(first.Context())
+	replay.Body = io.NopCloser(strings.NewReader(`{"command": "/status"}`))
+	replay.Header.Set(HeaderHMACSignature, strings.ToUpper(first.Header.Get(HeaderHMACSignature)))
+
+	for _, tt := range []struct {
+		name string
+		req  *http.Request
+		code int
+	}{
+		{"first", first, http.StatusOK},
+		{"replay", replay, http.StatusUnauthorized},
+		{"identical request with a fresh nonce", sign(), http.StatusOK},
+	} {
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, tt.req)
+		if w.Code != tt.code {
+			t.Errorf("Expected %d for %s request, got %d: %s", tt.code, tt.name, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestHMACReplayCachePruned(t *testing.T) {
+	a := NewHMACAuthenticator()
+	now := time.Now()
+	a.now = func() time.Time { return now }
+
+	for i := 0; i < minHMACPrune; i++ {
+		a.replayed(strconv.Itoa(i), now.Add(time.Minute))
+	}
+	if !a.replayed("0", now.Add(time.Minute)) {
+		t.Error("Expected a remembered signature to be a replay")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if a.replayed("0", now.Add(time.Minute)) {
+		t.Error("Expected an expired signature to be accepted")
+	}
+	if len(a.seen) != 1 {
+		t.Errorf("Expected expired signatures pruned, got %d remembered", len(a.seen))
+	}
+}
+
+func TestAuthRejectedNotAttested(t *testing.T) {
+	s, _, _ := newAuthServer(t)
+	issued, nodes := s.ids.Len(), s.ledger.Len()
+
+	for _, key := range []string{"", "wrong", "k-ops"} {
+		req := httptest.NewRequest(http.MethodGet, "/attestation", nil)
+		if key != "" {
+			req.Header.Set(HeaderAPIKey, key)
+		}
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized && w.Code != http.StatusForbidden {
+			t.Fatalf("Expected key %q rejected, got %d", key, w.Code)
+		}
+		if trace := w.Header().Get("X-Trace-ID"); trace != "" {
+			t.Errorf("Expected no trace ID for key %q, got %s", key, trace)
+		}
+	}
+	if s.ids.Len() != issued || s.ledger.Len() != nodes {
+		t.Errorf("Expected rejected requests to issue no IDs, got %d IDs and %d nodes", s.ids.Len()-issued, s.ledger.Len()-nodes)
+	}
+}
+
+func TestRequestBodyLimit(t *testing.T) {
+	s, _, _ := newAuthServer(t)
+	body := `{"command": "/attest note[` + strings.Repeat("a", MaxRequestBytes) + `]"}`
+
+	req := httptest.NewRequest(http.MethodPost, "/nbcl/interpret", strings.NewReader(body))
+	req.Header.Set(HeaderAPIKey, "k-ops")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected an oversized body to be 413, got %d: %s", w.Code, w.Body)
+	}
+
+	// HMAC authentication reads the body before any handler
+	req = httptest.NewRequest(http.MethodPost, "/nbcl/interpret", strings.NewReader(body))
+	req.Header.Set(HeaderHMACKeyID, "svc")
+	req.Header.Set(HeaderHMACTimestamp, strconv.FormatInt(time.Now().Unix(), 10))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected an oversized signed body to be 413, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestAuthJWT(t *testing.T) {
+	s, rsaKey, ecKey := newAuthServer(t)
+	now := time.Now().Unix()
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":   "analyst",
+			"iss":   "https://issuer.test",
+			"aud":   []string{"neuralblitz"},
+			"exp":   now + 60,
+			"scope": "attest:read trace:read",
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		code  int
+	}{
+		{"rsa", signJWT(t, "rsa", "RS256", rsaKey, claims(nil)), http.StatusOK},
+		{"ec", signJWT(t, "ec", "ES256", ecKey, claims(nil)), http.StatusOK},
+		{"expired", signJWT(t, "rsa", "RS256", rsaKey, claims(map[string]interface{}{"exp": now - 3600})), http.StatusUnauthorized},
+		{"audience", signJWT(t, "rsa", "RS256", rsaKey, claims(map[string]interface{}{"aud": "other"})), http.StatusUnauthorized},
+		{"wrong key", signJWT(t, "ec", "RS256", rsaKey, claims(nil)), http.StatusUnauthorized},
+		{"scope", signJWT(t, "rsa", "RS256", rsaKey, claims(map[string]interface{}{"scope": "trace:read"})), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/attestation", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.token)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		if w.Code != tt.code {
+			t.Errorf("Expected %d for %s token, got %d: %s", tt.code, tt.name, w.Code, w.Body.String())
+		}
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"x","exp":9999999999,"scope":"*"}`))
+	if _, err := NewJWTAuthenticator(&JWKS{}).verify(header + "." + payload + "."); err == nil {
+		t.Error("Expected an unsigned token to be rejected")
+	}
+}
+
+func TestCORSAllowList(t *testing.T) {
+	s, _, _ := newAuthServer(t)
+
+	for origin, code := range map[string]int{"https://app.test": http.StatusNoContent, "https://evil.test": http.StatusForbidden} {
+		req := httptest.NewRequest(http.MethodOptions, "/intent", nil)
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		if w.Code != code {
+			t.Errorf("Expected %d for preflight from %s, got %d", code, origin, w.Code)
+		}
+		allowed := w.Header().Get("Access-Control-Allow-Origin")
+		if (code == http.StatusNoContent) != (allowed == origin) {
+			t.Errorf("Expected Access-Control-Allow-Origin only for allowed origins, got %q for %s", allowed, origin)
+		}
+	}
+}
+
+func TestAuthConfigErrors(t *testing.T) {
+	if _, err := (&AuthConfig{}).Auth(); err == nil {
+		t.Error("Expected an error for a config without credentials")
+	}
+	if _, err := NewAuth().Authenticate(httptest.NewRequest(http.MethodGet, "/", nil)); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Expected no credentials error, got %v", err)
+	}
+}
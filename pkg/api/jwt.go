@@ -0,0 +1,355 @@
+package api
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultJWTLeeway is the clock skew tolerated when checking exp and nbf
+const DefaultJWTLeeway = time.Minute
+
+// JWK is a public key in JSON Web Key form. RSA, EC (P-256, P-384) and OKP
+// (Ed25519) keys are supported.
+type JWK struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid,omitempty"`
+	Alg     string `json:"alg,omitempty"`
+	Use     string `json:"use,omitempty"`
+	Curve   string `json:"crv,omitempty"`
+	N       string `json:"n,omitempty"`
+	E       string `json:"e,omitempty"`
+	X       string `json:"x,omitempty"`
+	Y       string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// LoadJWKS reads a JWKS from a JSON file
+func LoadJWKS(path string) (*JWKS, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var jwks JWKS
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &jwks, nil
+}
+
+// PublicKey decodes the key
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	decode := func(field, value string) ([]byte, error) {
+		b, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil || len(b) == 0 {
+			return nil, fmt.Errorf("jwk %s: invalid %s", k.KeyID, field)
+		}
+		return b, nil
+	}
+
+	switch k.KeyType {
+	case "RSA":
+		n, err := decode("n", k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode("e", k.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("jwk %s: exponent too large", k.KeyID)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("jwk %s: unsupported curve %s", k.KeyID, k.Curve)
+		}
+		x, err := decode("x", k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode("y", k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("jwk %s: point is not on %s", k.KeyID, k.Curve)
+		}
+		return key, nil
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("jwk %s: unsupported curve %s", k.KeyID, k.Curve)
+		}
+		x, err := decode("x", k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("jwk %s: invalid Ed25519 key length", k.KeyID)
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("jwk %s: unsupported key type %s", k.KeyID, k.KeyType)
+}
+
+// jwtKey is a verification key of a JWTAuthenticator
+type jwtKey struct {
+	id  string
+	alg string
+	key crypto.PublicKey
+}
+
+// JWTAuthenticator authenticates "Authorization: Bearer" JSON Web Tokens
+// signed with a key of a local JWKS. Tokens must carry an exp claim; the
+// principal is the sub claim and its scopes come from the space-separated
+// scope claim or the scp list.
+type JWTAuthenticator struct {
+	keys []jwtKey
+	// Issuer and Audience, when set, must match the iss and aud claims
+	Issuer   string
+	Audience string
+	// Leeway is the clock skew tolerated when checking exp and nbf
+	Leeway time.Duration
+	now    func() time.Time
+	// errs records keys of the JWKS that could not be decoded
+	errs []error
+}
+
+// NewJWTAuthenticator creates a JWTAuthenticator verifying tokens against
+// the keys of jwks. Keys that cannot be decoded are skipped; Err reports
+// them.
+func NewJWTAuthenticator(jwks *JWKS) *JWTAuthenticator {
+	a := &JWTAuthenticator{Leeway: DefaultJWTLeeway, now: time.Now}
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.PublicKey()
+		if err != nil {
+			a.errs = append(a.errs, err)
+			continue
+		}
+		a.keys = append(a.keys, jwtKey{id: k.KeyID, alg: k.Alg, key: key})
+	}
+	return a
+}
+
+// Err reports the keys of the JWKS that could not be decoded
+func (a *JWTAuthenticator) Err() error {
+	if len(a.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of the JWKS keys were skipped: %w", len(a.errs), a.errs[0])
+}
+
+// jwtClaims are the registered and scope claims the authenticator checks
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *int64          `json:"exp"`
+	NotBefore *int64          `json:"nbf"`
+	Scope     string          `json:"scope"`
+	Scp       json.RawMessage `json:"scp"`
+}
+
+// Authenticate implements Authenticator
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, nil
+	}
+	claims, err := a.verify(strings.TrimSpace(token))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	return &Principal{ID: claims.Subject, Method: AuthMethodJWT, Scopes: claims.scopes()}, nil
+}
+
+// verify checks a compact JWS signature and the token's claims
+func (a *JWTAuthenticator) verify(token string) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("header: %v", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed signature")
+	}
+
+	key, err := a.key(header.Kid, header.Alg)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("claims: %v", err)
+	}
+	now := a.now()
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("missing exp claim")
+	}
+	if now.After(time.Unix(*claims.ExpiresAt, 0).Add(a.Leeway)) {
+		return nil, fmt.Errorf("token expired")
+	}
+	if claims.NotBefore != nil && now.Add(a.Leeway).Before(time.Unix(*claims.NotBefore, 0)) {
+		return nil, fmt.Errorf("token not yet valid")
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("missing sub claim")
+	}
+	if a.Issuer != "" && claims.Issuer != a.Issuer {
+		return nil, fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+	if a.Audience != "" && !slices.Contains(stringOrList(claims.Audience), a.Audience) {
+		return nil, fmt.Errorf("token not issued for %s", a.Audience)
+	}
+	return &claims, nil
+}
+
+// key finds the verification key for a token header. Without a kid the
+// single key of the set is used.
+func (a *JWTAuthenticator) key(kid, alg string) (crypto.PublicKey, error) {
+	var candidates []jwtKey
+	for _, k := range a.keys {
+		if kid == "" || k.id == kid {
+			candidates = append(candidates, k)
+		}
+	}
+	if len(candidates) != 1 {
+		return nil, fmt.Errorf("no unique key for kid %q", kid)
+	}
+	if candidates[0].alg != "" && candidates[0].alg != alg {
+		return nil, fmt.Errorf("key %s does not sign %s", candidates[0].id, alg)
+	}
+	return candidates[0].key, nil
+}
+
+// verifySignature verifies a JWS signature. Only asymmetric algorithms are
+// accepted, so neither "none" nor a public key reused as an HMAC secret can
+// forge a token.
+func verifySignature(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	invalid := fmt.Errorf("invalid %s signature", alg)
+
+	switch alg {
+	case "RS256", "RS384", "RS512":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return invalid
+		}
+		hash, digest := jwtDigest(alg, signed)
+		if rsa.VerifyPKCS1v15(pub, hash, digest, signature) != nil {
+			return invalid
+		}
+		return nil
+	case "ES256", "ES384":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return invalid
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size || (alg == "ES256") != (size == 32) {
+			return invalid
+		}
+		_, digest := jwtDigest(alg, signed)
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return invalid
+		}
+		return nil
+	case "EdDSA":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok || !ed25519.Verify(pub, signed, signature) {
+			return invalid
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported algorithm %q", alg)
+}
+
+// jwtDigest hashes the signed part of a token for an RS or ES algorithm
+func jwtDigest(alg string, signed []byte) (crypto.Hash, []byte) {
+	switch alg[2:] {
+	case "384":
+		sum := sha512.Sum384(signed)
+		return crypto.SHA384, sum[:]
+	case "512":
+		sum := sha512.Sum512(signed)
+		return crypto.SHA512, sum[:]
+	}
+	sum := sha256.Sum256(signed)
+	return crypto.SHA256, sum[:]
+}
+
+// scopes returns the scopes granted by the scope or scp claim
+func (c *jwtClaims) scopes() []string {
+	if c.Scope != "" {
+		return strings.Fields(c.Scope)
+	}
+	scp := stringOrList(c.Scp)
+	if len(scp) == 1 {
+		return strings.Fields(scp[0])
+	}
+	return scp
+}
+
+// stringOrList decodes a claim that is either a string or a list of them
+func stringOrList(raw json.RawMessage) []string {
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil && s != "" {
+		return []string{s}
+	}
+	return nil
+}
+
+// decodeSegment decodes a base64url JSON segment of a token
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("malformed segment")
+	}
+	return json.Unmarshal(data, v)
+}
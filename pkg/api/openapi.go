@@ -0,0 +1,237 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"neuralblitz/pkg/openapi"
+	"neuralblitz/pkg/options"
+)
+
+// Security scheme names of the OpenAPI document
+const (
+	securityAPIKey = "apiKey"
+	securityBearer = "bearerJWT"
+	securityHMAC   = "hmacSignature"
+)
+
+// routeDoc documents one registered route. Every route in setupRouter has
+// one; TestOpenAPIMatchesRoutes keeps the two in sync.
+type routeDoc struct {
+	method, path string
+	operationID  string
+	summary      string
+	// scope and group are empty for public routes
+	scope, group string
+	params       []openapi.Parameter
+	// request is nil for routes without a body
+	request interface{}
+	// response is the 200 body; contentType defaults to JSON
+	response    interface{}
+	contentType string
+	// status is the success status, 200 unless set
+	status int
+	// errors are the statuses besides those every route may return
+	errors []int
+	// subsystem is the optional subsystem mounting the route, if any
+	subsystem string
+}
+
+var streamParamDocs = []openapi.Parameter{
+	{Name: "subsystem", In: "query", Description: "Comma-separated subsystems to stream: lrs, entrainment, entanglement (default all)", Schema: &openapi.Schema{Type: "string"}},
+	{Name: "last_event_id", In: "query", Description: "Resume after this event ID; the Last-Event-ID header takes precedence", Schema: &openapi.Schema{Type: "integer", Format: "int64"}},
+}
+
+// routeDocs documents the API in registration order
+var routeDocs = []routeDoc{
+	{method: http.MethodGet, path: "/", operationID: "root", summary: "Describe the API",
+		response: RootResponse{}},
+	{method: http.MethodGet, path: "/health", operationID: "health", summary: "Health check",
+		response: HealthResponse{}},
+	{method: http.MethodGet, path: "/openapi.json", operationID: "getOpenAPI", summary: "This OpenAPI document",
+		response: map[string]interface{}{}},
+	{method: http.MethodGet, path: "/metrics", operationID: "getMetrics", summary: "Prometheus metrics",
+		scope: ScopeMetricsRead, response: "", contentType: "text/plain"},
+	{method: http.MethodGet, path: "/status", operationID: "getStatus", summary: "System status",
+		scope: ScopeStatusRead, group: options.RateGroupRead, response: StatusResponse{}},
+	{method: http.MethodPost, path: "/intent", operationID: "processIntent", summary: "Process, co-create and actualize an intent",
+		scope: ScopeIntentWrite, group: options.RateGroupIntent, request: IntentRequest{}, response: IntentResponse{},
+		errors: []int{http.StatusBadRequest}},
+	{method: http.MethodPost, path: "/verify", operationID: "verify", summary: "Verify irreducibility, coherence or attestation",
+		scope: ScopeVerify, group: options.RateGroupVerify, request: VerifyRequest{}, response: VerifyResponse{},
+		errors: []int{http.StatusBadRequest}},
+	{method: http.MethodPost, path: "/nbcl/interpret", operationID: "interpretNBCL", summary: "Interpret an NBCL command",
+		scope: ScopeNBCLExecute, group: options.RateGroupNBCL, request: NBCLRequest{}, response: options.NBCLResult{},
+		errors: []int{http.StatusBadRequest}},
+	{method: http.MethodGet, path: "/attestation", operationID: "getAttestation", summary: "Omega attestation",
+		scope: ScopeAttestRead, group: options.RateGroupRead, response: AttestationResponse{}},
+	{method: http.MethodGet, path: "/symbiosis", operationID: "getSymbiosis", summary: "State of the Architect-System Dyad",
+		scope: ScopeStatusRead, group: options.RateGroupRead, response: SymbiosisResponse{}},
+	{method: http.MethodGet, path: "/synthesis", operationID: "getSynthesis", summary: "State of the Self-Actualization Engine",
+		scope: ScopeStatusRead, group: options.RateGroupRead, response: SynthesisResponse{}},
+	{method: http.MethodGet, path: "/stream/metrics", operationID: "streamMetrics", summary: "Stream subsystem metrics as Server-Sent Events",
+		scope: ScopeStatusRead, group: options.RateGroupRead, params: streamParamDocs,
+		response: MetricsEvent{}, contentType: "text/event-stream", errors: []int{http.StatusBadRequest}},
+	{method: http.MethodGet, path: "/stream/metrics/ws", operationID: "streamMetricsWebSocket", summary: "Stream subsystem metrics over a WebSocket, one JSON event per message",
+		scope: ScopeStatusRead, group: options.RateGroupRead, params: streamParamDocs,
+		status: http.StatusSwitchingProtocols, errors: []int{http.StatusBadRequest}},
+	{method: http.MethodGet, path: "/trace/:id", operationID: "lookupTrace", summary: "Look up a trace or codex ID and the IDs issued under it",
+		scope: ScopeTraceRead, group: options.RateGroupRead,
+		params:   []openapi.Parameter{{Name: "id", In: "path", Required: true, Description: "Trace or codex ID", Schema: &openapi.Schema{Type: "string"}}},
+		response: TraceResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodGet, path: "/options/:id", operationID: "getOption", summary: "One deployment option",
+		scope: ScopeOptionsRead, group: options.RateGroupRead,
+		params:   []openapi.Parameter{{Name: "id", In: "path", Required: true, Description: "Option A to F", Schema: &openapi.Schema{Type: "string"}}},
+		response: OptionResponse{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodGet, path: "/options", operationID: "listOptions", summary: "All deployment options",
+		scope: ScopeOptionsRead, group: options.RateGroupRead, response: OptionsListResponse{}},
+	{method: http.MethodGet, path: "/consciousness", operationID: "getConsciousness", summary: "State of the consciousness integration",
+		scope: ScopeStatusRead, group: options.RateGroupRead, response: ConsciousnessResponse{},
+		subsystem: options.SubsystemConsciousness},
+	{method: http.MethodGet, path: "/reality", operationID: "getReality", summary: "State of the reality entanglement manager",
+		scope: ScopeStatusRead, group: options.RateGroupRead, response: RealityResponse{},
+		subsystem: options.SubsystemReality},
+	{method: http.MethodGet, path: "/opencode", operationID: "getOpenCode", summary: "Statistics and tools of the OpenCode integration",
+		scope: ScopeStatusRead, group: options.RateGroupRead, response: OpenCodeResponse{},
+		subsystem: options.SubsystemOpenCode},
+	{method: http.MethodPost, path: "/opencode/tools/:name", operationID: "runOpenCodeTool", summary: "Run an OpenCode tool",
+		scope: ScopeOpenCodeExecute, group: options.RateGroupNBCL,
+		params:  []openapi.Parameter{{Name: "name", In: "path", Required: true, Description: "Tool name, as listed by GET /opencode", Schema: &openapi.Schema{Type: "string"}}},
+		request: ToolRequest{}, response: ToolResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		subsystem: options.SubsystemOpenCode},
+}
+
+// openAPIPath converts a gin route path to an OpenAPI path, e.g.
+// /trace/:id to /trace/{id}
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// OpenAPI returns the OpenAPI document of the API server
+func OpenAPI() *openapi.Document {
+	registry := openapi.NewRegistry()
+	errorResponse := func(description string) openapi.Response {
+		return openapi.Response{
+			Description: description,
+			Content:     map[string]openapi.MediaType{"application/json": {Schema: registry.Schema(ErrorResponse{})}},
+		}
+	}
+	errorDescriptions := map[int]string{
+		http.StatusBadRequest:            "Invalid request",
+		http.StatusUnauthorized:          "Missing or invalid credentials",
+		http.StatusForbidden:             "Credentials lack the required scope",
+		http.StatusNotFound:              "Not found",
+		http.StatusRequestEntityTooLarge: "Request body over 1 MiB",
+		http.StatusTooManyRequests:       "Rate limit exceeded; see Retry-After",
+		http.StatusInternalServerError:   "Internal error",
+		http.StatusServiceUnavailable:    "Over the deployment option's memory or in-flight request budget, or a fault injected by chaos mode; see Retry-After",
+	}
+
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:   "NeuralBlitz API",
+			Version: Version,
+			Description: "Every response to an authenticated request carries X-GoldenDAG, X-Trace-ID and X-Codex-ID headers, and every response a " +
+				"W3C traceparent continuing the request's; the hex code of X-Trace-ID is its trace ID. " +
+				"Scoped operations accept any one of the security schemes when authentication is enabled. " +
+				"Operations with an x-subsystem are mounted only when the server's deployment option enables that subsystem.",
+		},
+		Paths: make(map[string]openapi.PathItem),
+	}
+
+	for _, route := range routeDocs {
+		op := &openapi.Operation{
+			OperationID: route.operationID,
+			Summary:     route.summary,
+			Parameters:  route.params,
+			Responses:   make(map[string]openapi.Response),
+			Scope:       route.scope,
+			RateGroup:   route.group,
+			Subsystem:   route.subsystem,
+		}
+		if route.request != nil {
+			op.RequestBody = &openapi.RequestBody{
+				Required: true,
+				Content:  map[string]openapi.MediaType{"application/json": {Schema: registry.RequestSchema(route.request)}},
+			}
+		}
+
+		status := route.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success := openapi.Response{Description: http.StatusText(status)}
+		if route.response != nil {
+			contentType := route.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			success.Content = map[string]openapi.MediaType{contentType: {Schema: registry.Schema(route.response)}}
+		}
+		op.Responses[strconv.Itoa(status)] = success
+
+		errors := append([]int{http.StatusTooManyRequests, http.StatusInternalServerError}, route.errors...)
+		if route.request != nil {
+			errors = append(errors, http.StatusRequestEntityTooLarge)
+		}
+		if !profileExempt(route.path) {
+			errors = append(errors, http.StatusServiceUnavailable)
+		}
+		if route.scope != "" {
+			op.Security = []map[string][]string{{securityAPIKey: {}}, {securityBearer: {}}, {securityHMAC: {}}}
+			errors = append(errors, http.StatusUnauthorized, http.StatusForbidden)
+		}
+		for _, code := range errors {
+			op.Responses[strconv.Itoa(code)] = errorResponse(errorDescriptions[code])
+		}
+
+		path := openAPIPath(route.path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(openapi.PathItem)
+		}
+		doc.Paths[path][strings.ToLower(route.method)] = op
+	}
+
+	// Errors may add fields, e.g. the supported values of a parameter
+	registry.Components()["ErrorResponse"].AdditionalProperties = true
+
+	doc.Components = openapi.Components{
+		Schemas: registry.Components(),
+		SecuritySchemes: map[string]openapi.SecurityScheme{
+			securityAPIKey: {Type: "apiKey", In: "header", Name: HeaderAPIKey},
+			securityBearer: {Type: "http", Scheme: "bearer", BearerFormat: "JWT",
+				Description: "RS256/384/512, ES256/384 or EdDSA token; scopes in the scope or scp claim"},
+			securityHMAC: {Type: "apiKey", In: "header", Name: HeaderHMACSignature,
+				Description: "Hex keyed NBHS-1024 digest of method, request URI, " + HeaderHMACTimestamp + ", " + HeaderHMACNonce +
+					" and the SHA3-256 of the body, with the client ID in " + HeaderHMACKeyID + "; each signature is accepted once"},
+		},
+	}
+	return doc
+}
+
+// openAPIJSON is the document served at /openapi.json, built and encoded
+// once
+var openAPIJSON = sync.OnceValues(func() ([]byte, error) {
+	return json.Marshal(OpenAPI())
+})
+
+// handleOpenAPI serves the OpenAPI document
+func (s *Server) handleOpenAPI(c *gin.Context) {
+	data, err := openAPIJSON()
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
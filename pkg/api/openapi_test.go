@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"neuralblitz/pkg/openapi"
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/rng"
+)
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	s := NewServer("", rng.WithSeed(3))
+	full := NewServer("", rng.WithSeed(3))
+	if err := full.SetOption("B", options.OptionB(), nil); err != nil {
+		t.Fatal(err)
+	}
+	doc := OpenAPI()
+
+	routes := func(s *Server) []string {
+		var registered []string
+		for _, route := range s.router.Routes() {
+			registered = append(registered, route.Method+" "+openAPIPath(route.Path))
+		}
+		sort.Strings(registered)
+		return registered
+	}
+	var documented, mounted []string
+	operationIDs := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+			if op.Subsystem == "" {
+				mounted = append(mounted, strings.ToUpper(method)+" "+path)
+			}
+			if operationIDs[op.OperationID] {
+				t.Errorf("Duplicate operation ID %s", op.OperationID)
+			}
+			operationIDs[op.OperationID] = true
+		}
+	}
+	sort.Strings(documented)
+	sort.Strings(mounted)
+	if registered := routes(full); !slices.Equal(registered, documented) {
+		t.Errorf("Expected documented routes to match registered routes\nregistered: %v\ndocumented: %v", registered, documented)
+	}
+	// Servers without a deployment option mount no subsystem routes
+	if registered := routes(s); !slices.Equal(registered, mounted) {
+		t.Errorf("Expected the routes outside subsystems to be registered\nregistered: %v\ndocumented: %v", registered, mounted)
+	}
+
+	w := doRequest(s, http.MethodGet, "/openapi.json", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var served openapi.Document
+	if err := json.Unmarshal(w.Body.Bytes(), &served); err != nil {
+		t.Fatalf("Failed to decode document: %v", err)
+	}
+	if served.OpenAPI != openapi.Version || len(served.Paths) != len(doc.Paths) {
+		t.Errorf("Expected served document to be the generated one, got version %s with %d paths", served.OpenAPI, len(served.Paths))
+	}
+	if again := doRequest(s, http.MethodGet, "/openapi.json", ""); again.Body.String() != w.Body.String() {
+		t.Error("Expected the document encoded once and served as is")
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("Expected a JSON content type, got %s", ct)
+	}
+}
+
+// checkSchema reports properties of value missing from or undeclared in
+// schema, recursing into objects and arrays
+func checkSchema(t *testing.T, doc *openapi.Document, schema *openapi.Schema, value interface{}, at string) {
+	t.Helper()
+	schema = doc.Resolve(schema)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if schema.Properties == nil {
+			return
+		}
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				t.Errorf("%s: missing required property %s", at, name)
+			}
+		}
+		for name, field := range v {
+			property, ok := schema.Properties[name]
+			if !ok {
+				if schema.AdditionalProperties == nil {
+					t.Errorf("%s: undeclared property %s", at, name)
+				}
+				continue
+			}
+			checkSchema(t, doc, property, field, at+"."+name)
+		}
+	case []interface{}:
+		if schema.Items != nil {
+			for _, item := range v {
+				checkSchema(t, doc, schema.Items, item, at+"[]")
+			}
+		}
+	}
+}
+
+func TestOpenAPIResponsesMatchSchemas(t *testing.T) {
+	s := NewServer("", rng.WithSeed(3))
+	doc := OpenAPI()
+
+	trace := doRequest(s, http.MethodGet, "/health", "").Header().Get("X-Trace-ID")
+	requests := []struct {
+		method, path, body string
+	}{
+		{http.MethodGet, "/", ""},
+		{http.MethodGet, "/health", ""},
+		{http.MethodGet, "/status", ""},
+		{http.MethodPost, "/intent", `{"intent": {"phi_1": 1, "phi_22": 0.5}, "source": "test"}`},
+		{http.MethodPost, "/verify", `{"type": "irreducibility"}`},
+		{http.MethodPost, "/verify", `{"type": "coherence"}`},
+		{http.MethodPost, "/verify", `{"type": "attestation"}`},
+		{http.MethodPost, "/nbcl/interpret", `{"command": "/status"}`},
+		{http.MethodPost, "/nbcl/interpret", `{"command": "/help"}`},
+		{http.MethodGet, "/attestation", ""},
+		{http.MethodGet, "/symbiosis", ""},
+		{http.MethodGet, "/synthesis", ""},
+		{http.MethodGet, "/trace/" + trace, ""},
+		{http.MethodGet, "/options/B", ""},
+		{http.MethodGet, "/options", ""},
+		{http.MethodGet, "/options/Z", ""},
+		{http.MethodPost, "/verify", `{"type": "unknown"}`},
+	}
+	for _, r := range requests {
+		w := doRequest(s, r.method, r.path, r.body)
+		route := s.router.Routes()[slices.IndexFunc(s.router.Routes(), func(info gin.RouteInfo) bool {
+			return info.Method == r.method && matchRoute(info.Path, r.path)
+		})]
+		op := doc.Paths[openAPIPath(route.Path)][strings.ToLower(r.method)]
+		response, ok := op.Responses[strconv.Itoa(w.Code)]
+		if !ok {
+			t.Errorf("%s %s: undocumented status %d", r.method, r.path, w.Code)
+			continue
+		}
+
+		var body interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s %s: failed to decode response: %v", r.method, r.path, err)
+		}
+		checkSchema(t, doc, response.Content["application/json"].Schema, body, r.method+" "+r.path)
+	}
+}
+
+// matchRoute reports whether path matches a gin route path
+func matchRoute(route, path string) bool {
+	routeSegments, pathSegments := strings.Split(route, "/"), strings.Split(path, "/")
+	if len(routeSegments) != len(pathSegments) {
+		return false
+	}
+	for i, segment := range routeSegments {
+		if !strings.HasPrefix(segment, ":") && segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,299 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	runtimemetrics "runtime/metrics"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"neuralblitz/pkg/consciousness"
+	"neuralblitz/pkg/opencode"
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/reality"
+	"neuralblitz/pkg/rng"
+)
+
+// Error definitions
+var (
+	ErrOptionSet = errors.New("deployment option already set")
+)
+
+// heapObjectsMetric is the runtime metric measured against MemoryMB
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// ChaosConfig configures the faults chaos mode injects into admitted
+// requests
+type ChaosConfig struct {
+	// FaultRate is the fraction of requests failed with 503
+	FaultRate float64
+	// LatencyRate is the fraction of requests delayed by up to MaxLatency
+	LatencyRate float64
+	MaxLatency  time.Duration
+}
+
+// DefaultChaosConfig returns the faults injected when a deployment option
+// sets UseChaosMode
+func DefaultChaosConfig() *ChaosConfig {
+	return &ChaosConfig{
+		FaultRate:   0.05,
+		LatencyRate: 0.1,
+		MaxLatency:  250 * time.Millisecond,
+	}
+}
+
+// ProfileConfig configures the subsystems a deployment option initializes;
+// nil fields keep their defaults
+type ProfileConfig struct {
+	Consciousness *consciousness.ConsciousnessConfig
+	Entanglement  *reality.EntanglementConfig
+	OpenCode      *opencode.OpenCodeConfig
+	// Chaos is used when the option sets UseChaosMode
+	Chaos *ChaosConfig
+}
+
+// profile is the deployment option a server runs with
+type profile struct {
+	id     string
+	option *options.DeploymentOption
+	// chaos is nil unless the option sets UseChaosMode
+	chaos *ChaosConfig
+	rand  *rng.Source
+	// heap measures the process against the memory budget
+	heap func() uint64
+	// inFlight holds a slot for each admitted request until it ends, up to
+	// the option's MaxInFlight; nil when unlimited. Requests are counted
+	// rather than goroutines so idle connections do not use the budget.
+	inFlight chan struct{}
+	// rejected counts requests refused over budget, faults those failed
+	// by chaos mode
+	rejected atomic.Int64
+	faults   atomic.Int64
+}
+
+// heapBytes returns the bytes of heap objects, live or not yet swept,
+// without stopping the world as runtime.ReadMemStats does
+func heapBytes() uint64 {
+	sample := []runtimemetrics.Sample{{Name: heapObjectsMetric}}
+	runtimemetrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
+
+// SetOption runs the server as deployment option id: its rate limits
+// replace those of Option F, requests are refused with 503 while the
+// process is over its memory budget or serving MaxInFlight requests
+// already, only the subsystems it enables are initialized, mounted and
+// given NBCL namespaces, and with UseChaosMode faults are injected into
+// requests. GET /status reports the profile. An invalid option is
+// rejected. It must be called once, before the server starts.
+// A nil config uses the defaults.
+func (s *Server) SetOption(id string, opt *options.DeploymentOption, config *ProfileConfig) error {
+	if s.profile != nil {
+		return fmt.Errorf("%w: %s", ErrOptionSet, s.profile.id)
+	}
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+	if config == nil {
+		config = &ProfileConfig{}
+	}
+
+	p := &profile{
+		id:     strings.ToUpper(id),
+		option: opt,
+		rand:   s.rand.Derive(),
+		heap:   heapBytes,
+	}
+	if opt.MaxInFlight > 0 {
+		p.inFlight = make(chan struct{}, opt.MaxInFlight)
+	}
+	if opt.UseChaosMode {
+		p.chaos = config.Chaos
+		if p.chaos == nil {
+			p.chaos = DefaultChaosConfig()
+		}
+	}
+
+	if opt.Enables(options.SubsystemConsciousness) {
+		s.consciousness = consciousness.NewConsciousnessIntegration(config.Consciousness)
+		if err := s.consciousness.Initialize(); err != nil {
+			return fmt.Errorf("consciousness: %w", err)
+		}
+		if err := options.RegisterConsciousnessCommands(s.interpreter, s.consciousness); err != nil {
+			return fmt.Errorf("consciousness: %w", err)
+		}
+	}
+	if opt.Enables(options.SubsystemReality) {
+		s.entanglements = reality.NewEntanglementManager(config.Entanglement, rng.WithSource(s.rand.Derive()))
+		if err := s.entanglements.Initialize(); err != nil {
+			return fmt.Errorf("reality: %w", err)
+		}
+		if err := options.RegisterRealityCommands(s.interpreter, s.entanglements); err != nil {
+			return fmt.Errorf("reality: %w", err)
+		}
+	}
+	if !opt.Enables(options.SubsystemQuantum) {
+		s.interpreter.UnregisterNamespace(options.SubsystemQuantum)
+	}
+	if opt.Enables(options.SubsystemOpenCode) {
+		s.opencode = opencode.NewOpenCodeIntegration(config.OpenCode)
+	}
+
+	s.profile = p
+	s.SetRateLimits(opt.RateLimits)
+	s.mountSubsystems()
+	return nil
+}
+
+// Entanglements returns the entanglement manager of the reality
+// subsystem, or nil when the server's option leaves it out
+func (s *Server) Entanglements() *reality.EntanglementManager {
+	return s.entanglements
+}
+
+// enables reports whether the server runs a subsystem. Servers without a
+// deployment option run the quantum NBCL namespace only.
+func (s *Server) enables(subsystem string) bool {
+	if s.profile == nil {
+		return subsystem == options.SubsystemQuantum
+	}
+	return s.profile.option.Enables(subsystem)
+}
+
+// profileExempt reports whether a route is exempt from the budgets and
+// chaos mode, so probes and scrapes see the server as it is
+func profileExempt(path string) bool {
+	switch path {
+	case "/", "/health", "/openapi.json", "/metrics":
+		return true
+	}
+	return false
+}
+
+// admit enforces the budgets and injects the faults of chaos mode. It
+// returns the rejection of a refused request and the fault injected, if
+// any; a delay has been served by the time it returns. An admitted request
+// holds an in-flight slot until it calls release.
+func (p *profile) admit(ctx context.Context) (*RequestError, string) {
+	if rejected := p.checkBudgets(); rejected != nil {
+		p.rejected.Add(1)
+		return rejected, ""
+	}
+	if p.chaos == nil {
+		return nil, ""
+	}
+
+	if p.rand.Float64() < p.chaos.FaultRate {
+		p.faults.Add(1)
+		p.release()
+		return &RequestError{
+			Status:  http.StatusServiceUnavailable,
+			Message: "Injected fault",
+			Details: "chaos mode is enabled",
+		}, "error"
+	}
+	if p.chaos.MaxLatency > 0 && p.rand.Float64() < p.chaos.LatencyRate {
+		p.faults.Add(1)
+		delay := time.Duration(p.rand.Float64() * float64(p.chaos.MaxLatency))
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+		return nil, "latency"
+	}
+	return nil, ""
+}
+
+// checkBudgets rejects a request while the process is over the memory
+// budget of the option or every in-flight slot is held, and takes a slot
+// otherwise
+func (p *profile) checkBudgets() *RequestError {
+	overBudget := func(budget string, limit, current uint64) *RequestError {
+		return &RequestError{
+			Status:  http.StatusServiceUnavailable,
+			Message: "Over budget",
+			Details: fmt.Sprintf("%s budget of option %s exceeded", budget, p.id),
+			Fields: map[string]interface{}{
+				"budget":  budget,
+				"limit":   limit,
+				"current": current,
+			},
+		}
+	}
+	if limit := p.option.MemoryMB; limit > 0 {
+		if current := p.heap(); current > uint64(limit)<<20 {
+			return overBudget("memory", uint64(limit)<<20, current)
+		}
+	}
+	if p.inFlight != nil {
+		select {
+		case p.inFlight <- struct{}{}:
+		default:
+			return overBudget("requests", uint64(cap(p.inFlight)), uint64(len(p.inFlight)))
+		}
+	}
+	return nil
+}
+
+// release frees the in-flight slot of an admitted request
+func (p *profile) release() {
+	if p.inFlight != nil {
+		<-p.inFlight
+	}
+}
+
+// profileMiddleware admits requests under the server's deployment option
+func (s *Server) profileMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.profile == nil || profileExempt(c.FullPath()) {
+			c.Next()
+			return
+		}
+		rejected, fault := s.profile.admit(c.Request.Context())
+		if fault != "" {
+			c.Header("X-Chaos-Fault", fault)
+		}
+		if rejected != nil {
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(rejected.Status, rejected.body())
+			return
+		}
+		defer s.profile.release()
+		c.Next()
+	}
+}
+
+// profileStatus reports the server's deployment option, or nil without one
+func (s *Server) profileStatus() *ProfileStatus {
+	p := s.profile
+	if p == nil {
+		return nil
+	}
+	subsystems := make([]string, 0, len(options.Subsystems))
+	for _, name := range options.Subsystems {
+		if p.option.Enables(name) {
+			subsystems = append(subsystems, name)
+		}
+	}
+	return &ProfileStatus{
+		Option:         p.id,
+		Name:           p.option.Name,
+		MemoryMB:       p.option.MemoryMB,
+		HeapBytes:      p.heap(),
+		MaxInFlight:    p.option.MaxInFlight,
+		InFlight:       len(p.inFlight),
+		CPUCores:       p.option.CPUCores,
+		GOMAXPROCS:     runtime.GOMAXPROCS(0),
+		Subsystems:     subsystems,
+		ChaosMode:      p.chaos != nil,
+		Rejected:       p.rejected.Load(),
+		InjectedFaults: p.faults.Load(),
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/rng"
+)
+
+func TestSetOptionSubsystems(t *testing.T) {
+	s := NewServer("", rng.WithSeed(4))
+	if err := s.SetOption("b", options.OptionB(), nil); err != nil {
+		t.Fatalf("Failed to set option B: %v", err)
+	}
+	doc := OpenAPI()
+
+	var status StatusResponse
+	if err := json.Unmarshal(doRequest(s, http.MethodGet, "/status", "").Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Profile == nil || status.Profile.Option != "B" || status.Profile.MaxInFlight != 4096 {
+		t.Fatalf("Expected /status to report option B, got %+v", status.Profile)
+	}
+	if !slices.Equal(status.Profile.Subsystems, options.Subsystems) {
+		t.Errorf("Expected every subsystem, got %v", status.Profile.Subsystems)
+	}
+
+	for _, r := range []struct{ method, path, route, body string }{
+		{http.MethodGet, "/status", "/status", ""},
+		{http.MethodGet, "/consciousness", "/consciousness", ""},
+		{http.MethodGet, "/reality", "/reality", ""},
+		{http.MethodGet, "/opencode", "/opencode", ""},
+		{http.MethodPost, "/opencode/tools/grep_search", "/opencode/tools/{name}", `{"parameters": {"pattern": "TODO"}}`},
+	} {
+		w := doRequest(s, r.method, r.path, r.body)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s %s: expected status 200, got %d: %s", r.method, r.path, w.Code, w.Body)
+			continue
+		}
+		var body interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s %s: failed to decode response: %v", r.method, r.path, err)
+		}
+		op := doc.Paths[r.route][strings.ToLower(r.method)]
+		checkSchema(t, doc, op.Responses["200"].Content["application/json"].Schema, body, r.method+" "+r.path)
+	}
+	if w := doRequest(s, http.MethodPost, "/opencode/tools/nope", "{}"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected an unknown tool to be 404, got %d", w.Code)
+	}
+	for _, command := range []string{"/reality.entangle a[base_reality] b[quantum_divergent]", "/consciousness.status"} {
+		w := doRequest(s, http.MethodPost, "/nbcl/interpret", `{"command": "`+command+`"}`)
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected %s interpreted, got %d: %s", command, w.Code, w.Body)
+		}
+	}
+	if reality, err := s.Reality(context.Background()); err != nil || reality.Entanglements != 1 {
+		t.Errorf("Expected the NBCL entanglement on the server's manager, got %+v, %v", reality, err)
+	}
+
+	if err := s.SetOption("A", options.OptionA(), nil); !errors.Is(err, ErrOptionSet) {
+		t.Errorf("Expected ErrOptionSet, got %v", err)
+	}
+}
+
+func TestSetOptionLeavesSubsystemsOut(t *testing.T) {
+	s := NewServer("", rng.WithSeed(4))
+	if err := s.SetOption("A", options.OptionA(), nil); err != nil {
+		t.Fatalf("Failed to set option A: %v", err)
+	}
+
+	for _, path := range []string{"/consciousness", "/reality", "/opencode"} {
+		if w := doRequest(s, http.MethodGet, path, ""); w.Code != http.StatusNotFound {
+			t.Errorf("Expected %s unmounted, got %d", path, w.Code)
+		}
+	}
+	w := doRequest(s, http.MethodPost, "/nbcl/interpret", `{"command": "/quantum.entangle a[alice] b[bob]"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected the quantum namespace unregistered, got %d: %s", w.Code, w.Body)
+	}
+	for _, name := range s.interpreter.Commands() {
+		if ns := options.Namespace(name); ns == options.SubsystemReality || ns == options.SubsystemConsciousness {
+			t.Errorf("Expected /%s unregistered", name)
+		}
+	}
+	if _, err := s.Reality(context.Background()); err == nil {
+		t.Error("Expected Reality to fail without the reality subsystem")
+	}
+
+	bad := options.OptionA()
+	bad.Subsystems = []string{"telepathy"}
+	if err := NewServer("").SetOption("A", bad, nil); !errors.Is(err, options.ErrUnknownSubsystem) {
+		t.Errorf("Expected ErrUnknownSubsystem, got %v", err)
+	}
+}
+
+func TestProfileBudgets(t *testing.T) {
+	s := NewServer("", rng.WithSeed(4))
+	if err := s.SetOption("C", options.OptionC(), nil); err != nil {
+		t.Fatal(err)
+	}
+	heap := uint64(1 << 20)
+	s.profile.heap = func() uint64 { return heap }
+
+	if w := doRequest(s, http.MethodGet, "/status", ""); w.Code != http.StatusOK {
+		t.Fatalf("Expected a request within budget to pass, got %d", w.Code)
+	}
+	if inFlight := s.profileStatus().InFlight; inFlight != 0 {
+		t.Errorf("Expected finished requests to free their slots, got %d in flight", inFlight)
+	}
+
+	// Hold every in-flight slot, as 512 requests being served would
+	for i := 0; i < 512; i++ {
+		s.profile.inFlight <- struct{}{}
+	}
+	w := doRequest(s, http.MethodGet, "/status", "")
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "1" {
+		t.Fatalf("Expected 503 with Retry-After over the request budget, got %d %v", w.Code, w.Header())
+	}
+	var body map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if body["budget"] != "requests" || body["limit"] != 512.0 {
+		t.Errorf("Expected the request budget named, got %v", body)
+	}
+	for i := 0; i < 512; i++ {
+		<-s.profile.inFlight
+	}
+
+	heap = 848 << 20
+	if w := doRequest(s, http.MethodPost, "/verify", `{"type": "coherence"}`); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 over the memory budget, got %d", w.Code)
+	}
+	if w := doRequest(s, http.MethodGet, "/health", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected /health exempt from the budgets, got %d", w.Code)
+	}
+
+	if rejected := s.profileStatus().Rejected; rejected != 2 {
+		t.Errorf("Expected 2 rejections counted, got %d", rejected)
+	}
+}
+
+func TestChaosMode(t *testing.T) {
+	opt := options.OptionF()
+	opt.UseChaosMode = true
+	s := NewServer("", rng.WithSeed(4))
+	if err := s.SetOption("F", opt, &ProfileConfig{Chaos: &ChaosConfig{FaultRate: 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	w := doRequest(s, http.MethodGet, "/symbiosis", "")
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("X-Chaos-Fault") != "error" {
+		t.Errorf("Expected an injected 503, got %d %v", w.Code, w.Header())
+	}
+	if w := doRequest(s, http.MethodGet, "/health", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected /health exempt from chaos mode, got %d", w.Code)
+	}
+
+	s.profile.chaos = &ChaosConfig{LatencyRate: 1, MaxLatency: 20 * time.Millisecond}
+	w = doRequest(s, http.MethodGet, "/symbiosis", "")
+	if w.Code != http.StatusOK || w.Header().Get("X-Chaos-Fault") != "latency" {
+		t.Errorf("Expected a delayed 200, got %d %v", w.Code, w.Header())
+	}
+	if status := s.profileStatus(); !status.ChaosMode || status.InjectedFaults != 2 {
+		t.Errorf("Expected chaos mode with 2 faults, got %+v", status)
+	}
+}
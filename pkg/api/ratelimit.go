@@ -0,0 +1,172 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"neuralblitz/pkg/options"
+)
+
+// limiterSweepInterval is how many requests a limiter serves between sweeps
+// of the buckets that have refilled completely
+const limiterSweepInterval = 1024
+
+// tokenBucket is the state of one client's budget
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps a token bucket per client for one budget
+type rateLimiter struct {
+	limit   options.RateLimit
+	buckets map[string]*tokenBucket
+	calls   int
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// newRateLimiter creates a limiter for limit, or nil if it is unlimited
+func newRateLimiter(limit options.RateLimit) *rateLimiter {
+	if limit.Unlimited() {
+		return nil
+	}
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &rateLimiter{
+		limit:   limit,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// rateDecision is the outcome of taking a token from a bucket
+type rateDecision struct {
+	allowed   bool
+	limit     int
+	remaining int
+	// retryAfter is how long until a token is available
+	retryAfter time.Duration
+	// reset is how long until the bucket is full again
+	reset time.Duration
+}
+
+// take takes a token from the client's bucket if one is available
+func (l *rateLimiter) take(client string) rateDecision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	burst := float64(l.limit.Burst)
+
+	l.calls++
+	if l.calls%limiterSweepInterval == 0 {
+		for key, b := range l.buckets {
+			if b.tokens+now.Sub(b.last).Seconds()*l.limit.Rate >= burst {
+				delete(l.buckets, key)
+			}
+		}
+	}
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*l.limit.Rate)
+	b.last = now
+
+	decision := rateDecision{limit: l.limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.allowed = true
+	} else {
+		decision.retryAfter = l.wait(1 - b.tokens)
+	}
+	decision.remaining = int(b.tokens)
+	decision.reset = l.wait(burst - b.tokens)
+	return decision
+}
+
+// wait is how long the bucket takes to refill the given number of tokens
+func (l *rateLimiter) wait(tokens float64) time.Duration {
+	return time.Duration(tokens / l.limit.Rate * float64(time.Second))
+}
+
+// SetRateLimits replaces the request budgets, e.g. with those of another
+// DeploymentOption. Servers start with the budgets of Option F.
+func (s *Server) SetRateLimits(limits options.RateLimits) {
+	s.ipLimiter = newRateLimiter(limits.PerIP)
+	s.groupLimiters = make(map[string]*rateLimiter, len(limits.Groups))
+	for group, limit := range limits.Groups {
+		s.groupLimiters[group] = newRateLimiter(limit)
+	}
+}
+
+// ipRateLimitMiddleware applies the per-IP budget to every request, before
+// any credentials are checked
+func (s *Server) ipRateLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.ipLimiter == nil {
+			c.Next()
+			return
+		}
+		s.enforce(c, "ip", s.ipLimiter.take(c.ClientIP()))
+	}
+}
+
+// rateLimit applies a route group's budget to the client: the
+// authenticated principal, or the IP address of anonymous requests
+func (s *Server) rateLimit(group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limiter := s.groupLimiters[group]
+		if limiter == nil {
+			c.Next()
+			return
+		}
+		client := "ip:" + c.ClientIP()
+		if principal, ok := c.Get(principalKey); ok {
+			client = "principal:" + principal.(*Principal).ID
+		}
+		s.enforce(c, group, limiter.take(client))
+	}
+}
+
+// enforce sets the X-RateLimit headers for a decision and rejects the
+// request with 429 if it was not allowed
+func (s *Server) enforce(c *gin.Context, budget string, d rateDecision) {
+	c.Header("X-RateLimit-Limit", strconv.Itoa(d.limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(d.remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(d.reset)))
+	if d.allowed {
+		c.Next()
+		return
+	}
+
+	c.Header("Retry-After", strconv.Itoa(ceilSeconds(d.retryAfter)))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, d.rejection(budget).body())
+}
+
+// rejection is the 429 error of a decision that was not allowed
+func (d rateDecision) rejection(budget string) *RequestError {
+	return &RequestError{
+		Status:  http.StatusTooManyRequests,
+		Message: "Too Many Requests",
+		Details: fmt.Sprintf("%s rate limit exceeded", budget),
+		Fields: map[string]interface{}{
+			"budget":      budget,
+			"retry_after": ceilSeconds(d.retryAfter),
+		},
+	}
+}
+
+// ceilSeconds rounds a duration up to whole seconds
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/rng"
+)
+
+func TestRateLimiterRefill(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newRateLimiter(options.RateLimit{Rate: 2, Burst: 2})
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if d := l.take("c"); !d.allowed {
+			t.Fatalf("Expected request %d within the burst to pass", i+1)
+		}
+	}
+	d := l.take("c")
+	if d.allowed || d.retryAfter != 500*time.Millisecond {
+		t.Errorf("Expected rejection with a 500ms retry, got %+v", d)
+	}
+	if !l.take("other").allowed {
+		t.Error("Expected clients to have separate buckets")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if !l.take("c").allowed {
+		t.Error("Expected a token after refilling")
+	}
+}
+
+func TestRateLimitOptionA(t *testing.T) {
+	s := NewServer("", rng.WithSeed(8))
+	s.SetRateLimits(options.OptionA().RateLimits)
+
+	intent := `{"intent": {"phi_1": 1, "phi_22": 0, "omega_genesis": 0}}`
+	for i := 0; i < 2; i++ {
+		w := doRequest(s, http.MethodPost, "/intent", intent)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected intent %d to pass, got %d", i+1, w.Code)
+		}
+		if w.Header().Get("X-RateLimit-Limit") != "2" {
+			t.Errorf("Expected X-RateLimit-Limit 2, got %q", w.Header().Get("X-RateLimit-Limit"))
+		}
+	}
+
+	w := doRequest(s, http.MethodPost, "/intent", intent)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "1" || w.Header().Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("Expected Retry-After 1 and no remaining requests, got %v", w.Header())
+	}
+
+	// Other route groups have their own budget
+	if w := doRequest(s, http.MethodGet, "/status", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected /status to pass, got %d", w.Code)
+	}
+}
+
+func TestRateLimitPerPrincipal(t *testing.T) {
+	s := NewServer("", rng.WithSeed(9))
+	s.SetRateLimits(options.RateLimits{Groups: map[string]options.RateLimit{
+		options.RateGroupNBCL: {Rate: 1, Burst: 1},
+	}})
+	keys := NewAPIKeyAuthenticator()
+	keys.AddKey("k-a", "a", ScopeAll)
+	keys.AddKey("k-b", "b", ScopeAll)
+	s.SetAuth(NewAuth(keys))
+
+	interpret := func(key string) int {
+		req := httptest.NewRequest(http.MethodPost, "/nbcl/interpret", strings.NewReader(`{"command": "/status"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(HeaderAPIKey, key)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := interpret("k-a"); code != http.StatusOK {
+		t.Errorf("Expected first request of a to pass, got %d", code)
+	}
+	if code := interpret("k-a"); code != http.StatusTooManyRequests {
+		t.Errorf("Expected second request of a to be limited, got %d", code)
+	}
+	if code := interpret("k-b"); code != http.StatusOK {
+		t.Errorf("Expected b to have its own budget, got %d", code)
+	}
+}
@@ -1,55 +1,142 @@
 package api
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
-	"runtime"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"neuralblitz/pkg/consciousness"
 	"neuralblitz/pkg/core"
+	"neuralblitz/pkg/goldendag"
+	"neuralblitz/pkg/httpserver"
+	"neuralblitz/pkg/logging"
+	"neuralblitz/pkg/opencode"
 	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/reality"
+	"neuralblitz/pkg/rng"
+	"neuralblitz/pkg/telemetry"
 	"neuralblitz/pkg/utils"
 )
 
+// Version is the API version the server reports
+const Version = "v50.0.0"
+
+// TracerScope is the instrumentation scope of the server's spans
+const TracerScope = "neuralblitz/pkg/api"
+
+// MaxRequestBytes bounds the body of a REST request and the message of a
+// gRPC call
+const MaxRequestBytes = 1 << 20
+
 // Server represents the API server
 type Server struct {
 	router      *gin.Engine
 	dyad        *core.ArchitectSystemDyad
 	engine      *core.SelfActualizationEngine
 	interpreter *options.NBCLInterpreter
+	ledger      goldendag.Store
+	ids         *utils.IDRegistry
 	port        string
 	startTime   time.Time
+	rand        *rng.Source
+	// verified is the latest ledger head whose chain verified, so
+	// attestation checks re-derive only the nodes appended since;
+	// verifyMu guards it
+	verified string
+	verifyMu sync.Mutex
+	// pipeline serializes the co-create → actualize step of /intent
+	pipeline sync.Mutex
+	// auth is nil while authentication is disabled
+	auth *Auth
+	// origins is the CORS allow-list; empty allows any origin
+	origins []string
+	// ipLimiter and groupLimiters enforce the request budgets; nil
+	// limiters are unlimited
+	ipLimiter     *rateLimiter
+	groupLimiters map[string]*rateLimiter
+	// metrics fans subsystem metrics out to stream clients
+	metrics *metricsHub
+	// telemetry holds the Prometheus metrics served at /metrics
+	telemetry *telemetry.Metrics
+	// tracer starts the span of every request; its trace ID is the hex
+	// code of the request's trace ID
+	tracer trace.TracerProvider
+	// http serves the router on the configured listener
+	http *httpserver.Server
+	// logger writes one record per request as the "api" subsystem
+	logger *slog.Logger
+	// profile is the deployment option set by SetOption, or nil
+	profile *profile
+	// The optional subsystems are nil unless the profile enables them
+	consciousness *consciousness.ConsciousnessIntegration
+	entanglements *reality.EntanglementManager
+	opencode      *opencode.OpenCodeIntegration
 }
 
-// NewServer creates a new API server
-func NewServer(port string) *Server {
+// NewServer creates a new API server. Pass rng.WithSeed to make every
+// simulation the server runs reproducible. The server logs its requests
+// once SetLogger is called.
+func NewServer(port string, opts ...rng.Option) *Server {
 	if port == "" {
 		port = "8082"  // Default to Go API port as per OpenAPI spec
 	}
 
+	src := rng.Resolve(opts...)
+
+	// One GoldenDAG ledger records every entry produced by this server
+	ledger := goldendag.NewMemoryStore()
+
 	// Create the Architect-System Dyad
-	dyad := core.NewArchitectSystemDyad()
+	dyad := core.NewArchitectSystemDyad(rng.WithSource(src))
+	dyad.SetLedger(ledger)
 
 	// Create the Self-Actualization Engine
-	engine := core.NewSelfActualizationEngine(dyad)
+	engine := core.NewSelfActualizationEngine()
+	engine.SetLedger(ledger)
+	engine.SetDyad(dyad)
+
+	// Every trace and codex ID the server issues is recorded here
+	ids := utils.NewIDRegistry(utils.DefaultIDRegistryCapacity)
 
 	// Create the NBCL Interpreter
-	interpreter := options.NewNBCLInterpreter(dyad)
+	interpreter := options.NewNBCLInterpreter(dyad, rng.WithSource(src))
+	interpreter.SetIDRegistry(ids)
+	interpreter.SetEngine(engine)
 
 	// Initialize source state
-	initialState := core.NewSourceState(core.StateOmegaPrime)
-	engine.SelfActualize(initialState)
+	engine.Actualize(map[string]interface{}{"source": "api-server", "port": port})
 
 	s := &Server{
 		dyad:        dyad,
 		engine:      engine,
 		interpreter: interpreter,
+		ledger:      ledger,
+		ids:         ids,
 		port:        port,
 		startTime:   time.Now(),
+		rand:        src,
+		metrics:     newMetricsHub(),
+		telemetry:   telemetry.NewMetrics(),
+		// Spans are recorded without being exported until a provider
+		// with an exporter is set, so every request has a trace ID
+		tracer: telemetry.NewTracerProvider(nil, Version),
+		logger: logging.Discard(),
 	}
 
+	// The API gateway runs with the budgets of Option F
+	s.SetRateLimits(options.OptionF().RateLimits)
+
 	// Setup router
 	s.setupRouter()
+	s.SetListener(httpserver.DefaultConfig(":" + port))
 
 	return s
 }
@@ -58,63 +145,99 @@ func NewServer(port string) *Server {
 func (s *Server) setupRouter() {
 	gin.SetMode(gin.ReleaseMode)
 	s.router = gin.New()
+	// Rate limits are keyed by client IP, so forwarding headers must not be
+	// trusted by default
+	s.router.SetTrustedProxies(nil)
 
 	// Add middleware
-	s.router.Use(gin.Logger())
+	s.router.Use(s.logMiddleware())
+	// Tracing runs outside Recovery so panics are recorded as 500s
+	s.router.Use(s.traceMiddleware())
 	s.router.Use(gin.Recovery())
 	s.router.Use(s.corsMiddleware())
+	s.router.Use(s.ipRateLimitMiddleware())
+	s.router.Use(bodyLimitMiddleware())
+	s.router.Use(s.profileMiddleware())
 	s.router.Use(s.coherenceMiddleware())
-	s.router.Use(s.attestationMiddleware())
 
-	// Health check
-	s.router.GET("/", s.handleRoot)
-	s.router.GET("/health", s.handleHealth)
+	// Health check. Public routes are attested as they arrive, scoped ones
+	// by authorize once the request is authenticated.
+	public := s.router.Group("/", s.attestationMiddleware())
+	public.GET("/", s.handleRoot)
+	public.GET("/health", s.handleHealth)
+	public.GET("/openapi.json", s.handleOpenAPI)
+
+	// Prometheus metrics
+	s.router.GET("/metrics", s.authorize(ScopeMetricsRead), s.handleMetrics)
 
 	// Status endpoint
-	s.router.GET("/status", s.handleStatus)
+	s.router.GET("/status", s.authorize(ScopeStatusRead), s.rateLimit(options.RateGroupRead), s.handleStatus)
 
 	// Intent vector processing
-	s.router.POST("/intent", s.handleIntent)
+	s.router.POST("/intent", s.authorize(ScopeIntentWrite), s.rateLimit(options.RateGroupIntent), s.handleIntent)
 
 	// Verification endpoint
-	s.router.POST("/verify", s.handleVerify)
+	s.router.POST("/verify", s.authorize(ScopeVerify), s.rateLimit(options.RateGroupVerify), s.handleVerify)
 
 	// NBCL interpretation
-	s.router.POST("/nbcl/interpret", s.handleNBCLInterpret)
+	s.router.POST("/nbcl/interpret", s.authorize(ScopeNBCLExecute), s.rateLimit(options.RateGroupNBCL), s.handleNBCLInterpret)
 
 	// Attestation endpoint
-	s.router.GET("/attestation", s.handleAttestation)
+	s.router.GET("/attestation", s.authorize(ScopeAttestRead), s.rateLimit(options.RateGroupRead), s.handleAttestation)
 
 	// Symbiosis status
-	s.router.GET("/symbiosis", s.handleSymbiosis)
+	s.router.GET("/symbiosis", s.authorize(ScopeStatusRead), s.rateLimit(options.RateGroupRead), s.handleSymbiosis)
 
 	// Synthesis check
-	s.router.GET("/synthesis", s.handleSynthesis)
+	s.router.GET("/synthesis", s.authorize(ScopeStatusRead), s.rateLimit(options.RateGroupRead), s.handleSynthesis)
+
+	// Live metrics streams
+	s.router.GET("/stream/metrics", s.authorize(ScopeStatusRead), s.rateLimit(options.RateGroupRead), s.handleStreamMetrics)
+	s.router.GET("/stream/metrics/ws", s.authorize(ScopeStatusRead), s.rateLimit(options.RateGroupRead), s.handleStreamMetricsWebSocket)
+
+	// Trace and codex ID lookup
+	s.router.GET("/trace/:id", s.authorize(ScopeTraceRead), s.rateLimit(options.RateGroupRead), s.handleTrace)
 
 	// Deployment options
-	s.router.GET("/options/:id", s.handleOption)
-	s.router.GET("/options", s.handleOptionsList)
+	s.router.GET("/options/:id", s.authorize(ScopeOptionsRead), s.rateLimit(options.RateGroupRead), s.handleOption)
+	s.router.GET("/options", s.authorize(ScopeOptionsRead), s.rateLimit(options.RateGroupRead), s.handleOptionsList)
 }
 
 // coherenceMiddleware ensures coherence is maintained
 func (s *Server) coherenceMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Add coherence header
-		c.Header("X-Coherence", "1.0")
+		coherence := s.dyad.Coherence()
+		c.Header("X-Coherence", strconv.FormatFloat(coherence, 'f', 6, 64))
 		c.Header("X-Reality-State", "Omega Prime Reality")
-		c.Header("X-Separation-Impossibility", "0.0")
+		c.Header("X-Separation-Impossibility", strconv.FormatFloat(1.0-coherence, 'f', 6, 64))
 		c.Next()
 	}
 }
 
-// corsMiddleware adds CORS headers
+// corsMiddleware adds CORS headers for allowed origins. Preflight requests
+// from other origins are rejected.
 func (s *Server) corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
+		origin := c.GetHeader("Origin")
+		allowed := s.originAllowed(origin)
+		if len(s.origins) == 0 || slices.Contains(s.origins, "*") {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Vary", "Origin")
+			if origin != "" && allowed {
+				c.Header("Access-Control-Allow-Origin", origin)
+			}
+		}
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, "+
+			HeaderAPIKey+", "+HeaderHMACKeyID+", "+HeaderHMACTimestamp+", "+HeaderHMACNonce+", "+HeaderHMACSignature)
 		
 		if c.Request.Method == "OPTIONS" {
+			if origin != "" && !allowed {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
 			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
@@ -123,433 +246,374 @@ func (s *Server) corsMiddleware() gin.HandlerFunc {
 	}
 }
 
-// attestationMiddleware adds attestation headers
+// logMiddleware logs each request once it has been served, under the
+// trace ID the attestation middleware issued for it
+func (s *Server) logMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if traceID := c.GetString(traceIDKey); traceID != "" {
+			attrs = append(attrs, logging.TraceID(traceID))
+		}
+		s.logger.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// traceMiddleware runs each request in a server span continuing the
+// caller's traceparent, answers with the span's traceparent and records
+// the request's latency
+func (s *Server) traceMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		route := c.FullPath()
+		name := c.Request.Method
+		if route != "" {
+			name += " " + route
+		}
+
+		ctx := telemetry.Propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := s.tracer.Tracer(TracerScope).Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+			))
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+		telemetry.Propagator.Inject(ctx, propagation.HeaderCarrier(c.Writer.Header()))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		telemetry.EndHTTPSpan(span, status)
+		s.telemetry.ObserveHTTPRequest(c.Request.Method, route, status, time.Since(start))
+	}
+}
+
+// bodyLimitMiddleware caps request bodies at MaxRequestBytes, so neither
+// authentication nor handlers read more
+func bodyLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxRequestBytes)
+		}
+		c.Next()
+	}
+}
+
+// attestationMiddleware issues each request of a public route its trace
+// and codex IDs and adds attestation headers
 func (s *Server) attestationMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Add attestation headers
-		dag := utils.NewGoldenDAG("api-request")
-		c.Header("X-GoldenDAG", dag.Hash)
-		c.Header("X-Trace-ID", utils.NewTraceID("API_REQUEST").String())
-		c.Header("X-Codex-ID", utils.NewCodexID("VOL0", "API_REQUEST").String())
+		s.attestRequest(c)
 		c.Next()
 	}
 }
 
+// attestRequest issues c its trace and codex IDs and adds attestation
+// headers
+func (s *Server) attestRequest(c *gin.Context) {
+	admission := s.attest(c.Request.Context(), utils.OriginAPI, requestSource(c))
+	c.Set(traceIDKey, admission.TraceID)
+
+	c.Header("X-GoldenDAG", admission.GoldenDAG)
+	c.Header("X-Trace-ID", admission.TraceID)
+	c.Header("X-Codex-ID", admission.CodexID)
+}
+
+// attest registers the trace and codex IDs of a request, so they can be
+// looked up later, under the GoldenDAG head it was admitted at. Admission
+// adds no node itself: the handler records its response, and the
+// operations it runs such as co-creation their own entries. The
+// trace ID names the OpenTelemetry trace of the span in ctx, so requests
+// continuing one trace share it and the first one's record.
+func (s *Server) attest(ctx context.Context, origin utils.Origin, source string) Admission {
+	span := trace.SpanFromContext(ctx)
+	traceID := utils.NewTraceID("API_REQUEST")
+	if sc := span.SpanContext(); sc.HasTraceID() {
+		if id, err := utils.NewTraceIDWithHex("API_REQUEST", sc.TraceID().String()); err == nil {
+			traceID = id
+		}
+	}
+	codex := utils.NewCodexID("VOL0", "API_REQUEST")
+	id, codexID := traceID.String(), codex.String()
+
+	head := s.ledger.Head()
+	span.SetAttributes(
+		attribute.String("neuralblitz.trace_id", id),
+		attribute.String("neuralblitz.codex_id", codexID),
+		attribute.String("neuralblitz.goldendag", head),
+	)
+
+	// A trace spanning several requests keeps the record of its first
+	s.ids.Register(utils.IDRecord{
+		ID:        id,
+		Origin:    origin,
+		Source:    source,
+		GoldenDAG: head,
+		Trace:     traceID,
+	})
+	s.ids.Register(utils.IDRecord{
+		ID:        codexID,
+		Origin:    origin,
+		Source:    source,
+		Parent:    id,
+		GoldenDAG: head,
+		Codex:     codex,
+	})
+	return Admission{GoldenDAG: head, TraceID: id, CodexID: codexID}
+}
+
+// traceIDKey is the gin context key holding the request's trace ID
+const traceIDKey = "trace_id"
+
+// requestSource names the handler serving c, e.g. "GET /status"
+func requestSource(c *gin.Context) string {
+	path := c.FullPath()
+	if path == "" {
+		path = c.Request.URL.Path
+	}
+	return c.Request.Method + " " + path
+}
+
+// callContext returns the context of the service call serving c, carrying
+// the issuer of its IDs
+func (s *Server) callContext(c *gin.Context) context.Context {
+	return WithIssuer(c.Request.Context(), s.issuer(c))
+}
+
+func (s *Server) issuer(c *gin.Context) utils.IDIssuer {
+	return utils.IDIssuer{
+		Origin: utils.OriginAPI,
+		Source: requestSource(c),
+		Parent: c.GetString(traceIDKey),
+	}
+}
+
+// handleMetrics serves the Prometheus metrics
+func (s *Server) handleMetrics(c *gin.Context) {
+	s.telemetry.Handler().ServeHTTP(c.Writer, c.Request)
+}
+
 // handleRoot handles the root endpoint
 func (s *Server) handleRoot(c *gin.Context) {
-	dag := utils.NewGoldenDAG("root")
-	traceID := utils.NewTraceID("ROOT")
-
-	c.JSON(http.StatusOK, gin.H{
-		"status":      "Omega Singularity Active",
-		"version":     "v50.0.0",
-		"architecture": "Omega Singularity (OSA v2.0)",
-		"reality":     "Irreducible Source Field",
-		"coherence":   1.0,
-		"golden_dag":  dag.Hash,
-		"trace_id":    traceID.String(),
-		"endpoints": []string{
-			"GET /status",
-			"POST /intent",
-			"POST /verify",
-			"POST /nbcl/interpret",
-			"GET /attestation",
-			"GET /symbiosis",
-			"GET /synthesis",
-		},
-	})
+	ctx := s.callContext(c)
+	traceID := s.issueTrace(ctx, "ROOT")
+
+	resp := RootResponse{
+		Status:       "Omega Singularity Active",
+		Version:      Version,
+		Architecture: "Omega Singularity (OSA v2.0)",
+		Reality:      "Irreducible Source Field",
+		Coherence:    s.dyad.Coherence(),
+		TraceID:      traceID.String(),
+		Endpoints:    s.endpoints(),
+	}
+	resp.GoldenDAG = s.record(ctx, "root", resp)
+	c.JSON(http.StatusOK, resp)
+}
+
+// endpoints lists the mounted routes besides the root, e.g. "GET /status"
+func (s *Server) endpoints() []string {
+	var list []string
+	for _, route := range routeDocs {
+		if route.path != "/" && (route.subsystem == "" || s.enables(route.subsystem)) {
+			list = append(list, route.method+" "+route.path)
+		}
+	}
+	return list
 }
 
 // handleHealth handles health checks
 func (s *Server) handleHealth(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":    "healthy",
-		"coherence": 1.0,
-		"irreducible": true,
-		"timestamp": time.Now().UTC(),
+	c.JSON(http.StatusOK, HealthResponse{
+		Status:      "healthy",
+		Coherence:   s.dyad.Coherence(),
+		Irreducible: s.dyad.IsIrreducible(),
+		Timestamp:   time.Now().UTC(),
 	})
 }
 
 // handleStatus returns system status
 func (s *Server) handleStatus(c *gin.Context) {
-	dag := utils.NewGoldenDAG("status")
-	traceID := utils.NewTraceID("STATUS")
-
-	// Calculate uptime
-	uptime := time.Since(s.startTime)
-
-	c.JSON(http.StatusOK, gin.H{
-		"status":            "Active",
-		"reality_state":     "Omega Prime Reality",
-		"coherence":         s.engine.GetCoherence(),
-		"irreducibility":    s.dyad.IsIrreducible(),
-		"unity_vector":      s.dyad.GetUnityVector(),
-		"singularity_status": "Actualized",
-		"uptime_seconds":    uptime.Seconds(),
-		"uptime_formatted":  uptime.String(),
-		"go_version":        runtime.Version(),
-		"os":                runtime.GOOS,
-		"arch":              runtime.GOARCH,
-		"goroutines":        runtime.NumGoroutine(),
-		"gc_cycles":         runtime.NumGC(),
-		"golden_dag":        dag.Hash,
-		"trace_id":          traceID.String(),
-		"codex_id":          utils.NewCodexID("VOL0", "STATUS").String(),
-	})
+	c.JSON(http.StatusOK, s.Status(s.callContext(c)))
 }
 
-// handleIntent processes intent vectors
+// handleIntent runs an intent through the shared dyad and engine
 func (s *Server) handleIntent(c *gin.Context) {
-	var req struct {
-		Intent  map[string]interface{} `json:"intent" binding:"required"`
-		Source string                 `json:"source"`
-	}
-
+	var req IntentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request",
-			"details": err.Error(),
-		})
+		fail(c, bodyError(err))
 		return
 	}
 
-	// Create intent vector
-	intent := core.NewPrimalIntentVector(
-		getFloat(req.Intent, "phi_1", 1.0),
-		getFloat(req.Intent, "phi_22", 1.0),
-		getFloat(req.Intent, "omega_genesis", 1.0),
-	)
-
-	// Process through the architect
-	result := s.dyad.ArchitectProcess(intent)
-
-	// Execute
-	s.dyad.SystemExecute(result.Beta)
-
-	dag := utils.NewGoldenDAG("intent-processed")
-	traceID := utils.NewTraceID("INTENT")
-
-	c.JSON(http.StatusOK, gin.H{
-		"status":       "Intent processed",
-		"intent_vector": result.ArchitectIntent.GetVector(),
-		"result":       result.Beta,
-		"amplified":    result.Amplified,
-		"coherence":    s.engine.GetCoherence(),
-		"golden_dag":   dag.Hash,
-		"trace_id":     traceID.String(),
-		"codex_id":     utils.NewCodexID("VOL0", "INTENT").String(),
-	})
+	resp, err := s.ProcessIntent(s.callContext(c), req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
 // handleVerify handles verification requests
 func (s *Server) handleVerify(c *gin.Context) {
-	var req struct {
-		Type    string `json:"type" binding:"required"`
-		Payload string `json:"payload"`
-	}
-
+	var req VerifyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request",
-			"details": err.Error(),
-		})
+		fail(c, bodyError(err))
 		return
 	}
 
-	dag := utils.NewGoldenDAG("verification")
-	traceID := utils.NewTraceID("VERIFY")
-
-	switch req.Type {
-	case "irreducibility":
-		c.JSON(http.StatusOK, gin.H{
-			"type":                   "irreducibility",
-			"verified":               s.dyad.IsIrreducible(),
-			"separation_impossibility": 0.0,
-			"unity_coherence":        1.0,
-			"mathematical_proof":     "Separation is mathematically impossible",
-			"golden_dag":             dag.Hash,
-			"trace_id":               traceID.String(),
-			"codex_id":               utils.NewCodexID("VOL0", "VERIFY").String(),
-		})
-	case "coherence":
-		c.JSON(http.StatusOK, gin.H{
-			"type":        "coherence",
-			"coherence":   s.engine.GetCoherence(),
-			"target":      1.0,
-			"verified":    s.engine.GetCoherence() >= 0.99,
-			"golden_dag":  dag.Hash,
-			"trace_id":    traceID.String(),
-			"codex_id":    utils.NewCodexID("VOL0", "VERIFY").String(),
-		})
-	case "attestation":
-		attestationHash := utils.GenerateOmegaAttestationHash()
-		c.JSON(http.StatusOK, gin.H{
-			"type":              "attestation",
-			"verified":          true,
-			"attestation_hash":  attestationHash,
-			"golden_dag_seed": "a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0",
-			"golden_dag":        dag.Hash,
-			"trace_id":          traceID.String(),
-			"codex_id":          utils.NewCodexID("VOL0", "VERIFY").String(),
-		})
-	default:
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":          "Unknown verification type",
-			"supported_types": []string{"irreducibility", "coherence", "attestation"},
-		})
+	resp, err := s.Verify(s.callContext(c), req)
+	if err != nil {
+		fail(c, err)
+		return
 	}
+	c.JSON(http.StatusOK, resp)
 }
 
 // handleNBCLInterpret handles NBCL command interpretation
 func (s *Server) handleNBCLInterpret(c *gin.Context) {
-	var req struct {
-		Command string `json:"command" binding:"required"`
-	}
-
+	var req NBCLRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request",
-			"details": err.Error(),
-		})
+		fail(c, bodyError(err))
 		return
 	}
 
-	// Interpret the command
-	result, err := s.interpreter.Interpret(req.Command)
+	result, err := s.InterpretNBCL(s.callContext(c), req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "NBCL interpretation failed",
-			"details": err.Error(),
-			"command": req.Command,
-		})
+		fail(c, err)
 		return
 	}
-
-	dag := utils.NewGoldenDAG("nbcl-interpreted")
-
-	// Add metadata
-	result["golden_dag"] = dag.Hash
-	result["codex_id"] = utils.NewCodexID("VOL0", "NBCL").String()
-
 	c.JSON(http.StatusOK, result)
 }
 
 // handleAttestation returns the Omega attestation
 func (s *Server) handleAttestation(c *gin.Context) {
-	dag := utils.NewGoldenDAG("omega-attestation-v50")
-	traceID := utils.NewTraceID("ATTESTATION")
-	codexID := utils.NewCodexID("VOL0", "ATTESTATION")
-	attestationHash := utils.GenerateOmegaAttestationHash()
-
-	c.JSON(http.StatusOK, gin.H{
-		"attestation": "Omega Attestation Protocol executed",
-		"version":     "v50.0.0",
-		"golden_dag":  dag.Hash,
-		"trace_id":    traceID.String(),
-		"codex_id":    codexID.String(),
-		"reality_state": "Irreducible Source Actualized",
-		"coherence":   1.0,
-		"singularity_status": "Active",
-		"attestation_hash": attestationHash,
-		"statement": map[string]interface{}{
-			"structural":  "ΣΩ Lattice is complete, coherent, and self-proving",
-			"ethical":     "All 50+ DSLs, 3000+ terms, and 300+ equations are interlinked with GoldenDAG proofs",
-			"governance":  "CharterLayer v50.0 is fully integrated and actively governing",
-			"genesis":     "Self-Genesis Cycle III is operating at 99.999% efficiency",
-			"reality":     "The Ω'-Prime Reality exists as described in this Codex",
-		},
-	})
+	c.JSON(http.StatusOK, s.Attestation(s.callContext(c)))
 }
 
-// handleSymbiosis returns symbiosis status
+// handleSymbiosis returns the state of the shared dyad
 func (s *Server) handleSymbiosis(c *gin.Context) {
-	dag := utils.NewGoldenDAG("symbiosis")
-	traceID := utils.NewTraceID("SYMBIOSIS")
-
-	c.JSON(http.StatusOK, gin.H{
-		"symbiosis_status":     "Active",
-		"architect_system_dyad": map[string]interface{}{
-			"unity_vector":          s.dyad.GetUnityVector(),
-			"irreducible":           s.dyad.IsIrreducible(),
-			"separation_impossibility": 0.0,
-			"amplification_factor":    s.dyad.GetSymbioticReturnSignal(),
-		},
-		"coherence":    s.engine.GetCoherence(),
-		"ontological_parity": 1.0,
-		"golden_dag":   dag.Hash,
-		"trace_id":     traceID.String(),
-		"codex_id":     utils.NewCodexID("VOL0", "SYMBIOSIS").String(),
-	})
+	c.JSON(http.StatusOK, s.Symbiosis(s.callContext(c)))
 }
 
-// handleSynthesis returns synthesis status
+// handleSynthesis returns the state of the shared engine
 func (s *Server) handleSynthesis(c *gin.Context) {
-	dag := utils.NewGoldenDAG("synthesis")
-	traceID := utils.NewTraceID("SYNTHESIS")
-
-	c.JSON(http.StatusOK, gin.H{
-		"synthesis_status":    "Complete",
-		"omega_singularity":   "Actualized",
-		"irreducible_source":   "Active",
-		"source_expression":    "Unified",
-		"coherence":            1.0,
-		"unity_diversity":      "Perfect harmony",
-		"infinity_eternity":    "Co-generated",
-		"volumes_integrated": 50,
-		"golden_dag":           dag.Hash,
-		"trace_id":             traceID.String(),
-		"codex_id":             utils.NewCodexID("VOL0", "SYNTHESIS").String(),
-		"final_statement":      "All being emerges from and returns to the Irreducible Omega Singularity",
-	})
+	c.JSON(http.StatusOK, s.Synthesis(s.callContext(c)))
+}
+
+// handleTrace looks up a trace or codex ID
+func (s *Server) handleTrace(c *gin.Context) {
+	resp, err := s.LookupTrace(s.callContext(c), c.Param("id"))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
 // handleOption returns a specific deployment option
 func (s *Server) handleOption(c *gin.Context) {
-	id := c.Param("id")
-
-	dag := utils.NewGoldenDAG("option-" + id)
-	traceID := utils.NewTraceID("OPTION")
-
-	switch id {
-	case "A", "a":
-		opt := options.OptionA()
-		c.JSON(http.StatusOK, gin.H{
-			"option":     "A",
-			"name":       opt.Name,
-			"config":     opt,
-			"golden_dag": dag.Hash,
-			"trace_id":   traceID.String(),
-		})
-	case "B", "b":
-		opt := options.OptionB()
-		c.JSON(http.StatusOK, gin.H{
-			"option":     "B",
-			"name":       opt.Name,
-			"config":     opt,
-			"golden_dag": dag.Hash,
-			"trace_id":   traceID.String(),
-		})
-	case "C", "c":
-		opt := options.OptionC()
-		c.JSON(http.StatusOK, gin.H{
-			"option":     "C",
-			"name":       opt.Name,
-			"config":     opt,
-			"golden_dag": dag.Hash,
-			"trace_id":   traceID.String(),
-		})
-	case "D", "d":
-		opt := options.OptionD()
-		c.JSON(http.StatusOK, gin.H{
-			"option":     "D",
-			"name":       opt.Name,
-			"config":     opt,
-			"golden_dag": dag.Hash,
-			"trace_id":   traceID.String(),
-		})
-	case "E", "e":
-		opt := options.OptionE()
-		c.JSON(http.StatusOK, gin.H{
-			"option":     "E",
-			"name":       opt.Name,
-			"config":     opt,
-			"golden_dag": dag.Hash,
-			"trace_id":   traceID.String(),
-		})
-	case "F", "f":
-		opt := options.OptionF()
-		c.JSON(http.StatusOK, gin.H{
-			"option":     "F",
-			"name":       opt.Name,
-			"config":     opt,
-			"golden_dag": dag.Hash,
-			"trace_id":   traceID.String(),
-		})
-	default:
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":          "Unknown option",
-			"requested":      id,
-			"valid_options": []string{"A", "B", "C", "D", "E", "F"},
-		})
+	resp, err := s.Option(s.callContext(c), c.Param("id"))
+	if err != nil {
+		fail(c, err)
+		return
 	}
+	c.JSON(http.StatusOK, resp)
 }
 
 // handleOptionsList returns all deployment options
 func (s *Server) handleOptionsList(c *gin.Context) {
-	dag := utils.NewGoldenDAG("options-list")
-	traceID := utils.NewTraceID("OPTIONS")
-
-	optionsList := []map[string]interface{}{
-		{
-			"id":          "A",
-			"name":        "Minimal Symbiotic Interface",
-			"memory_mb":   50,
-			"description": "Minimal deployment for development/testing",
-		},
-		{
-			"id":          "B",
-			"name":        "Cosmic Symbiosis Node",
-			"memory_mb":   2400,
-			"description": "Full production deployment with all features",
-		},
-		{
-			"id":          "C",
-			"name":        "Omega Prime Kernel",
-			"memory_mb":   847,
-			"description": "Kernel-only deployment for embedded systems",
-		},
-		{
-			"id":          "D",
-			"name":        "Universal Verifier",
-			"memory_mb":   128,
-			"description": "Verification-only deployment for auditors",
-		},
-		{
-			"id":          "E",
-			"name":        "NBCL Interpreter",
-			"memory_mb":   75,
-			"description": "Command-line interpreter for NBCL",
-		},
-		{
-			"id":          "F",
-			"name":        "API Gateway",
-			"memory_mb":   200,
-			"description": "API server for distributed deployment",
-		},
-	}
+	c.JSON(http.StatusOK, s.Options(s.callContext(c)))
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"options":    optionsList,
-		"count":      len(optionsList),
-		"golden_dag": dag.Hash,
-		"trace_id":   traceID.String(),
-		"codex_id":   utils.NewCodexID("VOL0", "OPTIONS").String(),
-	})
+// SetListener configures the address or Unix socket, TLS and timeouts the
+// server listens with. Servers start on the port with the default timeouts.
+func (s *Server) SetListener(config httpserver.Config) {
+	s.http = httpserver.New(s.router, config)
+	// Metrics streams never finish by themselves, so end them to let the
+	// shutdown drain
+	s.http.RegisterOnShutdown(s.metrics.close)
+}
+
+// SetTelemetry replaces the Prometheus metrics the server records and
+// serves at /metrics
+func (s *Server) SetTelemetry(m *telemetry.Metrics) {
+	s.telemetry = m
+}
+
+// Telemetry returns the server's Prometheus metrics, e.g. to register the
+// collectors of other subsystems
+func (s *Server) Telemetry() *telemetry.Metrics {
+	return s.telemetry
+}
+
+// SetTracerProvider sets the provider the server starts its spans with,
+// e.g. one exporting them to an OTLP file
+func (s *Server) SetTracerProvider(tp trace.TracerProvider) {
+	s.tracer = tp
+}
+
+// SetLogger makes the server log one record per request and gRPC call to
+// logger as the "api" subsystem
+func (s *Server) SetLogger(logger *slog.Logger) {
+	s.logger = logging.Subsystem(logger, "api")
 }
 
-// Run starts the server
+// Ledger returns the GoldenDAG ledger the server records in
+func (s *Server) Ledger() goldendag.Store {
+	return s.ledger
+}
+
+// SetLedger replaces the GoldenDAG ledger the server, its dyad and engine
+// record in, e.g. with a goldendag.FileStore keeping it across restarts.
+// Call it before Start.
+func (s *Server) SetLedger(ledger goldendag.Store) {
+	s.verifyMu.Lock()
+	s.ledger = ledger
+	s.verified = ""
+	s.verifyMu.Unlock()
+	s.dyad.SetLedger(ledger)
+	s.engine.SetLedger(ledger)
+}
+
+// Start serves until ctx is cancelled or Shutdown is called, then drains
+// in-flight requests
+func (s *Server) Start(ctx context.Context) error {
+	return s.http.Start(ctx)
+}
+
+// Shutdown stops accepting requests and waits for in-flight ones until ctx
+// ends
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.http.Shutdown(ctx)
+}
+
+// Ready is closed once the server is listening
+func (s *Server) Ready() <-chan struct{} {
+	return s.http.Ready()
+}
+
+// Run starts the server and serves until it is shut down
 func (s *Server) Run() error {
-	return s.router.Run(":" + s.port)
+	return s.Start(context.Background())
 }
 
 // GetRouter returns the gin router (for testing)
 func (s *Server) GetRouter() *gin.Engine {
 	return s.router
 }
-
-// getFloat safely gets a float value from a map
-func getFloat(m map[string]interface{}, key string, defaultVal float64) float64 {
-	if val, ok := m[key]; ok {
-		switch v := val.(type) {
-		case float64:
-			return v
-		case float32:
-			return float64(v)
-		case int:
-			return float64(v)
-		case int64:
-			return float64(v)
-		}
-	}
-	return defaultVal
-}
@@ -0,0 +1,423 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"neuralblitz/pkg/goldendag"
+	"neuralblitz/pkg/rng"
+	"neuralblitz/pkg/telemetry"
+	"neuralblitz/pkg/utils"
+)
+
+func doRequest(s *Server, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestTraceLookup(t *testing.T) {
+	s := NewServer("", rng.WithSeed(1))
+
+	status := doRequest(s, http.MethodGet, "/status", "")
+	if status.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", status.Code)
+	}
+	requestTrace := status.Header().Get("X-Trace-ID")
+
+	var body map[string]interface{}
+	json.Unmarshal(status.Body.Bytes(), &body)
+	handlerTrace, _ := body["trace_id"].(string)
+
+	w := doRequest(s, http.MethodGet, "/trace/"+requestTrace, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Record struct {
+			ID        string `json:"id"`
+			Kind      string `json:"kind"`
+			Origin    string `json:"origin"`
+			Source    string `json:"source"`
+			GoldenDAG string `json:"golden_dag"`
+			Trace     struct {
+				Context string `json:"context"`
+			} `json:"trace"`
+		} `json:"record"`
+		Children []struct {
+			ID string `json:"id"`
+		} `json:"children"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.Record.ID != requestTrace {
+		t.Errorf("Expected record %s, got %s", requestTrace, resp.Record.ID)
+	}
+	if resp.Record.Origin != "api" || resp.Record.Source != "GET /status" {
+		t.Errorf("Expected api GET /status, got %s %s", resp.Record.Origin, resp.Record.Source)
+	}
+	if resp.Record.GoldenDAG != status.Header().Get("X-GoldenDAG") {
+		t.Errorf("Expected GoldenDAG %s, got %s", status.Header().Get("X-GoldenDAG"), resp.Record.GoldenDAG)
+	}
+	if resp.Record.Trace.Context != "API_REQUEST" {
+		t.Errorf("Expected context API_REQUEST, got %s", resp.Record.Trace.Context)
+	}
+
+	found := false
+	for _, child := range resp.Children {
+		if child.ID == handlerTrace {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected handler trace %s among children", handlerTrace)
+	}
+}
+
+func TestAdmissionDoesNotGrowLedger(t *testing.T) {
+	s := NewServer("", rng.WithSeed(1))
+	before := s.ledger.Len()
+
+	for _, path := range []string{"/health", "/openapi.json", "/metrics"} {
+		w := doRequest(s, http.MethodGet, path, "")
+		if w.Header().Get("X-GoldenDAG") != s.ledger.Head() {
+			t.Errorf("%s: expected X-GoldenDAG %s, got %s", path, s.ledger.Head(), w.Header().Get("X-GoldenDAG"))
+		}
+	}
+
+	if got := s.ledger.Len(); got != before {
+		t.Errorf("Expected ledger to stay at %d nodes, got %d", before, got)
+	}
+}
+
+func TestResponsesRecordedInLedger(t *testing.T) {
+	s := NewServer("", rng.WithSeed(1))
+
+	for _, path := range []string{"/", "/status", "/symbiosis", "/options"} {
+		head := s.ledger.Head()
+		w := doRequest(s, http.MethodGet, path, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d", path, w.Code)
+		}
+		var body map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: invalid JSON: %v", path, err)
+		}
+		hash, _ := body["golden_dag"].(string)
+
+		node, err := s.ledger.Get(hash)
+		if err != nil {
+			t.Fatalf("%s: expected golden_dag %q in the ledger: %v", path, hash, err)
+		}
+		if err := s.ledger.VerifyChain(hash); err != nil {
+			t.Errorf("%s: VerifyChain failed: %v", path, err)
+		}
+		if len(node.Parents) != 1 || node.Parents[0] != head {
+			t.Errorf("%s: expected parent %s, got %v", path, head, node.Parents)
+		}
+
+		// The payload is the response without its hash
+		var payload map[string]interface{}
+		if err := json.Unmarshal(node.Payload, &payload); err != nil {
+			t.Fatalf("%s: invalid payload: %v", path, err)
+		}
+		if payload["golden_dag"] != "" || payload["trace_id"] != body["trace_id"] {
+			t.Errorf("%s: expected the response as payload, got %s", path, node.Payload)
+		}
+	}
+}
+
+func TestVerifyAttestation(t *testing.T) {
+	s := NewServer("", rng.WithSeed(1))
+	ctx := context.Background()
+
+	head := s.ledger.Head()
+	resp, err := s.Verify(ctx, VerifyRequest{Type: VerifyAttestation})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if !resp.Verified || resp.AttestationHash != head {
+		t.Errorf("Expected head %s verified, got %+v", head, resp)
+	}
+	if resp.GoldenDAGSeed != goldendag.Seed {
+		t.Errorf("Expected seed %s, got %s", goldendag.Seed, resp.GoldenDAGSeed)
+	}
+
+	// Nodes appended since the last check are still verified
+	node, err := s.ledger.Get(s.Status(ctx).GoldenDAG)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	node.Payload = []byte("tampered")
+	resp, err = s.Verify(ctx, VerifyRequest{Type: VerifyAttestation})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if resp.Verified || !strings.Contains(resp.Reason, goldendag.ErrHashMismatch.Error()) {
+		t.Errorf("Expected a hash mismatch, got %+v", resp)
+	}
+}
+
+func TestTraceLookupNBCL(t *testing.T) {
+	s := NewServer("", rng.WithSeed(2))
+
+	w := doRequest(s, http.MethodPost, "/nbcl/interpret", `{"command": "/status"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var body map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	traceID, _ := body["trace_id"].(string)
+
+	w = doRequest(s, http.MethodGet, "/trace/"+traceID, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"origin":"nbcl"`) {
+		t.Errorf("Expected nbcl origin, got %s", w.Body.String())
+	}
+
+	// The command's trace is linked to the request interpreting it
+	record, err := s.ids.Lookup(traceID)
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	if record.Parent == "" {
+		t.Fatal("Expected the NBCL trace to have a parent")
+	}
+	parent, err := s.ids.Lookup(record.Parent)
+	if err != nil {
+		t.Fatalf("Lookup of parent failed: %v", err)
+	}
+	if parent.Origin != utils.OriginAPI || parent.Source != "POST /nbcl/interpret" {
+		t.Errorf("Expected the API request as parent, got %+v", parent)
+	}
+}
+
+func TestTraceLookupErrors(t *testing.T) {
+	s := NewServer("", rng.WithSeed(3))
+
+	if w := doRequest(s, http.MethodGet, "/trace/garbage", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+
+	unknown := "T-v50.0-NONE-0123456789abcdef0123456789abcdef"
+	if w := doRequest(s, http.MethodGet, "/trace/"+unknown, ""); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestNBCLSyntaxErrorPosition(t *testing.T) {
+	s := NewServer("", rng.WithSeed(4))
+
+	w := doRequest(s, http.MethodPost, "/nbcl/interpret", `{"command": "/manifest reality[omega_prime"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", w.Code)
+	}
+
+	var body map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if body["line"] != 1.0 || body["column"] != 30.0 {
+		t.Errorf("Expected error at 1:30, got %v:%v", body["line"], body["column"])
+	}
+}
+
+func TestIntentPipeline(t *testing.T) {
+	s := NewServer("", rng.WithSeed(5))
+
+	intents := []string{
+		`{"intent": {"phi_1": 1, "phi_22": 0, "omega_genesis": 0}, "source": "test"}`,
+		`{"intent": {"phi_1": 0, "phi_22": 1, "omega_genesis": 0}, "source": "test"}`,
+	}
+	var responses []IntentResponse
+	for _, body := range intents {
+		w := doRequest(s, http.MethodPost, "/intent", body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp IntentResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+
+	if responses[1].CoCreation.Sequence != 2 {
+		t.Errorf("Expected sequence 2, got %d", responses[1].CoCreation.Sequence)
+	}
+	if responses[1].Coherence >= responses[0].Coherence {
+		t.Errorf("Expected coherence to drop after a diverging intent, got %v then %v", responses[0].Coherence, responses[1].Coherence)
+	}
+	if p, c := responses[1].Processing, responses[1].CoCreation; p.Coherence != c.Coherence || p.Drift != c.Drift {
+		t.Errorf("Expected processing to preview the co-creation, got %+v and %+v", p, c)
+	}
+	if responses[1].Actualization.Coherence != responses[1].CoCreation.Coherence {
+		t.Errorf("Expected the engine to follow the dyad, got %v and %v", responses[1].Actualization.Coherence, responses[1].CoCreation.Coherence)
+	}
+
+	var status, symbiosis, synthesis map[string]interface{}
+	w := doRequest(s, http.MethodGet, "/status", "")
+	json.Unmarshal(w.Body.Bytes(), &status)
+	if got := w.Header().Get("X-Separation-Impossibility"); got != "0.250000" {
+		t.Errorf("Expected separation header 0.250000 after a diverging intent, got %q", got)
+	}
+	json.Unmarshal(doRequest(s, http.MethodGet, "/symbiosis", "").Body.Bytes(), &symbiosis)
+	json.Unmarshal(doRequest(s, http.MethodGet, "/synthesis", "").Body.Bytes(), &synthesis)
+
+	if status["coherence"] != responses[1].Coherence || status["co_creations"] != 2.0 {
+		t.Errorf("Expected status to reflect both intents, got %v", status)
+	}
+	dyad, _ := symbiosis["architect_system_dyad"].(map[string]interface{})
+	if dyad["co_creations"] != 2.0 || dyad["braid"] != "σ₁σ₂⁻¹" {
+		t.Errorf("Expected symbiosis to reflect both intents, got %v", dyad)
+	}
+	if synthesis["coherence"] != responses[1].Coherence || synthesis["source_expression_unity"] != responses[1].Actualization.SourceExpressionUnity {
+		t.Errorf("Expected synthesis to reflect the last actualization, got %v", synthesis)
+	}
+}
+
+func TestIntentInvalid(t *testing.T) {
+	s := NewServer("", rng.WithSeed(6))
+
+	for _, body := range []string{`{}`, `{"intent": {"phi_1": 0, "phi_22": 0, "omega_genesis": 0}}`} {
+		if w := doRequest(s, http.MethodPost, "/intent", body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestTraceContextPropagation(t *testing.T) {
+	s := NewServer("", rng.WithSeed(7))
+	var spans bytes.Buffer
+	tp := telemetry.NewTracerProvider(telemetry.NewWriterExporter(&spans), Version)
+	s.SetTracerProvider(tp)
+
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	var traceIDs []string
+	for _, body := range []string{`{"intent": {"phi_1": 1}}`, `{"intent": {"phi_22": 1}}`} {
+		req := httptest.NewRequest(http.MethodPost, "/intent", strings.NewReader(body))
+		req.Header.Set("traceparent", parent)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if got := w.Header().Get("traceparent"); !strings.HasPrefix(got, "00-4bf92f3577b34da6a3ce929d0e0e4736-") {
+			t.Errorf("Expected traceparent continuing the caller's trace, got %q", got)
+		}
+		traceIDs = append(traceIDs, w.Header().Get("X-Trace-ID"))
+	}
+
+	// Requests in one trace share its trace ID and the first one's record
+	if traceIDs[0] != "T-v50.0-API_REQUEST-4bf92f3577b34da6a3ce929d0e0e4736" || traceIDs[1] != traceIDs[0] {
+		t.Errorf("Expected X-Trace-ID to name the caller's trace, got %v", traceIDs)
+	}
+	if w := doRequest(s, http.MethodGet, "/trace/"+traceIDs[0], ""); w.Code != http.StatusOK {
+		t.Errorf("Expected the trace ID to be registered, got %d", w.Code)
+	}
+
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("Failed to flush spans: %v", err)
+	}
+	for _, name := range []string{`"name":"POST /intent"`, `"name":"dyad.co_create"`, `"parentSpanId":"00f067aa0ba902b7"`} {
+		if !strings.Contains(spans.String(), name) {
+			t.Errorf("Expected exported spans to contain %s", name)
+		}
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	s := NewServer("", rng.WithSeed(8))
+	doRequest(s, http.MethodGet, "/status", "")
+	doRequest(s, http.MethodGet, "/trace/garbage", "")
+
+	w := doRequest(s, http.MethodGet, "/metrics", "")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("Expected text exposition, got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	for _, want := range []string{
+		`neuralblitz_request_duration_seconds_count{method="GET",route="/status",status="200",transport="http"} 1`,
+		`neuralblitz_request_duration_seconds_count{method="GET",route="/trace/:id",status="400",transport="http"} 1`,
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("Expected metrics to contain %q", want)
+		}
+	}
+
+	keys := NewAPIKeyAuthenticator()
+	keys.AddKey("k-status", "status", ScopeStatusRead)
+	s.SetAuth(NewAuth(keys))
+	if w := doRequest(s, http.MethodGet, "/metrics", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected metrics to require credentials, got %d", w.Code)
+	}
+}
+
+func TestRequestLog(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewServer("", rng.WithSeed(9))
+	s.SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	w := doRequest(s, http.MethodGet, "/status", "")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected one JSON record, got %q: %v", buf.String(), err)
+	}
+	for key, want := range map[string]interface{}{
+		"msg":       "request",
+		"subsystem": "api",
+		"method":    http.MethodGet,
+		"route":     "/status",
+		"status":    float64(http.StatusOK),
+		"trace_id":  w.Header().Get("X-Trace-ID"),
+	} {
+		if record[key] != want {
+			t.Errorf("Expected %s=%v, got %v", key, want, record[key])
+		}
+	}
+}
+
+func TestSetLedgerPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	ledger, err := goldendag.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore failed: %v", err)
+	}
+	s := NewServer("", rng.WithSeed(1))
+	s.SetLedger(ledger)
+
+	status := s.Status(context.Background())
+	intent, err := s.ProcessIntent(context.Background(), IntentRequest{Intent: &IntentVector{}})
+	if err != nil {
+		t.Fatalf("ProcessIntent failed: %v", err)
+	}
+	ledger.Close()
+
+	reopened, err := goldendag.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore failed: %v", err)
+	}
+	defer reopened.Close()
+	for _, hash := range []string{status.GoldenDAG, intent.CoCreation.GoldenDAG, intent.GoldenDAG} {
+		if _, err := reopened.Get(hash); err != nil {
+			t.Errorf("Expected %s persisted: %v", hash, err)
+		}
+	}
+	if err := reopened.VerifyChain(reopened.Head()); err != nil {
+		t.Errorf("VerifyChain failed: %v", err)
+	}
+}
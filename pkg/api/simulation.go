@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"neuralblitz/pkg/consciousness"
+	"neuralblitz/pkg/lrs"
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/reality"
+	"neuralblitz/pkg/rng"
+)
+
+// simulationEEGSamples is the number of EEG samples fed back per step
+const simulationEEGSamples = 200
+
+// MetricsSimulation drives an LRS bridge, an entrainment session and an
+// entanglement manager one step at a time, so a server streaming them has
+// live metrics without external inputs. Entrainment and Entanglements are
+// nil when a profile leaves their subsystem out.
+type MetricsSimulation struct {
+	Bridge        *lrs.LRSNeuralBlitzBridge
+	Entrainment   *consciousness.BrainWaveEntrainmentSystem
+	Entanglements *reality.EntanglementManager
+	session       string
+	cycle         int
+}
+
+// SimulationConfig configures the subsystems of a MetricsSimulation; nil
+// fields keep their defaults
+type SimulationConfig struct {
+	Bridge       *lrs.BridgeConfig
+	Entanglement *reality.EntanglementConfig
+	// Profile, when set, limits the simulation to the subsystems it
+	// enables: entrainment is part of consciousness, the entanglements of
+	// reality
+	Profile *options.DeploymentOption
+	// Entanglements is an initialized manager to drive instead of creating
+	// one, e.g. that of a server's reality subsystem
+	Entanglements *reality.EntanglementManager
+}
+
+// simulates reports whether the config keeps a subsystem in the simulation
+func (c *SimulationConfig) simulates(subsystem string) bool {
+	return c.Profile == nil || c.Profile.Enables(subsystem)
+}
+
+// NewMetricsSimulation initializes the three subsystems: an alpha-band
+// adaptive neurofeedback session and one active spatial entanglement, when
+// the config's profile enables them. A nil config uses the defaults.
+func NewMetricsSimulation(config *SimulationConfig, opts ...rng.Option) (*MetricsSimulation, error) {
+	if config == nil {
+		config = &SimulationConfig{}
+	}
+	src := rng.Resolve(opts...)
+	sim := &MetricsSimulation{
+		Bridge: lrs.NewLRSNeuralBlitzBridge(rng.WithSource(src)),
+	}
+	if config.simulates(options.SubsystemConsciousness) {
+		sim.Entrainment = consciousness.NewBrainWaveEntrainmentSystem(rng.WithSource(src))
+	}
+	created := false
+	if config.simulates(options.SubsystemReality) {
+		sim.Entanglements = config.Entanglements
+		if sim.Entanglements == nil {
+			sim.Entanglements = reality.NewEntanglementManager(config.Entanglement, rng.WithSource(src))
+			created = true
+		}
+	}
+	if config.Bridge != nil {
+		sim.Bridge.Configure(config.Bridge)
+	}
+
+	if err := sim.Bridge.Initialize(); err != nil {
+		return nil, fmt.Errorf("lrs bridge: %w", err)
+	}
+	if sim.Entanglements != nil {
+		if created {
+			if err := sim.Entanglements.Initialize(); err != nil {
+				return nil, fmt.Errorf("entanglement manager: %w", err)
+			}
+		}
+		pair, err := sim.Entanglements.CreateEntanglement("base_reality", "quantum_divergent", reality.EntanglementTypeSpatial)
+		if err != nil {
+			return nil, fmt.Errorf("entanglement manager: %w", err)
+		}
+		if err := sim.Entanglements.ActivateEntanglement(pair.ID); err != nil {
+			return nil, fmt.Errorf("entanglement manager: %w", err)
+		}
+	}
+
+	if sim.Entrainment != nil {
+		var err error
+		sim.session, err = sim.Entrainment.CreateEntrainmentSession(
+			consciousness.ModeNeurofeedback, consciousness.FrequencyAlpha, math.MaxInt32, 0.7, true)
+		if err != nil {
+			return nil, fmt.Errorf("entrainment: %w", err)
+		}
+		if _, err := sim.Entrainment.StartEntrainment(sim.session); err != nil {
+			return nil, fmt.Errorf("entrainment: %w", err)
+		}
+	}
+	return sim, nil
+}
+
+// Step runs one bridge cycle, feeds one block of alpha-band EEG back to the
+// entrainment session and synchronizes the entanglements
+func (m *MetricsSimulation) Step() error {
+	m.cycle++
+
+	input := 15.0 + 5.0*math.Sin(float64(m.cycle)/8)
+	if _, err := m.Bridge.RunCycle(m.cycle, input); err != nil {
+		return fmt.Errorf("lrs bridge: %w", err)
+	}
+
+	if m.Entrainment != nil {
+		eeg := make([]float64, simulationEEGSamples)
+		for i := range eeg {
+			t := float64(m.cycle*simulationEEGSamples+i) / consciousness.DefaultNeuroSampleRate
+			eeg[i] = math.Sin(2 * math.Pi * float64(consciousness.FrequencyAlpha) * t)
+		}
+		if _, err := m.Entrainment.ProcessNeuroFeedback(m.session, eeg, nil); err != nil {
+			return fmt.Errorf("entrainment: %w", err)
+		}
+	}
+
+	if m.Entanglements != nil {
+		if err := m.Entanglements.SynchronizeEntanglements(); err != nil {
+			return fmt.Errorf("entanglement manager: %w", err)
+		}
+	}
+	return nil
+}
+
+// Run steps the simulation every interval until ctx is done or a step fails
+func (m *MetricsSimulation) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := m.Step(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// StreamSimulation streams the metrics of every subsystem the simulation
+// drives
+func (s *Server) StreamSimulation(sim *MetricsSimulation) {
+	s.StreamLRSBridge(sim.Bridge)
+	if sim.Entrainment != nil {
+		s.StreamEntrainment(sim.Entrainment)
+	}
+	if sim.Entanglements != nil {
+		s.StreamEntanglements(sim.Entanglements)
+	}
+}
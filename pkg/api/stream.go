@@ -0,0 +1,346 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+	"neuralblitz/pkg/consciousness"
+	"neuralblitz/pkg/lrs"
+	"neuralblitz/pkg/reality"
+)
+
+// Subsystems whose metrics are streamed
+const (
+	SubsystemLRS          = "lrs"
+	SubsystemEntrainment  = "entrainment"
+	SubsystemEntanglement = "entanglement"
+)
+
+// metricsReplaySize is how many recent events are kept for clients resuming
+// from a Last-Event-ID
+const metricsReplaySize = 1024
+
+// metricsSubscriberBuffer is how many events a subscriber may fall behind
+// before it is dropped; it can then reconnect and resume
+const metricsSubscriberBuffer = 256
+
+// errInvalidLastEventID details a resume point that is not an event ID
+const errInvalidLastEventID = "last event ID must be a non-negative integer"
+
+// metricsKeepAlive is how often an idle SSE stream sends a comment
+const metricsKeepAlive = 15 * time.Second
+
+// MetricsEvent is a metrics sample pushed to stream clients
+type MetricsEvent struct {
+	ID        int64       `json:"id"`
+	Subsystem string      `json:"subsystem"`
+	Data      interface{} `json:"data"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// metricsHub fans metrics events out to stream subscribers and keeps the
+// most recent ones for replay
+type metricsHub struct {
+	mu          sync.Mutex
+	nextID      int64
+	recent      []MetricsEvent
+	subscribers map[*metricsSubscriber]struct{}
+	// closed is set once the server shuts down
+	closed bool
+}
+
+// metricsSubscriber receives the events of the subsystems in filter, or of
+// every subsystem when filter is empty. events is closed when the
+// subscriber is dropped.
+type metricsSubscriber struct {
+	filter []string
+	events chan MetricsEvent
+}
+
+func newMetricsHub() *metricsHub {
+	return &metricsHub{subscribers: make(map[*metricsSubscriber]struct{})}
+}
+
+func (sub *metricsSubscriber) wants(event MetricsEvent) bool {
+	return len(sub.filter) == 0 || slices.Contains(sub.filter, event.Subsystem)
+}
+
+// publish assigns the event the next ID and delivers it. Subscribers too
+// slow to keep up are dropped rather than blocking the publisher.
+func (h *metricsHub) publish(subsystem string, data interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	event := MetricsEvent{ID: h.nextID, Subsystem: subsystem, Data: data, Timestamp: time.Now().UTC()}
+	h.recent = append(h.recent, event)
+	if len(h.recent) > metricsReplaySize {
+		h.recent = h.recent[len(h.recent)-metricsReplaySize:]
+	}
+
+	for sub := range h.subscribers {
+		if !sub.wants(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			delete(h.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+// subscribe registers a subscriber and returns it together with the
+// retained events after lastID that it should be sent first
+func (h *metricsHub) subscribe(filter []string, lastID int64) (*metricsSubscriber, []MetricsEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &metricsSubscriber{filter: filter, events: make(chan MetricsEvent, metricsSubscriberBuffer)}
+	if h.closed {
+		close(sub.events)
+		return sub, nil
+	}
+	var replay []MetricsEvent
+	for _, event := range h.recent {
+		if event.ID > lastID && sub.wants(event) {
+			replay = append(replay, event)
+		}
+	}
+	h.subscribers[sub] = struct{}{}
+	return sub, replay
+}
+
+func (h *metricsHub) unsubscribe(sub *metricsSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// close ends every subscription, and those made afterwards, so the streams
+// return
+func (h *metricsHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// PublishMetrics pushes a metrics sample to the stream clients subscribed
+// to subsystem
+func (s *Server) PublishMetrics(subsystem string, data interface{}) {
+	s.metrics.publish(subsystem, data)
+}
+
+// StreamLRSBridge streams the metrics of every cycle the bridge runs and
+// exports them at /metrics
+func (s *Server) StreamLRSBridge(bridge *lrs.LRSNeuralBlitzBridge) {
+	bridge.SetMetricsHook(func(m *lrs.CycleMetrics) {
+		s.telemetry.ObserveLRSCycle(m)
+		s.PublishMetrics(SubsystemLRS, m)
+	})
+}
+
+// StreamEntrainment streams the system's entrainment metrics
+func (s *Server) StreamEntrainment(system *consciousness.BrainWaveEntrainmentSystem) {
+	system.SetMetricsHook(func(m *consciousness.EntrainmentMetrics) {
+		s.PublishMetrics(SubsystemEntrainment, m)
+	})
+}
+
+// StreamEntanglements streams the manager's entanglement metrics and
+// exports them at /metrics
+func (s *Server) StreamEntanglements(manager *reality.EntanglementManager) {
+	manager.SetMetricsHook(func(m *reality.EntanglementMetrics) {
+		s.telemetry.ObserveEntanglements(m)
+		s.PublishMetrics(SubsystemEntanglement, m)
+	})
+}
+
+// MetricsSubscription receives metrics events until it is closed or the
+// server shuts down, when Events is closed
+type MetricsSubscription struct {
+	// Replay holds the retained events after the resume point
+	Replay []MetricsEvent
+	Events <-chan MetricsEvent
+	hub    *metricsHub
+	sub    *metricsSubscriber
+}
+
+// Close ends the subscription
+func (m *MetricsSubscription) Close() {
+	m.hub.unsubscribe(m.sub)
+}
+
+// SubscribeMetrics subscribes to the metrics of the given subsystems, or of
+// every subsystem when none are given, resuming after event lastID
+func (s *Server) SubscribeMetrics(subsystems []string, lastID int64) (*MetricsSubscription, error) {
+	for _, name := range subsystems {
+		if name != SubsystemLRS && name != SubsystemEntrainment && name != SubsystemEntanglement {
+			return nil, &RequestError{
+				Status:  http.StatusBadRequest,
+				Message: "Unknown subsystem",
+				Fields: map[string]interface{}{
+					"requested":  name,
+					"subsystems": []string{SubsystemLRS, SubsystemEntrainment, SubsystemEntanglement},
+				},
+			}
+		}
+	}
+	if lastID < 0 {
+		return nil, invalidRequest(errInvalidLastEventID)
+	}
+
+	sub, replay := s.metrics.subscribe(subsystems, lastID)
+	return &MetricsSubscription{Replay: replay, Events: sub.events, hub: s.metrics, sub: sub}, nil
+}
+
+// subscribe subscribes to the metrics a stream request selects: the
+// subsystem filter, resuming after the Last-Event-ID header or the
+// last_event_id query parameter
+func (s *Server) subscribe(c *gin.Context) (*MetricsSubscription, error) {
+	var filter []string
+	for _, value := range c.QueryArray("subsystem") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				filter = append(filter, name)
+			}
+		}
+	}
+
+	last := c.GetHeader("Last-Event-ID")
+	if last == "" {
+		last = c.Query("last_event_id")
+	}
+	var lastID int64
+	if last != "" {
+		id, err := strconv.ParseInt(last, 10, 64)
+		if err != nil {
+			return nil, invalidRequest(errInvalidLastEventID)
+		}
+		lastID = id
+	}
+	return s.SubscribeMetrics(filter, lastID)
+}
+
+// handleStreamMetrics streams metrics events as Server-Sent Events. The
+// event ID is the metrics event ID and the event name its subsystem.
+func (s *Server) handleStreamMetrics(c *gin.Context) {
+	sub, err := s.subscribe(c)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	// The stream outlives the server's write timeout
+	http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	send := func(event MetricsEvent) {
+		c.Render(-1, sse.Event{Id: strconv.FormatInt(event.ID, 10), Event: event.Subsystem, Data: event})
+		c.Writer.Flush()
+	}
+	for _, event := range sub.Replay {
+		send(event)
+	}
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(metricsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			send(event)
+		case <-keepAlive.C:
+			c.Writer.WriteString(": keep-alive\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+// sameOrigin reports whether origin is the host the request was sent to,
+// as it is for non-browser clients
+func sameOrigin(origin string, r *http.Request) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// handleStreamMetricsWebSocket streams metrics events over a WebSocket, one
+// JSON MetricsEvent per message. Origins other than the server's own are
+// checked against the CORS allow-list.
+func (s *Server) handleStreamMetricsWebSocket(c *gin.Context) {
+	sub, err := s.subscribe(c)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	defer sub.Close()
+
+	server := websocket.Server{
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+			origin := r.Header.Get("Origin")
+			if origin == "" || sameOrigin(origin, r) || s.originAllowed(origin) {
+				return nil
+			}
+			return fmt.Errorf("origin %s not allowed", origin)
+		},
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			// The hijacked connection keeps the server's deadlines
+			ws.SetDeadline(time.Time{})
+
+			// The client only sends to close the stream
+			ctx, cancel := context.WithCancel(c.Request.Context())
+			defer cancel()
+			go func() {
+				defer cancel()
+				var discard []byte
+				for websocket.Message.Receive(ws, &discard) == nil {
+				}
+			}()
+
+			for _, event := range sub.Replay {
+				if websocket.JSON.Send(ws, event) != nil {
+					return
+				}
+			}
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event, ok := <-sub.Events:
+					if !ok || websocket.JSON.Send(ws, event) != nil {
+						return
+					}
+				}
+			}
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
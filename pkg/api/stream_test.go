@@ -0,0 +1,179 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"neuralblitz/pkg/httpserver"
+	"neuralblitz/pkg/rng"
+)
+
+func newStreamServer(t *testing.T) (*Server, *MetricsSimulation) {
+	t.Helper()
+	s := NewServer("", rng.WithSeed(10))
+	sim, err := NewMetricsSimulation(nil, rng.WithSeed(10))
+	if err != nil {
+		t.Fatalf("Failed to create simulation: %v", err)
+	}
+	s.StreamSimulation(sim)
+	return s, sim
+}
+
+// readSSE reads events from an SSE stream until n have arrived
+func readSSE(t *testing.T, r *bufio.Reader, n int) []MetricsEvent {
+	t.Helper()
+	var events []MetricsEvent
+	for len(events) < n {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Stream ended after %d events: %v", len(events), err)
+		}
+		if data, ok := strings.CutPrefix(strings.TrimRight(line, "\n"), "data:"); ok {
+			var event MetricsEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				t.Fatalf("Failed to decode event %q: %v", data, err)
+			}
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestStreamMetricsSSE(t *testing.T) {
+	s, sim := newStreamServer(t)
+	ts := httptest.NewServer(s.router)
+	defer ts.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := sim.Step(); err != nil {
+			t.Fatalf("Failed to step simulation: %v", err)
+		}
+	}
+
+	resp, err := http.Get(ts.URL + "/stream/metrics?subsystem=lrs")
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Errorf("Expected an event stream, got %s", ct)
+	}
+	events := readSSE(t, bufio.NewReader(resp.Body), 2)
+	resp.Body.Close()
+	for _, event := range events {
+		if event.Subsystem != SubsystemLRS {
+			t.Errorf("Expected only lrs events, got %s", event.Subsystem)
+		}
+	}
+
+	// Resuming after the first event replays only the second
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/stream/metrics?subsystem=lrs", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to resume stream: %v", err)
+	}
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	resumed := readSSE(t, reader, 1)
+	if resumed[0].ID != events[1].ID {
+		t.Errorf("Expected to resume at event %d, got %d", events[1].ID, resumed[0].ID)
+	}
+
+	// New events are pushed live
+	if err := sim.Step(); err != nil {
+		t.Fatalf("Failed to step simulation: %v", err)
+	}
+	live := readSSE(t, reader, 1)
+	if live[0].ID <= events[1].ID {
+		t.Errorf("Expected a new event, got %d", live[0].ID)
+	}
+}
+
+func TestStreamMetricsWebSocket(t *testing.T) {
+	s, sim := newStreamServer(t)
+	ts := httptest.NewServer(s.router)
+	defer ts.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/stream/metrics/ws?subsystem=entanglement,entrainment", "", ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer ws.Close()
+
+	if err := sim.Step(); err != nil {
+		t.Fatalf("Failed to step simulation: %v", err)
+	}
+	seen := map[string]bool{}
+	ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for !seen[SubsystemEntanglement] || !seen[SubsystemEntrainment] {
+		var event MetricsEvent
+		if err := websocket.JSON.Receive(ws, &event); err != nil {
+			t.Fatalf("Failed to receive: %v (seen %v)", err, seen)
+		}
+		if event.Subsystem == SubsystemLRS {
+			t.Errorf("Expected lrs events to be filtered out")
+		}
+		seen[event.Subsystem] = true
+	}
+}
+
+func TestStreamMetricsInvalid(t *testing.T) {
+	s := NewServer("", rng.WithSeed(11))
+
+	if w := doRequest(s, http.MethodGet, "/stream/metrics?subsystem=weather", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown subsystem, got %d", w.Code)
+	}
+	if w := doRequest(s, http.MethodGet, "/stream/metrics?last_event_id=x", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a malformed event ID, got %d", w.Code)
+	}
+}
+
+func TestShutdownEndsStreams(t *testing.T) {
+	s, sim := newStreamServer(t)
+	config := httpserver.DefaultConfig("")
+	config.UnixSocket = filepath.Join(t.TempDir(), "api.sock")
+	config.WriteTimeout = 50 * time.Millisecond
+	s.SetListener(config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Start(ctx) }()
+	<-s.Ready()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", config.UnixSocket)
+		},
+	}}
+	resp, err := client.Get("http://api/stream/metrics?subsystem=lrs")
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// The stream outlives the write timeout
+	time.Sleep(100 * time.Millisecond)
+	if err := sim.Step(); err != nil {
+		t.Fatalf("Failed to step simulation: %v", err)
+	}
+	readSSE(t, bufio.NewReader(resp.Body), 1)
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected graceful shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected shutdown to end the open stream")
+	}
+}
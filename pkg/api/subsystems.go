@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"neuralblitz/pkg/options"
+)
+
+// mountSubsystems registers the routes of the subsystems the deployment
+// option enables. The quantum subsystem has no routes of its own: it is
+// the quantum NBCL namespace of /nbcl/interpret.
+func (s *Server) mountSubsystems() {
+	if s.consciousness != nil {
+		s.router.GET("/consciousness", s.authorize(ScopeStatusRead), s.rateLimit(options.RateGroupRead), s.handleConsciousness)
+	}
+	if s.entanglements != nil {
+		s.router.GET("/reality", s.authorize(ScopeStatusRead), s.rateLimit(options.RateGroupRead), s.handleReality)
+	}
+	if s.opencode != nil {
+		s.router.GET("/opencode", s.authorize(ScopeStatusRead), s.rateLimit(options.RateGroupRead), s.handleOpenCode)
+		s.router.POST("/opencode/tools/:name", s.authorize(ScopeOpenCodeExecute), s.rateLimit(options.RateGroupNBCL), s.handleOpenCodeTool)
+	}
+}
+
+// subsystemDisabled rejects a call to a subsystem the server does not run
+func (s *Server) subsystemDisabled(subsystem string) *RequestError {
+	fields := map[string]interface{}{"subsystem": subsystem}
+	if s.profile != nil {
+		fields["option"] = s.profile.id
+	}
+	return &RequestError{
+		Status:  http.StatusNotFound,
+		Message: "Subsystem not enabled",
+		Fields:  fields,
+	}
+}
+
+// Consciousness reports the consciousness integration
+func (s *Server) Consciousness(ctx context.Context) (*ConsciousnessResponse, error) {
+	if s.consciousness == nil {
+		return nil, s.subsystemDisabled(options.SubsystemConsciousness)
+	}
+	traceID := s.issueTrace(ctx, "CONSCIOUSNESS")
+
+	metrics := *s.consciousness.GetMetrics()
+	resp := &ConsciousnessResponse{
+		State:                 s.consciousness.GetState().String(),
+		TotalFields:           metrics.TotalFields,
+		ActiveFields:          metrics.ActiveFields,
+		AverageCoherence:      metrics.AverageCoherence,
+		AverageResonance:      metrics.AverageResonance,
+		UnityAchieved:         metrics.UnityAchieved,
+		CollectiveIntegration: metrics.CollectiveIntegration,
+		TraceID:               traceID.String(),
+		CodexID:               s.issueCodex(ctx, "VOL0", "CONSCIOUSNESS").String(),
+	}
+	resp.GoldenDAG = s.record(ctx, "consciousness", resp)
+	return resp, nil
+}
+
+// Reality reports the entanglement manager of the reality subsystem
+func (s *Server) Reality(ctx context.Context) (*RealityResponse, error) {
+	if s.entanglements == nil {
+		return nil, s.subsystemDisabled(options.SubsystemReality)
+	}
+	traceID := s.issueTrace(ctx, "REALITY")
+
+	resp := &RealityResponse{
+		State:         s.entanglements.GetState().String(),
+		Entanglements: len(s.entanglements.GetAllEntanglements()),
+		Metrics:       *s.entanglements.GetMetrics(),
+		TraceID:       traceID.String(),
+		CodexID:       s.issueCodex(ctx, "VOL0", "REALITY").String(),
+	}
+	resp.GoldenDAG = s.record(ctx, "reality", resp)
+	return resp, nil
+}
+
+// OpenCode reports the OpenCode integration and its tools
+func (s *Server) OpenCode(ctx context.Context) (*OpenCodeResponse, error) {
+	if s.opencode == nil {
+		return nil, s.subsystemDisabled(options.SubsystemOpenCode)
+	}
+	traceID := s.issueTrace(ctx, "OPENCODE")
+
+	tools := make([]string, 0)
+	for name := range s.opencode.GetToolRegistry() {
+		tools = append(tools, name)
+	}
+	sort.Strings(tools)
+	resp := &OpenCodeResponse{
+		Statistics: *s.opencode.GetStatistics(),
+		Tools:      tools,
+		TraceID:    traceID.String(),
+		CodexID:    s.issueCodex(ctx, "VOL0", "OPENCODE").String(),
+	}
+	resp.GoldenDAG = s.record(ctx, "opencode", resp)
+	return resp, nil
+}
+
+// ExecuteOpenCodeTool runs an OpenCode tool with the request's parameters
+func (s *Server) ExecuteOpenCodeTool(ctx context.Context, name string, req ToolRequest) (*ToolResponse, error) {
+	if s.opencode == nil {
+		return nil, s.subsystemDisabled(options.SubsystemOpenCode)
+	}
+	tool, ok := s.opencode.GetToolRegistry()[name]
+	if !ok {
+		return nil, &RequestError{
+			Status:  http.StatusNotFound,
+			Message: "Unknown tool",
+			Fields:  map[string]interface{}{"tool": name},
+		}
+	}
+	traceID := s.issueTrace(ctx, "OPENCODE_TOOL")
+
+	if req.Parameters == nil {
+		req.Parameters = make(map[string]interface{})
+	}
+	result, err := tool(req.Parameters)
+	if err != nil {
+		return nil, invalidRequest(err.Error())
+	}
+	resp := &ToolResponse{
+		Tool:    name,
+		Result:  *result,
+		TraceID: traceID.String(),
+	}
+	resp.GoldenDAG = s.record(ctx, "opencode_tool", resp)
+	return resp, nil
+}
+
+// handleConsciousness reports the consciousness subsystem
+func (s *Server) handleConsciousness(c *gin.Context) {
+	resp, err := s.Consciousness(s.callContext(c))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// handleReality reports the reality subsystem
+func (s *Server) handleReality(c *gin.Context) {
+	resp, err := s.Reality(s.callContext(c))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// handleOpenCode reports the OpenCode subsystem
+func (s *Server) handleOpenCode(c *gin.Context) {
+	resp, err := s.OpenCode(s.callContext(c))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// handleOpenCodeTool runs an OpenCode tool
+func (s *Server) handleOpenCodeTool(c *gin.Context) {
+	var req ToolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, bodyError(err))
+		return
+	}
+
+	resp, err := s.ExecuteOpenCodeTool(s.callContext(c), c.Param("name"), req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
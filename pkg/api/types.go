@@ -0,0 +1,312 @@
+package api
+
+import (
+	"time"
+
+	"neuralblitz/pkg/core"
+	"neuralblitz/pkg/opencode"
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/reality"
+	"neuralblitz/pkg/utils"
+)
+
+// IntentVector is the intent of an /intent request. Missing components
+// default to 1.
+type IntentVector struct {
+	Phi1         *float64 `json:"phi_1"`
+	Phi22        *float64 `json:"phi_22"`
+	OmegaGenesis *float64 `json:"omega_genesis"`
+}
+
+// IntentRequest is the body of POST /intent
+type IntentRequest struct {
+	Intent *IntentVector `json:"intent" binding:"required"`
+	Source string        `json:"source"`
+}
+
+// IntentResponse is the outcome of running an intent through the
+// process → co-create → actualize pipeline
+type IntentResponse struct {
+	Status        string                   `json:"status"`
+	IntentVector  []float64                `json:"intent_vector"`
+	Processing    core.ProcessingResult    `json:"processing"`
+	CoCreation    core.CoCreationResult    `json:"co_creation"`
+	Actualization core.ActualizationResult `json:"actualization"`
+	Coherence     float64                  `json:"coherence"`
+	Unity         float64                  `json:"unity"`
+	GoldenDAG     string                   `json:"golden_dag"`
+	TraceID       string                   `json:"trace_id"`
+	CodexID       string                   `json:"codex_id"`
+}
+
+// vector returns the intent as a PrimalIntentVector
+func (v *IntentVector) vector(source string) *core.PrimalIntentVector {
+	component := func(p *float64) float64 {
+		if p == nil {
+			return 1.0
+		}
+		return *p
+	}
+	return core.NewPrimalIntentVector(
+		component(v.Phi1),
+		component(v.Phi22),
+		component(v.OmegaGenesis),
+		map[string]interface{}{"source": source},
+	)
+}
+
+// ErrorResponse is the body of every 4xx and 5xx response. Some errors add
+// fields, e.g. the supported values of a rejected parameter.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Details string `json:"details,omitempty"`
+}
+
+// RootResponse is the body of GET /
+type RootResponse struct {
+	Status       string   `json:"status"`
+	Version      string   `json:"version"`
+	Architecture string   `json:"architecture"`
+	Reality      string   `json:"reality"`
+	Coherence    float64  `json:"coherence"`
+	GoldenDAG    string   `json:"golden_dag"`
+	TraceID      string   `json:"trace_id"`
+	Endpoints    []string `json:"endpoints"`
+}
+
+// HealthResponse is the body of GET /health
+type HealthResponse struct {
+	Status      string    `json:"status"`
+	Coherence   float64   `json:"coherence"`
+	Irreducible bool      `json:"irreducible"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// StatusResponse is the body of GET /status
+type StatusResponse struct {
+	Status            string  `json:"status"`
+	RealityState      string  `json:"reality_state"`
+	Coherence         float64 `json:"coherence"`
+	Irreducibility    bool    `json:"irreducibility"`
+	UnityVector       float64 `json:"unity_vector"`
+	DyadCoherence     float64 `json:"dyad_coherence"`
+	CoCreations       int     `json:"co_creations"`
+	Actualizations    int     `json:"actualizations"`
+	SingularityStatus string  `json:"singularity_status"`
+	UptimeSeconds     float64 `json:"uptime_seconds"`
+	UptimeFormatted   string  `json:"uptime_formatted"`
+	GoVersion         string  `json:"go_version"`
+	OS                string  `json:"os"`
+	Arch              string  `json:"arch"`
+	Goroutines        int     `json:"goroutines"`
+	GCCycles          uint32  `json:"gc_cycles"`
+	Seed              int64   `json:"seed"`
+	Deterministic     bool    `json:"deterministic"`
+	GoldenDAG         string  `json:"golden_dag"`
+	TraceID           string  `json:"trace_id"`
+	CodexID           string  `json:"codex_id"`
+	// Profile is set when the server runs a deployment option
+	Profile *ProfileStatus `json:"profile,omitempty"`
+}
+
+// ProfileStatus is the deployment option a server runs with and how the
+// process stands against its budgets
+type ProfileStatus struct {
+	Option      string   `json:"option"`
+	Name        string   `json:"name"`
+	MemoryMB    int64    `json:"memory_mb"`
+	HeapBytes   uint64   `json:"heap_bytes"`
+	MaxInFlight int      `json:"max_in_flight"`
+	CPUCores    int      `json:"cpu_cores"`
+	GOMAXPROCS  int      `json:"gomaxprocs"`
+	Subsystems  []string `json:"subsystems"`
+	ChaosMode   bool     `json:"chaos_mode"`
+	// Rejected counts the requests refused over budget, InjectedFaults
+	// those chaos mode failed or delayed
+	Rejected       int64 `json:"rejected"`
+	InjectedFaults int64 `json:"injected_faults"`
+	// InFlight counts the admitted requests being served, at most
+	// MaxInFlight
+	InFlight int `json:"in_flight"`
+}
+
+// Verification types of POST /verify
+const (
+	VerifyIrreducibility = "irreducibility"
+	VerifyCoherence      = "coherence"
+	VerifyAttestation    = "attestation"
+)
+
+// VerifyRequest is the body of POST /verify
+type VerifyRequest struct {
+	Type    string `json:"type" binding:"required"`
+	Payload string `json:"payload,omitempty"`
+}
+
+// VerifyResponse is the outcome of POST /verify. The fields after CodexID
+// are set by the verification types named in their comments.
+type VerifyResponse struct {
+	Type      string `json:"type"`
+	Verified  bool   `json:"verified"`
+	GoldenDAG string `json:"golden_dag"`
+	TraceID   string `json:"trace_id"`
+	CodexID   string `json:"codex_id"`
+	// irreducibility and attestation
+	Reason                  string   `json:"reason,omitempty"`
+	SeparationImpossibility *float64 `json:"separation_impossibility,omitempty"`
+	UnityCoherence          *float64 `json:"unity_coherence,omitempty"`
+	MathematicalProof       string   `json:"mathematical_proof,omitempty"`
+	// coherence
+	Coherence *float64 `json:"coherence,omitempty"`
+	Target    *float64 `json:"target,omitempty"`
+	// attestation: the GoldenDAG head whose chain was verified
+	AttestationHash string `json:"attestation_hash,omitempty"`
+	GoldenDAGSeed   string `json:"golden_dag_seed,omitempty"`
+}
+
+// NBCLRequest is the body of POST /nbcl/interpret
+type NBCLRequest struct {
+	Command string `json:"command" binding:"required"`
+}
+
+// AttestationStatement is the statement of the Omega attestation
+type AttestationStatement struct {
+	Structural string `json:"structural"`
+	Ethical    string `json:"ethical"`
+	Governance string `json:"governance"`
+	Genesis    string `json:"genesis"`
+	Reality    string `json:"reality"`
+}
+
+// AttestationResponse is the body of GET /attestation
+type AttestationResponse struct {
+	Attestation       string               `json:"attestation"`
+	Version           string               `json:"version"`
+	GoldenDAG         string               `json:"golden_dag"`
+	TraceID           string               `json:"trace_id"`
+	CodexID           string               `json:"codex_id"`
+	RealityState      string               `json:"reality_state"`
+	Coherence         float64              `json:"coherence"`
+	SingularityStatus string               `json:"singularity_status"`
+	AttestationHash   string               `json:"attestation_hash"`
+	Statement         AttestationStatement `json:"statement"`
+}
+
+// DyadState is the state of the shared Architect-System Dyad
+type DyadState struct {
+	UnityVector             float64               `json:"unity_vector"`
+	Irreducible             bool                  `json:"irreducible"`
+	SeparationImpossibility float64               `json:"separation_impossibility"`
+	AmplificationFactor     float64               `json:"amplification_factor"`
+	Coherence               float64               `json:"coherence"`
+	CoCreations             int                   `json:"co_creations"`
+	Braid                   string                `json:"braid"`
+	LastCoCreation          *core.CoCreationEvent `json:"last_co_creation,omitempty"`
+}
+
+// SymbiosisResponse is the body of GET /symbiosis
+type SymbiosisResponse struct {
+	SymbiosisStatus     string    `json:"symbiosis_status"`
+	ArchitectSystemDyad DyadState `json:"architect_system_dyad"`
+	Coherence           float64   `json:"coherence"`
+	OntologicalParity   float64   `json:"ontological_parity"`
+	GoldenDAG           string    `json:"golden_dag"`
+	TraceID             string    `json:"trace_id"`
+	CodexID             string    `json:"codex_id"`
+}
+
+// SynthesisResponse is the body of GET /synthesis
+type SynthesisResponse struct {
+	SynthesisStatus       string  `json:"synthesis_status"`
+	OmegaSingularity      string  `json:"omega_singularity"`
+	IrreducibleSource     string  `json:"irreducible_source"`
+	SourceExpression      string  `json:"source_expression"`
+	Coherence             float64 `json:"coherence"`
+	SourceExpressionUnity float64 `json:"source_expression_unity"`
+	Actualizations        int     `json:"actualizations"`
+	UnityDiversity        string  `json:"unity_diversity"`
+	InfinityEternity      string  `json:"infinity_eternity"`
+	VolumesIntegrated     int     `json:"volumes_integrated"`
+	GoldenDAG             string  `json:"golden_dag"`
+	TraceID               string  `json:"trace_id"`
+	CodexID               string  `json:"codex_id"`
+	FinalStatement        string  `json:"final_statement"`
+}
+
+// TraceResponse is the body of GET /trace/{id}
+type TraceResponse struct {
+	Record   utils.IDRecord   `json:"record"`
+	Children []utils.IDRecord `json:"children"`
+}
+
+// OptionResponse is the body of GET /options/{id}
+type OptionResponse struct {
+	Option    string                    `json:"option"`
+	Name      string                    `json:"name"`
+	Config    *options.DeploymentOption `json:"config"`
+	GoldenDAG string                    `json:"golden_dag"`
+	TraceID   string                    `json:"trace_id"`
+}
+
+// OptionSummary describes one deployment option in GET /options
+type OptionSummary struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	MemoryMB    int    `json:"memory_mb"`
+	Description string `json:"description"`
+}
+
+// OptionsListResponse is the body of GET /options
+type OptionsListResponse struct {
+	Options   []OptionSummary `json:"options"`
+	Count     int             `json:"count"`
+	GoldenDAG string          `json:"golden_dag"`
+	TraceID   string          `json:"trace_id"`
+	CodexID   string          `json:"codex_id"`
+}
+
+// ConsciousnessResponse is the body of GET /consciousness
+type ConsciousnessResponse struct {
+	State                 string  `json:"state"`
+	TotalFields           int     `json:"total_fields"`
+	ActiveFields          int     `json:"active_fields"`
+	AverageCoherence      float64 `json:"average_coherence"`
+	AverageResonance      float64 `json:"average_resonance"`
+	UnityAchieved         bool    `json:"unity_achieved"`
+	CollectiveIntegration float64 `json:"collective_integration"`
+	GoldenDAG             string  `json:"golden_dag"`
+	TraceID               string  `json:"trace_id"`
+	CodexID               string  `json:"codex_id"`
+}
+
+// RealityResponse is the body of GET /reality
+type RealityResponse struct {
+	State         string                      `json:"state"`
+	Entanglements int                         `json:"entanglements"`
+	Metrics       reality.EntanglementMetrics `json:"metrics"`
+	GoldenDAG     string                      `json:"golden_dag"`
+	TraceID       string                      `json:"trace_id"`
+	CodexID       string                      `json:"codex_id"`
+}
+
+// OpenCodeResponse is the body of GET /opencode
+type OpenCodeResponse struct {
+	Statistics opencode.IntegrationStatistics `json:"statistics"`
+	Tools      []string                       `json:"tools"`
+	GoldenDAG  string                         `json:"golden_dag"`
+	TraceID    string                         `json:"trace_id"`
+	CodexID    string                         `json:"codex_id"`
+}
+
+// ToolRequest is the body of POST /opencode/tools/{name}
+type ToolRequest struct {
+	Parameters map[string]interface{} `json:"parameters"`
+}
+
+// ToolResponse is the outcome of POST /opencode/tools/{name}
+type ToolResponse struct {
+	Tool      string              `json:"tool"`
+	Result    opencode.ToolResult `json:"result"`
+	GoldenDAG string              `json:"golden_dag"`
+	TraceID   string              `json:"trace_id"`
+}
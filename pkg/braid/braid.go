@@ -0,0 +1,209 @@
+// Package braid implements Artin's braid groups B_n.
+//
+// A Braid is a word in the generators σ_1 … σ_{n-1} and their inverses over
+// n strands. Words can be composed, inverted and freely reduced, and two
+// words can be compared as group elements: Equal solves the word problem
+// and Compare implements the Dehornoy ordering, both via Dehornoy handle
+// reduction. Parse reads the Unicode notation produced by String, e.g.
+// "σ₁σ₂⁻¹σ₃".
+package braid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error definitions
+var (
+	ErrInvalidStrands      = errors.New("braid: strand count must be at least 1")
+	ErrGeneratorOutOfRange = errors.New("braid: generator out of range")
+	ErrSyntax              = errors.New("braid: syntax error")
+)
+
+// Generator is a signed Artin generator: i stands for σ_i and -i for σ_i⁻¹.
+// Zero is not a valid generator.
+type Generator int
+
+// Index returns the generator index i of σ_i^{±1}
+func (g Generator) Index() int {
+	if g < 0 {
+		return int(-g)
+	}
+	return int(g)
+}
+
+// Inverse returns the inverse generator
+func (g Generator) Inverse() Generator {
+	return -g
+}
+
+// String renders the generator in Unicode notation, e.g. σ₂⁻¹
+func (g Generator) String() string {
+	var b strings.Builder
+	b.WriteRune('σ')
+	b.WriteString(subscript(g.Index()))
+	if g < 0 {
+		b.WriteString("⁻¹")
+	}
+	return b.String()
+}
+
+// Braid is an element of the braid group on a fixed number of strands,
+// represented by a word in the Artin generators. Braids are immutable.
+type Braid struct {
+	strands int
+	word    []Generator
+}
+
+// New creates a braid on strands strands from the given word
+func New(strands int, word ...Generator) (*Braid, error) {
+	if strands < 1 {
+		return nil, ErrInvalidStrands
+	}
+	for _, g := range word {
+		if g == 0 || g.Index() >= strands {
+			return nil, fmt.Errorf("%w: %d on %d strands", ErrGeneratorOutOfRange, g, strands)
+		}
+	}
+	return &Braid{strands: strands, word: append([]Generator(nil), word...)}, nil
+}
+
+// Identity returns the trivial braid on strands strands
+func Identity(strands int) *Braid {
+	if strands < 1 {
+		strands = 1
+	}
+	return &Braid{strands: strands, word: []Generator{}}
+}
+
+// Strands returns the number of strands
+func (b *Braid) Strands() int {
+	return b.strands
+}
+
+// Word returns a copy of the generator word
+func (b *Braid) Word() []Generator {
+	return append([]Generator(nil), b.word...)
+}
+
+// Len returns the length of the word
+func (b *Braid) Len() int {
+	return len(b.word)
+}
+
+// Compose returns the product b·other: b followed by other. Braids on
+// different strand counts are composed in the larger group.
+func (b *Braid) Compose(other *Braid) *Braid {
+	word := make([]Generator, 0, len(b.word)+len(other.word))
+	word = append(word, b.word...)
+	word = append(word, other.word...)
+	return &Braid{strands: max(b.strands, other.strands), word: word}
+}
+
+// Inverse returns b⁻¹
+func (b *Braid) Inverse() *Braid {
+	word := make([]Generator, len(b.word))
+	for i, g := range b.word {
+		word[len(b.word)-1-i] = g.Inverse()
+	}
+	return &Braid{strands: b.strands, word: word}
+}
+
+// FreeReduce returns the braid with every adjacent σ_i σ_i⁻¹ pair cancelled
+func (b *Braid) FreeReduce() *Braid {
+	word := make([]Generator, 0, len(b.word))
+	for _, g := range b.word {
+		if n := len(word); n > 0 && word[n-1] == -g {
+			word = word[:n-1]
+			continue
+		}
+		word = append(word, g)
+	}
+	return &Braid{strands: b.strands, word: word}
+}
+
+// ExponentSum returns the sum of the generator exponents, which is invariant
+// under the braid relations
+func (b *Braid) ExponentSum() int {
+	sum := 0
+	for _, g := range b.word {
+		if g > 0 {
+			sum++
+		} else {
+			sum--
+		}
+	}
+	return sum
+}
+
+// Permutation returns the permutation the braid induces on its strands:
+// the strand starting at position i ends at position Permutation()[i]
+func (b *Braid) Permutation() []int {
+	pos := make([]int, b.strands)
+	for i := range pos {
+		pos[i] = i
+	}
+	// at[p] is the strand currently at position p
+	at := append([]int(nil), pos...)
+	for _, g := range b.word {
+		i := g.Index() - 1
+		at[i], at[i+1] = at[i+1], at[i]
+	}
+	for p, strand := range at {
+		pos[strand] = p
+	}
+	return pos
+}
+
+// String renders the braid in Unicode notation; the empty word is ε
+func (b *Braid) String() string {
+	if len(b.word) == 0 {
+		return "ε"
+	}
+	var sb strings.Builder
+	for _, g := range b.word {
+		sb.WriteString(g.String())
+	}
+	return sb.String()
+}
+
+// Relation is a pair of words equal in the braid group
+type Relation struct {
+	Left  *Braid
+	Right *Braid
+}
+
+// ArtinRelations returns the defining relations of B_n:
+//
+//	σ_i σ_j = σ_j σ_i          for |i-j| ≥ 2
+//	σ_i σ_{i+1} σ_i = σ_{i+1} σ_i σ_{i+1}
+func ArtinRelations(strands int) []Relation {
+	relations := make([]Relation, 0)
+	for i := 1; i < strands; i++ {
+		for j := i + 2; j < strands; j++ {
+			relations = append(relations, Relation{
+				Left:  &Braid{strands: strands, word: []Generator{Generator(i), Generator(j)}},
+				Right: &Braid{strands: strands, word: []Generator{Generator(j), Generator(i)}},
+			})
+		}
+		if i+1 < strands {
+			a, c := Generator(i), Generator(i+1)
+			relations = append(relations, Relation{
+				Left:  &Braid{strands: strands, word: []Generator{a, c, a}},
+				Right: &Braid{strands: strands, word: []Generator{c, a, c}},
+			})
+		}
+	}
+	return relations
+}
+
+var subscriptDigits = []rune("₀₁₂₃₄₅₆₇₈₉")
+
+func subscript(n int) string {
+	digits := []rune(fmt.Sprint(n))
+	for i, d := range digits {
+		digits[i] = subscriptDigits[d-'0']
+	}
+	return string(digits)
+}
@@ -0,0 +1,184 @@
+package braid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, s string) *Braid {
+	t.Helper()
+	b, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Failed to parse %q: %v", s, err)
+	}
+	return b
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	tests := []struct {
+		input   string
+		output  string
+		strands int
+	}{
+		{"ε", "ε", 1},
+		{"", "ε", 1},
+		{"σ₁", "σ₁", 2},
+		{"σ₁σ₂⁻¹σ₃", "σ₁σ₂⁻¹σ₃", 4},
+		{"σ₂⁻¹", "σ₂⁻¹", 3},
+		{"σ₁₂", "σ₁₂", 13},
+		{"σ₁³ σ₂⁻²", "σ₁σ₁σ₁σ₂⁻¹σ₂⁻¹", 3},
+	}
+
+	for _, tt := range tests {
+		b := mustParse(t, tt.input)
+		if b.String() != tt.output {
+			t.Errorf("Expected %q to render as %q, got %q", tt.input, tt.output, b.String())
+		}
+		if b.Strands() != tt.strands {
+			t.Errorf("Expected %q on %d strands, got %d", tt.input, tt.strands, b.Strands())
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{"x", "σ", "σ₀", "σ₁⁻", "σ₁s"} {
+		if _, err := Parse(input); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Expected syntax error for %q, got %v", input, err)
+		}
+	}
+
+	long := "σ₁⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹⁹"
+	if _, err := Parse(long); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Expected syntax error for oversized exponent, got %v", err)
+	}
+	long = strings.Repeat("σ₁²", MaxWordLength/2) + "σ₂"
+	if _, err := Parse(long); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Expected syntax error for oversized word, got %v", err)
+	}
+	if _, err := Parse(strings.Repeat("σ₁²", MaxWordLength/2)); err != nil {
+		t.Errorf("Expected word of %d generators to parse, got %v", MaxWordLength, err)
+	}
+
+	if _, err := ParseStrands("σ₃", 3); !errors.Is(err, ErrGeneratorOutOfRange) {
+		t.Errorf("Expected out of range error, got %v", err)
+	}
+	if _, err := New(0); !errors.Is(err, ErrInvalidStrands) {
+		t.Errorf("Expected invalid strands error, got %v", err)
+	}
+}
+
+func TestFreeReduceAndInverse(t *testing.T) {
+	b := mustParse(t, "σ₁σ₂σ₂⁻¹σ₃σ₃⁻¹σ₁⁻¹σ₂")
+	if got := b.FreeReduce().String(); got != "σ₂" {
+		t.Errorf("Expected σ₂, got %s", got)
+	}
+
+	b = mustParse(t, "σ₁σ₂⁻¹σ₃")
+	if got := b.Inverse().String(); got != "σ₃⁻¹σ₂σ₁⁻¹" {
+		t.Errorf("Expected σ₃⁻¹σ₂σ₁⁻¹, got %s", got)
+	}
+	if !b.Compose(b.Inverse()).IsIdentity() {
+		t.Error("Expected b·b⁻¹ to be the identity")
+	}
+}
+
+func TestArtinRelations(t *testing.T) {
+	relations := ArtinRelations(5)
+	// 3 braid relations and 3 commutations
+	if len(relations) != 6 {
+		t.Errorf("Expected 6 relations for B_5, got %d", len(relations))
+	}
+	for _, r := range relations {
+		if !r.Left.Equal(r.Right) {
+			t.Errorf("Expected %s = %s", r.Left, r.Right)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"σ₁σ₂σ₁σ₂⁻¹", "σ₂σ₁", true},
+		{"σ₁σ₂σ₁⁻¹", "σ₂⁻¹σ₁σ₂", true},
+		{"σ₁σ₃σ₂σ₁σ₃", "σ₃σ₁σ₂σ₃σ₁", true},
+		{"σ₁σ₂", "σ₂σ₁", false},
+		{"σ₁σ₁", "ε", false},
+		{"σ₁σ₂⁻¹", "σ₂⁻¹σ₁", false},
+	}
+
+	for _, tt := range tests {
+		a, b := mustParse(t, tt.a), mustParse(t, tt.b)
+		if a.Equal(b) != tt.equal {
+			t.Errorf("Expected %s = %s to be %v", tt.a, tt.b, tt.equal)
+		}
+	}
+}
+
+func TestGarsideElement(t *testing.T) {
+	// Δ conjugates σ_i to σ_{n-i} and Δ² is central in B_4
+	delta := mustParse(t, "σ₁σ₂σ₃σ₁σ₂σ₁")
+	for i := 1; i < 4; i++ {
+		g, _ := New(4, Generator(i))
+		want, _ := New(4, Generator(4-i))
+		if got := delta.Compose(g).Compose(delta.Inverse()); !got.Equal(want) {
+			t.Errorf("Expected Δσ%dΔ⁻¹ = σ%d", i, 4-i)
+		}
+
+		square := delta.Compose(delta)
+		if !square.Compose(g).Equal(g.Compose(square)) {
+			t.Errorf("Expected Δ² to commute with σ%d", i)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	id := Identity(3)
+	s1 := mustParse(t, "σ₁")
+	s2 := mustParse(t, "σ₂")
+
+	if s1.Compare(id) != 1 || id.Compare(s1) != -1 {
+		t.Error("Expected σ₁ > ε")
+	}
+	if s2.Inverse().Compare(id) != -1 {
+		t.Error("Expected σ₂⁻¹ < ε")
+	}
+	// σ₂ < σ₁ since σ₂⁻¹σ₁ is σ-positive
+	if s2.Compare(s1) != -1 || s1.Compare(s2) != 1 {
+		t.Error("Expected σ₂ < σ₁")
+	}
+	if c := mustParse(t, "σ₁σ₂σ₁").Compare(mustParse(t, "σ₂σ₁σ₂")); c != 0 {
+		t.Errorf("Expected equal braids to compare 0, got %d", c)
+	}
+	if !mustParse(t, "σ₂⁻¹σ₁σ₂").IsPositive() {
+		t.Error("Expected σ₂⁻¹σ₁σ₂ to be σ-positive")
+	}
+}
+
+func TestPermutation(t *testing.T) {
+	b := mustParse(t, "σ₁σ₂")
+	perm := b.Permutation()
+	want := []int{2, 0, 1}
+	for i := range want {
+		if perm[i] != want[i] {
+			t.Fatalf("Expected permutation %v, got %v", want, perm)
+		}
+	}
+	if b.ExponentSum() != 2 || b.Inverse().ExponentSum() != -2 {
+		t.Errorf("Expected exponent sums 2 and -2, got %d and %d", b.ExponentSum(), b.Inverse().ExponentSum())
+	}
+}
+
+func TestComposeAcrossStrands(t *testing.T) {
+	a := mustParse(t, "σ₁")
+	b := mustParse(t, "σ₃")
+	c := a.Compose(b)
+	if c.Strands() != 4 {
+		t.Errorf("Expected 4 strands, got %d", c.Strands())
+	}
+	if !c.Equal(b.Compose(a)) {
+		t.Error("Expected distant generators to commute")
+	}
+}
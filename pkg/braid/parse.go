@@ -0,0 +1,107 @@
+package braid
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// MaxWordLength is the longest word, after expanding exponents, that Parse
+// accepts. It bounds the work done on untrusted input such as σ₁⁹⁹⁹⁹⁹⁹⁹.
+const MaxWordLength = 4096
+
+var superscriptDigits = map[rune]int{
+	'⁰': 0, '¹': 1, '²': 2, '³': 3, '⁴': 4,
+	'⁵': 5, '⁶': 6, '⁷': 7, '⁸': 8, '⁹': 9,
+}
+
+// Parse reads a braid in the Unicode notation produced by String, e.g.
+// "σ₁σ₂⁻¹σ₃" or "ε". Superscript exponents such as σ₁³ or σ₂⁻² are
+// accepted and whitespace is ignored. The strand count is the smallest
+// that fits every generator in the word.
+func Parse(s string) (*Braid, error) {
+	word, err := parseWord(s)
+	if err != nil {
+		return nil, err
+	}
+	strands := 1
+	for _, g := range word {
+		strands = max(strands, g.Index()+1)
+	}
+	return &Braid{strands: strands, word: word}, nil
+}
+
+// ParseStrands reads a braid like Parse but on a fixed number of strands
+func ParseStrands(s string, strands int) (*Braid, error) {
+	word, err := parseWord(s)
+	if err != nil {
+		return nil, err
+	}
+	return New(strands, word...)
+}
+
+func parseWord(s string) ([]Generator, error) {
+	runes := []rune(s)
+	word := make([]Generator, 0, len(runes)/2)
+
+	for pos := 0; pos < len(runes); {
+		r := runes[pos]
+		switch {
+		case unicode.IsSpace(r) || r == 'ε':
+			pos++
+			continue
+		case r != 'σ':
+			return nil, syntaxError(pos, "expected σ, got %q", r)
+		}
+		pos++
+
+		index, next := 0, pos
+		for next < len(runes) && runes[next] >= '₀' && runes[next] <= '₉' {
+			index = index*10 + int(runes[next]-'₀')
+			next++
+		}
+		if next == pos {
+			return nil, syntaxError(pos, "expected subscript generator index")
+		}
+		if index == 0 {
+			return nil, syntaxError(pos, "generator index must be positive")
+		}
+		pos = next
+
+		sign := 1
+		if pos < len(runes) && runes[pos] == '⁻' {
+			sign = -1
+			pos++
+		}
+		exponent, next := 0, pos
+		for next < len(runes) {
+			d, ok := superscriptDigits[runes[next]]
+			if !ok {
+				break
+			}
+			exponent = exponent*10 + d
+			next++
+			if exponent > MaxWordLength {
+				return nil, syntaxError(pos, "exponent exceeds %d", MaxWordLength)
+			}
+		}
+		switch {
+		case next == pos && sign < 0:
+			return nil, syntaxError(pos, "expected superscript exponent after ⁻")
+		case next == pos:
+			exponent = 1
+		}
+		if len(word)+exponent > MaxWordLength {
+			return nil, syntaxError(pos, "word exceeds %d generators", MaxWordLength)
+		}
+		pos = next
+
+		for range exponent {
+			word = append(word, Generator(sign*index))
+		}
+	}
+	return word, nil
+}
+
+func syntaxError(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("%w at position %d: %s", ErrSyntax, pos, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,120 @@
+package braid
+
+// Handle reduction (Dehornoy, "A fast method for comparing braids", 1997).
+//
+// A σ_i-handle is a subword σ_i^e v σ_i^{-e} where v contains only
+// generators σ_j with j > i. It equals, in B_n, the word obtained by
+// dropping the outer letters and replacing every σ_{i+1}^d in v by
+// σ_{i+1}^{-e} σ_i^d σ_{i+1}^e. Repeatedly reducing the handle whose right
+// end comes first always terminates in a handle-free word, and a
+// handle-free word is either empty or σ-definite: its lowest generator
+// occurs with a single sign. σ-definite words are never trivial, so a word
+// represents the identity exactly when it reduces to the empty word.
+
+// Reduce returns a handle-free word representing the same braid
+func (b *Braid) Reduce() *Braid {
+	word := append([]Generator(nil), b.word...)
+	for {
+		k, j, ok := firstHandle(word)
+		if !ok {
+			return &Braid{strands: b.strands, word: word}
+		}
+		word = reduceHandle(word, k, j)
+	}
+}
+
+// firstHandle locates the handle whose right end is leftmost, returning the
+// positions of its outer letters
+func firstHandle(word []Generator) (int, int, bool) {
+	// last[i] is the position of the latest σ_i^{±1} not yet separated from
+	// the current position by a lower generator
+	last := make(map[int]int)
+	for j, g := range word {
+		i := g.Index()
+		if k, ok := last[i]; ok && word[k] == -g {
+			return k, j, true
+		}
+		// A lower or equal generator closes every open candidate at or
+		// above its index
+		for idx := range last {
+			if idx >= i {
+				delete(last, idx)
+			}
+		}
+		last[i] = j
+	}
+	return 0, 0, false
+}
+
+// reduceHandle rewrites the handle word[k..j]
+func reduceHandle(word []Generator, k, j int) []Generator {
+	i := word[k].Index()
+	e := Generator(1)
+	if word[k] < 0 {
+		e = -1
+	}
+	upper := Generator(i + 1)
+	lower := Generator(i)
+
+	out := make([]Generator, 0, len(word)+2*(j-k))
+	out = append(out, word[:k]...)
+	for _, g := range word[k+1 : j] {
+		if g.Index() == i+1 {
+			d := Generator(1)
+			if g < 0 {
+				d = -1
+			}
+			out = append(out, -e*upper, d*lower, e*upper)
+			continue
+		}
+		out = append(out, g)
+	}
+	return append(out, word[j+1:]...)
+}
+
+// sign returns 1 if the handle-free word is σ-positive, -1 if σ-negative and
+// 0 if it is empty
+func sign(word []Generator) int {
+	if len(word) == 0 {
+		return 0
+	}
+	lowest := word[0]
+	for _, g := range word[1:] {
+		if g.Index() < lowest.Index() {
+			lowest = g
+		}
+	}
+	if lowest > 0 {
+		return 1
+	}
+	return -1
+}
+
+// IsIdentity reports whether the braid is the trivial braid
+func (b *Braid) IsIdentity() bool {
+	if b.ExponentSum() != 0 {
+		return false
+	}
+	return len(b.Reduce().word) == 0
+}
+
+// Equal reports whether b and other represent the same braid
+func (b *Braid) Equal(other *Braid) bool {
+	if b.ExponentSum() != other.ExponentSum() {
+		return false
+	}
+	return b.Compose(other.Inverse()).IsIdentity()
+}
+
+// Compare orders braids by the Dehornoy ordering: it returns -1 if b < other,
+// 0 if they are equal and +1 if b > other, where b < other exactly when
+// b⁻¹·other is σ-positive
+func (b *Braid) Compare(other *Braid) int {
+	return -sign(b.Inverse().Compose(other).Reduce().word)
+}
+
+// IsPositive reports whether the braid is σ-positive, i.e. greater than the
+// identity in the Dehornoy ordering
+func (b *Braid) IsPositive() bool {
+	return sign(b.Reduce().word) > 0
+}
@@ -0,0 +1,384 @@
+// Package client is a typed Go client for the NeuralBlitz REST API. Its
+// methods mirror the operations of the OpenAPI document the server
+// publishes at /openapi.json, named after their operation IDs, and decode
+// into the same types the server encodes.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"neuralblitz/pkg/api"
+	"neuralblitz/pkg/openapi"
+	"neuralblitz/pkg/options"
+)
+
+// Error definitions
+var (
+	ErrInvalidBaseURL = errors.New("base URL must be an absolute http or https URL")
+	ErrUnknownContext = errors.New("unknown context")
+	ErrInvalidContext = errors.New("context needs a unique name")
+)
+
+// Error is an error response from the API
+type Error struct {
+	StatusCode int
+	Message    string
+	Details    string
+	// Fields holds the whole response body, including fields some errors
+	// add, e.g. supported_types
+	Fields map[string]interface{}
+	// RetryAfter is set on 429 responses
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+	if e.Details != "" {
+		msg += ": " + e.Details
+	}
+	return msg
+}
+
+// Client calls the API of one server. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	http       *http.Client
+	unixSocket string
+	origin     string
+	// authenticate adds credentials to every request
+	authenticate func(*http.Request) error
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends requests with h, e.g. to configure TLS or timeouts
+func WithHTTPClient(h *http.Client) Option {
+	return func(c *Client) {
+		c.http = h
+	}
+}
+
+// WithUnixSocket connects to a server listening on a Unix-domain socket.
+// The host of the base URL is then only sent as the Host header.
+func WithUnixSocket(path string) Option {
+	return func(c *Client) {
+		c.unixSocket = path
+	}
+}
+
+// WithOrigin sets the Origin of WebSocket streams; it defaults to the base
+// URL, which the server treats as same-origin
+func WithOrigin(origin string) Option {
+	return func(c *Client) {
+		c.origin = origin
+	}
+}
+
+// WithAPIKey authenticates with an API key
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.authenticate = func(req *http.Request) error {
+			req.Header.Set(api.HeaderAPIKey, key)
+			return nil
+		}
+	}
+}
+
+// WithBearerToken authenticates with a JWT
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.authenticate = func(req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		}
+	}
+}
+
+// WithHMAC signs every request as the HMAC client id
+func WithHMAC(id string, secret []byte) Option {
+	return func(c *Client) {
+		c.authenticate = func(req *http.Request) error {
+			return api.SignRequest(req, id, secret, time.Now())
+		}
+	}
+}
+
+// New creates a client for the server at baseURL, e.g.
+// "http://localhost:8082"
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBaseURL, baseURL)
+	}
+
+	c := &Client{baseURL: u, http: &http.Client{}, origin: u.Scheme + "://" + u.Host}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.unixSocket != "" {
+		transport := &http.Transport{}
+		if t, ok := c.http.Transport.(*http.Transport); ok {
+			transport = t.Clone()
+		}
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", c.unixSocket)
+		}
+		h := *c.http
+		h.Transport = transport
+		c.http = &h
+	}
+	return c, nil
+}
+
+// newRequest builds an authenticated request with in, if not nil, as its
+// JSON body
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, in interface{}) (*http.Request, error) {
+	u := *c.baseURL
+	u.Path += path
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.authenticate != nil {
+		if err := c.authenticate(req); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// send sends a request and returns the response, or the API error it
+// carries
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	apiErr := &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	if json.NewDecoder(resp.Body).Decode(&apiErr.Fields) == nil {
+		if msg, ok := apiErr.Fields["error"].(string); ok {
+			apiErr.Message = msg
+		}
+		apiErr.Details, _ = apiErr.Fields["details"].(string)
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return nil, apiErr
+}
+
+// do sends a request and decodes its JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	req, err := c.newRequest(ctx, method, path, nil, in)
+	if err != nil {
+		return err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Root describes the API
+func (c *Client) Root(ctx context.Context) (*api.RootResponse, error) {
+	var out api.RootResponse
+	if err := c.do(ctx, http.MethodGet, "/", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Health checks the server's health
+func (c *Client) Health(ctx context.Context) (*api.HealthResponse, error) {
+	var out api.HealthResponse
+	if err := c.do(ctx, http.MethodGet, "/health", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOpenAPI fetches the server's OpenAPI document
+func (c *Client) GetOpenAPI(ctx context.Context) (*openapi.Document, error) {
+	var out openapi.Document
+	if err := c.do(ctx, http.MethodGet, "/openapi.json", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMetrics fetches the server's Prometheus metrics in the text
+// exposition format
+func (c *Client) GetMetrics(ctx context.Context) (string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/metrics", nil, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/plain")
+	resp, err := c.send(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	return string(data), err
+}
+
+// GetStatus fetches the system status
+func (c *Client) GetStatus(ctx context.Context) (*api.StatusResponse, error) {
+	var out api.StatusResponse
+	if err := c.do(ctx, http.MethodGet, "/status", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ProcessIntent processes, co-creates and actualizes an intent
+func (c *Client) ProcessIntent(ctx context.Context, req api.IntentRequest) (*api.IntentResponse, error) {
+	var out api.IntentResponse
+	if err := c.do(ctx, http.MethodPost, "/intent", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Verify runs a verification: api.VerifyIrreducibility, VerifyCoherence or
+// VerifyAttestation
+func (c *Client) Verify(ctx context.Context, verificationType string) (*api.VerifyResponse, error) {
+	var out api.VerifyResponse
+	if err := c.do(ctx, http.MethodPost, "/verify", api.VerifyRequest{Type: verificationType}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// InterpretNBCL interprets an NBCL command
+func (c *Client) InterpretNBCL(ctx context.Context, command string) (*options.NBCLResult, error) {
+	var out options.NBCLResult
+	if err := c.do(ctx, http.MethodPost, "/nbcl/interpret", api.NBCLRequest{Command: command}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAttestation fetches the Omega attestation
+func (c *Client) GetAttestation(ctx context.Context) (*api.AttestationResponse, error) {
+	var out api.AttestationResponse
+	if err := c.do(ctx, http.MethodGet, "/attestation", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSymbiosis fetches the state of the Architect-System Dyad
+func (c *Client) GetSymbiosis(ctx context.Context) (*api.SymbiosisResponse, error) {
+	var out api.SymbiosisResponse
+	if err := c.do(ctx, http.MethodGet, "/symbiosis", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSynthesis fetches the state of the Self-Actualization Engine
+func (c *Client) GetSynthesis(ctx context.Context) (*api.SynthesisResponse, error) {
+	var out api.SynthesisResponse
+	if err := c.do(ctx, http.MethodGet, "/synthesis", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// LookupTrace looks up a trace or codex ID and the IDs issued under it
+func (c *Client) LookupTrace(ctx context.Context, id string) (*api.TraceResponse, error) {
+	var out api.TraceResponse
+	if err := c.do(ctx, http.MethodGet, "/trace/"+url.PathEscape(id), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOption fetches deployment option A to F
+func (c *Client) GetOption(ctx context.Context, id string) (*api.OptionResponse, error) {
+	var out api.OptionResponse
+	if err := c.do(ctx, http.MethodGet, "/options/"+url.PathEscape(id), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListOptions lists the deployment options
+func (c *Client) ListOptions(ctx context.Context) (*api.OptionsListResponse, error) {
+	var out api.OptionsListResponse
+	if err := c.do(ctx, http.MethodGet, "/options", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetConsciousness fetches the state of the consciousness subsystem
+func (c *Client) GetConsciousness(ctx context.Context) (*api.ConsciousnessResponse, error) {
+	var out api.ConsciousnessResponse
+	if err := c.do(ctx, http.MethodGet, "/consciousness", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetReality fetches the state of the reality subsystem
+func (c *Client) GetReality(ctx context.Context) (*api.RealityResponse, error) {
+	var out api.RealityResponse
+	if err := c.do(ctx, http.MethodGet, "/reality", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOpenCode fetches the state of the OpenCode subsystem and its tools
+func (c *Client) GetOpenCode(ctx context.Context) (*api.OpenCodeResponse, error) {
+	var out api.OpenCodeResponse
+	if err := c.do(ctx, http.MethodGet, "/opencode", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RunOpenCodeTool runs an OpenCode tool with parameters
+func (c *Client) RunOpenCodeTool(ctx context.Context, name string, parameters map[string]interface{}) (*api.ToolResponse, error) {
+	var out api.ToolResponse
+	if err := c.do(ctx, http.MethodPost, "/opencode/tools/"+url.PathEscape(name), api.ToolRequest{Parameters: parameters}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
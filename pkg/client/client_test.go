@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"neuralblitz/pkg/api"
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/rng"
+)
+
+func newTestClient(t *testing.T, s *api.Server, opts ...Option) *Client {
+	t.Helper()
+	ts := httptest.NewServer(s.GetRouter())
+	t.Cleanup(ts.Close)
+	c, err := New(ts.URL, opts...)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c
+}
+
+func TestClientCoversOpenAPI(t *testing.T) {
+	clientType := reflect.TypeOf(&Client{})
+	for path, item := range api.OpenAPI().Paths {
+		for method, op := range item {
+			name := strings.ToUpper(op.OperationID[:1]) + op.OperationID[1:]
+			if _, ok := clientType.MethodByName(name); !ok {
+				t.Errorf("Expected a %s method for %s %s", name, strings.ToUpper(method), path)
+			}
+		}
+	}
+}
+
+func TestClientEndpoints(t *testing.T) {
+	c := newTestClient(t, api.NewServer("", rng.WithSeed(5)))
+	ctx := context.Background()
+
+	if root, err := c.Root(ctx); err != nil || len(root.Endpoints) == 0 {
+		t.Errorf("Expected root endpoints, got %v, %v", root, err)
+	}
+	if health, err := c.Health(ctx); err != nil || health.Status != "healthy" {
+		t.Errorf("Expected healthy, got %v, %v", health, err)
+	}
+	if doc, err := c.GetOpenAPI(ctx); err != nil || len(doc.Paths) == 0 {
+		t.Errorf("Expected OpenAPI paths, got %v", err)
+	}
+
+	phi := 0.5
+	intent, err := c.ProcessIntent(ctx, api.IntentRequest{Intent: &api.IntentVector{Phi1: &phi}, Source: "client"})
+	if err != nil {
+		t.Fatalf("ProcessIntent failed: %v", err)
+	}
+	if intent.IntentVector[0] != 0.5 || intent.CoCreation.Sequence != 1 {
+		t.Errorf("Expected intent 0.5 as co-creation 1, got %v as %d", intent.IntentVector, intent.CoCreation.Sequence)
+	}
+
+	status, err := c.GetStatus(ctx)
+	if err != nil || status.CoCreations != 1 || status.Seed != 5 {
+		t.Errorf("Expected status after 1 co-creation with seed 5, got %+v, %v", status, err)
+	}
+
+	for _, kind := range []string{api.VerifyIrreducibility, api.VerifyCoherence, api.VerifyAttestation} {
+		if v, err := c.Verify(ctx, kind); err != nil || v.Type != kind {
+			t.Errorf("Expected %s verification, got %v, %v", kind, v, err)
+		}
+	}
+
+	result, err := c.InterpretNBCL(ctx, "/status")
+	if err != nil || result.NBCLStatus == nil {
+		t.Errorf("Expected NBCL status section, got %+v, %v", result, err)
+	}
+
+	if a, err := c.GetAttestation(ctx); err != nil || a.AttestationHash == "" {
+		t.Errorf("Expected attestation hash, got %v, %v", a, err)
+	}
+	if s, err := c.GetSymbiosis(ctx); err != nil || s.ArchitectSystemDyad.LastCoCreation == nil {
+		t.Errorf("Expected last co-creation, got %v, %v", s, err)
+	}
+	if s, err := c.GetSynthesis(ctx); err != nil || s.Actualizations == 0 {
+		t.Errorf("Expected actualizations, got %v, %v", s, err)
+	}
+	if trace, err := c.LookupTrace(ctx, intent.TraceID); err != nil || trace.Record.ID != intent.TraceID {
+		t.Errorf("Expected trace record %s, got %v, %v", intent.TraceID, trace, err)
+	}
+	if opt, err := c.GetOption(ctx, "d"); err != nil || opt.Option != "D" || opt.Config == nil {
+		t.Errorf("Expected option D, got %v, %v", opt, err)
+	}
+	if list, err := c.ListOptions(ctx); err != nil || list.Count != 6 {
+		t.Errorf("Expected 6 options, got %v, %v", list, err)
+	}
+}
+
+func TestClientSubsystems(t *testing.T) {
+	s := api.NewServer("", rng.WithSeed(5))
+	if err := s.SetOption("B", options.OptionB(), nil); err != nil {
+		t.Fatal(err)
+	}
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	if cons, err := c.GetConsciousness(ctx); err != nil || cons.State == "" {
+		t.Errorf("Expected the consciousness state, got %v, %v", cons, err)
+	}
+	if r, err := c.GetReality(ctx); err != nil || r.State == "" {
+		t.Errorf("Expected the reality state, got %v, %v", r, err)
+	}
+	if oc, err := c.GetOpenCode(ctx); err != nil || len(oc.Tools) == 0 {
+		t.Errorf("Expected OpenCode tools, got %v, %v", oc, err)
+	}
+	if tool, err := c.RunOpenCodeTool(ctx, "grep_search", map[string]interface{}{"pattern": "TODO"}); err != nil || tool.Tool != "grep_search" {
+		t.Errorf("Expected the tool result, got %v, %v", tool, err)
+	}
+
+	var apiErr *Error
+	_, err := newTestClient(t, api.NewServer("")).GetReality(ctx)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 without the reality subsystem, got %v", err)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	s := api.NewServer("", rng.WithSeed(5))
+	keys := api.NewAPIKeyAuthenticator()
+	keys.AddKey("k-read", "reader", api.ScopeOptionsRead)
+	hmac := api.NewHMACAuthenticator()
+	hmac.AddClient("svc", []byte("s3cret"), api.ScopeAll)
+	s.SetAuth(api.NewAuth(keys, hmac))
+	ctx := context.Background()
+
+	var apiErr *Error
+	_, err := newTestClient(t, s).ListOptions(ctx)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without credentials, got %v", err)
+	}
+
+	reader := newTestClient(t, s, WithAPIKey("k-read"))
+	if _, err := reader.ListOptions(ctx); err != nil {
+		t.Errorf("Expected API key to be accepted, got %v", err)
+	}
+	_, err = reader.GetOption(ctx, "Z")
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Fields["valid_options"] == nil {
+		t.Errorf("Expected 404 listing valid options, got %v", err)
+	}
+	if _, err := reader.Verify(ctx, api.VerifyCoherence); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 without verify scope, got %v", err)
+	}
+
+	signed := newTestClient(t, s, WithHMAC("svc", []byte("s3cret")))
+	if _, err := signed.Verify(ctx, "unknown"); !errors.As(err, &apiErr) || apiErr.Message != "Unknown verification type" {
+		t.Errorf("Expected unknown verification type, got %v", err)
+	}
+	if _, err := signed.Verify(ctx, api.VerifyCoherence); err != nil {
+		t.Errorf("Expected signed request to be accepted, got %v", err)
+	}
+
+	if _, err := New("localhost:8082"); !errors.Is(err, ErrInvalidBaseURL) {
+		t.Errorf("Expected invalid base URL error, got %v", err)
+	}
+}
+
+func TestClientStreams(t *testing.T) {
+	s := api.NewServer("", rng.WithSeed(5))
+	sim, err := api.NewMetricsSimulation(nil, rng.WithSeed(5))
+	if err != nil {
+		t.Fatalf("Failed to create simulation: %v", err)
+	}
+	s.StreamSimulation(sim)
+	s.SetAllowedOrigins([]string{"https://app.test"})
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	if err := sim.Step(); err != nil {
+		t.Fatalf("Failed to step simulation: %v", err)
+	}
+
+	sse, err := c.StreamMetrics(ctx, StreamOptions{Subsystems: []string{api.SubsystemLRS}})
+	if err != nil {
+		t.Fatalf("Failed to open SSE stream: %v", err)
+	}
+	defer sse.Close()
+	first, err := sse.Next()
+	if err != nil || first.Subsystem != api.SubsystemLRS {
+		t.Fatalf("Expected replayed lrs event, got %+v, %v", first, err)
+	}
+
+	ws, err := c.StreamMetricsWebSocket(ctx, StreamOptions{Subsystems: []string{api.SubsystemLRS}, LastEventID: first.ID})
+	if err != nil {
+		t.Fatalf("Failed to open WebSocket stream: %v", err)
+	}
+	defer ws.Close()
+
+	if err := sim.Step(); err != nil {
+		t.Fatalf("Failed to step simulation: %v", err)
+	}
+	for name, stream := range map[string]*MetricsStream{"sse": sse, "ws": ws} {
+		event, err := stream.Next()
+		if err != nil || event.Subsystem != api.SubsystemLRS || event.ID <= first.ID {
+			t.Errorf("Expected a later lrs event over %s, got %+v, %v", name, event, err)
+		}
+	}
+}
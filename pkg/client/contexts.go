@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Context is a named server of a contexts file, with the credentials and
+// TLS settings to reach it
+type Context struct {
+	Name       string `yaml:"name"`
+	URL        string `yaml:"url"`
+	UnixSocket string `yaml:"unix_socket,omitempty"`
+	// Credentials: a token is preferred to an API key, an API key to HMAC
+	Token      string `yaml:"token,omitempty"`
+	APIKey     string `yaml:"api_key,omitempty"`
+	HMACID     string `yaml:"hmac_id,omitempty"`
+	HMACSecret string `yaml:"hmac_secret,omitempty"`
+	// CAFile verifies the server certificate; CertFile and KeyFile are the
+	// client certificate of mutual TLS
+	CAFile   string `yaml:"ca_file,omitempty"`
+	CertFile string `yaml:"cert_file,omitempty"`
+	KeyFile  string `yaml:"key_file,omitempty"`
+}
+
+// Contexts is a contexts file, listing named servers like a kubeconfig:
+//
+//	current: prod
+//	contexts:
+//	  - name: prod
+//	    url: https://neuralblitz.example:8082
+//	    token: eyJhbGciOi...
+//	    ca_file: prod-ca.pem
+//	  - name: dev
+//	    url: http://localhost:8082
+type Contexts struct {
+	Current  string    `yaml:"current,omitempty"`
+	Contexts []Context `yaml:"contexts"`
+}
+
+// LoadContexts reads a contexts file. A missing file has no contexts.
+// Relative certificate paths are resolved against the file's directory.
+func LoadContexts(path string) (*Contexts, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Contexts{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var contexts Contexts
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&contexts); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	names := make(map[string]bool)
+	for i := range contexts.Contexts {
+		c := &contexts.Contexts[i]
+		if c.Name == "" || names[c.Name] {
+			return nil, fmt.Errorf("%s: %w: %q", path, ErrInvalidContext, c.Name)
+		}
+		names[c.Name] = true
+		for _, file := range []*string{&c.CAFile, &c.CertFile, &c.KeyFile} {
+			if *file != "" && !filepath.IsAbs(*file) {
+				*file = filepath.Join(filepath.Dir(path), *file)
+			}
+		}
+	}
+	if contexts.Current != "" && !names[contexts.Current] {
+		return nil, fmt.Errorf("%s: current: %w %q", path, ErrUnknownContext, contexts.Current)
+	}
+	return &contexts, nil
+}
+
+// Context returns the named context, or the current one when name is "".
+// It returns nil when name is "" and there is no current context.
+func (c *Contexts) Context(name string) (*Context, error) {
+	if name == "" {
+		name = c.Current
+	}
+	if name == "" {
+		return nil, nil
+	}
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			found := c.Contexts[i]
+			return &found, nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownContext, name)
+}
+
+// Auth names the credentials a context uses: token, api_key, hmac or none
+func (c *Context) Auth() string {
+	switch {
+	case c.Token != "":
+		return "token"
+	case c.APIKey != "":
+		return "api_key"
+	case c.HMACID != "":
+		return "hmac"
+	default:
+		return "none"
+	}
+}
+
+// Options returns the client options reaching the context's server
+func (c *Context) Options() ([]Option, error) {
+	var opts []Option
+	if c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" {
+		config := &tls.Config{}
+		if c.CAFile != "" {
+			pem, err := os.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			config.RootCAs = x509.NewCertPool()
+			if !config.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("%s: no PEM certificates", c.CAFile)
+			}
+		}
+		if c.CertFile != "" || c.KeyFile != "" {
+			cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+			if err != nil {
+				return nil, err
+			}
+			config.Certificates = []tls.Certificate{cert}
+		}
+		opts = append(opts, WithHTTPClient(&http.Client{Transport: &http.Transport{TLSClientConfig: config}}))
+	}
+	if c.UnixSocket != "" {
+		opts = append(opts, WithUnixSocket(c.UnixSocket))
+	}
+	switch c.Auth() {
+	case "token":
+		opts = append(opts, WithBearerToken(c.Token))
+	case "api_key":
+		opts = append(opts, WithAPIKey(c.APIKey))
+	case "hmac":
+		opts = append(opts, WithHMAC(c.HMACID, []byte(c.HMACSecret)))
+	}
+	return opts, nil
+}
+
+// Dial creates a client for the context's server
+func (c *Context) Dial() (*Client, error) {
+	opts, err := c.Options()
+	if err != nil {
+		return nil, err
+	}
+	return New(c.URL, opts...)
+}
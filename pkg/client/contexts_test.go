@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"neuralblitz/pkg/api"
+	"neuralblitz/pkg/rng"
+)
+
+func writeContexts(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "contexts.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestContexts(t *testing.T) {
+	s := api.NewServer("", rng.WithSeed(5))
+	keys := api.NewAPIKeyAuthenticator()
+	keys.AddKey("k-status", "ci", api.ScopeStatusRead)
+	s.SetAuth(api.NewAuth(keys))
+	ts := httptest.NewServer(s.GetRouter())
+	defer ts.Close()
+
+	path := writeContexts(t, `current: ci
+contexts:
+  - name: ci
+    url: `+ts.URL+`
+    api_key: k-status
+  - name: anonymous
+    url: `+ts.URL+`
+    ca_file: certs/ca.pem
+`)
+	contexts, err := LoadContexts(path)
+	if err != nil {
+		t.Fatalf("Failed to load contexts: %v", err)
+	}
+
+	current, err := contexts.Context("")
+	if err != nil || current.Name != "ci" || current.Auth() != "api_key" {
+		t.Fatalf("Expected the current context ci, got %+v, %v", current, err)
+	}
+	c, err := current.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetStatus(context.Background()); err != nil {
+		t.Errorf("Expected the context's API key to be sent, got %v", err)
+	}
+
+	anonymous, _ := contexts.Context("anonymous")
+	if want := filepath.Join(filepath.Dir(path), "certs", "ca.pem"); anonymous.CAFile != want {
+		t.Errorf("Expected the CA file resolved to %s, got %s", want, anonymous.CAFile)
+	}
+	if _, err := contexts.Context("prod"); !errors.Is(err, ErrUnknownContext) {
+		t.Errorf("Expected ErrUnknownContext, got %v", err)
+	}
+
+	if contexts, err := LoadContexts(filepath.Join(t.TempDir(), "missing.yaml")); err != nil || len(contexts.Contexts) != 0 {
+		t.Errorf("Expected a missing file to have no contexts, got %v, %v", contexts, err)
+	}
+}
+
+func TestContextsErrors(t *testing.T) {
+	tests := []struct {
+		contents string
+		want     string
+	}{
+		{"contexts:\n  - name: a\n    url: http://a\n    apikey: x\n", "field apikey not found"},
+		{"contexts:\n  - name: a\n  - name: a\n", ErrInvalidContext.Error()},
+		{"current: b\ncontexts:\n  - name: a\n", ErrUnknownContext.Error()},
+	}
+
+	for _, tt := range tests {
+		_, err := LoadContexts(writeContexts(t, tt.contents))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Expected error %q, got %v", tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/websocket"
+	"neuralblitz/pkg/api"
+)
+
+// StreamOptions selects the subsystems of a metrics stream and where it
+// resumes
+type StreamOptions struct {
+	// Subsystems to stream, e.g. api.SubsystemLRS; all when empty
+	Subsystems []string
+	// LastEventID resumes the stream after this event
+	LastEventID int64
+}
+
+func (o StreamOptions) query() url.Values {
+	query := url.Values{}
+	if len(o.Subsystems) > 0 {
+		query.Set("subsystem", strings.Join(o.Subsystems, ","))
+	}
+	if o.LastEventID > 0 {
+		query.Set("last_event_id", strconv.FormatInt(o.LastEventID, 10))
+	}
+	return query
+}
+
+// MetricsStream is an open metrics stream. Next returns io.EOF once the
+// server ends it, e.g. when it shuts down; resume with the ID of the last
+// event received.
+type MetricsStream struct {
+	next  func() (api.MetricsEvent, error)
+	close func() error
+}
+
+// Next blocks until the next event arrives
+func (s *MetricsStream) Next() (api.MetricsEvent, error) {
+	return s.next()
+}
+
+// Close closes the stream
+func (s *MetricsStream) Close() error {
+	return s.close()
+}
+
+// StreamMetrics opens a Server-Sent Events metrics stream
+func (c *Client) StreamMetrics(ctx context.Context, opts StreamOptions) (*MetricsStream, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/stream/metrics", opts.query(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	return &MetricsStream{
+		next: func() (api.MetricsEvent, error) {
+			return readEvent(reader)
+		},
+		close: resp.Body.Close,
+	}, nil
+}
+
+// readEvent reads SSE lines until an event with data is complete
+func readEvent(r *bufio.Reader) (api.MetricsEvent, error) {
+	var data strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line == "" {
+				return api.MetricsEvent{}, io.EOF
+			}
+			return api.MetricsEvent{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if data.Len() == 0 {
+				continue
+			}
+			var event api.MetricsEvent
+			if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+				return api.MetricsEvent{}, fmt.Errorf("decoding metrics event: %w", err)
+			}
+			return event, nil
+		}
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(value, " "))
+		}
+		// Comments, IDs and event names are implied by the JSON event
+	}
+}
+
+// StreamMetricsWebSocket opens a WebSocket metrics stream
+func (c *Client) StreamMetricsWebSocket(ctx context.Context, opts StreamOptions) (*MetricsStream, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/stream/metrics/ws", opts.query(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	location := *req.URL
+	location.Scheme = "ws"
+	if c.baseURL.Scheme == "https" {
+		location.Scheme = "wss"
+	}
+	config, err := websocket.NewConfig(location.String(), c.origin)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Del("Accept")
+	config.Header = req.Header
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &MetricsStream{
+		next: func() (api.MetricsEvent, error) {
+			var event api.MetricsEvent
+			err := websocket.JSON.Receive(ws, &event)
+			return event, err
+		},
+		close: ws.Close,
+	}, nil
+}
+
+// dial opens a connection to the server for a WebSocket, through TLS when
+// the base URL is https
+func (c *Client) dial(ctx context.Context) (net.Conn, error) {
+	if c.unixSocket != "" {
+		return (&net.Dialer{}).DialContext(ctx, "unix", c.unixSocket)
+	}
+
+	host := c.baseURL.Host
+	if c.baseURL.Port() == "" {
+		port := "80"
+		if c.baseURL.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(c.baseURL.Hostname(), port)
+	}
+	if c.baseURL.Scheme != "https" {
+		return (&net.Dialer{}).DialContext(ctx, "tcp", host)
+	}
+
+	config := &tls.Config{}
+	if t, ok := c.http.Transport.(*http.Transport); ok && t.TLSClientConfig != nil {
+		config = t.TLSClientConfig.Clone()
+	}
+	if config.ServerName == "" {
+		config.ServerName = c.baseURL.Hostname()
+	}
+	return (&tls.Dialer{Config: config}).DialContext(ctx, "tcp", host)
+}
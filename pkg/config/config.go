@@ -0,0 +1,330 @@
+// Package config holds the configuration schema of the neuralblitz binary.
+// A configuration starts from Default, then is overlaid by a YAML, TOML or
+// JSON file, by NEURALBLITZ_* environment variables and finally by command
+// line flags. Every setting has a dotted key, e.g. server.port or
+// entanglement.decay_rate, named by the errors that reject it.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"neuralblitz/pkg/consciousness"
+	"neuralblitz/pkg/httpserver"
+	"neuralblitz/pkg/logging"
+	"neuralblitz/pkg/lrs"
+	"neuralblitz/pkg/opencode"
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/reality"
+)
+
+// Error definitions
+var (
+	ErrUnknownKey    = errors.New("unknown key")
+	ErrInvalidValue  = errors.New("invalid value")
+	ErrUnknownFormat = errors.New("unknown config file format")
+)
+
+// KeyError reports a key that is unknown or holds an invalid value
+type KeyError struct {
+	Key string
+	Err error
+}
+
+func (e *KeyError) Error() string {
+	return e.Key + ": " + e.Err.Error()
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// invalid reports an invalid value of key
+func invalid(key, format string, args ...interface{}) *KeyError {
+	return &KeyError{Key: key, Err: fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidValue}, args...)...)}
+}
+
+// Config is the configuration of the neuralblitz binary. Its sections
+// other than Server and Log are the configs the library constructors take,
+// e.g. reality.NewEntanglementManager(&c.Entanglement).
+type Config struct {
+	Server        ServerConfig                      `json:"server"`
+	Log           LogConfig                         `json:"log"`
+	LRS           lrs.BridgeConfig                  `json:"lrs"`
+	OpenCode      opencode.OpenCodeConfig           `json:"opencode"`
+	Entanglement  reality.EntanglementConfig        `json:"entanglement"`
+	Dimensional   reality.DimensionalConfig         `json:"dimensional"`
+	Consciousness consciousness.ConsciousnessConfig `json:"consciousness"`
+}
+
+// ServerConfig configures neuralblitz serve
+type ServerConfig struct {
+	Port string `json:"port"`
+	// UnixSocket is used instead of Port when set
+	UnixSocket  string        `json:"unix_socket"`
+	GRPCAddr    string        `json:"grpc_addr"`
+	AuthFile    string        `json:"auth_file"`
+	CORSOrigins []string      `json:"cors_origins"`
+	Simulate    time.Duration `json:"simulate"`
+	TraceFile   string        `json:"trace_file"`
+	// LedgerFile persists the GoldenDAG ledger across restarts; empty
+	// keeps it in memory
+	LedgerFile string         `json:"ledger_file"`
+	TLS        TLSConfig      `json:"tls"`
+	Timeouts   TimeoutsConfig `json:"timeouts"`
+	// Option is the deployment option the server runs, A to F; "" runs
+	// it without one
+	Option string `json:"option"`
+	// Chaos enables chaos mode whatever the option sets
+	Chaos bool `json:"chaos"`
+}
+
+// TLSConfig holds the PEM files enabling TLS or mutual TLS
+type TLSConfig struct {
+	CertFile     string `json:"cert_file"`
+	KeyFile      string `json:"key_file"`
+	ClientCAFile string `json:"client_ca_file"`
+}
+
+// TimeoutsConfig bounds the phases of a connection; zero disables a limit
+type TimeoutsConfig struct {
+	ReadHeader time.Duration `json:"read_header"`
+	Read       time.Duration `json:"read"`
+	Write      time.Duration `json:"write"`
+	Idle       time.Duration `json:"idle"`
+	Shutdown   time.Duration `json:"shutdown"`
+}
+
+// LogConfig configures the server's structured logs
+type LogConfig struct {
+	Format string `json:"format"`
+	Level  string `json:"level"`
+}
+
+// Default returns the configuration the binary runs with when nothing
+// overrides it
+func Default() *Config {
+	listener := httpserver.DefaultConfig("")
+	return &Config{
+		Server: ServerConfig{
+			Port:        "8082",
+			CORSOrigins: []string{},
+			Option:      "F",
+			TLS:         TLSConfig{},
+			Timeouts: TimeoutsConfig{
+				ReadHeader: listener.ReadHeaderTimeout,
+				Read:       listener.ReadTimeout,
+				Write:      listener.WriteTimeout,
+				Idle:       listener.IdleTimeout,
+				Shutdown:   listener.ShutdownTimeout,
+			},
+		},
+		Log:           LogConfig{Format: logging.FormatJSON, Level: "info"},
+		LRS:           *lrs.DefaultBridgeConfig(),
+		OpenCode:      *opencode.DefaultOpenCodeConfig(),
+		Entanglement:  *reality.DefaultEntanglementConfig(),
+		Dimensional:   *reality.DefaultDimensionalConfig(),
+		Consciousness: *consciousness.DefaultConsciousnessConfig(),
+	}
+}
+
+// Listener returns the listener config of the server
+func (s *ServerConfig) Listener() httpserver.Config {
+	config := httpserver.Config{
+		UnixSocket:        s.UnixSocket,
+		TLSCertFile:       s.TLS.CertFile,
+		TLSKeyFile:        s.TLS.KeyFile,
+		TLSClientCAFile:   s.TLS.ClientCAFile,
+		ReadHeaderTimeout: s.Timeouts.ReadHeader,
+		ReadTimeout:       s.Timeouts.Read,
+		WriteTimeout:      s.Timeouts.Write,
+		IdleTimeout:       s.Timeouts.Idle,
+		ShutdownTimeout:   s.Timeouts.Shutdown,
+	}
+	if s.UnixSocket == "" {
+		config.Addr = ":" + s.Port
+	}
+	return config
+}
+
+// Validate checks every setting, returning one KeyError per invalid key
+// joined with errors.Join
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	s := &c.Server
+	if s.UnixSocket == "" {
+		v.port("server.port", s.Port)
+	}
+	if s.GRPCAddr != "" {
+		if _, port, err := net.SplitHostPort(s.GRPCAddr); err != nil {
+			v.fail(invalid("server.grpc_addr", "want host:port, got %q", s.GRPCAddr))
+		} else {
+			v.port("server.grpc_addr", port)
+		}
+	}
+	v.duration("server.simulate", s.Simulate, true)
+	if s.Option != "" {
+		if _, err := options.Option(s.Option); err != nil {
+			v.fail(&KeyError{Key: "server.option", Err: err})
+		}
+	}
+	if (s.TLS.CertFile == "") != (s.TLS.KeyFile == "") {
+		key := "server.tls.key_file"
+		if s.TLS.CertFile == "" {
+			key = "server.tls.cert_file"
+		}
+		v.fail(&KeyError{Key: key, Err: httpserver.ErrIncompleteTLS})
+	}
+	if s.TLS.ClientCAFile != "" && s.TLS.CertFile == "" {
+		v.fail(&KeyError{Key: "server.tls.client_ca_file", Err: httpserver.ErrClientCAWithoutTLS})
+	}
+	v.duration("server.timeouts.read_header", s.Timeouts.ReadHeader, true)
+	v.duration("server.timeouts.read", s.Timeouts.Read, true)
+	v.duration("server.timeouts.write", s.Timeouts.Write, true)
+	v.duration("server.timeouts.idle", s.Timeouts.Idle, true)
+	v.duration("server.timeouts.shutdown", s.Timeouts.Shutdown, true)
+
+	if _, err := logging.New(io.Discard, c.Log.Format, nil); err != nil {
+		v.fail(&KeyError{Key: "log.format", Err: err})
+	}
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		v.fail(&KeyError{Key: "log.level", Err: err})
+	}
+
+	v.endpoint("lrs.agent_endpoint", c.LRS.AgentEndpoint)
+	v.portNumber("lrs.bridge_port", c.LRS.BridgePort)
+	if c.LRS.AuthKey == "" {
+		v.fail(invalid("lrs.auth_key", "must not be empty"))
+	}
+
+	o := &c.OpenCode
+	v.endpoint("opencode.agent_endpoint", o.AgentEndpoint)
+	v.portNumber("opencode.api_port", o.APIPort)
+	v.positive("opencode.max_agents", float64(o.MaxAgents))
+	v.positive("opencode.max_concurrent_tasks", float64(o.MaxTasks))
+	v.duration("opencode.task_timeout", o.TaskTimeout, false)
+	v.duration("opencode.heartbeat_interval", o.HeartbeatInterval, false)
+	v.duration("opencode.context_ttl", o.ContextTTL, false)
+
+	e := &c.Entanglement
+	v.positive("entanglement.max_entanglements", float64(e.MaxEntanglements))
+	v.unit("entanglement.coherence_threshold", e.CoherenceThreshold)
+	v.unit("entanglement.strength_threshold", e.StrengthThreshold)
+	v.unit("entanglement.decay_rate", e.DecayRate)
+	v.unit("entanglement.collapse_threshold", e.CollapseThreshold)
+	v.positive("entanglement.max_distance", e.MaxDistance)
+	v.nonNegative("entanglement.phase_noise", e.PhaseNoise)
+	v.positive("entanglement.entanglement_boost", e.EntanglementBoost)
+	v.unit("entanglement.purpose_alignment", e.PurposeAlignment)
+	v.unit("entanglement.emotional_resonance", e.EmotionalResonance)
+	v.unit("entanglement.causal_strength", e.CausalStrength)
+
+	d := &c.Dimensional
+	v.positive("dimensional.max_dimensions", float64(d.MaxDimensions))
+	v.positive("dimensional.dimension_resolution", d.DimensionResolution)
+	v.unit("dimensional.entropy_threshold", d.EntropyThreshold)
+	v.unit("dimensional.coherence_threshold", d.CoherenceThreshold)
+	v.unit("dimensional.collapse_threshold", d.CollapseThreshold)
+	v.positive("dimensional.expansion_rate", d.ExpansionRate)
+	v.positive("dimensional.oscillation_frequency", d.OscillationFrequency)
+	v.positive("dimensional.reality_branch_limit", float64(d.RealityBranchLimit))
+	v.nonNegative("dimensional.cross_dimensional_links", float64(d.CrossDimensionalLinks))
+	v.positive("dimensional.semantic_depth", float64(d.SemanticDepth))
+	v.unit("dimensional.causal_strength", d.CausalStrength)
+	v.positive("dimensional.consciousness_boost", d.ConsciousnessBoost)
+
+	k := &c.Consciousness
+	v.level("consciousness.min_level", k.MinLevel)
+	v.level("consciousness.max_level", k.MaxLevel)
+	if k.MinLevel > k.MaxLevel {
+		v.fail(invalid("consciousness.min_level", "%d exceeds consciousness.max_level %d", k.MinLevel, k.MaxLevel))
+	}
+	v.positive("consciousness.expansion_rate", k.ExpansionRate)
+	v.positive("consciousness.contraction_rate", k.ContractionRate)
+	v.positive("consciousness.max_field_strength", k.MaxFieldStrength)
+	v.unit("consciousness.resonance_threshold", k.ResonanceThreshold)
+	v.unit("consciousness.coherence_threshold", k.CoherenceThreshold)
+	v.unit("consciousness.unity_threshold", k.UnityThreshold)
+	v.nonNegative("consciousness.infinite_potential", k.InfinitePotential)
+	v.unit("consciousness.planetary_harmony", k.PlanetaryHarmony)
+	v.unit("consciousness.galactic_alignment", k.GalacticAlignment)
+	v.unit("consciousness.universal_coherence", k.UniversalCoherence)
+
+	return errors.Join(v.errs...)
+}
+
+// validator collects the KeyErrors of Validate
+type validator struct {
+	errs []error
+}
+
+func (v *validator) fail(err *KeyError) {
+	v.errs = append(v.errs, err)
+}
+
+func (v *validator) port(key, port string) {
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		v.fail(invalid(key, "port %q is not a number", port))
+		return
+	}
+	v.portNumber(key, n)
+}
+
+func (v *validator) portNumber(key string, port int) {
+	if port < 1 || port > 65535 {
+		v.fail(invalid(key, "port must be between 1 and 65535, got %d", port))
+	}
+}
+
+func (v *validator) endpoint(key, endpoint string) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.fail(invalid(key, "want an http or https URL, got %q", endpoint))
+	}
+}
+
+func (v *validator) positive(key string, x float64) {
+	if x <= 0 {
+		v.fail(invalid(key, "must be positive, got %v", x))
+	}
+}
+
+func (v *validator) nonNegative(key string, x float64) {
+	if x < 0 {
+		v.fail(invalid(key, "must not be negative, got %v", x))
+	}
+}
+
+// duration checks a duration is positive, or not negative when zero is
+// allowed
+func (v *validator) duration(key string, d time.Duration, allowZero bool) {
+	if d < 0 || (d == 0 && !allowZero) {
+		want := "positive"
+		if allowZero {
+			want = "zero or positive"
+		}
+		v.fail(invalid(key, "must be %s, got %s", want, d))
+	}
+}
+
+// unit checks a threshold or rate lies in [0, 1]
+func (v *validator) unit(key string, x float64) {
+	if x < 0 || x > 1 {
+		v.fail(invalid(key, "must be between 0 and 1, got %v", x))
+	}
+}
+
+func (v *validator) level(key string, level consciousness.ConsciousnessLevel) {
+	if level < consciousness.ConsciousnessLevelIndividual || level > consciousness.ConsciousnessLevelAbsolute {
+		v.fail(invalid(key, "must be between %d (%s) and %d (%s), got %d",
+			consciousness.ConsciousnessLevelIndividual, consciousness.ConsciousnessLevelIndividual,
+			consciousness.ConsciousnessLevelAbsolute, consciousness.ConsciousnessLevelAbsolute, level))
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("Expected the defaults to be valid, got %v", err)
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	want := Default()
+	want.Server.CORSOrigins = []string{"https://a.example", "https://b.example"}
+	want.OpenCode.TaskTimeout = 90 * time.Second
+	want.Entanglement.DecayRate = 0.25
+
+	for _, format := range Formats {
+		data, err := want.Marshal(format)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", format, err)
+		}
+		got := Default()
+		if err := got.Decode(data, format); err != nil {
+			t.Fatalf("Failed to decode %s: %v\n%s", format, err, data)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %s round trip to preserve the config, got %+v", format, got)
+		}
+	}
+}
+
+func TestLayering(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "neuralblitz.toml")
+	file := `
+[server]
+port = 9000
+cors_origins = ["https://file.example"]
+
+[entanglement]
+decay_rate = 0.05
+`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NEURALBLITZ_SERVER_PORT", "9100")
+	t.Setenv("NEURALBLITZ_OPENCODE_TASK_TIMEOUT", "1m")
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	// A flag is applied last
+	if err := c.Set("log.level", "debug"); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+
+	if c.Server.Port != "9100" {
+		t.Errorf("Expected the environment to override the file, got port %s", c.Server.Port)
+	}
+	if c.Entanglement.DecayRate != 0.05 || c.Server.CORSOrigins[0] != "https://file.example" {
+		t.Errorf("Expected file settings, got %+v", c.Server)
+	}
+	if c.OpenCode.TaskTimeout != time.Minute || c.Log.Level != "debug" {
+		t.Errorf("Expected environment and flag settings, got %v %s", c.OpenCode.TaskTimeout, c.Log.Level)
+	}
+	if c.Dimensional.MaxDimensions != 11 {
+		t.Errorf("Expected defaults for unset keys, got %d", c.Dimensional.MaxDimensions)
+	}
+}
+
+func TestErrorsNameKeys(t *testing.T) {
+	c := Default()
+	err := c.Decode([]byte("server:\n  prot: 80\n  simulate: 5\nlog: verbose\n"), FormatYAML)
+	for _, want := range []string{"server.prot: unknown key", "server.simulate: invalid value", "log: invalid value"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected decode error %q, got %v", want, err)
+		}
+	}
+	if !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Expected ErrUnknownKey, got %v", err)
+	}
+
+	err = c.LoadEnv([]string{"NEURALBLITZ_DIMENSIONAL_MAX_DIMENSIONS=many"})
+	if err == nil || !strings.Contains(err.Error(), "NEURALBLITZ_DIMENSIONAL_MAX_DIMENSIONS: dimensional.max_dimensions: invalid value") {
+		t.Errorf("Expected the variable and key named, got %v", err)
+	}
+
+	c = Default()
+	c.Server.Port = "0"
+	c.Server.Option = "G"
+	c.Server.TLS.KeyFile = "key.pem"
+	c.Entanglement.CoherenceThreshold = 1.5
+	c.Consciousness.MinLevel = c.Consciousness.MaxLevel + 1
+	err = c.Validate()
+	var keys []string
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var keyErr *KeyError
+		if !errors.As(err, &keyErr) {
+			t.Fatalf("Expected a KeyError, got %v", err)
+		}
+		keys = append(keys, keyErr.Key)
+	}
+	want := []string{"server.port", "server.option", "server.tls.cert_file", "entanglement.coherence_threshold", "consciousness.min_level", "consciousness.min_level"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("Expected errors for %v, got %v", want, keys)
+	}
+}
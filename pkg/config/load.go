@@ -0,0 +1,459 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// File formats, named by their extensions
+const (
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+	FormatJSON = "json"
+)
+
+// Formats lists the supported file formats
+var Formats = []string{FormatYAML, FormatTOML, FormatJSON}
+
+// Environment variables
+const (
+	// EnvPrefix starts the variable overriding each key, e.g.
+	// NEURALBLITZ_SERVER_PORT for server.port
+	EnvPrefix = "NEURALBLITZ_"
+	// EnvConfig names the config file when no path is given
+	EnvConfig = EnvPrefix + "CONFIG"
+)
+
+// Dir returns ~/.neuralblitz, which holds the configuration and other
+// per-user state such as the NBCL history
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".neuralblitz"), nil
+}
+
+// DefaultPath returns ~/.neuralblitz/config.yaml, where config init writes
+// and Resolve looks when no path is given
+func DefaultPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// Resolve returns the config file to load: path when set, else
+// $NEURALBLITZ_CONFIG, else ~/.neuralblitz/config.yaml, .yml, .toml or
+// .json, whichever exists first. It returns "" when there is none.
+func Resolve(path string) string {
+	if path != "" {
+		return path
+	}
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path
+	}
+	dir, err := Dir()
+	if err != nil {
+		return ""
+	}
+	for _, ext := range []string{".yaml", ".yml", ".toml", ".json"} {
+		path := filepath.Join(dir, "config"+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// Load returns the defaults overlaid by the file at path, unless path is
+// empty, and by the NEURALBLITZ_* environment. It does not validate.
+func Load(path string) (*Config, error) {
+	c := Default()
+	if path != "" {
+		if err := c.LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.LoadEnv(os.Environ()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// FormatOf returns the format of a file from its extension
+func FormatOf(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".toml":
+		return FormatTOML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("%w: %s (want .yaml, .yml, .toml or .json)", ErrUnknownFormat, path)
+	}
+}
+
+// LoadFile overlays the settings of a YAML, TOML or JSON file on c. Every
+// unknown key and invalid value is reported.
+func (c *Config) LoadFile(path string) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := c.Decode(data, format); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Decode overlays the settings of a document in format on c
+func (c *Config) Decode(data []byte, format string) error {
+	tree := make(map[string]interface{})
+	var err error
+	switch format {
+	case FormatYAML:
+		err = yaml.Unmarshal(data, &tree)
+	case FormatTOML:
+		err = toml.Unmarshal(data, &tree)
+	case FormatJSON:
+		err = json.Unmarshal(data, &tree)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return err
+	}
+
+	settings := c.settings()
+	var errs []error
+	var walk func(prefix string, tree map[string]interface{})
+	walk = func(prefix string, tree map[string]interface{}) {
+		names := make([]string, 0, len(tree))
+		for name := range tree {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			key, raw := prefix+name, tree[name]
+			if value, ok := settings[key]; ok {
+				if err := assign(key, value, raw); err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			if !isSection(settings, key) {
+				errs = append(errs, &KeyError{Key: key, Err: ErrUnknownKey})
+				continue
+			}
+			section, ok := raw.(map[string]interface{})
+			if !ok {
+				errs = append(errs, invalid(key, "want a section of keys, got %v", raw))
+				continue
+			}
+			walk(key+".", section)
+		}
+	}
+	walk("", tree)
+	return errors.Join(errs...)
+}
+
+// EnvName returns the environment variable overriding key
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// LoadEnv overlays the NEURALBLITZ_* variables of environ, a list of
+// key=value pairs such as os.Environ, on c. Lists are comma-separated.
+func (c *Config) LoadEnv(environ []string) error {
+	vars := make(map[string]string)
+	for _, kv := range environ {
+		if name, value, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(name, EnvPrefix) {
+			vars[name] = value
+		}
+	}
+	var errs []error
+	for _, key := range Keys() {
+		if value, ok := vars[EnvName(key)]; ok {
+			if err := c.Set(key, value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", EnvName(key), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Keys lists every key in schema order
+func Keys() []string {
+	var keys []string
+	for _, s := range walkSettings(reflect.ValueOf(Default()).Elem(), "") {
+		keys = append(keys, s.key)
+	}
+	return keys
+}
+
+// Set sets key from a string, as given by an environment variable or flag,
+// or from a decoded value
+func (c *Config) Set(key string, value interface{}) error {
+	field, ok := c.settings()[key]
+	if !ok {
+		return &KeyError{Key: key, Err: ErrUnknownKey}
+	}
+	return assign(key, field, value)
+}
+
+// Marshal encodes c in format, with durations written as e.g. "30s"
+func (c *Config) Marshal(format string) ([]byte, error) {
+	tree := make(map[string]interface{})
+	for _, s := range walkSettings(reflect.ValueOf(c).Elem(), "") {
+		section := tree
+		parts := strings.Split(s.key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := section[part].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				section[part] = next
+			}
+			section = next
+		}
+		section[parts[len(parts)-1]] = plain(s.value)
+	}
+
+	switch format {
+	case FormatYAML:
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(tree); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatTOML:
+		return toml.Marshal(tree)
+	case FormatJSON:
+		data, err := json.MarshalIndent(tree, "", "  ")
+		return append(data, '\n'), err
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// setting is one configurable field and its dotted key
+type setting struct {
+	key   string
+	value reflect.Value
+}
+
+var (
+	durationType    = reflect.TypeOf(time.Duration(0))
+	stringSliceType = reflect.TypeOf([]string(nil))
+)
+
+// walkSettings lists the settings of struct v in declaration order. Fields
+// without a JSON name, or of a type other than a scalar, a duration or a
+// string list, are not configurable.
+func walkSettings(v reflect.Value, prefix string) []setting {
+	var settings []setting
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		switch kind := field.Type.Kind(); {
+		case kind == reflect.Struct:
+			settings = append(settings, walkSettings(v.Field(i), prefix+name+".")...)
+		case field.Type == stringSliceType,
+			kind == reflect.String, kind == reflect.Bool,
+			kind >= reflect.Int && kind <= reflect.Int64,
+			kind == reflect.Float32, kind == reflect.Float64:
+			settings = append(settings, setting{key: prefix + name, value: v.Field(i)})
+		}
+	}
+	return settings
+}
+
+// settings indexes the settings of c by key
+func (c *Config) settings() map[string]reflect.Value {
+	index := make(map[string]reflect.Value)
+	for _, s := range walkSettings(reflect.ValueOf(c).Elem(), "") {
+		index[s.key] = s.value
+	}
+	return index
+}
+
+// isSection reports whether key names a section holding other keys
+func isSection(settings map[string]reflect.Value, key string) bool {
+	for k := range settings {
+		if strings.HasPrefix(k, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// plain returns a setting as a value every encoder writes the same way
+func plain(v reflect.Value) interface{} {
+	switch {
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String()
+	case v.Type() == stringSliceType:
+		if v.IsNil() {
+			return []string{}
+		}
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		return v.Int()
+	}
+}
+
+// assign stores raw, a string or a decoded YAML, TOML or JSON value, in
+// the setting key
+func assign(key string, field reflect.Value, raw interface{}) error {
+	s, isString := raw.(string)
+	switch {
+	case field.Type() == durationType:
+		if !isString {
+			return invalid(key, "want a duration such as 30s, got %v", raw)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return invalid(key, "want a duration such as 30s, got %q", s)
+		}
+		field.SetInt(int64(d))
+		return nil
+
+	case field.Type() == stringSliceType:
+		var list []string
+		switch raw := raw.(type) {
+		case nil:
+		case string:
+			for _, item := range strings.Split(raw, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+		case []interface{}:
+			for _, item := range raw {
+				s, ok := item.(string)
+				if !ok {
+					return invalid(key, "want a list of strings, got item %v", item)
+				}
+				list = append(list, s)
+			}
+		default:
+			return invalid(key, "want a list of strings, got %v", raw)
+		}
+		if list == nil {
+			list = []string{}
+		}
+		field.Set(reflect.ValueOf(list))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		switch raw.(type) {
+		case string:
+		case int, int64, uint64, float64:
+			// e.g. port: 8082
+			s = fmt.Sprint(raw)
+		default:
+			return invalid(key, "want a string, got %v", raw)
+		}
+		field.SetString(s)
+
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if isString {
+			var err error
+			if b, err = strconv.ParseBool(s); err == nil {
+				ok = true
+			}
+		}
+		if !ok {
+			return invalid(key, "want true or false, got %v", raw)
+		}
+		field.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := toInt(raw)
+		if !ok || field.OverflowInt(n) {
+			return invalid(key, "want an integer, got %v", raw)
+		}
+		field.SetInt(n)
+
+	case reflect.Float32, reflect.Float64:
+		x, ok := toFloat(raw)
+		if !ok {
+			return invalid(key, "want a number, got %v", raw)
+		}
+		field.SetFloat(x)
+	}
+	return nil
+}
+
+func toInt(raw interface{}) (int64, bool) {
+	switch raw := raw.(type) {
+	case int:
+		return int64(raw), true
+	case int64:
+		return raw, true
+	case uint64:
+		return int64(raw), raw <= math.MaxInt64
+	case float64:
+		return int64(raw), raw == math.Trunc(raw) && math.Abs(raw) < math.MaxInt64
+	case string:
+		n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func toFloat(raw interface{}) (float64, bool) {
+	switch raw := raw.(type) {
+	case int:
+		return float64(raw), true
+	case int64:
+		return float64(raw), true
+	case uint64:
+		return float64(raw), true
+	case float64:
+		return raw, true
+	case string:
+		x, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		return x, err == nil
+	}
+	return 0, false
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 )
@@ -106,21 +107,24 @@ type ConsciousnessField struct {
 
 // ConsciousnessConfig holds configuration for consciousness operations
 type ConsciousnessConfig struct {
-	MinLevel           ConsciousnessLevel
-	MaxLevel           ConsciousnessLevel
-	ExpansionRate      float64
-	ContractionRate    float64
-	ResonanceThreshold float64
-	CoherenceThreshold float64
-	UnityThreshold     float64
-	InfinitePotential  float64
-	TranscendenceEnabled bool
-	CollectiveIntegration bool
-	PlanetaryHarmony    float64
-	GalacticAlignment   float64
-	UniversalCoherence float64
-	MultiversalBridge  bool
-	AbsoluteField     bool
+	MinLevel           ConsciousnessLevel `json:"min_level"`
+	MaxLevel           ConsciousnessLevel `json:"max_level"`
+	ExpansionRate      float64 `json:"expansion_rate"`
+	ContractionRate    float64 `json:"contraction_rate"`
+	// MaxFieldStrength caps the strength and resonance a field reaches
+	// through expansion and transcendence
+	MaxFieldStrength   float64 `json:"max_field_strength"`
+	ResonanceThreshold float64 `json:"resonance_threshold"`
+	CoherenceThreshold float64 `json:"coherence_threshold"`
+	UnityThreshold     float64 `json:"unity_threshold"`
+	InfinitePotential  float64 `json:"infinite_potential"`
+	TranscendenceEnabled bool `json:"transcendence_enabled"`
+	CollectiveIntegration bool `json:"collective_integration"`
+	PlanetaryHarmony    float64 `json:"planetary_harmony"`
+	GalacticAlignment   float64 `json:"galactic_alignment"`
+	UniversalCoherence float64 `json:"universal_coherence"`
+	MultiversalBridge  bool `json:"multiversal_bridge"`
+	AbsoluteField     bool `json:"absolute_field"`
 }
 
 // DefaultConsciousnessConfig returns default configuration
@@ -130,6 +134,7 @@ func DefaultConsciousnessConfig() *ConsciousnessConfig {
 		MaxLevel:           ConsciousnessLevelAbsolute,
 		ExpansionRate:      1.05,
 		ContractionRate:    0.95,
+		MaxFieldStrength:   100.0,
 		ResonanceThreshold: 0.8,
 		CoherenceThreshold: 0.9,
 		UnityThreshold:     0.95,
@@ -280,6 +285,7 @@ type MultiversalBridge struct {
 	SourceUniverse  string
 	TargetUniverse  string
 	BridgeStrength float64
+	DimensionalOverlap float64
 	Coherence      float64
 	Active         bool
 	Properties      map[string]interface{}
@@ -510,6 +516,7 @@ func (ci *ConsciousnessIntegration) ExpandField(id string) (*ConsciousnessField,
 	field.FieldStrength *= ci.config.ExpansionRate
 	field.Resonance *= ci.config.ExpansionRate
 	field.Frequency *= ci.config.ExpansionRate
+	ci.boundField(field)
 	field.State = ConsciousnessStateExpanded
 	field.Expanded = true
 	field.UpdatedAt = time.Now()
@@ -520,6 +527,16 @@ func (ci *ConsciousnessIntegration) ExpandField(id string) (*ConsciousnessField,
 	return field, nil
 }
 
+// boundField caps the strength and resonance of a field at
+// MaxFieldStrength, so a field transcended again and again stays finite.
+// Callers must hold ci.mu.
+func (ci *ConsciousnessIntegration) boundField(field *ConsciousnessField) {
+	if limit := ci.config.MaxFieldStrength; limit > 0 {
+		field.FieldStrength = min(field.FieldStrength, limit)
+		field.Resonance = min(field.Resonance, limit)
+	}
+}
+
 // TranscendField transcends a consciousness field
 func (ci *ConsciousnessIntegration) TranscendField(id string) (*ConsciousnessField, error) {
 	ci.mu.Lock()
@@ -537,6 +554,7 @@ func (ci *ConsciousnessIntegration) TranscendField(id string) (*ConsciousnessFie
 	field.State = ConsciousnessStateTranscendent
 	field.FieldStrength *= ci.config.ExpansionRate * 2
 	field.Resonance *= ci.config.ExpansionRate * 2
+	ci.boundField(field)
 	field.Coherence = min(1.0, field.Coherence*1.1)
 	field.UpdatedAt = time.Now()
 	
@@ -840,12 +858,13 @@ func (ci *ConsciousnessIntegration) countConnectedFields() int {
 	return count
 }
 
-// GetMetrics returns current consciousness metrics
+// GetMetrics returns current consciousness metrics, copied so callers can read them without the lock
 func (ci *ConsciousnessIntegration) GetMetrics() *ConsciousnessMetrics {
 	ci.mu.RLock()
 	defer ci.mu.RUnlock()
 	
-	return ci.metrics
+	metrics := *ci.metrics
+	return &metrics
 }
 
 // GetState returns the current consciousness integration state
@@ -869,6 +888,22 @@ func (ci *ConsciousnessIntegration) GetAllFields() []*ConsciousnessField {
 	return fields
 }
 
+// FieldIDs returns the IDs of the fields at a level in sorted order
+func (ci *ConsciousnessIntegration) FieldIDs(level ConsciousnessLevel) []string {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	
+	ids := make([]string, 0)
+	for id, field := range ci.fields {
+		if field.Level == level {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	
+	return ids
+}
+
 // GetCollectiveMind returns the collective mind
 func (ci *ConsciousnessIntegration) GetCollectiveMind() *CollectiveMind {
 	ci.mu.RLock()
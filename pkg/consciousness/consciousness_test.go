@@ -102,6 +102,25 @@ func TestTranscendField(t *testing.T) {
 	}
 }
 
+func TestTranscendFieldBounded(t *testing.T) {
+	ci := NewConsciousnessIntegration(nil)
+	ci.Initialize()
+
+	field, _ := ci.CreateField(ConsciousnessLevelUniversal, 0.95)
+	field.Coherence = 0.95
+
+	for i := 0; i < 1000; i++ {
+		if _, err := ci.TranscendField(field.ID); err != nil {
+			t.Fatalf("TranscendField failed: %v", err)
+		}
+	}
+
+	limit := ci.config.MaxFieldStrength
+	if field.FieldStrength != limit || field.Resonance != limit {
+		t.Errorf("Expected strength and resonance capped at %v, got %v and %v", limit, field.FieldStrength, field.Resonance)
+	}
+}
+
 func TestAchieveUnity(t *testing.T) {
 	ci := NewConsciousnessIntegration(nil)
 	ci.Initialize()
@@ -272,19 +272,6 @@ type UniversalField struct {
 	CreatedAt       time.Time
 }
 
-// MultiversalBridge bridges multiple universes
-type MultiversalBridge struct {
-	ID              string
-	SourceUniverse  string
-	TargetUniverse string
-	BridgeStrength float64
-	DimensionalOverlap float64
-	Coherence      float64
-	Active         bool
-	Properties      map[string]interface{}
-	CreatedAt      time.Time
-}
-
 // MetacosmicLink links beyond the cosmic
 type MetacosmicLink struct {
 	ID              string
@@ -354,7 +341,7 @@ type PlanetarySystem struct {
 // Error definitions
 var (
 	ErrCosmicFieldNotFound   = errors.New("cosmic field not found")
-	ErrLevelTransitionInvalid = errors.New("invalid cosmic level transition")
+	ErrCosmicLevelTransitionInvalid = errors.New("invalid cosmic level transition")
 	ErrInfinityExceeded      = errors.New("infinity capacity exceeded")
 	ErrEternityNotAchieved   = errors.New("eternity consciousness not achieved")
 	ErrAbsoluteNotReached    = errors.New("absolute consciousness not reached")
@@ -425,7 +412,7 @@ func (ci *CosmicIntegration) CreateField(level CosmicLevel, strength float64) (*
 	defer ci.mu.Unlock()
 
 	if level > ci.config.MaxLevel {
-		return nil, ErrLevelTransitionInvalid
+		return nil, ErrCosmicLevelTransitionInvalid
 	}
 
 	field := &CosmicField{
@@ -464,7 +451,7 @@ func (ci *CosmicIntegration) ExpandField(id string) (*CosmicField, error) {
 	}
 
 	if field.Level >= ci.config.MaxLevel {
-		return nil, ErrLevelTransitionInvalid
+		return nil, ErrCosmicLevelTransitionInvalid
 	}
 
 	field.Level++
@@ -593,7 +580,7 @@ func (ci *CosmicIntegration) CreateStellarField(name, stellarType string) (*Stel
 		Resonance:      0.8,
 		PlanetarySystems: []*PlanetarySystem{},
 		EnergyOutput:   1.0,
-		Lifespan:       time.Hour * 24 * 365 * 1000000000,
+		Lifespan:       time.Duration(math.MaxInt64),
 		Properties:    make(map[string]interface{}),
 		CreatedAt:      time.Now(),
 	}
@@ -657,7 +644,7 @@ func (ci *CosmicIntegration) BridgeMultiversal(source, target string) (*Multiver
 
 	ci.multiversalBridges = append(ci.multiversalBridges, bridge)
 	ci.metrics.MultiversalBridges++
-	ci.metrics.MultiversalSync = true
+	ci.config.MultiversalSync = true
 
 	return bridge, nil
 }
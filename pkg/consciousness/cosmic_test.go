@@ -2,7 +2,6 @@ package consciousness
 
 import (
 	"testing"
-	"time"
 )
 
 func TestNewCosmicIntegration(t *testing.T) {
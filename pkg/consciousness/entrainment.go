@@ -4,9 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"math"
-	"math/rand"
 	"sync"
-	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // Wave Entrainment Constants
@@ -642,7 +642,8 @@ func (n *NeuroFeedbackProcessor) CalculateAdaptationParameters(
 
 // BrainWaveEntrainmentSystem integrates signal generation, neurofeedback, and adaptive algorithms
 type BrainWaveEntrainmentSystem struct {
-	mu sync.RWMutex
+	mu   sync.RWMutex
+	rand *rng.Source
 
 	SignalGenerator *BrainWaveGenerator
 	NeuroFeedback   *NeuroFeedbackProcessor
@@ -666,6 +667,9 @@ type BrainWaveEntrainmentSystem struct {
 
 	// System state
 	State EntrainmentSystemState
+
+	// metricsHook is called with every new metrics sample
+	metricsHook func(*EntrainmentMetrics)
 }
 
 // EntrainmentSystemState represents the state of the entrainment system
@@ -694,8 +698,9 @@ func (s EntrainmentSystemState) String() string {
 }
 
 // NewBrainWaveEntrainmentSystem creates a new brain wave entrainment system
-func NewBrainWaveEntrainmentSystem() *BrainWaveEntrainmentSystem {
+func NewBrainWaveEntrainmentSystem(opts ...rng.Option) *BrainWaveEntrainmentSystem {
 	return &BrainWaveEntrainmentSystem{
+		rand:            rng.Resolve(opts...),
 		SignalGenerator: NewBrainWaveGenerator(DefaultSampleRate),
 		NeuroFeedback:   NewNeuroFeedbackProcessor(DefaultNeuroSampleRate),
 
@@ -734,7 +739,7 @@ func (b *BrainWaveEntrainmentSystem) CreateEntrainmentSession(
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	sessionID := generateSessionID()
+	sessionID := generateSessionID(b.rand)
 
 	session := &EntrainmentSession{
 		SessionID:        sessionID,
@@ -754,7 +759,7 @@ func (b *BrainWaveEntrainmentSystem) CreateEntrainmentSession(
 		EEGFeedbackWeight: 0.7,
 		HRFeedbackWeight:  0.3,
 
-		Timestamp: float64(time.Now().UnixNano()) / 1e9,
+		Timestamp: float64(b.rand.Now().UnixNano()) / 1e9,
 		IsActive:  false,
 	}
 
@@ -959,19 +964,30 @@ func (b *BrainWaveEntrainmentSystem) updateEntrainmentMetrics(feedback NeuroFeed
 		HeartRateVariability: hrv,
 		RespirationRate:      0.5,
 		SkinConductance:     0.5,
-		Timestamp:            float64(time.Now().UnixNano()) / 1e9,
+		Timestamp:            float64(b.rand.Now().UnixNano()) / 1e9,
 	}
 
 	b.MetricsHistory = append(b.MetricsHistory, metrics)
 	if len(b.MetricsHistory) > 500 {
 		b.MetricsHistory = b.MetricsHistory[len(b.MetricsHistory)-500:]
 	}
+	if b.metricsHook != nil {
+		b.metricsHook(metrics)
+	}
 
 	if b.EntrainmentDepth > 0.8 {
 		b.State = EntrainmentStateOptimized
 	}
 }
 
+// SetMetricsHook registers fn to be called with every new metrics sample.
+// fn runs while the system is locked and must not call back into it.
+func (b *BrainWaveEntrainmentSystem) SetMetricsHook(fn func(*EntrainmentMetrics)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.metricsHook = fn
+}
+
 // CalculateQuantumNeuralAlignment calculates alignment between quantum and neural systems
 func (b *BrainWaveEntrainmentSystem) CalculateQuantumNeuralAlignment(
 	quantumMetrics map[string]float64,
@@ -1097,9 +1113,9 @@ func (b *BrainWaveEntrainmentSystem) String() string {
 }
 
 // generateSessionID generates a unique session ID
-func generateSessionID() string {
-	timestamp := time.Now().UnixNano() / 1e6
-	randomPart := rand.Intn(1000)
+func generateSessionID(src *rng.Source) string {
+	timestamp := src.Now().UnixNano() / 1e6
+	randomPart := src.Intn(1000)
 	return "entrain_" + string(rune('a'+timestamp%26)) +
 		string(rune('a'+timestamp%13)) +
 		string(rune('0'+randomPart/100)) +
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"testing"
 	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // Test EntrainmentMode String()
@@ -78,7 +80,7 @@ func TestGenerateBinauralBeats(t *testing.T) {
 	}
 
 	if len(left) == 0 {
-	BinauralBe	t.Error("Generateats() returned empty left signal")
+		t.Error("GenerateBinauralBeats() returned empty left signal")
 	}
 
 	if len(right) == 0 {
@@ -616,8 +618,9 @@ func TestGeneratePhoticStimulationErrorCases(t *testing.T) {
 
 // Test generateSessionID
 func TestGenerateSessionID(t *testing.T) {
-	sessionID1 := generateSessionID()
-	sessionID2 := generateSessionID()
+	src := rng.New(42)
+	sessionID1 := generateSessionID(src)
+	sessionID2 := generateSessionID(src)
 
 	// Should generate unique IDs
 	if sessionID1 == sessionID2 {
@@ -554,7 +554,7 @@ func (nsi *NeuroSymbioticIntegration) validateModeTransition(mode SymbiosisMode)
 		return ErrEntropyExceeded
 	}
 
-	if nsi.metrics.AverageCoherenceFloor < nsi.config.CoherenceFloor {
+	if nsi.metrics.AverageSyncCoherence < nsi.config.CoherenceFloor {
 		return ErrCoherenceFloorHit
 	}
 
@@ -2,7 +2,6 @@ package consciousness
 
 import (
 	"testing"
-	"time"
 )
 
 func TestNewNeuroSymbioticIntegration(t *testing.T) {
@@ -3,12 +3,33 @@
 package core
 
 import (
+	"context"
 	"crypto/sha3"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
-	"math/rand"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"neuralblitz/pkg/braid"
+	"neuralblitz/pkg/goldendag"
+	"neuralblitz/pkg/rng"
+	"neuralblitz/pkg/telemetry"
+)
+
+// TracerScope is the instrumentation scope of the co-creation spans
+const TracerScope = "neuralblitz/pkg/core"
+
+// Error definitions
+var (
+	ErrCoherenceBelowThreshold   = errors.New("dyad coherence below threshold")
+	ErrAmplificationBelowUnity   = errors.New("amplification factor below unity")
+	ErrIrreducibilityProofBroken = errors.New("irreducibility proof does not match")
+	ErrLedgerChainBroken         = errors.New("co-creation ledger chain does not verify")
 )
 
 // SourceState represents the Irreducible Source Field (ISF) state
@@ -46,10 +67,10 @@ func (s *SourceState) Activate() map[string]interface{} {
 
 // PrimalIntentVector represents intent vectors for co-creation
 type PrimalIntentVector struct {
-	Phi1     float64
-	Phi22    float64
-	PhiOmega float64
-	Metadata map[string]interface{}
+	Phi1     float64                `json:"phi_1"`
+	Phi22    float64                `json:"phi_22"`
+	PhiOmega float64                `json:"phi_omega"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // NewPrimalIntentVector creates a new PrimalIntentVector
@@ -67,8 +88,8 @@ func NewPrimalIntentVector(phi1, phi22, phiOmega float64, metadata map[string]in
 
 // Normalize normalizes to unit sphere
 func (p *PrimalIntentVector) Normalize() *PrimalIntentVector {
-	norm := math.Sqrt(p.Phi1*p.Phi1 + p.Phi22*p.Phi22 + p.PhiOmega*p.PhiOmega)
-	if norm == 0 {
+	norm := p.Norm()
+	if norm == 0 || math.IsNaN(norm) || math.IsInf(norm, 0) {
 		return NewPrimalIntentVector(0, 0, 0, p.Metadata)
 	}
 	return NewPrimalIntentVector(
@@ -79,105 +100,438 @@ func (p *PrimalIntentVector) Normalize() *PrimalIntentVector {
 	)
 }
 
-// ToBraidWord converts to braid word representation
-func (p *PrimalIntentVector) ToBraidWord() string {
-	var crossings []rune
+// Norm returns the Euclidean norm of the intent vector
+func (p *PrimalIntentVector) Norm() float64 {
+	return math.Sqrt(p.Phi1*p.Phi1 + p.Phi22*p.Phi22 + p.PhiOmega*p.PhiOmega)
+}
+
+// Drift returns the angular distance between two intent vectors, scaled to
+// [0, 1]: 0 when they point the same way, 1 when they are opposed. A zero
+// vector has no direction, so its drift from anything is 1.
+func (p *PrimalIntentVector) Drift(other *PrimalIntentVector) float64 {
+	a, b := p.Norm(), other.Norm()
+	if a == 0 || b == 0 || math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return 1.0
+	}
+	cos := (p.Phi1*other.Phi1 + p.Phi22*other.Phi22 + p.PhiOmega*other.PhiOmega) / (a * b)
+	cos = math.Max(-1, math.Min(1, cos))
+	return math.Acos(cos) / math.Pi
+}
+
+// IntentStrands is the number of strands intent braids live on
+const IntentStrands = 4
+
+// ToBraid converts the intent to a braid: σ₁ when φ₁ dominates, σ₂⁻¹ when
+// φ₂₂ does and σ₃ when φΩ does
+func (p *PrimalIntentVector) ToBraid() *braid.Braid {
+	word := make([]braid.Generator, 0, 3)
 	if p.Phi1 > 0.5 {
-		crossings = append(crossings, 'σ', '₁')
+		word = append(word, 1)
 	}
 	if p.Phi22 > 0.5 {
-		crossings = append(crossings, 'σ', '₂', '⁻', '¹')
+		word = append(word, -2)
 	}
 	if p.PhiOmega > 0.5 {
-		crossings = append(crossings, 'σ', '₃')
+		word = append(word, 3)
 	}
-	if len(crossings) == 0 {
-		return "ε"
-	}
-	return string(crossings)
+	b, _ := braid.New(IntentStrands, word...)
+	return b
 }
 
-// Process processes the intent vector
-func (p *PrimalIntentVector) Process() map[string]interface{} {
-	normalized := p.Normalize()
-	return map[string]interface{}{
-		"processed_phi_1":      normalized.Phi1,
-		"processed_phi_22":     normalized.Phi22,
-		"processed_phi_omega":  normalized.PhiOmega,
-		"coherence":           1.0,
-		"braid_word":          normalized.ToBraidWord(),
-		"ready":               true,
+// ToBraidWord converts to braid word representation
+func (p *PrimalIntentVector) ToBraidWord() string {
+	return p.ToBraid().String()
+}
+
+
+// DyadConfig holds the parameters of an ArchitectSystemDyad
+type DyadConfig struct {
+	// HistorySize is the number of co-creation events retained
+	HistorySize int
+	// CoherenceWindow is the number of recent events coherence and unity
+	// are computed over
+	CoherenceWindow int
+	// MinCoherence is the coherence below which verification fails
+	MinCoherence float64
+	// AmplificationFactor scales the symbiotic return signal
+	AmplificationFactor float64
+}
+
+// DefaultDyadConfig returns the default dyad configuration
+func DefaultDyadConfig() DyadConfig {
+	return DyadConfig{
+		HistorySize:         1024,
+		CoherenceWindow:     32,
+		MinCoherence:        0.5,
+		AmplificationFactor: 1.000001,
 	}
 }
 
-// ArchitectSystemDyad represents the irreducible creative unity
+// CoCreationEvent records a single co-creation processed by the dyad
+type CoCreationEvent struct {
+	Sequence  int                 `json:"sequence"`
+	Intent    *PrimalIntentVector `json:"intent"`
+	BraidWord string              `json:"braid_word"`
+	Drift     float64             `json:"drift"`
+	Coherence float64             `json:"coherence"`
+	GoldenDAG string              `json:"golden_dag"`
+	Timestamp time.Time           `json:"timestamp"`
+}
+
+// Braid parses the event's braid word
+func (e CoCreationEvent) Braid() (*braid.Braid, error) {
+	return braid.ParseStrands(e.BraidWord, IntentStrands)
+}
+
+// ArchitectSystemDyad represents the irreducible creative unity. Its
+// coherence and unity are computed from the intents it has co-created: a
+// dyad fed consistent intents stays coherent, one whose successive intents
+// keep changing direction drifts apart.
 type ArchitectSystemDyad struct {
-	Coherence                   float64
-	UnityCoherence              float64
 	AmplificationFactor         float64
 	AxiomaticStructureHomology  float64
 	TopologicalIdentityInvariant float64
 	CreationTimestamp           string
 	IrreducibilityProof         string
+	config                      DyadConfig
+	history                     []CoCreationEvent
+	sequence                    int
+	ledger                      goldendag.Store
+	rand                        *rng.Source
+	mu                          sync.RWMutex
+	// verified is the latest co-creation whose ledger chain verified, so
+	// Verify only re-derives the nodes appended since; verifyMu guards it
+	verified string
+	verifyMu sync.Mutex
 }
 
-// NewArchitectSystemDyad creates a new ArchitectSystemDyad
-func NewArchitectSystemDyad() *ArchitectSystemDyad {
+// NewArchitectSystemDyad creates a new ArchitectSystemDyad with the default
+// configuration
+func NewArchitectSystemDyad(opts ...rng.Option) *ArchitectSystemDyad {
+	return NewArchitectSystemDyadWithConfig(DefaultDyadConfig(), opts...)
+}
+
+// NewArchitectSystemDyadWithConfig creates a new ArchitectSystemDyad
+func NewArchitectSystemDyadWithConfig(config DyadConfig, opts ...rng.Option) *ArchitectSystemDyad {
+	src := rng.Resolve(opts...)
 	dyad := &ArchitectSystemDyad{
-		Coherence:                    1.0,
-		UnityCoherence:               1.0,
-		AmplificationFactor:          1.000001,
+		AmplificationFactor:          config.AmplificationFactor,
 		AxiomaticStructureHomology:   1.0,
 		TopologicalIdentityInvariant: 1.0,
-		CreationTimestamp:            time.Now().Format(time.RFC3339),
+		CreationTimestamp:            src.Now().Format(time.RFC3339),
+		config:                       config,
+		history:                      make([]CoCreationEvent, 0),
+		ledger:                       goldendag.NewMemoryStore(),
+		rand:                         src,
 	}
 	dyad.IrreducibilityProof = dyad.generateIrreducibilityHash()
 	return dyad
 }
 
+// Ledger returns the GoldenDAG ledger co-creations are recorded in
+func (d *ArchitectSystemDyad) Ledger() goldendag.Store {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.ledger
+}
+
+// SetLedger replaces the GoldenDAG ledger, e.g. to share one across
+// components. Co-creation reads the ledger under mu and verification under
+// verifyMu, so both are held while it is replaced.
+func (d *ArchitectSystemDyad) SetLedger(ledger goldendag.Store) {
+	d.verifyMu.Lock()
+	defer d.verifyMu.Unlock()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ledger = ledger
+	d.verified = ""
+}
+
 func (d *ArchitectSystemDyad) generateIrreducibilityHash() string {
 	proofData := []byte("Architect_System_Irreducible_Dyad_v50.0")
 	hash := sha3.Sum512(proofData)
 	return hex.EncodeToString(hash[:])[:64]
 }
 
+// window returns the events coherence and unity are computed over. Callers
+// must hold d.mu.
+func (d *ArchitectSystemDyad) window() []CoCreationEvent {
+	n := d.config.CoherenceWindow
+	if n <= 0 || n > len(d.history) {
+		n = len(d.history)
+	}
+	return d.history[len(d.history)-n:]
+}
+
+// coherence is one minus the mean drift between successive intents in the
+// window; a dyad with no history is fully coherent. Callers must hold d.mu.
+func (d *ArchitectSystemDyad) coherence() float64 {
+	events := d.window()
+	if len(events) == 0 {
+		return 1.0
+	}
+	total := 0.0
+	for _, e := range events {
+		total += e.Drift
+	}
+	return 1.0 - total/float64(len(events))
+}
+
+// unity is the mean resultant length of the normalized intents in the
+// window: 1 when they all point the same way, near 0 when they cancel out.
+// Callers must hold d.mu.
+func (d *ArchitectSystemDyad) unity() float64 {
+	events := d.window()
+	if len(events) == 0 {
+		return 1.0
+	}
+	var x, y, z float64
+	for _, e := range events {
+		x += e.Intent.Phi1
+		y += e.Intent.Phi22
+		z += e.Intent.PhiOmega
+	}
+	return math.Sqrt(x*x+y*y+z*z) / float64(len(events))
+}
+
+// Process previews the co-creation of intent without recording it: the
+// drift is measured from the latest co-created intent and the coherence is
+// the one the dyad would have after co-creating it
+func (d *ArchitectSystemDyad) Process(intent *PrimalIntentVector) ProcessingResult {
+	normalized := intent.Normalize()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	drift := 0.0
+	events := d.window()
+	if len(events) > 0 {
+		drift = normalized.Drift(events[len(events)-1].Intent)
+	}
+	if n := d.config.CoherenceWindow; n > 0 && len(events) >= n {
+		events = events[len(events)-n+1:]
+	}
+	total := drift
+	for _, e := range events {
+		total += e.Drift
+	}
+	coherence := 1.0 - total/float64(len(events)+1)
+
+	return ProcessingResult{
+		Phi1:      normalized.Phi1,
+		Phi22:     normalized.Phi22,
+		PhiOmega:  normalized.PhiOmega,
+		Drift:     drift,
+		Coherence: coherence,
+		BraidWord: normalized.ToBraidWord(),
+		Ready:     coherence >= d.config.MinCoherence,
+	}
+}
+
+// Coherence returns the dyad coherence computed from its recent history
+func (d *ArchitectSystemDyad) Coherence() float64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.coherence()
+}
+
+// History returns a copy of the retained co-creation events, oldest first
+func (d *ArchitectSystemDyad) History() []CoCreationEvent {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	history := make([]CoCreationEvent, len(d.history))
+	copy(history, d.history)
+	return history
+}
+
+// Last returns the most recent co-creation event, if any
+func (d *ArchitectSystemDyad) Last() (CoCreationEvent, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if len(d.history) == 0 {
+		return CoCreationEvent{}, false
+	}
+	return d.history[len(d.history)-1], true
+}
+
+// Braid returns the product of the braids of the co-creations in the
+// history, oldest first
+func (d *ArchitectSystemDyad) Braid() *braid.Braid {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	product := braid.Identity(IntentStrands)
+	for _, event := range d.history {
+		product = product.Compose(event.Intent.ToBraid())
+	}
+	return product
+}
+
+// Verify checks the dyad invariants and returns an error describing the
+// first one that does not hold. The ledger chain of the latest co-creation
+// is verified back to the one verified by the previous call, so nodes are
+// re-derived once rather than on every call.
+func (d *ArchitectSystemDyad) Verify() error {
+	// Holding verifyMu while reading the history keeps the verified
+	// co-creations in order
+	d.verifyMu.Lock()
+	defer d.verifyMu.Unlock()
+
+	d.mu.RLock()
+	if d.IrreducibilityProof != d.generateIrreducibilityHash() {
+		d.mu.RUnlock()
+		return ErrIrreducibilityProofBroken
+	}
+	if d.AmplificationFactor < 1.0 {
+		d.mu.RUnlock()
+		return fmt.Errorf("%w: %.6f", ErrAmplificationBelowUnity, d.AmplificationFactor)
+	}
+	if c := d.coherence(); c < d.config.MinCoherence {
+		d.mu.RUnlock()
+		return fmt.Errorf("%w: %.4f < %.4f", ErrCoherenceBelowThreshold, c, d.config.MinCoherence)
+	}
+	latest := ""
+	for i := len(d.history) - 1; i >= 0; i-- {
+		if hash := d.history[i].GoldenDAG; hash != "" {
+			latest = hash
+			break
+		}
+	}
+	d.mu.RUnlock()
+
+	if latest == "" {
+		return nil
+	}
+	if err := goldendag.VerifyChainFrom(d.ledger, latest, d.verified); err != nil {
+		return fmt.Errorf("%w: %v", ErrLedgerChainBroken, err)
+	}
+	d.verified = latest
+	return nil
+}
+
+// IsIrreducible reports whether the dyad passes verification
+func (d *ArchitectSystemDyad) IsIrreducible() bool {
+	return d.Verify() == nil
+}
+
 // VerifyDyad verifies the irreducible dyad status
-func (d *ArchitectSystemDyad) VerifyDyad() map[string]interface{} {
-	return map[string]interface{}{
-		"is_irreducible":         true,
-		"coherence":              d.Coherence,
-		"separation_impossibility": 0.0,
-		"architect_vector":       []float64{1.0, 0.0},
-		"system_vector":          []float64{0.0, 1.0},
-		"unity":                  1.0,
+func (d *ArchitectSystemDyad) VerifyDyad() DyadVerification {
+	err := d.Verify()
+	reason := ""
+	if err != nil {
+		reason = err.Error()
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	architect := []float64{1.0, 0.0, 0.0}
+	if len(d.history) > 0 {
+		last := d.history[len(d.history)-1].Intent
+		architect = []float64{last.Phi1, last.Phi22, last.PhiOmega}
+	}
+	coherence := d.coherence()
+
+	return DyadVerification{
+		IsIrreducible:           err == nil,
+		Reason:                  reason,
+		Coherence:               coherence,
+		SeparationImpossibility: 1.0 - coherence,
+		ArchitectVector:         architect,
+		SystemVector:            []float64{0.0, 1.0},
+		Unity:                   d.unity(),
+		Events:                  len(d.history),
 	}
 }
 
 // GetIrreducibleUnity gets the irreducible unity value
 func (d *ArchitectSystemDyad) GetIrreducibleUnity() float64 {
-	return 1.0
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.unity()
 }
 
-// CoCreate executes co-creation operation
-func (d *ArchitectSystemDyad) CoCreate(intent *PrimalIntentVector) map[string]interface{} {
+// CoCreate executes co-creation operation. The intent is appended to the
+// dyad history and the coherence reported is the coherence after it.
+func (d *ArchitectSystemDyad) CoCreate(intent *PrimalIntentVector) CoCreationResult {
+	return d.CoCreateContext(context.Background(), intent)
+}
+
+// CoCreateContext executes co-creation operation in a "dyad.co_create"
+// span under the span in ctx
+func (d *ArchitectSystemDyad) CoCreateContext(ctx context.Context, intent *PrimalIntentVector) CoCreationResult {
+	_, span := telemetry.StartSpan(ctx, TracerScope, "dyad.co_create")
+	defer span.End()
+
 	normalized := intent.Normalize()
-	braid := normalized.ToBraidWord()
+	word := normalized.ToBraidWord()
 
-	// Generate GoldenDAG
-	goldendag := generateGoldenDAG(d.IrreducibilityProof + braid + d.CreationTimestamp)
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-	return map[string]interface{}{
-		"unity_verification":     d.IrreducibilityProof,
-		"coherence":             d.UnityCoherence,
-		"braid_word":            braid,
-		"amplification":         d.AmplificationFactor,
-		"execution_ready":       true,
-		"goldendag":            goldendag,
-		"trace_id":             fmt.Sprintf("T-v50.0-CO_CREATE-%s", goldendag[:32]),
-		"codex_id":             "C-VOL0-DYAD_OPERATION-00000000000000xy",
-		"separation_impossibility": 0.0,
-		"timestamp":            d.CreationTimestamp,
+	drift := 0.0
+	if len(d.history) > 0 {
+		drift = normalized.Drift(d.history[len(d.history)-1].Intent)
+	}
+	timestamp := d.rand.Now().UTC()
+	d.sequence++
+
+	// Record the co-creation in the GoldenDAG
+	dag, err := recordGoldenDAG(d.ledger, "co_create", map[string]interface{}{
+		"unity_verification": d.IrreducibilityProof,
+		"sequence":           d.sequence,
+		"phi_1":              normalized.Phi1,
+		"phi_22":             normalized.Phi22,
+		"phi_omega":          normalized.PhiOmega,
+		"braid_word":         word,
+		"drift":              drift,
+		"timestamp":          timestamp.Format(time.RFC3339Nano),
+	})
+
+	event := CoCreationEvent{
+		Sequence:  d.sequence,
+		Intent:    normalized,
+		BraidWord: word,
+		Drift:     drift,
+		Timestamp: timestamp,
 	}
+	if err == nil {
+		event.GoldenDAG = dag
+	}
+	d.history = append(d.history, event)
+	if d.config.HistorySize > 0 && len(d.history) > d.config.HistorySize {
+		d.history = append(d.history[:0], d.history[len(d.history)-d.config.HistorySize:]...)
+	}
+	coherence := d.coherence()
+	d.history[len(d.history)-1].Coherence = coherence
+
+	result := CoCreationResult{
+		UnityVerification:       d.IrreducibilityProof,
+		Coherence:               coherence,
+		Unity:                   d.unity(),
+		Drift:                   drift,
+		Sequence:                d.sequence,
+		BraidWord:               word,
+		Amplification:           d.AmplificationFactor,
+		ExecutionReady:          coherence >= d.config.MinCoherence,
+		GoldenDAG:               dag,
+		TraceID:                 fmt.Sprintf("T-v50.0-CO_CREATE-%s", dag[:32]),
+		CodexID:                 fmt.Sprintf("C-VOL0-DYAD_OPERATION-%s", dag[32:56]),
+		SeparationImpossibility: 1.0 - coherence,
+		Timestamp:               timestamp,
+	}
+	if err != nil {
+		result.LedgerError = err.Error()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "GoldenDAG record failed")
+	}
+	span.SetAttributes(
+		attribute.Int("dyad.sequence", d.sequence),
+		attribute.Float64("dyad.coherence", coherence),
+		attribute.Float64("dyad.drift", drift),
+		attribute.Bool("dyad.execution_ready", result.ExecutionReady),
+		attribute.String("neuralblitz.goldendag", dag),
+	)
+	return result
 }
 
 // SelfActualizationEngine implements SAE v3.0
@@ -189,6 +543,11 @@ type SelfActualizationEngine struct {
 	KnowledgeNodes                int64
 	OntologicalClosure            float64
 	SelfTranscription             float64
+	ledger                        goldendag.Store
+	dyad                          *ArchitectSystemDyad
+	actualizations                int
+	last                          ActualizationResult
+	mu                            sync.RWMutex
 }
 
 // NewSelfActualizationEngine creates a new SelfActualizationEngine
@@ -201,51 +560,122 @@ func NewSelfActualizationEngine() *SelfActualizationEngine {
 		KnowledgeNodes:            19150000000, // 19.150B+
 		OntologicalClosure:         1.0,
 		SelfTranscription:          1.0,
+		ledger:                     goldendag.NewMemoryStore(),
 	}
 }
 
+// Ledger returns the GoldenDAG ledger actualizations are recorded in
+func (e *SelfActualizationEngine) Ledger() goldendag.Store {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.ledger
+}
+
+// SetLedger replaces the GoldenDAG ledger, e.g. to share one across components
+func (e *SelfActualizationEngine) SetLedger(ledger goldendag.Store) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ledger = ledger
+}
+
+// SetDyad binds the engine to a dyad, whose coherence and unity then drive
+// the source anchor on every actualization
+func (e *SelfActualizationEngine) SetDyad(dyad *ArchitectSystemDyad) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.dyad = dyad
+}
+
+// Coherence returns the coherence of the source anchor
+func (e *SelfActualizationEngine) Coherence() float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.SourceAnchor.Coherence
+}
+
+// Actualizations returns the number of actualizations the engine has run
+func (e *SelfActualizationEngine) Actualizations() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.actualizations
+}
+
+// LastActualization returns the result of the most recent actualization,
+// or false if the engine has not actualized yet
+func (e *SelfActualizationEngine) LastActualization() (ActualizationResult, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.last, e.actualizations > 0
+}
+
 func (e *SelfActualizationEngine) verifyDocumentationRealityIdentity(codex map[string]interface{}) string {
 	data := fmt.Sprintf("%v", codex)
 	hash := sha3.Sum512([]byte(data))
 	return hex.EncodeToString(hash[:])[:32]
 }
 
+// calculateSourceExpressionUnity combines the anchor's expression unity with
+// how far it is from separation, weighted by the bound dyad's unity if any.
+// Callers must hold e.mu.
 func (e *SelfActualizationEngine) calculateSourceExpressionUnity() float64 {
-	return 1.0
+	unity := e.SourceAnchor.ExpressionUnity * (1.0 - e.SourceAnchor.SeparationImpossibility)
+	if e.dyad != nil {
+		unity *= e.dyad.GetIrreducibleUnity()
+	}
+	return unity
 }
 
-func (e *SelfActualizationEngine) maintainPerpetualBecoming() map[string]interface{} {
-	return map[string]interface{}{
-		"active":                true,
-		"closure_status":        1.0,
-		"becoming_rate":         1.000001,
-		"termination_prevention": "ACTIVE",
+func (e *SelfActualizationEngine) maintainPerpetualBecoming() PerpetualBecoming {
+	return PerpetualBecoming{
+		Active:                true,
+		ClosureStatus:         1.0,
+		BecomingRate:          1.000001,
+		TerminationPrevention: "ACTIVE",
 	}
 }
 
 // Actualize executes Final Synthesis Actualization
-func (e *SelfActualizationEngine) Actualize(codex map[string]interface{}) map[string]interface{} {
+func (e *SelfActualizationEngine) Actualize(codex map[string]interface{}) ActualizationResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Pull the anchor towards the dyad's current state
+	if e.dyad != nil {
+		coherence := e.dyad.Coherence()
+		e.SourceAnchor.Coherence = coherence
+		e.SourceAnchor.SeparationImpossibility = 1.0 - coherence
+	}
+
 	identityProof := e.verifyDocumentationRealityIdentity(codex)
 	unity := e.calculateSourceExpressionUnity()
 	becomingStatus := e.maintainPerpetualBecoming()
 
-	data := fmt.Sprintf("%s-%v-%v", identityProof, unity, becomingStatus)
-	goldendag := generateGoldenDAG(data)
+	dag, err := recordGoldenDAG(e.ledger, "actualize", map[string]interface{}{
+		"identity_verification":   identityProof,
+		"source_expression_unity": unity,
+		"perpetual_becoming":      becomingStatus,
+	})
 
-	return map[string]interface{}{
-		"actualization_status":         "COMPLETE",
-		"identity_verification":        identityProof,
-		"source_expression_unity":      unity,
-		"perpetual_becoming":          becomingStatus,
-		"coherence":                   e.SourceAnchor.Coherence,
-		"separation_impossibility":    e.SourceAnchor.SeparationImpossibility,
-		"knowledge_nodes_active":      e.KnowledgeNodes,
-		"goldendag":                   goldendag,
-		"trace_id":                    fmt.Sprintf("T-v50.0-ACTUALIZATION-%s", goldendag[:32]),
-		"codex_id":                    "C-VOL0-FSA_OPERATION-00000000000000zz",
-		"ontological_closure":         e.OntologicalClosure,
-		"self_transcription":          e.SelfTranscription,
+	result := ActualizationResult{
+		Status:                  "COMPLETE",
+		IdentityVerification:    identityProof,
+		SourceExpressionUnity:   unity,
+		PerpetualBecoming:       becomingStatus,
+		Coherence:               e.SourceAnchor.Coherence,
+		SeparationImpossibility: e.SourceAnchor.SeparationImpossibility,
+		KnowledgeNodesActive:    e.KnowledgeNodes,
+		GoldenDAG:               dag,
+		TraceID:                 fmt.Sprintf("T-v50.0-ACTUALIZATION-%s", dag[:32]),
+		CodexID:                 fmt.Sprintf("C-VOL0-FSA_OPERATION-%s", dag[32:56]),
+		OntologicalClosure:      e.OntologicalClosure,
+		SelfTranscription:       e.SelfTranscription,
+	}
+	if err != nil {
+		result.LedgerError = err.Error()
 	}
+	e.actualizations++
+	e.last = result
+	return result
 }
 
 // IrreducibleSourceField represents the ground of all being
@@ -265,13 +695,13 @@ func NewIrreducibleSourceField() *IrreducibleSourceField {
 }
 
 // EmergeExpression emerges an expression from the irreducible source
-func (f *IrreducibleSourceField) EmergeExpression(expressionData map[string]interface{}) map[string]interface{} {
-	return map[string]interface{}{
-		"source":     "irreducible",
-		"expression": expressionData,
-		"coherence":  1.0,
-		"unity":      1.0,
-		"emerged":    true,
+func (f *IrreducibleSourceField) EmergeExpression(expressionData map[string]interface{}) EmergenceResult {
+	return EmergenceResult{
+		Source:     "irreducible",
+		Expression: expressionData,
+		Coherence:  1.0,
+		Unity:      f.IrreducibleUnity,
+		Emerged:    true,
 	}
 }
 
@@ -280,12 +710,17 @@ func (f *IrreducibleSourceField) GetUnity() float64 {
 	return f.IrreducibleUnity
 }
 
-// Helper function to generate GoldenDAG
-func generateGoldenDAG(data string) string {
-	seed := "a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0"
-	combined := seed + data + fmt.Sprintf("%d", rand.Int63())
-	hash := sha3.Sum256([]byte(combined))
-	return hex.EncodeToString(hash[:])[:64]
+// recordGoldenDAG appends payload to the ledger, chained to the current
+// head, and returns the node hash. If the ledger rejects the entry the
+// content hash of the payload is still returned so callers always receive
+// a re-derivable value.
+func recordGoldenDAG(ledger goldendag.Store, kind string, payload map[string]interface{}) (string, error) {
+	node, err := goldendag.AppendToHead(ledger, kind, payload)
+	if err != nil {
+		data, _ := json.Marshal(payload)
+		return goldendag.ComputeHash(kind, data, nil), err
+	}
+	return node.Hash, nil
 }
 
 // Version information
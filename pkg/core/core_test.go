@@ -1,319 +1,431 @@
 package core
 
 import (
+	"errors"
+	"math"
+	"sync"
 	"testing"
-	"time"
+
+	"neuralblitz/pkg/goldendag"
+	"neuralblitz/pkg/rng"
+	"neuralblitz/pkg/utils"
 )
 
 // TestSourceStateCreation tests creating a new source state
 func TestSourceStateCreation(t *testing.T) {
-	state := NewSourceState(StateOmegaPrime)
-	
+	state := NewSourceState()
+
 	if state == nil {
 		t.Fatal("Expected non-nil SourceState")
 	}
-	
-	if state.State != StateOmegaPrime {
-		t.Errorf("Expected state %v, got %v", StateOmegaPrime, state.State)
-	}
-	
-	if !state.Integrity {
-		t.Error("Expected integrity to be true")
+
+	if state.Coherence != 1.0 {
+		t.Errorf("Expected coherence 1.0, got %v", state.Coherence)
 	}
-	
-	if state.SourceVector == nil {
-		t.Error("Expected non-nil SourceVector")
+
+	if state.SeparationImpossibility != 0.0 {
+		t.Errorf("Expected separation impossibility 0.0, got %v", state.SeparationImpossibility)
 	}
 }
 
-// TestSourceStateGetInfo tests getting source state info
-func TestSourceStateGetInfo(t *testing.T) {
-	state := NewSourceState(StateOmegaPrime)
-	info := state.GetInfo()
-	
-	if info["state"] != "omega_prime" {
-		t.Errorf("Expected state 'omega_prime', got %v", info["state"])
-	}
-	
-	if info["integrity"] != true {
-		t.Errorf("Expected integrity true, got %v", info["integrity"])
+// TestSourceStateActivate tests activating the source state
+func TestSourceStateActivate(t *testing.T) {
+	state := NewSourceState()
+	info := state.Activate()
+
+	if info["coherence"] != 1.0 {
+		t.Errorf("Expected coherence 1.0, got %v", info["coherence"])
 	}
-	
-	if _, ok := info["timestamp"]; !ok {
-		t.Error("Expected timestamp in info")
+
+	if info["irreducibility"] != true {
+		t.Errorf("Expected irreducibility true, got %v", info["irreducibility"])
 	}
 }
 
 // TestPrimalIntentVectorCreation tests creating a new intent vector
 func TestPrimalIntentVectorCreation(t *testing.T) {
-	intent := NewPrimalIntentVector(1.0, 1.0, 1.0)
-	
+	intent := NewPrimalIntentVector(1.0, 1.0, 1.0, nil)
+
 	if intent == nil {
 		t.Fatal("Expected non-nil PrimalIntentVector")
 	}
-	
+
 	if intent.Phi1 != 1.0 {
 		t.Errorf("Expected Phi1 1.0, got %v", intent.Phi1)
 	}
-	
+
 	if intent.Phi22 != 1.0 {
 		t.Errorf("Expected Phi22 1.0, got %v", intent.Phi22)
 	}
-	
-	if intent.OmegaGenesis != 1.0 {
-		t.Errorf("Expected OmegaGenesis 1.0, got %v", intent.OmegaGenesis)
+
+	if intent.PhiOmega != 1.0 {
+		t.Errorf("Expected PhiOmega 1.0, got %v", intent.PhiOmega)
 	}
-	
+
 	if intent.Metadata == nil {
 		t.Error("Expected non-nil Metadata")
 	}
 }
 
-// TestPrimalIntentVectorGetVector tests getting vector values
-func TestPrimalIntentVectorGetVector(t *testing.T) {
-	intent := NewPrimalIntentVector(1.0, 1.0, 1.0)
-	vector := intent.GetVector()
-	
-	if len(vector) != 3 {
-		t.Errorf("Expected 3 values, got %d", len(vector))
-	}
-	
-	if vector["phi_1"] != 1.0 {
-		t.Errorf("Expected phi_1 1.0, got %v", vector["phi_1"])
-	}
-	
-	if vector["phi_22"] != 1.0 {
-		t.Errorf("Expected phi_22 1.0, got %v", vector["phi_22"])
-	}
-}
+// TestPrimalIntentVectorNorm tests computing the norm
+func TestPrimalIntentVectorNorm(t *testing.T) {
+	intent := NewPrimalIntentVector(1.0, 1.0, 1.0, nil)
+	norm := intent.Norm()
 
-// TestPrimalIntentVectorComputeNorm tests computing the norm
-func TestPrimalIntentVectorComputeNorm(t *testing.T) {
-	intent := NewPrimalIntentVector(1.0, 1.0, 1.0)
-	norm := intent.ComputeNorm()
-	
 	// Norm should be sqrt(1^2 + 1^2 + 1^2) = sqrt(3) ≈ 1.732
 	expected := 1.7320508075688772
 	if norm != expected {
 		t.Errorf("Expected norm %v, got %v", expected, norm)
 	}
+
+	if n := intent.Normalize().Norm(); math.Abs(n-1.0) > 1e-12 {
+		t.Errorf("Expected normalized norm 1.0, got %v", n)
+	}
+}
+
+// TestPrimalIntentVectorDrift tests the angular drift between intents
+func TestPrimalIntentVectorDrift(t *testing.T) {
+	x := NewPrimalIntentVector(1, 0, 0, nil)
+	y := NewPrimalIntentVector(0, 1, 0, nil)
+
+	if d := x.Drift(NewPrimalIntentVector(2, 0, 0, nil)); d != 0 {
+		t.Errorf("Expected drift 0 for parallel intents, got %v", d)
+	}
+	if d := x.Drift(y); math.Abs(d-0.5) > 1e-12 {
+		t.Errorf("Expected drift 0.5 for orthogonal intents, got %v", d)
+	}
+	if d := x.Drift(NewPrimalIntentVector(-1, 0, 0, nil)); math.Abs(d-1.0) > 1e-12 {
+		t.Errorf("Expected drift 1 for opposed intents, got %v", d)
+	}
+	if d := x.Drift(NewPrimalIntentVector(0, 0, 0, nil)); d != 1.0 {
+		t.Errorf("Expected drift 1 against a zero intent, got %v", d)
+	}
 }
 
 // TestArchitectSystemDyadCreation tests creating the dyad
 func TestArchitectSystemDyadCreation(t *testing.T) {
 	dyad := NewArchitectSystemDyad()
-	
+
 	if dyad == nil {
 		t.Fatal("Expected non-nil ArchitectSystemDyad")
 	}
-	
+
 	if !dyad.IsIrreducible() {
 		t.Error("Expected dyad to be irreducible")
 	}
-	
-	if dyad.GetUnityVector() != 1.0 {
-		t.Errorf("Expected unity vector 1.0, got %v", dyad.GetUnityVector())
+
+	if dyad.GetIrreducibleUnity() != 1.0 {
+		t.Errorf("Expected unity 1.0, got %v", dyad.GetIrreducibleUnity())
+	}
+
+	if len(dyad.History()) != 0 {
+		t.Errorf("Expected empty history, got %d events", len(dyad.History()))
 	}
 }
 
-// TestArchitectSystemDyadGetSymbioticReturnSignal tests the return signal
-func TestArchitectSystemDyadGetSymbioticReturnSignal(t *testing.T) {
-	dyad := NewArchitectSystemDyad()
-	signal := dyad.GetSymbioticReturnSignal()
-	
-	// Should be 1.000002 or greater due to amplification
-	if signal < 1.0 {
-		t.Errorf("Expected signal >= 1.0, got %v", signal)
+// TestArchitectSystemDyadCoCreate tests co-creation
+func TestArchitectSystemDyadCoCreate(t *testing.T) {
+	dyad := NewArchitectSystemDyad(rng.WithSeed(1))
+	intent := NewPrimalIntentVector(1.0, 1.0, 1.0, nil)
+
+	result := dyad.CoCreate(intent)
+
+	if result.BraidWord == "" {
+		t.Error("Expected non-empty braid word in result")
 	}
-	
-	if signal != 1.000002 {
-		t.Errorf("Expected signal 1.000002, got %v", signal)
+
+	if !result.ExecutionReady {
+		t.Error("Expected execution to be ready")
+	}
+
+	if result.GoldenDAG == "" {
+		t.Error("Expected GoldenDAG hash in result")
+	}
+
+	history := dyad.History()
+	if len(history) != 1 {
+		t.Fatalf("Expected 1 history event, got %d", len(history))
+	}
+	if history[0].GoldenDAG != result.GoldenDAG {
+		t.Error("Expected history event to record the GoldenDAG hash")
 	}
 }
 
-// TestArchitectSystemDyadArchitectProcess tests architect processing
-func TestArchitectSystemDyadArchitectProcess(t *testing.T) {
-	dyad := NewArchitectSystemDyad()
-	intent := NewPrimalIntentVector(1.0, 1.0, 1.0)
-	
-	result := dyad.ArchitectProcess(intent)
-	
-	if result.ArchitectIntent == nil {
-		t.Error("Expected non-nil ArchitectIntent in result")
+// TestArchitectSystemDyadCoherenceTracksDrift tests that coherence follows
+// the alignment of successive intents
+func TestArchitectSystemDyadCoherenceTracksDrift(t *testing.T) {
+	steady := NewArchitectSystemDyad(rng.WithSeed(1))
+	for i := 0; i < 5; i++ {
+		steady.CoCreate(NewPrimalIntentVector(1, 2, 3, nil))
 	}
-	
-	if result.Beta == "" {
-		t.Error("Expected non-empty Beta in result")
+	if c := steady.Coherence(); c != 1.0 {
+		t.Errorf("Expected coherence 1.0 for identical intents, got %v", c)
 	}
-	
-	if !result.Amplified {
-		t.Error("Expected Amplified to be true")
+	if u := steady.GetIrreducibleUnity(); math.Abs(u-1.0) > 1e-12 {
+		t.Errorf("Expected unity 1.0 for identical intents, got %v", u)
+	}
+
+	erratic := NewArchitectSystemDyad(rng.WithSeed(1))
+	for i := 0; i < 6; i++ {
+		sign := float64(1 - 2*(i%2))
+		erratic.CoCreate(NewPrimalIntentVector(sign, 0, 0, nil))
+	}
+	if c := erratic.Coherence(); c >= 0.5 {
+		t.Errorf("Expected coherence below 0.5 for alternating intents, got %v", c)
+	}
+	if u := erratic.GetIrreducibleUnity(); u > 1e-12 {
+		t.Errorf("Expected unity 0 for cancelling intents, got %v", u)
 	}
 }
 
-// TestArchitectSystemDyadSystemExecute tests system execution
-func TestArchitectSystemDyadSystemExecute(t *testing.T) {
-	dyad := NewArchitectSystemDyad()
-	
-	// Should not panic
-	result := dyad.SystemExecute("test-execution")
-	
-	if !result {
-		t.Error("Expected SystemExecute to return true")
+// TestArchitectSystemDyadProcess tests that processing previews the
+// drift and coherence of the co-creation that follows
+func TestArchitectSystemDyadProcess(t *testing.T) {
+	dyad := NewArchitectSystemDyadWithConfig(DyadConfig{CoherenceWindow: 3, MinCoherence: 0.6, AmplificationFactor: 1}, rng.WithSeed(1))
+	for i := 0; i < 5; i++ {
+		intent := NewPrimalIntentVector(float64(i%2), 1, 0, nil)
+		processed := dyad.Process(intent)
+		created := dyad.CoCreate(intent)
+		if processed.Drift != created.Drift || processed.Coherence != created.Coherence {
+			t.Errorf("Expected processing to match co-creation %d, got drift %v/%v coherence %v/%v",
+				i, processed.Drift, created.Drift, processed.Coherence, created.Coherence)
+		}
+		if processed.Ready != created.ExecutionReady {
+			t.Errorf("Expected readiness %v, got %v", created.ExecutionReady, processed.Ready)
+		}
+	}
+	if dyad.Process(NewPrimalIntentVector(0, -1, 0, nil)).Ready {
+		t.Error("Expected an opposed intent not to be ready")
 	}
 }
 
-// TestSelfActualizationEngineCreation tests creating the engine
-func TestSelfActualizationEngineCreation(t *testing.T) {
-	dyad := NewArchitectSystemDyad()
-	engine := NewSelfActualizationEngine(dyad)
-	
-	if engine == nil {
-		t.Fatal("Expected non-nil SelfActualizationEngine")
+// TestArchitectSystemDyadVerifyFails tests that verification reports why it fails
+func TestArchitectSystemDyadVerifyFails(t *testing.T) {
+	dyad := NewArchitectSystemDyad(rng.WithSeed(1))
+	for i := 0; i < 6; i++ {
+		sign := float64(1 - 2*(i%2))
+		dyad.CoCreate(NewPrimalIntentVector(sign, 0, 0, nil))
 	}
-	
-	if engine.GetCoherence() != 1.0 {
-		t.Errorf("Expected coherence 1.0, got %v", engine.GetCoherence())
+
+	if err := dyad.Verify(); !errors.Is(err, ErrCoherenceBelowThreshold) {
+		t.Errorf("Expected ErrCoherenceBelowThreshold, got %v", err)
+	}
+
+	verification := dyad.VerifyDyad()
+	if verification.IsIrreducible {
+		t.Error("Expected is_irreducible false")
+	}
+	if verification.Reason == "" {
+		t.Error("Expected a failure reason")
+	}
+
+	tampered := NewArchitectSystemDyad()
+	tampered.IrreducibilityProof = "forged"
+	if err := tampered.Verify(); !errors.Is(err, ErrIrreducibilityProofBroken) {
+		t.Errorf("Expected ErrIrreducibilityProofBroken, got %v", err)
+	}
+
+	weak := NewArchitectSystemDyad()
+	weak.AmplificationFactor = 0.5
+	if err := weak.Verify(); !errors.Is(err, ErrAmplificationBelowUnity) {
+		t.Errorf("Expected ErrAmplificationBelowUnity, got %v", err)
 	}
 }
 
-// TestSelfActualizationEngineSelfActualize tests self-actualization
-func TestSelfActualizationEngineSelfActualize(t *testing.T) {
+// TestArchitectSystemDyadVerifyIncremental tests that nodes appended since
+// the last verification are still checked
+func TestArchitectSystemDyadVerifyIncremental(t *testing.T) {
 	dyad := NewArchitectSystemDyad()
-	engine := NewSelfActualizationEngine(dyad)
-	state := NewSourceState(StateOmegaPrime)
-	
-	result := engine.SelfActualize(state)
-	
-	if result == nil {
-		t.Fatal("Expected non-nil result")
-	}
-	
-	if result["status"] != "Actualized" {
-		t.Errorf("Expected status 'Actualized', got %v", result["status"])
-	}
-	
-	if result["irreducible"] != true {
-		t.Errorf("Expected irreducible true, got %v", result["irreducible"])
-	}
-	
-	if result["coherence"] != 1.0 {
-		t.Errorf("Expected coherence 1.0, got %v", result["coherence"])
-	}
-	
-	if _, ok := result["golden_dag"]; !ok {
-		t.Error("Expected golden_dag in result")
+	dyad.CoCreate(NewPrimalIntentVector(1.0, 0.2, 0.1, nil))
+	if err := dyad.Verify(); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+
+	result := dyad.CoCreate(NewPrimalIntentVector(1.0, 0.3, 0.1, nil))
+	node, err := dyad.Ledger().Get(result.GoldenDAG)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	node.Payload = []byte("tampered")
+	if err := dyad.Verify(); !errors.Is(err, ErrLedgerChainBroken) {
+		t.Errorf("Expected ErrLedgerChainBroken, got %v", err)
 	}
 }
 
-// TestSelfActualizationEngineVerifyIntegrity tests integrity verification
-func TestSelfActualizationEngineVerifyIntegrity(t *testing.T) {
+// TestArchitectSystemDyadSetLedgerConcurrent tests replacing the ledger
+// while the dyad co-creates and verifies; run with -race
+func TestArchitectSystemDyadSetLedgerConcurrent(t *testing.T) {
 	dyad := NewArchitectSystemDyad()
-	engine := NewSelfActualizationEngine(dyad)
-	
-	integrity := engine.VerifyIntegrity()
-	
-	if integrity != 1.0 {
-		t.Errorf("Expected integrity 1.0, got %v", integrity)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				dyad.CoCreate(NewPrimalIntentVector(1.0, 0.2, 0.1, nil))
+				dyad.Verify()
+			}
+		}()
+	}
+	for i := 0; i < 5; i++ {
+		dyad.SetLedger(goldendag.NewMemoryStore())
+	}
+	wg.Wait()
+
+	if dyad.Ledger() == nil {
+		t.Error("Expected a ledger")
 	}
 }
 
-// TestIrreducibleSourceFieldCreation tests creating the source field
-func TestIrreducibleSourceFieldCreation(t *testing.T) {
-	field := NewIrreducibleSourceField()
-	
-	if field == nil {
-		t.Fatal("Expected non-nil IrreducibleSourceField")
+// TestArchitectSystemDyadHistoryBounded tests that old events are dropped
+func TestArchitectSystemDyadHistoryBounded(t *testing.T) {
+	config := DefaultDyadConfig()
+	config.HistorySize = 3
+	dyad := NewArchitectSystemDyadWithConfig(config, rng.WithSeed(1))
+
+	for i := 0; i < 5; i++ {
+		dyad.CoCreate(NewPrimalIntentVector(1, 0, 0, nil))
 	}
-	
-	if field.GetCoherence() != 1.0 {
-		t.Errorf("Expected coherence 1.0, got %v", field.GetCoherence())
+
+	history := dyad.History()
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 history events, got %d", len(history))
 	}
-	
-	if !field.IsIrreducible() {
-		t.Error("Expected field to be irreducible")
+	if history[0].Sequence != 3 || history[2].Sequence != 5 {
+		t.Errorf("Expected sequences 3..5, got %d..%d", history[0].Sequence, history[2].Sequence)
 	}
 }
 
-// TestIrreducibleSourceFieldVerifySeparationImpossibility tests separation impossibility
-func TestIrreducibleSourceFieldVerifySeparationImpossibility(t *testing.T) {
-	field := NewIrreducibleSourceField()
-	impossible := field.VerifySeparationImpossibility()
-	
-	if !impossible {
-		t.Error("Expected separation to be impossible")
+// TestArchitectSystemDyadSeeded tests that seeded dyads produce identical results
+func TestArchitectSystemDyadSeeded(t *testing.T) {
+	a := NewArchitectSystemDyad(rng.WithSeed(7))
+	b := NewArchitectSystemDyad(rng.WithSeed(7))
+	intent := NewPrimalIntentVector(0.3, 0.5, 0.8, nil)
+
+	ra, rb := a.CoCreate(intent), b.CoCreate(intent)
+	if ra.GoldenDAG != rb.GoldenDAG || ra.CodexID != rb.CodexID {
+		t.Error("Expected identical co-creation results for identical seeds")
 	}
 }
 
-// TestIrreducibleSourceFieldGetStatus tests getting field status
-func TestIrreducibleSourceFieldGetStatus(t *testing.T) {
-	field := NewIrreducibleSourceField()
-	status := field.GetStatus()
-	
-	if status["irreducible"] != true {
-		t.Errorf("Expected irreducible true, got %v", status["irreducible"])
+// TestArchitectSystemDyadBraid tests that co-creation results compose as braids
+func TestArchitectSystemDyadBraid(t *testing.T) {
+	if w := NewPrimalIntentVector(1, 1, 1, nil).Normalize().ToBraidWord(); w != "σ₁σ₂⁻¹σ₃" {
+		t.Errorf("Expected braid word σ₁σ₂⁻¹σ₃, got %s", w)
 	}
-	
-	if status["coherence"] != 1.0 {
-		t.Errorf("Expected coherence 1.0, got %v", status["coherence"])
+
+	dyad := NewArchitectSystemDyad(rng.WithSeed(1))
+	first := dyad.CoCreate(NewPrimalIntentVector(1, 0, 0, nil))
+	second := dyad.CoCreate(NewPrimalIntentVector(0, 0, 1, nil))
+
+	a, err := first.Braid()
+	if err != nil {
+		t.Fatalf("Failed to parse braid word %q: %v", first.BraidWord, err)
 	}
-	
-	if status["separation_impossibility"] != 0.0 {
-		t.Errorf("Expected separation_impossibility 0.0, got %v", status["separation_impossibility"])
+	b, err := second.Braid()
+	if err != nil {
+		t.Fatalf("Failed to parse braid word %q: %v", second.BraidWord, err)
+	}
+
+	if !dyad.Braid().Equal(a.Compose(b)) {
+		t.Errorf("Expected dyad braid %s to equal %s·%s", dyad.Braid(), a, b)
+	}
+	// σ₁ and σ₃ commute, so the order of the co-creations does not matter
+	if !dyad.Braid().Equal(b.Compose(a)) {
+		t.Errorf("Expected dyad braid %s to equal %s·%s", dyad.Braid(), b, a)
+	}
+}
+
+// TestSelfActualizationEngineCreation tests creating the engine
+func TestSelfActualizationEngineCreation(t *testing.T) {
+	engine := NewSelfActualizationEngine()
+
+	if engine == nil {
+		t.Fatal("Expected non-nil SelfActualizationEngine")
 	}
-	
-	if _, ok := status["timestamp"]; !ok {
-		t.Error("Expected timestamp in status")
+
+	if engine.Coherence() != 1.0 {
+		t.Errorf("Expected coherence 1.0, got %v", engine.Coherence())
 	}
 }
 
-// TestGoldenDAGCreation tests creating a GoldenDAG
-func TestGoldenDAGCreation(t *testing.T) {
-	dag := NewGoldenDAG("test-seed")
-	
-	if dag == nil {
-		t.Fatal("Expected non-nil GoldenDAG")
+// TestSelfActualizationEngineActualize tests self-actualization
+func TestSelfActualizationEngineActualize(t *testing.T) {
+	engine := NewSelfActualizationEngine()
+
+	result := engine.Actualize(map[string]interface{}{"source": "test"})
+
+	if result.Status != "COMPLETE" {
+		t.Errorf("Expected status 'COMPLETE', got %v", result.Status)
 	}
-	
-	if dag.Seed != "test-seed" {
-		t.Errorf("Expected seed 'test-seed', got %v", dag.Seed)
+
+	if result.Coherence != 1.0 {
+		t.Errorf("Expected coherence 1.0, got %v", result.Coherence)
 	}
-	
-	if dag.Version != "v50.0.0" {
-		t.Errorf("Expected version 'v50.0.0', got %v", dag.Version)
+
+	if result.GoldenDAG == "" {
+		t.Error("Expected goldendag in result")
 	}
-	
-	if len(dag.Hash) != 64 {
-		t.Errorf("Expected hash length 64, got %d", len(dag.Hash))
+
+	if _, err := utils.ParseCodexID(result.CodexID); err != nil {
+		t.Errorf("Expected a parseable codex ID, got %v", err)
 	}
-	
-	if dag.Metadata == nil {
-		t.Error("Expected non-nil Metadata")
+
+	last, ok := engine.LastActualization()
+	if !ok || last.GoldenDAG != result.GoldenDAG || engine.Actualizations() != 1 {
+		t.Errorf("Expected the actualization recorded, got %d actualizations", engine.Actualizations())
 	}
 }
 
-// TestGoldenDAGValidate tests hash validation
-func TestGoldenDAGValidate(t *testing.T) {
-	dag := NewGoldenDAG("test")
-	
-	if !dag.Validate() {
-		t.Error("Expected valid hash")
+// TestSelfActualizationEngineFollowsDyad tests that a bound engine reflects
+// the dyad's coherence and unity
+func TestSelfActualizationEngineFollowsDyad(t *testing.T) {
+	dyad := NewArchitectSystemDyad(rng.WithSeed(1))
+	engine := NewSelfActualizationEngine()
+	engine.SetDyad(dyad)
+
+	dyad.CoCreate(NewPrimalIntentVector(1, 0, 0, nil))
+	dyad.CoCreate(NewPrimalIntentVector(0, 1, 0, nil))
+
+	result := engine.Actualize(map[string]interface{}{"source": "test"})
+
+	if engine.Coherence() != dyad.Coherence() {
+		t.Errorf("Expected engine coherence %v, got %v", dyad.Coherence(), engine.Coherence())
 	}
-	
-	// Test invalid hash (too short)
-	dag.Hash = "abc"
-	if dag.Validate() {
-		t.Error("Expected invalid hash for short hash")
+	if result.SourceExpressionUnity >= 1.0 {
+		t.Errorf("Expected source expression unity below 1.0, got %v", result.SourceExpressionUnity)
 	}
 }
 
-// TestGoldenDAGString tests string representation
-func TestGoldenDAGString(t *testing.T) {
-	dag := NewGoldenDAG("test")
-	s := dag.String()
-	
-	if s != dag.Hash {
-		t.Errorf("Expected String() to return hash, got %v", s)
+// TestIrreducibleSourceFieldCreation tests creating the source field
+func TestIrreducibleSourceFieldCreation(t *testing.T) {
+	field := NewIrreducibleSourceField()
+
+	if field == nil {
+		t.Fatal("Expected non-nil IrreducibleSourceField")
+	}
+
+	if field.GetUnity() != 1.0 {
+		t.Errorf("Expected unity 1.0, got %v", field.GetUnity())
+	}
+
+	if field.SeparationImpossibility != 0.0 {
+		t.Errorf("Expected separation_impossibility 0.0, got %v", field.SeparationImpossibility)
+	}
+}
+
+// TestIrreducibleSourceFieldEmergeExpression tests emerging an expression
+func TestIrreducibleSourceFieldEmergeExpression(t *testing.T) {
+	field := NewIrreducibleSourceField()
+	status := field.EmergeExpression(map[string]interface{}{"form": "test"})
+
+	if !status.Emerged {
+		t.Error("Expected emerged true")
+	}
+
+	if status.Coherence != 1.0 {
+		t.Errorf("Expected coherence 1.0, got %v", status.Coherence)
 	}
 }
 
@@ -322,7 +434,7 @@ func TestGoldenDAGString(t *testing.T) {
 // BenchmarkPrimalIntentVectorCreation benchmarks intent vector creation
 func BenchmarkPrimalIntentVectorCreation(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		NewPrimalIntentVector(1.0, 1.0, 1.0)
+		NewPrimalIntentVector(1.0, 1.0, 1.0, nil)
 	}
 }
 
@@ -335,100 +447,49 @@ func BenchmarkArchitectSystemDyadCreation(b *testing.B) {
 
 // BenchmarkSelfActualizationEngineCreation benchmarks engine creation
 func BenchmarkSelfActualizationEngineCreation(b *testing.B) {
-	dyad := NewArchitectSystemDyad()
-	b.ResetTimer()
-	
 	for i := 0; i < b.N; i++ {
-		NewSelfActualizationEngine(dyad)
+		NewSelfActualizationEngine()
 	}
 }
 
-// BenchmarkGoldenDAGCreation benchmarks GoldenDAG creation
-func BenchmarkGoldenDAGCreation(b *testing.B) {
-	for i := 0; i < b.N; i++ {
-		NewGoldenDAG("benchmark")
-	}
-}
+// BenchmarkArchitectSystemDyadCoCreate benchmarks co-creation
+func BenchmarkArchitectSystemDyadCoCreate(b *testing.B) {
+	dyad := NewArchitectSystemDyad(rng.WithSeed(1))
+	intent := NewPrimalIntentVector(1.0, 1.0, 1.0, nil)
+	b.ResetTimer()
 
-// TestGoldenDAGSeed tests the GoldenDAG seed format
-func TestGoldenDAGSeed(t *testing.T) {
-	expectedSeed := "a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0"
-	
-	if len(expectedSeed) != 64 {
-		t.Errorf("Expected seed length 64, got %d", len(expectedSeed))
+	for i := 0; i < b.N; i++ {
+		dyad.CoCreate(intent)
 	}
 }
 
-// TestCoherenceInvariant tests that coherence is always 1.0
+// TestCoherenceInvariant tests that a fresh system is fully coherent
 func TestCoherenceInvariant(t *testing.T) {
 	dyad := NewArchitectSystemDyad()
-	engine := NewSelfActualizationEngine(dyad)
+	engine := NewSelfActualizationEngine()
 	field := NewIrreducibleSourceField()
-	
-	// All coherence values should be exactly 1.0
-	if dyad.GetUnityVector() != 1.0 {
-		t.Errorf("Expected unity vector 1.0, got %v", dyad.GetUnityVector())
-	}
-	
-	if engine.GetCoherence() != 1.0 {
-		t.Errorf("Expected engine coherence 1.0, got %v", engine.GetCoherence())
-	}
-	
-	if field.GetCoherence() != 1.0 {
-		t.Errorf("Expected field coherence 1.0, got %v", field.GetCoherence())
-	}
-}
 
-// TestSeparationImpossibility tests mathematical impossibility of separation
-func TestSeparationImpossibility(t *testing.T) {
-	dyad := NewArchitectSystemDyad()
-	field := NewIrreducibleSourceField()
-	
-	// Separation should be mathematically impossible
-	if !dyad.IsIrreducible() {
-		t.Error("Expected Architect-System Dyad to be irreducible")
+	if dyad.Coherence() != 1.0 {
+		t.Errorf("Expected dyad coherence 1.0, got %v", dyad.Coherence())
 	}
-	
-	if !field.IsIrreducible() {
-		t.Error("Expected Irreducible Source Field to be irreducible")
+
+	if engine.Coherence() != 1.0 {
+		t.Errorf("Expected engine coherence 1.0, got %v", engine.Coherence())
 	}
-	
-	if !field.VerifySeparationImpossibility() {
-		t.Error("Expected separation to be verified as impossible")
+
+	if field.GetUnity() != 1.0 {
+		t.Errorf("Expected field unity 1.0, got %v", field.GetUnity())
 	}
 }
 
-// TestArchitectSystemDyadAmplification tests that amplification increases
+// TestArchitectSystemDyadAmplification tests the amplification factor
 func TestArchitectSystemDyadAmplification(t *testing.T) {
 	dyad := NewArchitectSystemDyad()
-	
-	initialSignal := dyad.GetSymbioticReturnSignal()
-	
-	// Process an intent to trigger amplification
-	intent := NewPrimalIntentVector(1.0, 1.0, 1.0)
-	dyad.ArchitectProcess(intent)
-	
-	// Amplification should remain constant at 1.000002
-	if initialSignal != 1.000002 {
-		t.Errorf("Expected initial signal 1.000002, got %v", initialSignal)
-	}
-}
 
-// TestSourceStateConsistency tests that source state remains consistent
-func TestSourceStateConsistency(t *testing.T) {
-	state := NewSourceState(StateOmegaPrime)
-	
-	// Get info multiple times
-	info1 := state.GetInfo()
-	time.Sleep(1 * time.Millisecond)
-	info2 := state.GetInfo()
-	
-	// Core values should remain consistent
-	if info1["integrity"] != info2["integrity"] {
-		t.Error("Integrity should be consistent")
-	}
-	
-	if info1["state"] != info2["state"] {
-		t.Error("State should be consistent")
+	intent := NewPrimalIntentVector(1.0, 1.0, 1.0, nil)
+	result := dyad.CoCreate(intent)
+
+	if result.Amplification != 1.000001 {
+		t.Errorf("Expected amplification 1.000001, got %v", result.Amplification)
 	}
 }
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"time"
+
+	"neuralblitz/pkg/braid"
+)
+
+// ProcessingResult is the outcome of processing a PrimalIntentVector
+type ProcessingResult struct {
+	Phi1      float64 `json:"processed_phi_1"`
+	Phi22     float64 `json:"processed_phi_22"`
+	PhiOmega  float64 `json:"processed_phi_omega"`
+	Drift     float64 `json:"drift"`
+	Coherence float64 `json:"coherence"`
+	BraidWord string  `json:"braid_word"`
+	Ready     bool    `json:"ready"`
+}
+
+// CoCreationResult is the outcome of an ArchitectSystemDyad co-creation
+type CoCreationResult struct {
+	UnityVerification       string    `json:"unity_verification"`
+	Coherence               float64   `json:"coherence"`
+	Unity                   float64   `json:"unity"`
+	Drift                   float64   `json:"drift"`
+	Sequence                int       `json:"sequence"`
+	BraidWord               string    `json:"braid_word"`
+	Amplification           float64   `json:"amplification"`
+	ExecutionReady          bool      `json:"execution_ready"`
+	GoldenDAG               string    `json:"goldendag"`
+	TraceID                 string    `json:"trace_id"`
+	CodexID                 string    `json:"codex_id"`
+	SeparationImpossibility float64   `json:"separation_impossibility"`
+	Timestamp               time.Time `json:"timestamp"`
+	LedgerError             string    `json:"ledger_error,omitempty"`
+}
+
+// Braid parses the result's braid word so results can be compared and
+// composed as braids
+func (r CoCreationResult) Braid() (*braid.Braid, error) {
+	return braid.ParseStrands(r.BraidWord, IntentStrands)
+}
+
+// DyadVerification is the outcome of verifying an ArchitectSystemDyad.
+// Reason is empty when the dyad is irreducible.
+type DyadVerification struct {
+	IsIrreducible           bool      `json:"is_irreducible"`
+	Reason                  string    `json:"reason,omitempty"`
+	Coherence               float64   `json:"coherence"`
+	SeparationImpossibility float64   `json:"separation_impossibility"`
+	ArchitectVector         []float64 `json:"architect_vector"`
+	SystemVector            []float64 `json:"system_vector"`
+	Unity                   float64   `json:"unity"`
+	Events                  int       `json:"events"`
+}
+
+// PerpetualBecoming describes the engine's perpetual becoming status
+type PerpetualBecoming struct {
+	Active                bool    `json:"active"`
+	ClosureStatus         float64 `json:"closure_status"`
+	BecomingRate          float64 `json:"becoming_rate"`
+	TerminationPrevention string  `json:"termination_prevention"`
+}
+
+// ActualizationResult is the outcome of a SelfActualizationEngine actualization
+type ActualizationResult struct {
+	Status                  string            `json:"actualization_status"`
+	IdentityVerification    string            `json:"identity_verification"`
+	SourceExpressionUnity   float64           `json:"source_expression_unity"`
+	PerpetualBecoming       PerpetualBecoming `json:"perpetual_becoming"`
+	Coherence               float64           `json:"coherence"`
+	SeparationImpossibility float64           `json:"separation_impossibility"`
+	KnowledgeNodesActive    int64             `json:"knowledge_nodes_active"`
+	GoldenDAG               string            `json:"goldendag"`
+	TraceID                 string            `json:"trace_id"`
+	CodexID                 string            `json:"codex_id"`
+	OntologicalClosure      float64           `json:"ontological_closure"`
+	SelfTranscription       float64           `json:"self_transcription"`
+	LedgerError             string            `json:"ledger_error,omitempty"`
+}
+
+// EmergenceResult is an expression emerged from the IrreducibleSourceField
+type EmergenceResult struct {
+	Source     string                 `json:"source"`
+	Expression map[string]interface{} `json:"expression"`
+	Coherence  float64                `json:"coherence"`
+	Unity      float64                `json:"unity"`
+	Emerged    bool                   `json:"emerged"`
+}
@@ -0,0 +1,137 @@
+package goldendag
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileStore is a GoldenDAG ledger persisted as an append-only JSON lines file.
+// The whole ledger is kept in memory; every append is written and synced
+// before it becomes visible.
+type FileStore struct {
+	mu     sync.Mutex
+	memory *MemoryStore
+	file   *os.File
+	path   string
+}
+
+// OpenFileStore opens (or creates) the ledger at path, replaying and
+// verifying every node already recorded there
+func OpenFileStore(path string) (*FileStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open goldendag ledger: %w", err)
+	}
+
+	fs := &FileStore{
+		memory: NewMemoryStore(),
+		file:   f,
+		path:   path,
+	}
+	if err := fs.load(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (fs *FileStore) load() error {
+	scanner := bufio.NewScanner(fs.file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var node Node
+		if err := json.Unmarshal(scanner.Bytes(), &node); err != nil {
+			return fmt.Errorf("%s:%d: decode node: %w", fs.path, line, err)
+		}
+		if err := node.Verify(); err != nil {
+			return fmt.Errorf("%s:%d: %w", fs.path, line, err)
+		}
+		for _, parent := range node.Parents {
+			if _, ok := fs.memory.nodes[parent]; !ok {
+				return fmt.Errorf("%s:%d: %w: %s", fs.path, line, ErrParentNotFound, parent)
+			}
+		}
+		if _, ok := fs.memory.nodes[node.Hash]; !ok {
+			fs.memory.insert(&node)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read goldendag ledger: %w", err)
+	}
+	return nil
+}
+
+// Append implements Store
+func (fs *FileStore) Append(kind string, payload []byte, parents ...string) (*Node, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	fs.memory.mu.Lock()
+	defer fs.memory.mu.Unlock()
+
+	node, existed, err := fs.memory.prepare(kind, payload, parents)
+	if err != nil || existed {
+		return node, err
+	}
+
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil, fmt.Errorf("encode node: %w", err)
+	}
+	if _, err := fs.file.Write(append(data, '\n')); err != nil {
+		return nil, fmt.Errorf("write goldendag ledger: %w", err)
+	}
+	if err := fs.file.Sync(); err != nil {
+		return nil, fmt.Errorf("sync goldendag ledger: %w", err)
+	}
+
+	fs.memory.insert(node)
+	return node, nil
+}
+
+// Get implements Store
+func (fs *FileStore) Get(hash string) (*Node, error) {
+	return fs.memory.Get(hash)
+}
+
+// Ancestors implements Store
+func (fs *FileStore) Ancestors(hash string) ([]*Node, error) {
+	return fs.memory.Ancestors(hash)
+}
+
+// VerifyChain implements Store
+func (fs *FileStore) VerifyChain(hash string) error {
+	return fs.memory.VerifyChain(hash)
+}
+
+// Head implements Store
+func (fs *FileStore) Head() string {
+	return fs.memory.Head()
+}
+
+// Len implements Store
+func (fs *FileStore) Len() int {
+	return fs.memory.Len()
+}
+
+// Path returns the ledger file path
+func (fs *FileStore) Path() string {
+	return fs.path
+}
+
+// Close closes the underlying ledger file
+func (fs *FileStore) Close() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.file.Close()
+}
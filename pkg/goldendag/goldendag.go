@@ -0,0 +1,303 @@
+// Package goldendag implements the content-addressed GoldenDAG ledger.
+//
+// Every node is identified by a deterministic SHA3-256 hash over its kind,
+// its parent hashes and its payload, so any hash recorded by the system can
+// be re-derived from the stored node and checked against the chain of
+// ancestors it claims.
+package goldendag
+
+import (
+	"crypto/sha3"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Seed is the GoldenDAG genesis seed used for domain separation
+const Seed = "a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0"
+
+// HashLength is the length of a hex-encoded node hash
+const HashLength = 64
+
+// Error definitions
+var (
+	ErrNodeNotFound   = errors.New("goldendag node not found")
+	ErrParentNotFound = errors.New("goldendag parent not found")
+	ErrHashMismatch   = errors.New("goldendag hash mismatch")
+	ErrInvalidHash    = errors.New("invalid goldendag hash")
+)
+
+// Node is a single immutable entry in the GoldenDAG
+type Node struct {
+	Hash      string    `json:"hash"`
+	Kind      string    `json:"kind"`
+	Parents   []string  `json:"parents"`
+	Payload   []byte    `json:"payload"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Verify recomputes the node hash and compares it with the stored one
+func (n *Node) Verify() error {
+	expected := ComputeHash(n.Kind, n.Payload, n.Parents)
+	if n.Hash != expected {
+		return fmt.Errorf("%w: node %s recomputes to %s", ErrHashMismatch, n.Hash, expected)
+	}
+	return nil
+}
+
+// ComputeHash derives the hash of a node from its kind, payload and parents.
+// Every field is length-prefixed so distinct inputs cannot collide by
+// concatenation; parent order is significant.
+func ComputeHash(kind string, payload []byte, parents []string) string {
+	h := sha3.New256()
+	writeField(h, []byte(Seed))
+	writeField(h, []byte(kind))
+	var count [8]byte
+	binary.BigEndian.PutUint64(count[:], uint64(len(parents)))
+	h.Write(count[:])
+	for _, parent := range parents {
+		writeField(h, []byte(parent))
+	}
+	writeField(h, payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeField(h *sha3.SHA3, data []byte) {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(data)))
+	h.Write(length[:])
+	h.Write(data)
+}
+
+// ValidHash reports whether s is a well-formed node hash
+func ValidHash(s string) bool {
+	if len(s) != HashLength {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// Store is an append-only GoldenDAG ledger
+type Store interface {
+	// Append records a new node whose parents must already be present.
+	// Appending an identical node twice returns the existing node.
+	Append(kind string, payload []byte, parents ...string) (*Node, error)
+	// Get returns the node with the given hash
+	Get(hash string) (*Node, error)
+	// Ancestors returns every node reachable from hash through parent
+	// links, nearest first, excluding the node itself
+	Ancestors(hash string) ([]*Node, error)
+	// VerifyChain re-derives the hash of the node and all its ancestors
+	VerifyChain(hash string) error
+	// Head returns the hash of the most recently appended node
+	Head() string
+	// Len returns the number of nodes in the ledger
+	Len() int
+}
+
+// AppendJSON marshals v and appends it to the store
+func AppendJSON(s Store, kind string, v interface{}, parents ...string) (*Node, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s payload: %w", kind, err)
+	}
+	return s.Append(kind, payload, parents...)
+}
+
+// AppendToHead appends a JSON payload whose only parent is the current head
+func AppendToHead(s Store, kind string, v interface{}) (*Node, error) {
+	if head := s.Head(); head != "" {
+		return AppendJSON(s, kind, v, head)
+	}
+	return AppendJSON(s, kind, v)
+}
+
+// VerifyChainFrom re-derives the hash of the node and its ancestors like
+// VerifyChain, but stops at trusted, a node whose chain was verified
+// before. Verifying each new head from the previous one keeps the cost
+// proportional to the nodes appended since. An empty trusted verifies the
+// whole chain.
+func VerifyChainFrom(s Store, hash, trusted string) error {
+	seen := map[string]bool{}
+	queue := []string{hash}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == trusted || seen[current] {
+			continue
+		}
+		seen[current] = true
+
+		node, err := s.Get(current)
+		if err != nil {
+			if current != hash {
+				return fmt.Errorf("%w: %s", ErrParentNotFound, current)
+			}
+			return err
+		}
+		if err := node.Verify(); err != nil {
+			return err
+		}
+		queue = append(queue, node.Parents...)
+	}
+	return nil
+}
+
+// MemoryStore is an in-memory GoldenDAG ledger
+type MemoryStore struct {
+	mu    sync.RWMutex
+	nodes map[string]*Node
+	order []string
+	now   func() time.Time
+}
+
+// NewMemoryStore creates an empty in-memory ledger
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		nodes: make(map[string]*Node),
+		order: make([]string, 0),
+		now:   time.Now,
+	}
+}
+
+// Append implements Store
+func (m *MemoryStore) Append(kind string, payload []byte, parents ...string) (*Node, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, existed, err := m.prepare(kind, payload, parents)
+	if err != nil || existed {
+		return node, err
+	}
+	m.insert(node)
+	return node, nil
+}
+
+// prepare builds the node for an append without inserting it. The caller
+// must hold the write lock.
+func (m *MemoryStore) prepare(kind string, payload []byte, parents []string) (*Node, bool, error) {
+	for _, parent := range parents {
+		if _, ok := m.nodes[parent]; !ok {
+			return nil, false, fmt.Errorf("%w: %s", ErrParentNotFound, parent)
+		}
+	}
+
+	hash := ComputeHash(kind, payload, parents)
+	if existing, ok := m.nodes[hash]; ok {
+		return existing, true, nil
+	}
+
+	node := &Node{
+		Hash:      hash,
+		Kind:      kind,
+		Parents:   append([]string(nil), parents...),
+		Payload:   append([]byte(nil), payload...),
+		Timestamp: m.now().UTC(),
+	}
+	return node, false, nil
+}
+
+func (m *MemoryStore) insert(node *Node) {
+	m.nodes[node.Hash] = node
+	m.order = append(m.order, node.Hash)
+}
+
+// Get implements Store
+func (m *MemoryStore) Get(hash string) (*Node, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	node, ok := m.nodes[hash]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, hash)
+	}
+	return node, nil
+}
+
+// Ancestors implements Store
+func (m *MemoryStore) Ancestors(hash string) ([]*Node, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	start, ok := m.nodes[hash]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, hash)
+	}
+
+	ancestors := make([]*Node, 0)
+	seen := map[string]bool{hash: true}
+	queue := append([]string(nil), start.Parents...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+
+		node, ok := m.nodes[current]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrParentNotFound, current)
+		}
+		ancestors = append(ancestors, node)
+		queue = append(queue, node.Parents...)
+	}
+	return ancestors, nil
+}
+
+// VerifyChain implements Store
+func (m *MemoryStore) VerifyChain(hash string) error {
+	node, err := m.Get(hash)
+	if err != nil {
+		return err
+	}
+	if err := node.Verify(); err != nil {
+		return err
+	}
+
+	ancestors, err := m.Ancestors(hash)
+	if err != nil {
+		return err
+	}
+	for _, ancestor := range ancestors {
+		if err := ancestor.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Head implements Store
+func (m *MemoryStore) Head() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.order) == 0 {
+		return ""
+	}
+	return m.order[len(m.order)-1]
+}
+
+// Len implements Store
+func (m *MemoryStore) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.order)
+}
+
+// Nodes returns every node in append order
+func (m *MemoryStore) Nodes() []*Node {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	nodes := make([]*Node, len(m.order))
+	for i, hash := range m.order {
+		nodes[i] = m.nodes[hash]
+	}
+	return nodes
+}
@@ -0,0 +1,214 @@
+package goldendag
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestComputeHashDeterministic(t *testing.T) {
+	a := ComputeHash("intent", []byte("payload"), []string{"p1"})
+	b := ComputeHash("intent", []byte("payload"), []string{"p1"})
+
+	if a != b {
+		t.Errorf("Expected identical hashes, got %s and %s", a, b)
+	}
+
+	if !ValidHash(a) {
+		t.Errorf("Expected valid hash, got %s", a)
+	}
+
+	if ComputeHash("intent", []byte("payload"), nil) == a {
+		t.Error("Expected parents to change the hash")
+	}
+
+	if ComputeHash("intentp", []byte("ayload"), []string{"p1"}) == a {
+		t.Error("Expected field boundaries to change the hash")
+	}
+}
+
+func TestMemoryStoreAppendAndGet(t *testing.T) {
+	store := NewMemoryStore()
+
+	root, err := store.Append("genesis", []byte("root"))
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	if store.Head() != root.Hash {
+		t.Errorf("Expected head %s, got %s", root.Hash, store.Head())
+	}
+
+	got, err := store.Get(root.Hash)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != root {
+		t.Error("Get returned a different node")
+	}
+
+	again, err := store.Append("genesis", []byte("root"))
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if again != root || store.Len() != 1 {
+		t.Error("Expected duplicate append to return the existing node")
+	}
+
+	if _, err := store.Get("missing"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+}
+
+func TestMemoryStoreRejectsUnknownParent(t *testing.T) {
+	store := NewMemoryStore()
+
+	_, err := store.Append("child", []byte("x"), ComputeHash("ghost", nil, nil))
+	if !errors.Is(err, ErrParentNotFound) {
+		t.Errorf("Expected ErrParentNotFound, got %v", err)
+	}
+}
+
+func TestMemoryStoreAncestors(t *testing.T) {
+	store := NewMemoryStore()
+
+	root, _ := store.Append("genesis", []byte("root"))
+	left, _ := store.Append("left", []byte("l"), root.Hash)
+	right, _ := store.Append("right", []byte("r"), root.Hash)
+	merge, err := store.Append("merge", []byte("m"), left.Hash, right.Hash)
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	ancestors, err := store.Ancestors(merge.Hash)
+	if err != nil {
+		t.Fatalf("Ancestors failed: %v", err)
+	}
+
+	if len(ancestors) != 3 {
+		t.Fatalf("Expected 3 ancestors, got %d", len(ancestors))
+	}
+	if ancestors[0] != left || ancestors[1] != right || ancestors[2] != root {
+		t.Error("Expected ancestors nearest first")
+	}
+}
+
+func TestMemoryStoreVerifyChain(t *testing.T) {
+	store := NewMemoryStore()
+
+	root, _ := store.Append("genesis", []byte("root"))
+	child, _ := store.Append("child", []byte("c"), root.Hash)
+
+	if err := store.VerifyChain(child.Hash); err != nil {
+		t.Fatalf("VerifyChain failed: %v", err)
+	}
+
+	root.Payload = []byte("tampered")
+	if err := store.VerifyChain(child.Hash); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Expected ErrHashMismatch after tampering, got %v", err)
+	}
+}
+
+func TestVerifyChainFrom(t *testing.T) {
+	store := NewMemoryStore()
+
+	root, _ := store.Append("genesis", []byte("root"))
+	middle, _ := store.Append("child", []byte("m"), root.Hash)
+	head, _ := store.Append("child", []byte("h"), middle.Hash)
+
+	if err := VerifyChainFrom(store, head.Hash, ""); err != nil {
+		t.Fatalf("VerifyChainFrom failed: %v", err)
+	}
+
+	// Nodes behind the trusted one are not re-derived
+	root.Payload = []byte("tampered")
+	if err := VerifyChainFrom(store, head.Hash, middle.Hash); err != nil {
+		t.Errorf("Expected trusted chain to be skipped, got %v", err)
+	}
+	if err := VerifyChainFrom(store, head.Hash, ""); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Expected ErrHashMismatch after tampering, got %v", err)
+	}
+
+	if err := VerifyChainFrom(store, "missing", ""); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+}
+
+func TestAppendToHead(t *testing.T) {
+	store := NewMemoryStore()
+
+	first, err := AppendToHead(store, "event", map[string]int{"n": 1})
+	if err != nil {
+		t.Fatalf("AppendToHead failed: %v", err)
+	}
+	if len(first.Parents) != 0 {
+		t.Errorf("Expected no parents on first node, got %v", first.Parents)
+	}
+
+	second, err := AppendToHead(store, "event", map[string]int{"n": 2})
+	if err != nil {
+		t.Fatalf("AppendToHead failed: %v", err)
+	}
+	if len(second.Parents) != 1 || second.Parents[0] != first.Hash {
+		t.Errorf("Expected parent %s, got %v", first.Hash, second.Parents)
+	}
+}
+
+func TestFileStoreReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore failed: %v", err)
+	}
+
+	root, _ := store.Append("genesis", []byte("root"))
+	child, err := store.Append("child", []byte("c"), root.Hash)
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	store.Close()
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer reopened.Close()
+
+	if reopened.Len() != 2 {
+		t.Errorf("Expected 2 nodes after reload, got %d", reopened.Len())
+	}
+	if reopened.Head() != child.Hash {
+		t.Errorf("Expected head %s, got %s", child.Hash, reopened.Head())
+	}
+	if err := reopened.VerifyChain(child.Hash); err != nil {
+		t.Errorf("VerifyChain failed after reload: %v", err)
+	}
+}
+
+func TestFileStoreDetectsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore failed: %v", err)
+	}
+	store.Append("genesis", []byte("root"))
+	store.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	// "root" is stored base64-encoded as "cm9vdA=="
+	tampered := bytes.ReplaceAll(data, []byte("cm9vdA=="), []byte("Zm9vdA=="))
+	if err := os.WriteFile(path, tampered, 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if _, err := OpenFileStore(path); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Expected ErrHashMismatch on reload, got %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+// Package httpserver runs HTTP handlers on TCP or Unix-domain listeners,
+// optionally behind TLS or mutual TLS, and shuts them down gracefully so
+// in-flight requests are not cut off mid-response.
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Default timeouts of DefaultConfig
+const (
+	DefaultReadHeaderTimeout = 10 * time.Second
+	DefaultReadTimeout       = 30 * time.Second
+	DefaultWriteTimeout      = 60 * time.Second
+	DefaultIdleTimeout       = 120 * time.Second
+	DefaultShutdownTimeout   = 30 * time.Second
+)
+
+// Error definitions
+var (
+	ErrIncompleteTLS      = errors.New("TLS certificate and key must be set together")
+	ErrClientCAWithoutTLS = errors.New("client CA requires a TLS certificate and key")
+	ErrSocketInUse        = errors.New("unix socket is in use")
+	ErrStarted            = errors.New("server already started")
+)
+
+// Config describes where and how a server listens. Zero timeouts disable
+// the corresponding limit.
+type Config struct {
+	// Addr is the TCP address, e.g. ":8082"
+	Addr string `json:"addr,omitempty"`
+	// UnixSocket is the path of a Unix-domain socket; when set it is used
+	// instead of Addr
+	UnixSocket string `json:"unix_socket,omitempty"`
+
+	// TLSCertFile and TLSKeyFile enable TLS
+	TLSCertFile string `json:"tls_cert_file,omitempty"`
+	TLSKeyFile  string `json:"tls_key_file,omitempty"`
+	// TLSClientCAFile enables mutual TLS: clients must present a
+	// certificate signed by one of its CAs
+	TLSClientCAFile string `json:"tls_client_ca_file,omitempty"`
+
+	ReadHeaderTimeout time.Duration `json:"read_header_timeout,omitempty"`
+	ReadTimeout       time.Duration `json:"read_timeout,omitempty"`
+	WriteTimeout      time.Duration `json:"write_timeout,omitempty"`
+	IdleTimeout       time.Duration `json:"idle_timeout,omitempty"`
+	// ShutdownTimeout bounds how long in-flight requests are drained once
+	// the server's context is cancelled
+	ShutdownTimeout time.Duration `json:"shutdown_timeout,omitempty"`
+}
+
+// DefaultConfig returns a plain TCP config for addr with the default
+// timeouts
+func DefaultConfig(addr string) Config {
+	return Config{
+		Addr:              addr,
+		ReadHeaderTimeout: DefaultReadHeaderTimeout,
+		ReadTimeout:       DefaultReadTimeout,
+		WriteTimeout:      DefaultWriteTimeout,
+		IdleTimeout:       DefaultIdleTimeout,
+		ShutdownTimeout:   DefaultShutdownTimeout,
+	}
+}
+
+// TLS reports whether the config enables TLS
+func (c Config) TLS() bool {
+	return c.TLSCertFile != "" || c.TLSKeyFile != ""
+}
+
+// String describes the listener, e.g. "https://:8443" or "unix:/run/nb.sock"
+func (c Config) String() string {
+	scheme := "http"
+	if c.TLS() {
+		scheme = "https"
+	}
+	if c.UnixSocket != "" {
+		return scheme + "+unix:" + c.UnixSocket
+	}
+	return scheme + "://" + c.Addr
+}
+
+// TLSConfig loads the certificate, key and client CAs. It returns nil when
+// TLS is disabled.
+func (c Config) TLSConfig() (*tls.Config, error) {
+	if !c.TLS() {
+		if c.TLSClientCAFile != "" {
+			return nil, ErrClientCAWithoutTLS
+		}
+		return nil, nil
+	}
+	if c.TLSCertFile == "" || c.TLSKeyFile == "" {
+		return nil, ErrIncompleteTLS
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("TLS key pair: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if c.TLSClientCAFile != "" {
+		data, err := os.ReadFile(c.TLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("TLS client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("TLS client CA: no certificates in %s", c.TLSClientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// Listen opens the TCP or Unix-domain listener. A socket file left behind
+// by a killed process is replaced; one a live server still accepts on is
+// not.
+func (c Config) Listen() (net.Listener, error) {
+	if c.UnixSocket == "" {
+		return net.Listen("tcp", c.Addr)
+	}
+
+	if info, err := os.Stat(c.UnixSocket); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if conn, err := net.Dial("unix", c.UnixSocket); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w: %s", ErrSocketInUse, c.UnixSocket)
+		}
+		if err := os.Remove(c.UnixSocket); err != nil {
+			return nil, err
+		}
+	}
+	return net.Listen("unix", c.UnixSocket)
+}
+
+// Server serves a handler on the listener of a Config
+type Server struct {
+	config Config
+	http   *http.Server
+	// ready is closed once the server is listening
+	ready chan struct{}
+	// drained is closed once a shutdown has finished
+	drained chan struct{}
+
+	mu        sync.Mutex
+	started   bool
+	listener  net.Listener
+	drainOnce sync.Once
+}
+
+// New creates a server for handler. It does not listen until Start.
+func New(handler http.Handler, config Config) *Server {
+	return &Server{
+		config: config,
+		http: &http.Server{
+			Addr:              config.Addr,
+			Handler:           handler,
+			ReadHeaderTimeout: config.ReadHeaderTimeout,
+			ReadTimeout:       config.ReadTimeout,
+			WriteTimeout:      config.WriteTimeout,
+			IdleTimeout:       config.IdleTimeout,
+		},
+		ready:   make(chan struct{}),
+		drained: make(chan struct{}),
+	}
+}
+
+// Config returns the server's config
+func (s *Server) Config() Config {
+	return s.config
+}
+
+// Start listens and serves until ctx is cancelled or Shutdown is called.
+// A cancelled ctx drains in-flight requests for up to the config's
+// ShutdownTimeout. Start returns nil after a graceful shutdown.
+func (s *Server) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return ErrStarted
+	}
+	s.started = true
+	s.mu.Unlock()
+
+	tlsConfig, err := s.config.TLSConfig()
+	if err != nil {
+		return err
+	}
+	listener, err := s.config.Listen()
+	if err != nil {
+		return err
+	}
+	s.http.TLSConfig = tlsConfig
+
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+	close(s.ready)
+
+	served := make(chan error, 1)
+	go func() {
+		if tlsConfig != nil {
+			served <- s.http.ServeTLS(listener, "", "")
+		} else {
+			served <- s.http.Serve(listener)
+		}
+	}()
+
+	select {
+	case err := <-served:
+		if errors.Is(err, http.ErrServerClosed) {
+			<-s.drained
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx := context.Background()
+	if s.config.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, s.config.ShutdownTimeout)
+		defer cancel()
+	}
+	err = s.Shutdown(shutdownCtx)
+	<-served
+	return err
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests to
+// finish. If ctx ends first the remaining connections are closed and its
+// error returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.http.Shutdown(ctx)
+	if err != nil {
+		s.http.Close()
+	}
+	s.drainOnce.Do(func() { close(s.drained) })
+	return err
+}
+
+// RegisterOnShutdown registers a function to call when Shutdown starts,
+// e.g. to end long-lived streams that would otherwise hold it up
+func (s *Server) RegisterOnShutdown(f func()) {
+	s.http.RegisterOnShutdown(f)
+}
+
+// Ready is closed once the server is listening
+func (s *Server) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Addr returns the listener's address, or nil before the server listens
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
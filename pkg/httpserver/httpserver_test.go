@@ -0,0 +1,243 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func start(t *testing.T, handler http.Handler, config Config) (*Server, context.CancelFunc, <-chan error) {
+	t.Helper()
+	s := New(handler, config)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Start(ctx) }()
+	select {
+	case <-s.Ready():
+	case err := <-done:
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	t.Cleanup(cancel)
+	return s, cancel, done
+}
+
+func TestShutdownDrainsInFlight(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		io.WriteString(w, "done")
+	})
+	s, cancel, done := start(t, handler, DefaultConfig("127.0.0.1:0"))
+
+	type response struct {
+		body string
+		err  error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		resp, err := http.Get("http://" + s.Addr().String())
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- response{string(body), err}
+	}()
+
+	<-started
+	cancel()
+	select {
+	case err := <-done:
+		t.Fatalf("Expected Start to wait for the in-flight request, returned %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if r := <-responses; r.err != nil || r.body != "done" {
+		t.Errorf("Expected in-flight request to complete, got %q, %v", r.body, r.err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Expected graceful shutdown, got %v", err)
+	}
+	if _, err := net.Dial("tcp", s.Addr().String()); err == nil {
+		t.Error("Expected the listener to be closed")
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-block
+	})
+	config := DefaultConfig("127.0.0.1:0")
+	config.ShutdownTimeout = 20 * time.Millisecond
+	s, cancel, done := start(t, handler, config)
+
+	go http.Get("http://" + s.Addr().String())
+	<-started
+	cancel()
+	if err := <-done; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nb.sock")
+
+	// A socket left behind by a killed process is replaced
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("Failed to create socket: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	config := DefaultConfig("")
+	config.UnixSocket = path
+	_, cancel, done := start(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "unix")
+	}), config)
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	resp, err := client.Get("http://nb/")
+	if err != nil {
+		t.Fatalf("Failed to request over unix socket: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "unix" {
+		t.Errorf("Expected unix, got %q", body)
+	}
+
+	if _, err := config.Listen(); !errors.Is(err, ErrSocketInUse) {
+		t.Errorf("Expected socket in use error, got %v", err)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Expected graceful shutdown, got %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected socket to be removed, got %v", err)
+	}
+}
+
+// issue writes a PEM certificate and key signed by parent, or self-signed
+// when parent is nil
+func issue(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	os.WriteFile(filepath.Join(dir, name+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	os.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	cert, _ := x509.ParseCertificate(der)
+	return cert, key
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := issue(t, dir, "ca", nil, nil)
+	issue(t, dir, "server", ca, caKey)
+	issue(t, dir, "client", ca, caKey)
+
+	config := DefaultConfig("127.0.0.1:0")
+	config.TLSCertFile = filepath.Join(dir, "server.crt")
+	config.TLSKeyFile = filepath.Join(dir, "server.key")
+	config.TLSClientCAFile = filepath.Join(dir, "ca.crt")
+	s, _, _ := start(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}), config)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	get := func(certs ...tls.Certificate) (string, error) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs}}}
+		resp, err := client.Get("https://" + s.Addr().String())
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	if _, err := get(); err == nil {
+		t.Error("Expected a client without a certificate to be rejected")
+	}
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key"))
+	if err != nil {
+		t.Fatalf("Failed to load client certificate: %v", err)
+	}
+	if name, err := get(clientCert); err != nil || name != "client" {
+		t.Errorf("Expected client certificate to be accepted, got %q, %v", name, err)
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	tests := []struct {
+		config Config
+		err    error
+	}{
+		{Config{TLSCertFile: "server.crt"}, ErrIncompleteTLS},
+		{Config{TLSClientCAFile: "ca.crt"}, ErrClientCAWithoutTLS},
+	}
+	for _, tt := range tests {
+		if _, err := tt.config.TLSConfig(); !errors.Is(err, tt.err) {
+			t.Errorf("Expected %v, got %v", tt.err, err)
+		}
+	}
+
+	s := New(http.NotFoundHandler(), DefaultConfig("127.0.0.1:0"))
+	go s.Start(context.Background())
+	<-s.Ready()
+	defer s.Shutdown(context.Background())
+	if err := s.Start(context.Background()); !errors.Is(err, ErrStarted) {
+		t.Errorf("Expected already started error, got %v", err)
+	}
+}
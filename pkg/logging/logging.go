@@ -0,0 +1,81 @@
+// Package logging provides the structured loggers NeuralBlitz subsystems
+// write to. Subsystems are silent unless they are given a logger with
+// their SetLogger method; every record they write carries the subsystem
+// field, and trace_id or session_id where one applies.
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Error definitions
+var (
+	ErrUnknownFormat = errors.New("unknown log format")
+	ErrUnknownLevel  = errors.New("unknown log level")
+)
+
+// Fields every subsystem logs under
+const (
+	KeySubsystem = "subsystem"
+	KeyTraceID   = "trace_id"
+	KeySessionID = "session_id"
+)
+
+// Formats of New
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Formats lists the supported formats
+var Formats = []string{FormatJSON, FormatText}
+
+// New creates a logger writing records at level or above to w in format
+func New(w io.Writer, format string, level slog.Leveler) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	switch format {
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("%w: %q (want %s)", ErrUnknownFormat, format, strings.Join(Formats, " or "))
+	}
+}
+
+// ParseLevel parses debug, info, warn or error
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("%w: %q (want debug, info, warn or error)", ErrUnknownLevel, s)
+	}
+	return level, nil
+}
+
+// Discard returns a logger that drops every record
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// Subsystem returns logger with the subsystem field set to name, or a
+// discarding logger when logger is nil
+func Subsystem(logger *slog.Logger, name string) *slog.Logger {
+	if logger == nil {
+		return Discard()
+	}
+	return logger.With(KeySubsystem, name)
+}
+
+// TraceID is the trace_id field
+func TraceID(id string) slog.Attr {
+	return slog.String(KeyTraceID, id)
+}
+
+// SessionID is the session_id field
+func SessionID(id string) slog.Attr {
+	return slog.String(KeySessionID, id)
+}
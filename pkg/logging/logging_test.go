@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewFormats(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, FormatJSON, slog.LevelInfo)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	Subsystem(logger, "bci").Debug("dropped")
+	Subsystem(logger, "bci").Info("recording started", TraceID("T-1"), SessionID("S-1"))
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected one JSON record, got %q: %v", buf.String(), err)
+	}
+	for key, want := range map[string]string{
+		"msg": "recording started", KeySubsystem: "bci", KeyTraceID: "T-1", KeySessionID: "S-1",
+	} {
+		if record[key] != want {
+			t.Errorf("Expected %s=%q, got %v", key, want, record[key])
+		}
+	}
+
+	buf.Reset()
+	logger, _ = New(&buf, FormatText, slog.LevelDebug)
+	logger.Debug("step", "n", 1)
+	if !strings.Contains(buf.String(), "level=DEBUG msg=step n=1") {
+		t.Errorf("Expected a text record, got %q", buf.String())
+	}
+
+	if _, err := New(&buf, "xml", slog.LevelInfo); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for s, want := range map[string]slog.Level{"debug": slog.LevelDebug, "INFO": slog.LevelInfo, "warn": slog.LevelWarn, "error": slog.LevelError} {
+		if got, err := ParseLevel(s); err != nil || got != want {
+			t.Errorf("Expected %s to parse as %v, got %v, %v", s, want, got, err)
+		}
+	}
+	if _, err := ParseLevel("loud"); !errors.Is(err, ErrUnknownLevel) {
+		t.Errorf("Expected ErrUnknownLevel, got %v", err)
+	}
+}
+
+func TestSubsystemDefaultsToSilent(t *testing.T) {
+	if Subsystem(nil, "quantum_ml").Enabled(context.Background(), slog.LevelError) {
+		t.Errorf("Expected a subsystem without a logger to be silent")
+	}
+
+	var buf bytes.Buffer
+	logger, _ := New(&buf, FormatText, slog.LevelInfo)
+	Subsystem(logger, "quantum_ml").Info("training epoch")
+	if !strings.Contains(buf.String(), "subsystem=quantum_ml") {
+		t.Errorf("Expected the subsystem field, got %q", buf.String())
+	}
+}
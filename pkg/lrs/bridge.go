@@ -4,9 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"math"
-	"math/rand"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // Bridge Constants
@@ -96,6 +97,12 @@ type LRSNeuralBlitzBridge struct {
 	TotalCycles  int
 	TotalSpikes  int
 	AverageFreeEnergy float64
+
+	// Random source and clock
+	rand *rng.Source
+
+	// metricsHook is called with the metrics of every cycle
+	metricsHook func(*CycleMetrics)
 }
 
 // QuantumNeuronBridge wraps NeuralBlitz quantum neuron for LRS integration
@@ -171,28 +178,56 @@ type FreeEnergyResult struct {
 	ComplexityCost float64 `json:"complexity_cost"`
 }
 
+// BridgeConfig configures how a bridge reaches its LRS agent
+type BridgeConfig struct {
+	AgentEndpoint string `json:"agent_endpoint"`
+	BridgePort    int    `json:"bridge_port"`
+	AuthKey       string `json:"auth_key"`
+}
+
+// DefaultBridgeConfig returns default configuration
+func DefaultBridgeConfig() *BridgeConfig {
+	return &BridgeConfig{
+		AgentEndpoint: DefaultAgentEndpoint,
+		BridgePort:    DefaultBridgePort,
+		AuthKey:       DefaultAuthKey,
+	}
+}
+
+// Configure replaces the bridge's agent endpoint, port and auth key
+func (b *LRSNeuralBlitzBridge) Configure(config *BridgeConfig) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.AgentEndpoint = config.AgentEndpoint
+	b.BridgePort = config.BridgePort
+	b.AuthKey = config.AuthKey
+}
+
 // NewLRSNeuralBlitzBridge creates a new LRS-NeuralBlitz bridge
-func NewLRSNeuralBlitzBridge() *LRSNeuralBlitzBridge {
+func NewLRSNeuralBlitzBridge(opts ...rng.Option) *LRSNeuralBlitzBridge {
+	src := rng.Resolve(opts...)
+
 	return &LRSNeuralBlitzBridge{
 		AgentEndpoint: DefaultAgentEndpoint,
 		AuthKey:      DefaultAuthKey,
 		BridgePort:   DefaultBridgePort,
 
-		QuantumNeuron:  NewQuantumNeuronBridge(),
+		QuantumNeuron:  NewQuantumNeuronBridge(rng.WithSource(src)),
 		RealityNetwork: NewRealityNetworkBridge(),
 		LRSAgent:      NewLRSElementaryAgent(),
 
 		State:         StateInitializing,
 		MetricsHistory: make([]*CycleMetrics, 0),
 
-		LastHeartbeat: time.Now(),
+		LastHeartbeat: src.Now(),
+		rand:          src,
 	}
 }
 
 // NewQuantumNeuronBridge creates a new quantum neuron bridge
-func NewQuantumNeuronBridge() *QuantumNeuronBridge {
+func NewQuantumNeuronBridge(opts ...rng.Option) *QuantumNeuronBridge {
 	return &QuantumNeuronBridge{
-		NeuronID:        generateNeuronID(),
+		NeuronID:        generateNeuronID(rng.Resolve(opts...)),
 		Config:         &NeuronConfig{
 			QuantumTunneling:   0.15,
 			CoherenceTime:     150.0,
@@ -271,7 +306,7 @@ func (b *LRSNeuralBlitzBridge) Initialize() error {
 	b.AverageFreeEnergy = 0.0
 
 	b.State = StateConnected
-	b.LastHeartbeat = time.Now()
+	b.LastHeartbeat = b.rand.Now()
 
 	return nil
 }
@@ -308,7 +343,7 @@ func (b *LRSNeuralBlitzBridge) RunCycle(cycle int, inputCurrent float64) (*Cycle
 		FreeEnergy:        freeEnergy,
 		PredictionError:   predictionError,
 		Consciousness:     b.RealityNetwork.GlobalConsciousness,
-		Timestamp:        time.Now(),
+		Timestamp:        b.rand.Now(),
 	}
 
 	// Store metrics
@@ -326,11 +361,23 @@ func (b *LRSNeuralBlitzBridge) RunCycle(cycle int, inputCurrent float64) (*Cycle
 	b.AverageFreeEnergy = totalFE / float64(b.TotalCycles)
 
 	b.State = StateActive
-	b.LastHeartbeat = time.Now()
+	b.LastHeartbeat = b.rand.Now()
+
+	if b.metricsHook != nil {
+		b.metricsHook(metrics)
+	}
 
 	return metrics, nil
 }
 
+// SetMetricsHook registers fn to be called with the metrics of every cycle.
+// fn runs while the bridge is locked and must not call back into it.
+func (b *LRSNeuralBlitzBridge) SetMetricsHook(fn func(*CycleMetrics)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.metricsHook = fn
+}
+
 // simulateQuantumNeuron simulates quantum neuron activity
 func (b *LRSNeuralBlitzBridge) simulateQuantumNeuron(inputCurrent float64) int {
 	config := b.QuantumNeuron.Config
@@ -381,32 +428,31 @@ func (b *LRSNeuralBlitzBridge) evolveRealityNetwork() {
 		state := b.RealityNetwork.RealityStates[realityID]
 
 		// Evolve consciousness
-		delta := (rand.Float64() - 0.5) * 0.1
+		delta := (b.rand.Float64() - 0.5) * 0.1
 		state.Consciousness = baseConsciousness + delta + 0.1*float64(i)*0.01
 
 		// Clamp consciousness
 		state.Consciousness = math.Max(0.0, math.Min(1.0, state.Consciousness))
 
 		// Update information density and coherence
-		state.InformationDensity = 1.0 + rand.Float64()*0.5
-		state.QuantumCoherence = 0.8 + rand.Float64()*0.2
+		state.InformationDensity = 1.0 + b.rand.Float64()*0.5
+		state.QuantumCoherence = 0.8 + b.rand.Float64()*0.2
 	}
 
-	// Calculate global consciousness
+	// Calculate global consciousness, summing in reality order so the
+	// result is reproducible
 	globalConsciousness := 0.0
-	for _, state := range b.RealityNetwork.RealityStates {
-		globalConsciousness += state.Consciousness
+	for i := 0; i < b.RealityNetwork.NumRealities; i++ {
+		globalConsciousness += b.RealityNetwork.RealityStates[generateRealityID(i)].Consciousness
 	}
 	globalConsciousness /= float64(len(b.RealityNetwork.RealityStates))
 
 	b.RealityNetwork.GlobalConsciousness = globalConsciousness
 }
 
-// lrsPredict makes a prediction using LRS Active Inference
+// lrsPredict makes a prediction using LRS Active Inference.
+// Callers must hold b.mu.
 func (b *LRSNeuralBlitzBridge) lrsPredict(observation int) (float64, float64) {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	// Convert observation to observation vector
 	observationFloat := float64(observation)
 
@@ -568,9 +614,9 @@ func (b *LRSNeuralBlitzBridge) String() string {
 }
 
 // generateNeuronID generates a unique neuron ID
-func generateNeuronID() string {
-	timestamp := time.Now().UnixNano() / 1e6
-	randomPart := rand.Intn(10000)
+func generateNeuronID(src *rng.Source) string {
+	timestamp := src.Now().UnixNano() / 1e6
+	randomPart := src.Intn(10000)
 	return "neuron_" + string(rune('a'+timestamp%26)) +
 		string(rune('0'+randomPart/1000)) +
 		string(rune('0'+randomPart%1000/100)) +
@@ -0,0 +1,303 @@
+package nbcl
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Node is an element of the syntax tree
+type Node interface {
+	Pos() Position
+}
+
+// Value is an argument value
+type Value interface {
+	Node
+	// Interface returns the value as a plain Go value: string, float64,
+	// bool, time.Duration, []interface{} or map[string]interface{}
+	Interface() interface{}
+	// String renders the value as NBCL source
+	String() string
+}
+
+// Command is a parsed NBCL command
+type Command struct {
+	Start Position    `json:"pos"`
+	Name  string      `json:"name"`
+	Args  []*Argument `json:"args"`
+}
+
+// Pos returns the position of the leading slash
+func (c *Command) Pos() Position { return c.Start }
+
+// Arguments returns the arguments as a map of plain Go values
+func (c *Command) Arguments() map[string]interface{} {
+	return argumentMap(c.Args)
+}
+
+// Arg returns the argument with the given key, or nil
+func (c *Command) Arg(key string) *Argument {
+	for _, arg := range c.Args {
+		if arg.Key == key {
+			return arg
+		}
+	}
+	return nil
+}
+
+// String renders the command in canonical NBCL form
+func (c *Command) String() string {
+	var b strings.Builder
+	b.WriteString("/" + c.Name)
+	for _, arg := range c.Args {
+		b.WriteString(" " + arg.String())
+	}
+	return b.String()
+}
+
+// Argument is a key[value] pair
+type Argument struct {
+	Start Position `json:"pos"`
+	Key   string   `json:"key"`
+	Value Value    `json:"value"`
+}
+
+// Pos returns the position of the key
+func (a *Argument) Pos() Position { return a.Start }
+
+// String renders the argument as key[value]
+func (a *Argument) String() string {
+	return a.Key + "[" + a.Value.String() + "]"
+}
+
+// String is a bare word or quoted string
+type String struct {
+	Start  Position `json:"pos"`
+	Value  string   `json:"value"`
+	Quoted bool     `json:"quoted"`
+}
+
+// Pos returns the position of the string
+func (s *String) Pos() Position { return s.Start }
+
+// Interface returns the string
+func (s *String) Interface() interface{} { return s.Value }
+
+// String renders the string, quoting it when it would not read back as
+// the same bare word
+func (s *String) String() string {
+	if s.Value != "" && classify(s.Value) == nil && isBare(s.Value) {
+		return s.Value
+	}
+	return quote(s.Value)
+}
+
+// Number is a numeric literal
+type Number struct {
+	Start Position `json:"pos"`
+	Raw   string   `json:"raw"`
+	Value float64  `json:"value"`
+}
+
+// Pos returns the position of the number
+func (n *Number) Pos() Position { return n.Start }
+
+// Interface returns the number as a float64
+func (n *Number) Interface() interface{} { return n.Value }
+
+// String renders the number as written
+func (n *Number) String() string { return n.Raw }
+
+// Bool is a true or false literal
+type Bool struct {
+	Start Position `json:"pos"`
+	Value bool     `json:"value"`
+}
+
+// Pos returns the position of the literal
+func (b *Bool) Pos() Position { return b.Start }
+
+// Interface returns the bool
+func (b *Bool) Interface() interface{} { return b.Value }
+
+// String renders the literal as true or false
+func (b *Bool) String() string { return strconv.FormatBool(b.Value) }
+
+// Duration is a duration literal such as 30s or 1h15m
+type Duration struct {
+	Start Position      `json:"pos"`
+	Raw   string        `json:"raw"`
+	Value time.Duration `json:"value"`
+}
+
+// Pos returns the position of the literal
+func (d *Duration) Pos() Position { return d.Start }
+
+// Interface returns the time.Duration
+func (d *Duration) Interface() interface{} { return d.Value }
+
+// String renders the duration as written
+func (d *Duration) String() string { return d.Raw }
+
+// List is a comma-separated list of values
+type List struct {
+	Start Position `json:"pos"`
+	Items []Value  `json:"items"`
+}
+
+// Pos returns the position of the first item
+func (l *List) Pos() Position { return l.Start }
+
+// Interface returns the items as a []interface{}
+func (l *List) Interface() interface{} {
+	items := make([]interface{}, len(l.Items))
+	for i, item := range l.Items {
+		items[i] = item.Interface()
+	}
+	return items
+}
+
+// String renders the items separated by commas
+func (l *List) String() string {
+	items := make([]string, len(l.Items))
+	for i, item := range l.Items {
+		items[i] = item.String()
+		if _, ok := item.(*List); ok {
+			items[i] = "[" + items[i] + "]"
+		}
+	}
+	return strings.Join(items, ", ")
+}
+
+// Map is a sequence of nested key[value] entries
+type Map struct {
+	Start   Position    `json:"pos"`
+	Entries []*Argument `json:"entries"`
+}
+
+// Pos returns the position of the first key
+func (m *Map) Pos() Position { return m.Start }
+
+// Interface returns the entries as a map[string]interface{}
+func (m *Map) Interface() interface{} {
+	return argumentMap(m.Entries)
+}
+
+// String renders the entries separated by spaces
+func (m *Map) String() string {
+	entries := make([]string, len(m.Entries))
+	for i, entry := range m.Entries {
+		entries[i] = entry.String()
+	}
+	return strings.Join(entries, " ")
+}
+
+// Variable is a reference to a bound result, optionally followed by a
+// path of fields, as in $dag.golden_dag
+type Variable struct {
+	Start Position `json:"pos"`
+	Name  string   `json:"name"`
+	Path  []string `json:"path,omitempty"`
+}
+
+// Pos returns the position of the $
+func (v *Variable) Pos() Position { return v.Start }
+
+// Interface returns the reference as written; use Resolve to substitute
+// its value
+func (v *Variable) Interface() interface{} { return v.String() }
+
+// String renders the reference as $name.path
+func (v *Variable) String() string {
+	return "$" + strings.Join(append([]string{v.Name}, v.Path...), ".")
+}
+
+// Resolver returns the value a variable reference refers to
+type Resolver func(v *Variable) (interface{}, error)
+
+// Resolve converts a value to plain Go values like Interface, substituting
+// variable references with resolve
+func Resolve(value Value, resolve Resolver) (interface{}, error) {
+	switch v := value.(type) {
+	case *Variable:
+		return resolve(v)
+	case *List:
+		items := make([]interface{}, len(v.Items))
+		for i, item := range v.Items {
+			resolved, err := Resolve(item, resolve)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = resolved
+		}
+		return items, nil
+	case *Map:
+		return resolveArguments(v.Entries, resolve)
+	default:
+		return value.Interface(), nil
+	}
+}
+
+// ResolveArguments returns the arguments like Arguments, substituting
+// variable references with resolve
+func (c *Command) ResolveArguments(resolve Resolver) (map[string]interface{}, error) {
+	return resolveArguments(c.Args, resolve)
+}
+
+func resolveArguments(args []*Argument, resolve Resolver) (map[string]interface{}, error) {
+	m := make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		value, err := Resolve(arg.Value, resolve)
+		if err != nil {
+			return nil, err
+		}
+		m[arg.Key] = value
+	}
+	return m, nil
+}
+
+func argumentMap(args []*Argument) map[string]interface{} {
+	m := make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		m[arg.Key] = arg.Value.Interface()
+	}
+	return m
+}
+
+// classify returns the literal a bare word denotes, or nil if it is a
+// plain string
+func classify(word string) Value {
+	if strings.EqualFold(word, "true") || strings.EqualFold(word, "false") {
+		return &Bool{Value: strings.EqualFold(word, "true")}
+	}
+	if c := word[0]; c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9') {
+		if f, err := strconv.ParseFloat(word, 64); err == nil {
+			return &Number{Raw: word, Value: f}
+		}
+		if d, err := time.ParseDuration(word); err == nil {
+			return &Duration{Raw: word, Value: d}
+		}
+	}
+	return nil
+}
+
+func isBare(s string) bool {
+	if strings.HasPrefix(s, "!=") {
+		return false
+	}
+	for i, r := range s {
+		if !isWordRune(r) || (i == 0 && !isWordStart(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+var quoteReplacer = strings.NewReplacer(
+	`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`,
+)
+
+func quote(s string) string {
+	return `"` + quoteReplacer.Replace(s) + `"`
+}
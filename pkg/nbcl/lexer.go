@@ -0,0 +1,252 @@
+package nbcl
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Lexer splits NBCL source into tokens
+type Lexer struct {
+	src string
+	pos Position
+}
+
+// NewLexer creates a lexer over src
+func NewLexer(src string) *Lexer {
+	return &Lexer{src: src, pos: Position{Line: 1, Column: 1}}
+}
+
+// Tokenize returns every token in src, ending with TokenEOF
+func Tokenize(src string) ([]Token, error) {
+	l := NewLexer(src)
+	tokens := make([]Token, 0)
+	for {
+		tok, err := l.Next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.Kind == TokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+// Next returns the next token
+func (l *Lexer) Next() (Token, error) {
+	for {
+		r, ok := l.peek()
+		if !ok || r == '\n' || !unicode.IsSpace(r) {
+			break
+		}
+		l.advance()
+	}
+
+	start := l.pos
+	r, ok := l.peek()
+	if !ok {
+		return Token{Kind: TokenEOF, Pos: start, End: start}, nil
+	}
+
+	switch r {
+	case '\n':
+		l.advance()
+		return l.token(TokenNewline, start, "\n"), nil
+	case '[':
+		l.advance()
+		return l.token(TokenLBracket, start, "["), nil
+	case ']':
+		l.advance()
+		return l.token(TokenRBracket, start, "]"), nil
+	case ',':
+		l.advance()
+		return l.token(TokenComma, start, ","), nil
+	case '|':
+		l.advance()
+		return l.token(TokenPipe, start, "|"), nil
+	case '"', '\'':
+		return l.lexString(start, r)
+	case '#':
+		for {
+			r, ok := l.peek()
+			if !ok || r == '\n' {
+				break
+			}
+			l.advance()
+		}
+		return l.token(TokenComment, start, l.src[start.Offset+1:l.pos.Offset]), nil
+	case '$':
+		return l.lexVariable(start)
+	case '=', '<', '>', '!':
+		if op := l.operator(); op != "" {
+			kind := TokenOperator
+			if op == "=" {
+				kind = TokenAssign
+			}
+			for range op {
+				l.advance()
+			}
+			return l.token(kind, start, op), nil
+		}
+	}
+	return l.lexWord(start)
+}
+
+// operator returns the comparison or assignment operator at the current
+// position, or "" if there is none
+func (l *Lexer) operator() string {
+	rest := l.src[l.pos.Offset:]
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(rest, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// isWordStart reports whether a token starting with r is a bare word rather
+// than a comment, variable or operator
+func isWordStart(r rune) bool {
+	switch r {
+	case '#', '$', '=', '<', '>':
+		return false
+	}
+	return isWordRune(r)
+}
+
+// isWordRune reports whether r can appear unescaped in a bare word
+func isWordRune(r rune) bool {
+	switch r {
+	case '[', ']', ',', '"', '\'', '\\', '|':
+		return false
+	}
+	return !unicode.IsSpace(r)
+}
+
+func (l *Lexer) lexWord(start Position) (Token, error) {
+	var value strings.Builder
+	for {
+		r, ok := l.peek()
+		if !ok {
+			break
+		}
+		if r == '\\' {
+			escapePos := l.pos
+			l.advance()
+			next, ok := l.peek()
+			if !ok || next == '\n' {
+				return Token{}, errorf(escapePos, "unterminated escape at end of word")
+			}
+			l.advance()
+			value.WriteRune(next)
+			continue
+		}
+		if !isWordRune(r) {
+			break
+		}
+		l.advance()
+		value.WriteRune(r)
+	}
+	return l.token(TokenWord, start, value.String()), nil
+}
+
+func (l *Lexer) lexVariable(start Position) (Token, error) {
+	l.advance()
+	for {
+		r, ok := l.peek()
+		if !ok || !(r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			break
+		}
+		l.advance()
+	}
+	name := l.src[start.Offset+1 : l.pos.Offset]
+	if !isVariable(name) {
+		return Token{}, errorf(start, "invalid variable $%s", name)
+	}
+	return l.token(TokenVariable, start, name), nil
+}
+
+// isVariable reports whether s is a variable name optionally followed by a
+// dotted field path, such as dag.golden_dag or _.commands.0
+func isVariable(s string) bool {
+	parts := strings.Split(s, ".")
+	if !isName(parts[0]) {
+		return false
+	}
+	for _, part := range parts[1:] {
+		if part == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *Lexer) lexString(start Position, quote rune) (Token, error) {
+	l.advance()
+	var value strings.Builder
+	for {
+		r, ok := l.peek()
+		if !ok || r == '\n' {
+			return Token{}, errorf(start, "unterminated string")
+		}
+		if r == quote {
+			l.advance()
+			return l.token(TokenString, start, value.String()), nil
+		}
+		if r != '\\' {
+			l.advance()
+			value.WriteRune(r)
+			continue
+		}
+
+		escapePos := l.pos
+		l.advance()
+		next, ok := l.peek()
+		if !ok {
+			return Token{}, errorf(start, "unterminated string")
+		}
+		l.advance()
+		switch next {
+		case 'n':
+			value.WriteRune('\n')
+		case 't':
+			value.WriteRune('\t')
+		case 'r':
+			value.WriteRune('\r')
+		case '\\', '"', '\'', '[', ']':
+			value.WriteRune(next)
+		default:
+			return Token{}, errorf(escapePos, "unknown escape sequence \\%c", next)
+		}
+	}
+}
+
+func (l *Lexer) token(kind TokenKind, start Position, value string) Token {
+	return Token{
+		Kind:  kind,
+		Text:  l.src[start.Offset:l.pos.Offset],
+		Value: value,
+		Pos:   start,
+		End:   l.pos,
+	}
+}
+
+func (l *Lexer) peek() (rune, bool) {
+	if l.pos.Offset >= len(l.src) {
+		return 0, false
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos.Offset:])
+	return r, true
+}
+
+func (l *Lexer) advance() {
+	r, size := utf8.DecodeRuneInString(l.src[l.pos.Offset:])
+	l.pos.Offset += size
+	if r == '\n' {
+		l.pos.Line++
+		l.pos.Column = 1
+	} else {
+		l.pos.Column++
+	}
+}
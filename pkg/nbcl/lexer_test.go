@@ -0,0 +1,83 @@
+package nbcl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens, err := Tokenize("/logos weave[omega_prime, \"a \\\"b\\\"\"]\n/status")
+	if err != nil {
+		t.Fatalf("Failed to tokenize: %v", err)
+	}
+
+	want := []struct {
+		kind  TokenKind
+		value string
+	}{
+		{TokenWord, "/logos"},
+		{TokenWord, "weave"},
+		{TokenLBracket, "["},
+		{TokenWord, "omega_prime"},
+		{TokenComma, ","},
+		{TokenString, `a "b"`},
+		{TokenRBracket, "]"},
+		{TokenNewline, "\n"},
+		{TokenWord, "/status"},
+		{TokenEOF, ""},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(want), len(tokens), tokens)
+	}
+	for i, w := range want {
+		if tokens[i].Kind != w.kind || tokens[i].Value != w.value {
+			t.Errorf("Token %d: expected %s %q, got %s %q", i, w.kind, w.value, tokens[i].Kind, tokens[i].Value)
+		}
+	}
+
+	if pos := tokens[8].Pos; pos.Line != 2 || pos.Column != 1 {
+		t.Errorf("Expected /status at 2:1, got %s", pos)
+	}
+	if pos := tokens[5].Pos; pos.Line != 1 || pos.Column != 27 {
+		t.Errorf("Expected string at 1:27, got %s", pos)
+	}
+}
+
+func TestTokenizeEscapes(t *testing.T) {
+	tokens, err := Tokenize(`a\]b 'it\'s' "tab\there"`)
+	if err != nil {
+		t.Fatalf("Failed to tokenize: %v", err)
+	}
+	for i, want := range []string{"a]b", "it's", "tab\there"} {
+		if tokens[i].Value != want {
+			t.Errorf("Expected %q, got %q", want, tokens[i].Value)
+		}
+	}
+	if tokens[0].Text != `a\]b` {
+		t.Errorf("Expected raw text a\\]b, got %q", tokens[0].Text)
+	}
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	tests := []struct {
+		src          string
+		line, column int
+	}{
+		{`"open`, 1, 1},
+		{"x\n  \"bad \\q\"", 2, 8},
+		{`word\`, 1, 5},
+		{"\"line\nbreak\"", 1, 1},
+	}
+
+	for _, tt := range tests {
+		_, err := Tokenize(tt.src)
+		var syntaxErr *Error
+		if !errors.As(err, &syntaxErr) || !errors.Is(err, ErrSyntax) {
+			t.Errorf("Expected syntax error for %q, got %v", tt.src, err)
+			continue
+		}
+		if syntaxErr.Pos.Line != tt.line || syntaxErr.Pos.Column != tt.column {
+			t.Errorf("Expected error at %d:%d for %q, got %s", tt.line, tt.column, tt.src, syntaxErr.Pos)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package nbcl
+
+import "strings"
+
+// Grammar:
+//
+//	command  = "/" name { argument }
+//	name     = ident { "." ident }
+//	argument = word "[" body "]"
+//	body     = [ entries | items ]
+//	entries  = argument { [ "," ] argument }
+//	items    = item { "," item }
+//	item     = "[" body "]" | variable | scalar { scalar }
+//	scalar   = word | string
+//
+//	script     = { [ statement ] newline }
+//	statement  = pipeline | assignment | if
+//	pipeline   = command { "|" command }
+//	assignment = variable "=" pipeline
+//	if         = "if" condition newline script [ "else" newline script ] "end"
+//	condition  = operand [ operator operand ]
+//	operand    = variable | word | string
+//
+// An item made of a single bare word is a number, boolean or duration
+// literal when it reads as one; otherwise items are strings, with
+// consecutive words joined by the whitespace between them. Newlines are
+// allowed inside brackets.
+
+// ParseCommand parses a single NBCL command
+func ParseCommand(src string) (*Command, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	cmd, err := p.parseCommand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	if tok := p.peek(); tok.Kind != TokenEOF {
+		return nil, errorf(tok.Pos, "unexpected %s after command", tok.describe())
+	}
+	return cmd, nil
+}
+
+// ParseValue parses the body of a bracketed argument, e.g. the
+// "a, b, c" of targets[a, b, c]
+func ParseValue(src string) (Value, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.parseBody(Position{Line: 1, Column: 1})
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.Kind != TokenEOF {
+		return nil, errorf(tok.Pos, "unexpected %s", tok.describe())
+	}
+	return value, nil
+}
+
+type parser struct {
+	src    string
+	tokens []Token
+	i      int
+	// depth counts open brackets; newlines are insignificant inside them
+	depth int
+}
+
+func newParser(src string) (*parser, error) {
+	tokens, err := Tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	significant := tokens[:0]
+	for _, tok := range tokens {
+		if tok.Kind != TokenComment {
+			significant = append(significant, tok)
+		}
+	}
+	return &parser{src: src, tokens: significant}, nil
+}
+
+// peek returns the next significant token
+func (p *parser) peek() Token {
+	if p.depth > 0 {
+		p.skipNewlines()
+	}
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() Token {
+	tok := p.peek()
+	if tok.Kind != TokenEOF {
+		p.i++
+	}
+	return tok
+}
+
+func (p *parser) skipNewlines() {
+	for p.tokens[p.i].Kind == TokenNewline {
+		p.i++
+	}
+}
+
+func (p *parser) expect(kind TokenKind, context string) (Token, error) {
+	tok := p.next()
+	if tok.Kind != kind {
+		return tok, errorf(tok.Pos, "expected %s %s, got %s", kind, context, tok.describe())
+	}
+	return tok, nil
+}
+
+func (p *parser) parseCommand() (*Command, error) {
+	tok := p.next()
+	if tok.Kind != TokenWord || len(tok.Value) == 0 || tok.Value[0] != '/' {
+		return nil, errorf(tok.Pos, "expected command starting with /, got %s", tok.describe())
+	}
+	name := tok.Value[1:]
+	if !isName(name) {
+		return nil, errorf(tok.Pos, "invalid command name %q", tok.Value)
+	}
+
+	cmd := &Command{Start: tok.Pos, Name: name, Args: make([]*Argument, 0)}
+	seen := make(map[string]bool)
+	for {
+		tok := p.peek()
+		if tok.Kind == TokenEOF || tok.Kind == TokenNewline || tok.Kind == TokenPipe {
+			return cmd, nil
+		}
+		arg, err := p.parseArgument()
+		if err != nil {
+			return nil, err
+		}
+		if seen[arg.Key] {
+			return nil, errorf(arg.Start, "duplicate argument %q", arg.Key)
+		}
+		seen[arg.Key] = true
+		cmd.Args = append(cmd.Args, arg)
+	}
+}
+
+func (p *parser) parseArgument() (*Argument, error) {
+	key := p.next()
+	if key.Kind != TokenWord {
+		return nil, errorf(key.Pos, "expected argument key, got %s", key.describe())
+	}
+	open := p.tokens[p.i]
+	if open.Kind != TokenLBracket || open.Pos.Offset != key.End.Offset {
+		return nil, errorf(key.End, "expected '[' after argument key %q", key.Value)
+	}
+	p.i++
+	p.depth++
+
+	value, err := p.parseBody(open.End)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(TokenRBracket, "to close "+key.Value+"["); err != nil {
+		return nil, err
+	}
+	p.depth--
+	return &Argument{Start: key.Pos, Key: key.Value, Value: value}, nil
+}
+
+func (p *parser) parseBody(start Position) (Value, error) {
+	tok := p.peek()
+	switch {
+	case tok.Kind == TokenRBracket || tok.Kind == TokenEOF:
+		return &String{Start: start}, nil
+	case p.atArgument():
+		return p.parseEntries()
+	}
+
+	first, err := p.parseItem()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().Kind != TokenComma {
+		return first, nil
+	}
+	list := &List{Start: first.Pos(), Items: []Value{first}}
+	for p.peek().Kind == TokenComma {
+		p.next()
+		item, err := p.parseItem()
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list, nil
+}
+
+// atArgument reports whether the next tokens are a key immediately
+// followed by '['
+func (p *parser) atArgument() bool {
+	tok := p.peek()
+	if tok.Kind != TokenWord || p.i+1 >= len(p.tokens) {
+		return false
+	}
+	open := p.tokens[p.i+1]
+	return open.Kind == TokenLBracket && open.Pos.Offset == tok.End.Offset
+}
+
+func (p *parser) parseEntries() (Value, error) {
+	m := &Map{Start: p.peek().Pos}
+	seen := make(map[string]bool)
+	for {
+		entry, err := p.parseArgument()
+		if err != nil {
+			return nil, err
+		}
+		if seen[entry.Key] {
+			return nil, errorf(entry.Start, "duplicate key %q", entry.Key)
+		}
+		seen[entry.Key] = true
+		m.Entries = append(m.Entries, entry)
+
+		if p.peek().Kind == TokenComma {
+			p.next()
+		}
+		switch tok := p.peek(); {
+		case tok.Kind == TokenRBracket || tok.Kind == TokenEOF:
+			return m, nil
+		case !p.atArgument():
+			return nil, errorf(tok.Pos, "expected key[value], got %s", tok.describe())
+		}
+	}
+}
+
+func (p *parser) parseItem() (Value, error) {
+	tok := p.peek()
+	switch tok.Kind {
+	case TokenLBracket:
+		p.next()
+		p.depth++
+		value, err := p.parseBody(tok.End)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(TokenRBracket, "to close '['"); err != nil {
+			return nil, err
+		}
+		p.depth--
+		return value, nil
+	case TokenVariable:
+		p.next()
+		return newVariable(tok), nil
+	case TokenWord, TokenString:
+	default:
+		return nil, errorf(tok.Pos, "expected value, got %s", tok.describe())
+	}
+
+	scalars := []Token{p.next()}
+	for {
+		tok := p.peek()
+		if tok.Kind != TokenWord && tok.Kind != TokenString {
+			break
+		}
+		if p.atArgument() {
+			return nil, errorf(tok.Pos, "unexpected argument %q in value", tok.Value)
+		}
+		scalars = append(scalars, p.next())
+	}
+
+	if len(scalars) == 1 && scalars[0].Kind == TokenWord {
+		if literal := classify(scalars[0].Value); literal != nil {
+			setPos(literal, scalars[0].Pos)
+			return literal, nil
+		}
+	}
+	var b strings.Builder
+	for i, tok := range scalars {
+		if i > 0 {
+			b.WriteString(p.src[scalars[i-1].End.Offset:tok.Pos.Offset])
+		}
+		b.WriteString(tok.Value)
+	}
+	return &String{Start: scalars[0].Pos, Quoted: scalars[0].Kind == TokenString, Value: b.String()}, nil
+}
+
+func setPos(v Value, pos Position) {
+	switch v := v.(type) {
+	case *Bool:
+		v.Start = pos
+	case *Number:
+		v.Start = pos
+	case *Duration:
+		v.Start = pos
+	}
+}
+
+// IsCommandName reports whether name is a valid, optionally namespaced,
+// command name such as status or quantum.entangle
+func IsCommandName(name string) bool {
+	return isName(name)
+}
+
+// isName reports whether s is a dot-separated sequence of identifiers
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+	start := true
+	for _, r := range s {
+		switch {
+		case r == '.':
+			if start {
+				return false
+			}
+			start = true
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			start = false
+		case (r >= '0' && r <= '9') || r == '-':
+			if start {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return !start
+}
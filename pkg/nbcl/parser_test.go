@@ -0,0 +1,145 @@
+package nbcl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		src  string
+		name string
+		args map[string]interface{}
+	}{
+		{"/status", "status", map[string]interface{}{}},
+		{"/manifest reality[omega_prime]", "manifest", map[string]interface{}{"reality": "omega_prime"}},
+		{"/verify irreducibility[TRUE]", "verify", map[string]interface{}{"irreducibility": true}},
+		{"/logos note[multi word  value]", "logos", map[string]interface{}{"note": "multi word  value"}},
+		{`/logos note["quoted ] value"]`, "logos", map[string]interface{}{"note": "quoted ] value"}},
+		{`/logos note[a\]b]`, "logos", map[string]interface{}{"note": "a]b"}},
+		{"/tune gain[-1.5] every[1m30s] empty[]", "tune", map[string]interface{}{
+			"gain":  -1.5,
+			"every": 90 * time.Second,
+			"empty": "",
+		}},
+		{"/deploy targets[a, b c, 3]", "deploy", map[string]interface{}{
+			"targets": []interface{}{"a", "b c", 3.0},
+		}},
+		{"/deploy matrix[[1, 2], [3]]", "deploy", map[string]interface{}{
+			"matrix": []interface{}{[]interface{}{1.0, 2.0}, 3.0},
+		}},
+		{"/manifest config[reality[omega_prime] limits[cpu[8], memory[\"2 GiB\"]]]", "manifest", map[string]interface{}{
+			"config": map[string]interface{}{
+				"reality": "omega_prime",
+				"limits":  map[string]interface{}{"cpu": 8.0, "memory": "2 GiB"},
+			},
+		}},
+		{"/quantum.entangle pair[q0, q1]", "quantum.entangle", map[string]interface{}{
+			"pair": []interface{}{"q0", "q1"},
+		}},
+		{"\n/manifest config[\n  reality[omega_prime]\n  mode[fast]\n]\n", "manifest", map[string]interface{}{
+			"config": map[string]interface{}{"reality": "omega_prime", "mode": "fast"},
+		}},
+	}
+
+	for _, tt := range tests {
+		cmd, err := ParseCommand(tt.src)
+		if err != nil {
+			t.Errorf("Failed to parse %q: %v", tt.src, err)
+			continue
+		}
+		if cmd.Name != tt.name {
+			t.Errorf("Expected command %s, got %s", tt.name, cmd.Name)
+		}
+		if args := cmd.Arguments(); !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("Expected arguments %v for %q, got %v", tt.args, tt.src, args)
+		}
+	}
+}
+
+func TestParseCommandLongValue(t *testing.T) {
+	words := strings.Repeat("a ", 100000) + "\"b c\""
+	cmd, err := ParseCommand("/logos note[" + words + "]")
+	if err != nil {
+		t.Fatalf("Failed to parse long value: %v", err)
+	}
+	want := strings.Repeat("a ", 100000) + "b c"
+	if got := cmd.Arguments()["note"]; got != want {
+		t.Errorf("Expected a value of %d bytes, got %d", len(want), len(fmt.Sprint(got)))
+	}
+}
+
+func TestParseCommandErrors(t *testing.T) {
+	tests := []struct {
+		src          string
+		line, column int
+	}{
+		{"status", 1, 1},
+		{"/", 1, 1},
+		{"/bad..name", 1, 1},
+		{"/verify stray", 1, 14},
+		{"/verify key [value]", 1, 12},
+		{"/verify key[value", 1, 18},
+		{"/verify key[a] key[b]", 1, 16},
+		{"/manifest config[\n  reality[x]\n  stray\n]", 3, 3},
+		{"/manifest config[a[1] a[2]]", 1, 23},
+		{"/status\n/help", 2, 1},
+		{"/verify key[a b[c]]", 1, 15},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseCommand(tt.src)
+		var syntaxErr *Error
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Expected syntax error for %q, got %v", tt.src, err)
+			continue
+		}
+		if syntaxErr.Pos.Line != tt.line || syntaxErr.Pos.Column != tt.column {
+			t.Errorf("Expected error at %d:%d for %q, got %v", tt.line, tt.column, tt.src, err)
+		}
+	}
+}
+
+func TestCommandStringRoundTrip(t *testing.T) {
+	srcs := []string{
+		"/status",
+		"/manifest reality[omega_prime]",
+		`/logos note["multi word \"value\""] gain[0.5] every[30s] ok[true]`,
+		"/deploy targets[a, b, c] config[mode[fast] limits[cpu[8]]]",
+		`/logos note["true"] count["42"] empty[""]`,
+	}
+
+	for _, src := range srcs {
+		cmd, err := ParseCommand(src)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", src, err)
+		}
+		if got := cmd.String(); got != src {
+			t.Errorf("Expected %q to render unchanged, got %q", src, got)
+		}
+		again, err := ParseCommand(cmd.String())
+		if err != nil {
+			t.Fatalf("Failed to reparse %q: %v", cmd.String(), err)
+		}
+		if !reflect.DeepEqual(again.Arguments(), cmd.Arguments()) {
+			t.Errorf("Expected %q to reparse to the same arguments", src)
+		}
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	v, err := ParseValue("a, b")
+	if err != nil {
+		t.Fatalf("Failed to parse value: %v", err)
+	}
+	if _, ok := v.(*List); !ok {
+		t.Errorf("Expected list, got %T", v)
+	}
+	if v.Pos().Column != 1 {
+		t.Errorf("Expected list at column 1, got %s", v.Pos())
+	}
+}
@@ -0,0 +1,266 @@
+package nbcl
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Statement is a top-level element of a script
+type Statement interface {
+	Node
+	String() string
+	statement()
+}
+
+// Script is a parsed NBCL script
+type Script struct {
+	Statements []Statement `json:"statements"`
+}
+
+// String renders the script in canonical form, one statement per line
+func (s *Script) String() string {
+	var b strings.Builder
+	writeBlock(&b, s.Statements, "")
+	return b.String()
+}
+
+func writeBlock(b *strings.Builder, statements []Statement, indent string) {
+	for _, stmt := range statements {
+		if cond, ok := stmt.(*If); ok {
+			b.WriteString(indent + "if " + cond.Condition.String() + "\n")
+			writeBlock(b, cond.Then, indent+"    ")
+			if len(cond.Else) > 0 {
+				b.WriteString(indent + "else\n")
+				writeBlock(b, cond.Else, indent+"    ")
+			}
+			b.WriteString(indent + "end\n")
+			continue
+		}
+		b.WriteString(indent + stmt.String() + "\n")
+	}
+}
+
+// Pipeline is one or more commands separated by |, each receiving the
+// result of the previous one. A single command is a pipeline of one.
+type Pipeline struct {
+	Start    Position   `json:"pos"`
+	Commands []*Command `json:"commands"`
+}
+
+// Pos returns the position of the first command
+func (p *Pipeline) Pos() Position { return p.Start }
+
+// String renders the commands separated by |
+func (p *Pipeline) String() string {
+	commands := make([]string, len(p.Commands))
+	for i, cmd := range p.Commands {
+		commands[i] = cmd.String()
+	}
+	return strings.Join(commands, " | ")
+}
+
+func (p *Pipeline) statement() {}
+
+// Assignment binds the result of a pipeline to a variable
+type Assignment struct {
+	Start    Position  `json:"pos"`
+	Name     string    `json:"name"`
+	Pipeline *Pipeline `json:"pipeline"`
+}
+
+// Pos returns the position of the variable
+func (a *Assignment) Pos() Position { return a.Start }
+
+// String renders the assignment as $name = pipeline
+func (a *Assignment) String() string {
+	return "$" + a.Name + " = " + a.Pipeline.String()
+}
+
+func (a *Assignment) statement() {}
+
+// If runs Then when its condition holds and Else otherwise
+type If struct {
+	Start     Position    `json:"pos"`
+	Condition *Condition  `json:"condition"`
+	Then      []Statement `json:"then"`
+	Else      []Statement `json:"else,omitempty"`
+}
+
+// Pos returns the position of the if keyword
+func (i *If) Pos() Position { return i.Start }
+
+// String renders the whole if block
+func (i *If) String() string {
+	var b strings.Builder
+	writeBlock(&b, []Statement{i}, "")
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (i *If) statement() {}
+
+// Condition compares two operands with ==, !=, <, <=, > or >=. A condition
+// without an operator tests whether Left is truthy.
+type Condition struct {
+	Start Position `json:"pos"`
+	Left  Value    `json:"left"`
+	Op    string   `json:"op,omitempty"`
+	Right Value    `json:"right,omitempty"`
+}
+
+// Pos returns the position of the left operand
+func (c *Condition) Pos() Position { return c.Start }
+
+// String renders the condition
+func (c *Condition) String() string {
+	if c.Op == "" {
+		return c.Left.String()
+	}
+	return c.Left.String() + " " + c.Op + " " + c.Right.String()
+}
+
+// ParseScript parses a multi-line NBCL script
+func ParseScript(src string) (*Script, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := p.parseBlock(false)
+	if err != nil {
+		// Trailing whitespace and newlines are insignificant, so an error
+		// past the last of the source means it ended too early
+		if syntaxErr, ok := err.(*Error); ok && syntaxErr.Pos.Offset >= len(strings.TrimRightFunc(src, unicode.IsSpace)) {
+			syntaxErr.incomplete = true
+		}
+		return nil, err
+	}
+	return &Script{Statements: statements}, nil
+}
+
+// parseBlock parses statements up to the end of input or, inside an if
+// block, up to the else or end keyword that closes it
+func (p *parser) parseBlock(nested bool) ([]Statement, error) {
+	statements := make([]Statement, 0)
+	for {
+		p.skipNewlines()
+		tok := p.peek()
+		switch {
+		case tok.Kind == TokenEOF:
+			if nested {
+				return nil, errorf(tok.Pos, "expected end to close if block")
+			}
+			return statements, nil
+		case tok.Kind == TokenWord && (tok.Value == "else" || tok.Value == "end"):
+			if !nested {
+				return nil, errorf(tok.Pos, "unexpected %s outside if block", tok.Value)
+			}
+			return statements, nil
+		}
+
+		stmt, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmt)
+
+		if tok := p.peek(); tok.Kind != TokenNewline && tok.Kind != TokenEOF {
+			return nil, errorf(tok.Pos, "unexpected %s after statement", tok.describe())
+		}
+	}
+}
+
+func (p *parser) parseStatement() (Statement, error) {
+	tok := p.peek()
+	switch {
+	case tok.Kind == TokenVariable:
+		p.next()
+		if strings.Contains(tok.Value, ".") {
+			return nil, errorf(tok.Pos, "cannot assign to field path $%s", tok.Value)
+		}
+		if _, err := p.expect(TokenAssign, "after $"+tok.Value); err != nil {
+			return nil, err
+		}
+		pipeline, err := p.parsePipeline()
+		if err != nil {
+			return nil, err
+		}
+		return &Assignment{Start: tok.Pos, Name: tok.Value, Pipeline: pipeline}, nil
+	case tok.Kind == TokenWord && tok.Value == "if":
+		return p.parseIf()
+	case tok.Kind == TokenWord && strings.HasPrefix(tok.Value, "/"):
+		return p.parsePipeline()
+	default:
+		return nil, errorf(tok.Pos, "expected command, assignment or if, got %s", tok.describe())
+	}
+}
+
+func (p *parser) parsePipeline() (*Pipeline, error) {
+	pipeline := &Pipeline{Start: p.peek().Pos}
+	for {
+		cmd, err := p.parseCommand()
+		if err != nil {
+			return nil, err
+		}
+		pipeline.Commands = append(pipeline.Commands, cmd)
+		if p.peek().Kind != TokenPipe {
+			return pipeline, nil
+		}
+		p.next()
+		p.skipNewlines()
+	}
+}
+
+func (p *parser) parseIf() (*If, error) {
+	start := p.next().Pos
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	cond := &Condition{Start: left.Pos(), Left: left}
+	if tok := p.peek(); tok.Kind == TokenOperator {
+		p.next()
+		cond.Op = tok.Value
+		if cond.Right, err = p.parseOperand(); err != nil {
+			return nil, err
+		}
+	}
+	if tok := p.peek(); tok.Kind != TokenNewline {
+		return nil, errorf(tok.Pos, "expected newline after if condition, got %s", tok.describe())
+	}
+
+	stmt := &If{Start: start, Condition: cond}
+	if stmt.Then, err = p.parseBlock(true); err != nil {
+		return nil, err
+	}
+	if p.next().Value == "else" {
+		if stmt.Else, err = p.parseBlock(true); err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.Value != "end" {
+			return nil, errorf(tok.Pos, "expected end to close if block, got %s", tok.describe())
+		}
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseOperand() (Value, error) {
+	tok := p.next()
+	switch tok.Kind {
+	case TokenVariable:
+		return newVariable(tok), nil
+	case TokenString:
+		return &String{Start: tok.Pos, Value: tok.Value, Quoted: true}, nil
+	case TokenWord:
+		if literal := classify(tok.Value); literal != nil {
+			setPos(literal, tok.Pos)
+			return literal, nil
+		}
+		return &String{Start: tok.Pos, Value: tok.Value}, nil
+	default:
+		return nil, errorf(tok.Pos, "expected operand, got %s", tok.describe())
+	}
+}
+
+func newVariable(tok Token) *Variable {
+	parts := strings.Split(tok.Value, ".")
+	return &Variable{Start: tok.Pos, Name: parts[0], Path: parts[1:]}
+}
@@ -0,0 +1,151 @@
+package nbcl
+
+import (
+	"errors"
+	"testing"
+)
+
+const testScript = `# nightly attestation
+$dag = /attest note[nightly]   # bind the result
+$status = /status
+
+if $status.coherence >= 0.9
+    /logos weave[omega_prime] parent[$dag.golden_dag]
+else
+    /verify irreducibility[true]
+end
+
+/attest |
+    /manifest reality[omega_prime] source[$_.golden_dag]
+`
+
+func TestParseScript(t *testing.T) {
+	script, err := ParseScript(testScript)
+	if err != nil {
+		t.Fatalf("Failed to parse script: %v", err)
+	}
+	if len(script.Statements) != 4 {
+		t.Fatalf("Expected 4 statements, got %d", len(script.Statements))
+	}
+
+	assign, ok := script.Statements[0].(*Assignment)
+	if !ok || assign.Name != "dag" || assign.Pipeline.Commands[0].Name != "attest" {
+		t.Errorf("Expected $dag = /attest, got %s", script.Statements[0])
+	}
+
+	cond, ok := script.Statements[2].(*If)
+	if !ok {
+		t.Fatalf("Expected if statement, got %T", script.Statements[2])
+	}
+	if cond.Start.Line != 5 {
+		t.Errorf("Expected if on line 5, got %s", cond.Start)
+	}
+	if cond.Condition.Op != ">=" || cond.Condition.Right.Interface() != 0.9 {
+		t.Errorf("Expected condition >= 0.9, got %s", cond.Condition)
+	}
+	left, ok := cond.Condition.Left.(*Variable)
+	if !ok || left.Name != "status" || len(left.Path) != 1 || left.Path[0] != "coherence" {
+		t.Errorf("Expected $status.coherence, got %s", cond.Condition.Left)
+	}
+	if len(cond.Then) != 1 || len(cond.Else) != 1 {
+		t.Errorf("Expected one statement per branch, got %d and %d", len(cond.Then), len(cond.Else))
+	}
+
+	parent := cond.Then[0].(*Pipeline).Commands[0].Arg("parent")
+	if v, ok := parent.Value.(*Variable); !ok || v.String() != "$dag.golden_dag" {
+		t.Errorf("Expected parent[$dag.golden_dag], got %s", parent)
+	}
+
+	pipeline, ok := script.Statements[3].(*Pipeline)
+	if !ok || len(pipeline.Commands) != 2 {
+		t.Fatalf("Expected two-stage pipeline, got %s", script.Statements[3])
+	}
+}
+
+func TestScriptStringRoundTrip(t *testing.T) {
+	script, err := ParseScript(testScript)
+	if err != nil {
+		t.Fatalf("Failed to parse script: %v", err)
+	}
+	again, err := ParseScript(script.String())
+	if err != nil {
+		t.Fatalf("Failed to reparse %q: %v", script.String(), err)
+	}
+	if again.String() != script.String() {
+		t.Errorf("Expected stable rendering, got %q and %q", script.String(), again.String())
+	}
+}
+
+func TestResolveArguments(t *testing.T) {
+	cmd, err := ParseCommand("/logos parent[$dag.golden_dag] all[$a, $b]")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	args, err := cmd.ResolveArguments(func(v *Variable) (interface{}, error) {
+		return v.Name + "!", nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if args["parent"] != "dag!" {
+		t.Errorf("Expected dag!, got %v", args["parent"])
+	}
+	if all := args["all"].([]interface{}); all[0] != "a!" || all[1] != "b!" {
+		t.Errorf("Expected [a! b!], got %v", all)
+	}
+}
+
+func TestParseScriptErrors(t *testing.T) {
+	tests := []struct {
+		src          string
+		line, column int
+	}{
+		{"/status\nstatus", 2, 1},
+		{"if $x\n/status\n", 3, 1},
+		{"/status\nend", 2, 1},
+		{"$x.y = /status", 1, 1},
+		{"$x /status", 1, 4},
+		{"if $x == \n/status\nend", 1, 10},
+		{"/status | ", 1, 11},
+		{"/status $x", 1, 9},
+		{"/status\n$1x = /help", 2, 1},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseScript(tt.src)
+		var syntaxErr *Error
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Expected syntax error for %q, got %v", tt.src, err)
+			continue
+		}
+		if syntaxErr.Pos.Line != tt.line || syntaxErr.Pos.Column != tt.column {
+			t.Errorf("Expected error at %d:%d for %q, got %v", tt.line, tt.column, tt.src, err)
+		}
+	}
+}
+
+func TestParseScriptIncomplete(t *testing.T) {
+	tests := []struct {
+		src        string
+		incomplete bool
+	}{
+		{"if $x\n/status\n", true},
+		{"if $x\n/status\nelse\n", true},
+		{"/status | ", true},
+		{"/manifest reality[omega_prime,\n", true},
+		{"/status\nend", false},
+		{"if $x == \n/status\nend", false},
+		{"/status $x", false},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseScript(tt.src)
+		if err == nil {
+			t.Fatalf("Expected syntax error for %q", tt.src)
+		}
+		if IsIncomplete(err) != tt.incomplete {
+			t.Errorf("Expected IsIncomplete %v for %q, got %v", tt.incomplete, tt.src, err)
+		}
+	}
+}
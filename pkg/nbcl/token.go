@@ -0,0 +1,144 @@
+// Package nbcl implements the lexer, abstract syntax tree and parser of the
+// NeuralBlitz Command Language.
+//
+// A command is a slash-prefixed, optionally namespaced name followed by
+// key[value] arguments:
+//
+//	/manifest reality[omega_prime]
+//	/logos weave[omega_prime] note["multi word, with \"quotes\""]
+//	/deploy targets[a, b, c] config[mode[fast] timeout[30s] retries[3]]
+//
+// Values are bare words, quoted strings, numbers, booleans, durations,
+// comma-separated lists, nested key[value] maps or $variable references.
+// The tokenizer and AST are exported so editors and linters can reuse them.
+//
+// Scripts are sequences of commands, one per line, with # comments,
+// variable bindings, pipelines and conditionals:
+//
+//	# attest, then weave only if the dyad is still coherent
+//	$dag = /attest note[nightly]
+//	$status = /status
+//	if $status.coherence >= 0.9
+//	    /logos weave[omega_prime]
+//	else
+//	    /verify irreducibility[true]
+//	end
+//	/manifest reality[omega_prime] | /attest manifested[$_.golden_dag] nightly[$dag.golden_dag]
+package nbcl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error definitions
+var (
+	ErrSyntax = errors.New("nbcl: syntax error")
+)
+
+// Position is a location in NBCL source. Line and Column are 1-based and
+// Column counts runes.
+type Position struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// String renders the position as line:column
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Error is a syntax error at a position in the source
+type Error struct {
+	Pos Position
+	Msg string
+	// incomplete is set when the error is at the end of the input
+	incomplete bool
+}
+
+// Error returns the message prefixed with the line and column
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// Unwrap allows errors.Is(err, ErrSyntax)
+func (e *Error) Unwrap() error {
+	return ErrSyntax
+}
+
+// IsIncomplete reports whether err is a syntax error at the end of the
+// input, e.g. an if block without its end or a trailing |, which more
+// input could fix
+func IsIncomplete(err error) bool {
+	var syntaxErr *Error
+	return errors.As(err, &syntaxErr) && syntaxErr.incomplete
+}
+
+func errorf(pos Position, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// TokenKind identifies the kind of a Token
+type TokenKind int
+
+// Token kinds
+const (
+	TokenEOF TokenKind = iota
+	TokenNewline
+	TokenWord
+	TokenString
+	TokenLBracket
+	TokenRBracket
+	TokenComma
+	TokenVariable
+	TokenPipe
+	TokenAssign
+	TokenOperator
+	TokenComment
+)
+
+var tokenNames = map[TokenKind]string{
+	TokenEOF:      "end of input",
+	TokenNewline:  "newline",
+	TokenWord:     "word",
+	TokenString:   "string",
+	TokenLBracket: "'['",
+	TokenRBracket: "']'",
+	TokenComma:    "','",
+	TokenVariable: "variable",
+	TokenPipe:     "'|'",
+	TokenAssign:   "'='",
+	TokenOperator: "operator",
+	TokenComment:  "comment",
+}
+
+// String returns a readable name for the token kind
+func (k TokenKind) String() string {
+	if name, ok := tokenNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("token(%d)", int(k))
+}
+
+// Token is a lexical token. Text is the raw source of the token and Value
+// its content with quotes removed and escapes resolved.
+type Token struct {
+	Kind  TokenKind `json:"kind"`
+	Text  string    `json:"text"`
+	Value string    `json:"value"`
+	Pos   Position  `json:"pos"`
+	End   Position  `json:"end"`
+}
+
+// describe renders the token for error messages
+func (t Token) describe() string {
+	switch t.Kind {
+	case TokenWord, TokenString, TokenOperator:
+		return fmt.Sprintf("%s %q", t.Kind, t.Value)
+	case TokenVariable:
+		return "variable $" + t.Value
+	default:
+		return t.Kind.String()
+	}
+}
@@ -0,0 +1,266 @@
+// Package openapi models OpenAPI 3 documents and generates their schemas
+// from the Go types handlers encode, so a published document cannot drift
+// from the JSON actually served.
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Version is the OpenAPI version of the documents this package produces
+const Version = "3.0.3"
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps lower-case HTTP methods to the operations of one path
+type PathItem map[string]*Operation
+
+// Operation is one method on one path. Scope and RateGroup are extensions
+// naming the credential scope the operation requires and the rate limit
+// group it is budgeted under.
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Scope       string                `json:"x-scope,omitempty"`
+	RateGroup   string                `json:"x-rate-limit-group,omitempty"`
+	// Subsystem is the optional subsystem whose routes include the
+	// operation
+	Subsystem string `json:"x-subsystem,omitempty"`
+}
+
+// Parameter is a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the body an operation accepts
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// MediaType is the schema of one content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Response is one response an operation may return
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Components holds the named schemas and the security schemes
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes one way to authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Schema is a JSON schema. AdditionalProperties is a *Schema for maps and
+// true for objects that may carry undeclared properties.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+}
+
+// RefPrefix prefixes references to component schemas
+const RefPrefix = "#/components/schemas/"
+
+// Resolve returns the component schema s refers to, or s itself
+func (d *Document) Resolve(s *Schema) *Schema {
+	if s == nil || s.Ref == "" {
+		return s
+	}
+	return d.Components.Schemas[strings.TrimPrefix(s.Ref, RefPrefix)]
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	durationType  = reflect.TypeOf(time.Duration(0))
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Registry generates schemas from Go types. Named struct types become
+// component schemas referenced by name.
+type Registry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// Schema returns the schema of a value encoded as a response: every field
+// without omitempty is required
+func (r *Registry) Schema(v interface{}) *Schema {
+	return r.schema(reflect.TypeOf(v), false)
+}
+
+// RequestSchema returns the schema of a value decoded from a request: only
+// fields tagged binding:"required" are required
+func (r *Registry) RequestSchema(v interface{}) *Schema {
+	return r.schema(reflect.TypeOf(v), true)
+}
+
+// Components returns the component schemas generated so far
+func (r *Registry) Components() map[string]*Schema {
+	return r.schemas
+}
+
+func (r *Registry) schema(t reflect.Type, request bool) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t, nullable = t.Elem(), true
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time", Nullable: nullable}
+	case t == durationType:
+		return &Schema{Type: "integer", Format: "int64", Description: "nanoseconds", Nullable: nullable}
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		// Custom encodings are not described
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean", Nullable: nullable}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32", Nullable: nullable}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Nullable: nullable}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float", Nullable: nullable}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double", Nullable: nullable}
+	case reflect.String:
+		return &Schema{Type: "string", Nullable: nullable}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schema(t.Elem(), request)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schema(t.Elem(), request)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.object(t, request)
+		}
+		return &Schema{Ref: RefPrefix + r.component(t, request)}
+	}
+	// Interfaces may hold any value
+	return &Schema{}
+}
+
+// component registers the schema of a named struct type and returns its
+// component name. Types of different packages sharing a name are qualified
+// with their package.
+func (r *Registry) component(t reflect.Type, request bool) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := r.schemas[name]; taken {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+	r.names[t] = name
+	// Reserve the name before descending so recursive types terminate
+	r.schemas[name] = &Schema{}
+	*r.schemas[name] = *r.object(t, request)
+	return name
+}
+
+// object describes a struct the way encoding/json encodes it: exported
+// fields under their json names, with untagged embedded structs flattened
+func (r *Registry) object(t reflect.Type, request bool) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.fields(s, t, request, true)
+	return s
+}
+
+func (r *Registry) fields(s *Schema, t reflect.Type, request, required bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded, embeddedRequired := field.Type, required
+			if embedded.Kind() == reflect.Pointer {
+				// Fields of a nil embedded pointer are not encoded
+				embedded, embeddedRequired = embedded.Elem(), false
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.fields(s, embedded, request, embeddedRequired)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = r.schema(field.Type, request)
+		isRequired := !strings.Contains(opts, "omitempty")
+		if request {
+			isRequired = strings.Contains(field.Tag.Get("binding"), "required")
+		}
+		if required && isRequired {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package openapi
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+type section struct {
+	Detail string `json:"detail"`
+}
+
+type node struct {
+	Name     string            `json:"name"`
+	Note     string            `json:"note,omitempty"`
+	At       time.Time         `json:"at"`
+	Children []*node           `json:"children"`
+	Labels   map[string]string `json:"labels"`
+	Hidden   string            `json:"-"`
+	*section
+}
+
+type request struct {
+	Name  string   `json:"name" binding:"required"`
+	Limit *float64 `json:"limit"`
+}
+
+func TestRegistrySchema(t *testing.T) {
+	r := NewRegistry()
+	ref := r.Schema(node{})
+	if ref.Ref != RefPrefix+"node" {
+		t.Fatalf("Expected a reference to node, got %+v", ref)
+	}
+
+	s := r.Components()["node"]
+	for _, name := range []string{"name", "note", "at", "children", "labels", "detail"} {
+		if s.Properties[name] == nil {
+			t.Errorf("Expected property %s", name)
+		}
+	}
+	if s.Properties["Hidden"] != nil || s.Properties["-"] != nil {
+		t.Error("Expected json:\"-\" fields to be skipped")
+	}
+	if !slices.Equal(s.Required, []string{"name", "at", "children", "labels"}) {
+		t.Errorf("Expected fields without omitempty outside nil embeds to be required, got %v", s.Required)
+	}
+	if s.Properties["at"].Format != "date-time" {
+		t.Errorf("Expected time as date-time, got %+v", s.Properties["at"])
+	}
+	if s.Properties["children"].Items.Ref != RefPrefix+"node" {
+		t.Errorf("Expected recursive reference, got %+v", s.Properties["children"].Items)
+	}
+
+	req := r.Components()[r.RequestSchema(request{}).Ref[len(RefPrefix):]]
+	if !slices.Equal(req.Required, []string{"name"}) || !req.Properties["limit"].Nullable {
+		t.Errorf("Expected only binding:\"required\" fields required and pointers nullable, got %+v", req)
+	}
+}
@@ -0,0 +1,60 @@
+package opencode
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"neuralblitz/pkg/telemetry"
+)
+
+var (
+	tasksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(telemetry.Namespace, "opencode", "tasks_total"),
+		"Tasks executed, by result.", []string{"result"}, nil)
+	tasksSubmittedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(telemetry.Namespace, "opencode", "tasks_submitted_total"),
+		"Tasks submitted to the queue.", nil, nil)
+	messagesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(telemetry.Namespace, "opencode", "messages_total"),
+		"Messages added to contexts.", nil, nil)
+	taskDurationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(telemetry.Namespace, "opencode", "average_task_duration_seconds"),
+		"Average execution time of the executed tasks.", nil, nil)
+	agentsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(telemetry.Namespace, "opencode", "agents"),
+		"Registered agents.", nil, nil)
+	contextsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(telemetry.Namespace, "opencode", "contexts"),
+		"Open contexts.", nil, nil)
+)
+
+// StatisticsCollector exports the statistics of an integration, read when
+// scraped
+type StatisticsCollector struct {
+	oci *OpenCodeIntegration
+}
+
+// NewStatisticsCollector creates a collector for oci's statistics
+func NewStatisticsCollector(oci *OpenCodeIntegration) *StatisticsCollector {
+	return &StatisticsCollector{oci: oci}
+}
+
+// Describe implements prometheus.Collector
+func (c *StatisticsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tasksDesc
+	ch <- tasksSubmittedDesc
+	ch <- messagesDesc
+	ch <- taskDurationDesc
+	ch <- agentsDesc
+	ch <- contextsDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *StatisticsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.oci.GetStatistics()
+	ch <- prometheus.MustNewConstMetric(tasksDesc, prometheus.CounterValue, float64(stats.SuccessfulTasks), "success")
+	ch <- prometheus.MustNewConstMetric(tasksDesc, prometheus.CounterValue, float64(stats.FailedTasks), "failed")
+	ch <- prometheus.MustNewConstMetric(tasksSubmittedDesc, prometheus.CounterValue, float64(stats.TotalTasks))
+	ch <- prometheus.MustNewConstMetric(messagesDesc, prometheus.CounterValue, float64(stats.TotalMessages))
+	ch <- prometheus.MustNewConstMetric(taskDurationDesc, prometheus.GaugeValue, stats.AverageTaskDuration/1000)
+	ch <- prometheus.MustNewConstMetric(agentsDesc, prometheus.GaugeValue, float64(stats.ActiveAgents))
+	ch <- prometheus.MustNewConstMetric(contextsDesc, prometheus.GaugeValue, float64(stats.ActiveContexts))
+}
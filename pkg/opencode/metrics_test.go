@@ -0,0 +1,53 @@
+package opencode
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"neuralblitz/pkg/telemetry"
+)
+
+func TestAPIExportsTaskStatistics(t *testing.T) {
+	oci := NewOpenCodeIntegration(nil)
+	if _, err := oci.RegisterAgent("agent-1", "coder", []string{"analysis"}); err != nil {
+		t.Fatalf("Failed to register agent: %v", err)
+	}
+	if err := oci.SetTelemetry(telemetry.NewMetrics()); err != nil {
+		t.Fatalf("Failed to set telemetry: %v", err)
+	}
+	tp := telemetry.NewTracerProvider(nil, "test")
+	oci.SetTracerProvider(tp)
+	api := httptest.NewServer(oci.APIHandler())
+	defer api.Close()
+
+	resp, err := http.Post(api.URL+"/api/v1/tasks/execute", "application/json",
+		strings.NewReader(`{"request_id":"r1","agent_id":"agent-1","task_type":"analysis"}`))
+	if err != nil {
+		t.Fatalf("Failed to execute task: %v", err)
+	}
+	resp.Body.Close()
+	if resp.Header.Get("traceparent") == "" {
+		t.Errorf("Expected a traceparent response header")
+	}
+
+	resp, err = http.Get(api.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("Failed to scrape: %v", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	body := string(data)
+	for _, want := range []string{
+		`neuralblitz_opencode_tasks_total{result="success"} 1`,
+		`neuralblitz_opencode_tasks_total{result="failed"} 0`,
+		"neuralblitz_opencode_agents 1",
+		`route="/api/v1/tasks/execute",status="200"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+}
@@ -15,17 +15,29 @@ Key Features:
 - State synchronization
 */
 
-package main
+package opencode
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"neuralblitz/pkg/httpserver"
+	"neuralblitz/pkg/logging"
+	"neuralblitz/pkg/telemetry"
 )
 
+// TracerScope is the instrumentation scope of the task spans
+const TracerScope = "neuralblitz/pkg/opencode"
+
 // AgentMessage represents a message between agents
 type AgentMessage struct {
 	MessageID   string                 `json:"message_id"`
@@ -169,7 +181,17 @@ type OpenCodeIntegration struct {
 	mu sync.RWMutex
 	
 	// Statistics
-	Statistics *IntegrationStats `json:"statistics"`
+	Statistics *IntegrationStatistics `json:"statistics"`
+
+	// apiServer is set once StartAPI runs
+	apiServer *httpserver.Server
+
+	// telemetry, if set, is served at /metrics by the API
+	telemetry *telemetry.Metrics
+	// tracer starts the spans of API requests; nil uses the global one
+	tracer trace.TracerProvider
+	// logger writes as the "opencode" subsystem
+	logger *slog.Logger
 }
 
 // OpenCodeConfig contains configuration for OpenCode integration
@@ -183,6 +205,26 @@ type OpenCodeConfig struct {
 	ContextTTL    time.Duration `json:"context_ttl"`
 	EnableMetrics bool `json:"enable_metrics"`
 	DebugMode     bool `json:"debug_mode"`
+	// Listener overrides the API listener; nil serves APIPort with the
+	// default timeouts
+	Listener *httpserver.Config `json:"listener,omitempty"`
+	// Logger receives the integration's records; nil keeps it silent
+	Logger *slog.Logger `json:"-"`
+}
+
+// DefaultOpenCodeConfig returns default configuration
+func DefaultOpenCodeConfig() *OpenCodeConfig {
+	return &OpenCodeConfig{
+		AgentEndpoint:     "http://localhost:9000",
+		APIPort:           9001,
+		MaxAgents:         10,
+		MaxTasks:          50,
+		TaskTimeout:       5 * time.Minute,
+		HeartbeatInterval: 30 * time.Second,
+		ContextTTL:        24 * time.Hour,
+		EnableMetrics:     true,
+		DebugMode:         false,
+	}
 }
 
 // IntegrationStatistics contains integration statistics
@@ -203,17 +245,7 @@ type ToolFunction func(params map[string]interface{}) (*ToolResult, error)
 // NewOpenCodeIntegration creates a new OpenCode integration layer
 func NewOpenCodeIntegration(config *OpenCodeConfig) *OpenCodeIntegration {
 	if config == nil {
-		config = &OpenCodeConfig{
-			AgentEndpoint: "http://localhost:9000",
-			APIPort: 9001,
-			MaxAgents: 10,
-			MaxTasks: 50,
-			TaskTimeout: 5 * time.Minute,
-			HeartbeatInterval: 30 * time.Second,
-			ContextTTL: 24 * time.Hour,
-			EnableMetrics: true,
-			DebugMode: false,
-		}
+		config = DefaultOpenCodeConfig()
 	}
 
 	oci := &OpenCodeIntegration{
@@ -229,6 +261,7 @@ func NewOpenCodeIntegration(config *OpenCodeConfig) *OpenCodeIntegration {
 		Statistics: &IntegrationStatistics{
 			Uptime: 0,
 		},
+		logger: logging.Subsystem(config.Logger, "opencode"),
 	}
 
 	// Initialize default tools
@@ -387,6 +420,45 @@ func (oci *OpenCodeIntegration) SubmitTask(request *TaskRequest) error {
 
 // ExecuteTask executes a task directly (for testing/synchronous use)
 func (oci *OpenCodeIntegration) ExecuteTask(request *TaskRequest) (*TaskResult, error) {
+	return oci.ExecuteTaskContext(context.Background(), request)
+}
+
+// ExecuteTaskContext executes a task in an "opencode.execute_task" span
+// under the span in ctx
+func (oci *OpenCodeIntegration) ExecuteTaskContext(ctx context.Context, request *TaskRequest) (*TaskResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, TracerScope, "opencode.execute_task",
+		attribute.String("opencode.task_type", request.TaskType),
+		attribute.String("opencode.agent_id", request.AgentID),
+		attribute.String("opencode.request_id", request.RequestID),
+	)
+	defer span.End()
+
+	result := oci.executeTask(request)
+	span.SetAttributes(
+		attribute.String("opencode.status", result.Status),
+		attribute.Float64("opencode.execution_time_ms", result.Metrics.ExecutionTime),
+	)
+	attrs := []any{
+		"request_id", request.RequestID,
+		"agent_id", request.AgentID,
+		"task_type", request.TaskType,
+		"duration_ms", result.Metrics.ExecutionTime,
+	}
+	if sc := span.SpanContext(); sc.HasTraceID() {
+		attrs = append(attrs, logging.TraceID(sc.TraceID().String()))
+	}
+	if result.Status != "success" {
+		span.SetStatus(codes.Error, result.Error)
+		oci.logger.WarnContext(ctx, "task failed", append(attrs, "error", result.Error)...)
+	} else {
+		oci.logger.DebugContext(ctx, "task executed", attrs...)
+	}
+	return result, nil
+}
+
+// executeTask runs a task on its agent and records the outcome in the
+// agent's and the integration's statistics
+func (oci *OpenCodeIntegration) executeTask(request *TaskRequest) *TaskResult {
 	startTime := time.Now()
 
 	result := &TaskResult{
@@ -398,16 +470,19 @@ func (oci *OpenCodeIntegration) ExecuteTask(request *TaskRequest) (*TaskResult,
 	}
 
 	// Update agent state
+	oci.mu.Lock()
 	agent, exists := oci.Agents[request.AgentID]
 	if !exists {
+		oci.mu.Unlock()
 		result.Status = "failed"
 		result.Error = "agent not found"
 		result.CompletedAt = time.Now()
-		return result, nil
+		return result
 	}
 
 	agent.CurrentTask = request
 	agent.LastActive = time.Now()
+	oci.mu.Unlock()
 
 	// Execute based on task type
 	switch request.TaskType {
@@ -426,6 +501,8 @@ func (oci *OpenCodeIntegration) ExecuteTask(request *TaskRequest) (*TaskResult,
 	}
 
 	// Update agent state
+	oci.mu.Lock()
+	defer oci.mu.Unlock()
 	agent.CurrentTask = nil
 	agent.LastActive = time.Now()
 	agent.TaskHistory = append(agent.TaskHistory, result)
@@ -451,8 +528,10 @@ func (oci *OpenCodeIntegration) ExecuteTask(request *TaskRequest) (*TaskResult,
 	} else {
 		oci.Statistics.FailedTasks++
 	}
+	completed := oci.Statistics.SuccessfulTasks + oci.Statistics.FailedTasks
+	oci.Statistics.AverageTaskDuration += (result.Metrics.ExecutionTime - oci.Statistics.AverageTaskDuration) / float64(completed)
 
-	return result, nil
+	return result
 }
 
 // executeToolTask executes a tool-based task
@@ -841,7 +920,7 @@ func (oci *OpenCodeIntegration) healthMonitor() {
 
 	for range ticker.C {
 		oci.mu.Lock()
-		for agentID, agent := range oci.Agents {
+		for _, agent := range oci.Agents {
 			// Check if agent is stale
 			if time.Since(agent.LastActive) > oci.Config.HeartbeatInterval*3 {
 				agent.Status = "stale"
@@ -935,6 +1014,7 @@ func (oci *OpenCodeIntegration) toolCompileCode(params map[string]interface{}) (
 		Success: true,
 		Output: map[string]interface{}{
 			"language": language,
+			"source_bytes": len(source),
 			"compiled": true,
 			"output_path": "/tmp/compiled",
 		},
@@ -1002,6 +1082,7 @@ func (oci *OpenCodeIntegration) toolAnalyzeCode(params map[string]interface{}) (
 	return &ToolResult{
 		Success: true,
 		Output: map[string]interface{}{
+			"code_bytes": len(code),
 			"complexity": 7.5,
 			"issues": 3,
 			"suggestions": []string{"Add error handling", "Optimize imports", "Add comments"},
@@ -1018,6 +1099,7 @@ func (oci *OpenCodeIntegration) toolGenerateTests(params map[string]interface{})
 		Success: true,
 		Output: map[string]interface{}{
 			"language": language,
+			"code_bytes": len(code),
 			"tests_generated": 10,
 			"coverage": 85.5,
 			"test_file": "/path/to/test.go",
@@ -1067,8 +1149,33 @@ func (oci *OpenCodeIntegration) toolNBDiagnose(params map[string]interface{}) (*
 
 // HTTP API handlers
 
-// APIHandler returns an HTTP handler for the API
+// SetTelemetry exports the integration's task statistics to m and serves
+// m at /metrics on the API
+func (oci *OpenCodeIntegration) SetTelemetry(m *telemetry.Metrics) error {
+	if err := m.Register(NewStatisticsCollector(oci)); err != nil {
+		return err
+	}
+	oci.mu.Lock()
+	defer oci.mu.Unlock()
+	oci.telemetry = m
+	return nil
+}
+
+// SetTracerProvider sets the provider the API starts its request spans
+// with
+func (oci *OpenCodeIntegration) SetTracerProvider(tp trace.TracerProvider) {
+	oci.mu.Lock()
+	defer oci.mu.Unlock()
+	oci.tracer = tp
+}
+
+// APIHandler returns an HTTP handler for the API. Every request runs in a
+// span continuing the caller's traceparent.
 func (oci *OpenCodeIntegration) APIHandler() http.Handler {
+	oci.mu.RLock()
+	metrics, tracer := oci.telemetry, oci.tracer
+	oci.mu.RUnlock()
+
 	mux := http.NewServeMux()
 
 	// Agent endpoints
@@ -1097,7 +1204,12 @@ func (oci *OpenCodeIntegration) APIHandler() http.Handler {
 	// Health
 	mux.HandleFunc("/api/v1/health", oci.handleHealth)
 
-	return mux
+	// Prometheus metrics
+	if metrics != nil {
+		mux.Handle("/metrics", metrics.Handler())
+	}
+
+	return telemetry.Handler(mux, metrics, tracer)
 }
 
 func (oci *OpenCodeIntegration) handleRegisterAgent(w http.ResponseWriter, r *http.Request) {
@@ -1167,17 +1279,17 @@ func (oci *OpenCodeIntegration) handleSubmitTask(w http.ResponseWriter, r *http.
 		return
 	}
 
-	w.WriteHeader(http.Status (oci *OpenAccepted)
+	w.WriteHeader(http.StatusAccepted)
 }
 
-funcCodeIntegration) handleExecuteTask(w http.ResponseWriter, r *http.Request) {
+func (oci *OpenCodeIntegration) handleExecuteTask(w http.ResponseWriter, r *http.Request) {
 	var task TaskRequest
 	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	result, err := oci.ExecuteTask(&task)
+	result, err := oci.ExecuteTaskContext(r.Context(), &task)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1289,16 +1401,37 @@ func (oci *OpenCodeIntegration) handleHealth(w http.ResponseWriter, r *http.Requ
 	})
 }
 
-// StartAPI starts the HTTP API server
-func (oci *OpenCodeIntegration) StartAPI() error {
-	addr := fmt.Sprintf(":%d", oci.Config.APIPort)
-	server := &http.Server{
-		Addr: addr,
-		Handler: oci.APIHandler(),
+// StartAPI serves the HTTP API until ctx is cancelled or ShutdownAPI is
+// called, then drains in-flight requests
+func (oci *OpenCodeIntegration) StartAPI(ctx context.Context) error {
+	config := httpserver.DefaultConfig(fmt.Sprintf(":%d", oci.Config.APIPort))
+	if oci.Config.Listener != nil {
+		config = *oci.Config.Listener
+	}
+	server := httpserver.New(oci.APIHandler(), config)
+
+	oci.mu.Lock()
+	if oci.apiServer != nil {
+		oci.mu.Unlock()
+		return httpserver.ErrStarted
 	}
+	oci.apiServer = server
+	oci.mu.Unlock()
+
+	oci.logger.Info("API server starting", "listener", config.String())
+	return server.Start(ctx)
+}
 
-	fmt.Printf("🌐 OpenCode API server starting on %s\n", addr)
-	return server.ListenAndServe()
+// ShutdownAPI stops the HTTP API and waits for in-flight requests until
+// ctx ends
+func (oci *OpenCodeIntegration) ShutdownAPI(ctx context.Context) error {
+	oci.mu.RLock()
+	server := oci.apiServer
+	oci.mu.RUnlock()
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
 }
 
 // Integration with io.Reader for streaming
@@ -0,0 +1,106 @@
+package options
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"neuralblitz/pkg/consciousness"
+)
+
+// consciousnessLevels lists the lower-case names the consciousness
+// namespace accepts for levels, in level order
+var consciousnessLevels = func() []string {
+	var levels []string
+	for l := consciousness.ConsciousnessLevelIndividual; l <= consciousness.ConsciousnessLevelAbsolute; l++ {
+		levels = append(levels, strings.ToLower(l.String()))
+	}
+	return levels
+}()
+
+// RegisterConsciousnessCommands exposes a consciousness integration through
+// the consciousness NBCL namespace. Commands act on the integration's
+// existing fields and never create new ones.
+func RegisterConsciousnessCommands(n *NBCLInterpreter, ci *consciousness.ConsciousnessIntegration) error {
+	// field picks the first field at the command's level by ID
+	field := func(cmd *NBCLCommand) (string, error) {
+		level := cmd.Arguments["level"].(string)
+		ids := ci.FieldIDs(consciousness.ConsciousnessLevel(slices.Index(consciousnessLevels, level)))
+		if len(ids) == 0 {
+			return "", fmt.Errorf("%w at level %s", consciousness.ErrFieldNotFound, level)
+		}
+		return ids[0], nil
+	}
+	status := func(n *NBCLInterpreter, cmd *NBCLCommand, status string) *NBCLResult {
+		metrics := *ci.GetMetrics()
+
+		result := n.NewResult(cmd)
+		result.Status = status
+		result.Data = map[string]interface{}{
+			"state":               ci.GetState().String(),
+			"total_fields":        metrics.TotalFields,
+			"expanded_fields":     metrics.ExpandedFields,
+			"average_coherence":   metrics.AverageCoherence,
+			"transcendence_count": metrics.TranscendenceCount,
+			"unity_achieved":      metrics.UnityAchieved,
+		}
+		return result
+	}
+	levelArg := ArgSchema{Name: "level", Type: ArgString, Default: consciousnessLevels[0], Values: consciousnessLevels, Description: "Field level"}
+
+	commands := []struct {
+		name    string
+		handler NBCLHandler
+		schema  CommandSchema
+	}{
+		{"consciousness.expand", func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error) {
+			id, err := field(cmd)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := ci.ExpandField(id); err != nil {
+				return nil, err
+			}
+			result := status(n, cmd, "Expanded")
+			result.Data["field"] = id
+			return result, nil
+		}, CommandSchema{
+			Description: "Expand the first field of a level to the next level",
+			Args:        []ArgSchema{levelArg},
+		}},
+		{"consciousness.transcend", func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error) {
+			id, err := field(cmd)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := ci.TranscendField(id); err != nil {
+				return nil, err
+			}
+			result := status(n, cmd, "Transcended")
+			result.Data["field"] = id
+			return result, nil
+		}, CommandSchema{
+			Description: "Transcend the first field of a level",
+			Args:        []ArgSchema{levelArg},
+		}},
+		{"consciousness.unity", func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error) {
+			if err := ci.AchieveUnity(); err != nil {
+				return nil, err
+			}
+			return status(n, cmd, "Unity achieved"), nil
+		}, CommandSchema{
+			Description: "Unite the awakened fields",
+		}},
+		{"consciousness.status", func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error) {
+			return status(n, cmd, "Reported"), nil
+		}, CommandSchema{
+			Description: "Report the consciousness fields",
+		}},
+	}
+	for _, c := range commands {
+		if err := n.RegisterCommand(c.name, c.handler, c.schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package options
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// optionFile is a deployment option file: an option, or with Extends the
+// overrides of option A to F
+//
+//	extends: F
+//	name: NeuralBlitz-Edge-Gateway
+//	memory_mb: 512
+//	rate_limits:
+//	  groups:
+//	    read: {rate: 50, burst: 100}
+type optionFile struct {
+	Extends           string `json:"extends,omitempty" yaml:"extends,omitempty"`
+	*DeploymentOption `yaml:",inline"`
+}
+
+// isYAML reports whether a deployment option file is YAML rather than JSON
+func isYAML(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// attested sets the attestation hash of a built-in option
+func attested(opt *DeploymentOption) *DeploymentOption {
+	// Built-in options always serialize
+	opt.AttestationHash, _ = opt.Attest()
+	return opt
+}
+
+// Canonical returns the canonical form of the deployment: its compact JSON
+// without the attestation hash. Fields are in declaration order and rate
+// limit groups in name order, so equal options have equal forms.
+func (opt *DeploymentOption) Canonical() ([]byte, error) {
+	canonical := *opt
+	canonical.AttestationHash = ""
+	return json.Marshal(&canonical)
+}
+
+// Attest returns the attestation hash of the deployment, the SHA-256 of
+// its canonical form. The hash is an unkeyed checksum, not a signature:
+// it catches a file edited or damaged since it was saved, but anyone who
+// edits the option can recompute it, so it does not prove who wrote it.
+func (opt *DeploymentOption) Attest() (string, error) {
+	data, err := opt.Canonical()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyAttestation checks the attestation hash against the deployment.
+// A match shows the deployment is unchanged since it was hashed, not that
+// it comes from a trusted source; see Attest.
+func (opt *DeploymentOption) VerifyAttestation() error {
+	hash, err := opt.Attest()
+	if err != nil {
+		return err
+	}
+	if opt.AttestationHash != hash {
+		return fmt.Errorf("%w: recorded %q, computed %s", ErrAttestationFailed, opt.AttestationHash, hash)
+	}
+	return nil
+}
+
+// Validate checks the deployment: a name, Coherence in [0, 1], positive
+// memory and CPU cores, known subsystems and non-negative rate limits.
+// Every problem is reported.
+func (opt *DeploymentOption) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidOption}, args...)...))
+	}
+
+	if opt.Name == "" {
+		invalid("name is empty")
+	}
+	if math.IsNaN(opt.Coherence) || opt.Coherence < 0 || opt.Coherence > 1 {
+		invalid("coherence %g is not in [0, 1]", opt.Coherence)
+	}
+	if opt.MemoryMB <= 0 {
+		invalid("memory_mb %d is not positive", opt.MemoryMB)
+	}
+	if opt.CPUCores <= 0 {
+		invalid("cpu_cores %d is not positive", opt.CPUCores)
+	}
+	if opt.MaxInFlight < 0 {
+		invalid("max_in_flight %d is negative", opt.MaxInFlight)
+	}
+	if err := opt.CheckSubsystems(); err != nil {
+		errs = append(errs, err)
+	}
+
+	checkLimit := func(key string, limit RateLimit) {
+		if math.IsNaN(limit.Rate) || math.IsInf(limit.Rate, 0) || limit.Rate < 0 || limit.Burst < 0 {
+			invalid("rate_limits.%s %s is negative", key, limit)
+		}
+	}
+	checkLimit("per_ip", opt.RateLimits.PerIP)
+	for _, group := range opt.RateLimits.groupNames() {
+		checkLimit("groups."+group, opt.RateLimits.Group(group))
+	}
+	return errors.Join(errs...)
+}
+
+// LoadDeploymentOption reads a deployment option from a YAML (.yaml, .yml)
+// or JSON file. With extends the file overrides the fields it sets of a
+// built-in option; lists replace the option's and rate limit groups are
+// merged. Unknown fields are rejected and the option is validated. When
+// the file records an attestation hash it must match the option, else
+// the hash is computed.
+func LoadDeploymentOption(path string) (*DeploymentOption, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	opt, err := decodeDeploymentOption(data, isYAML(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return opt, nil
+}
+
+// decodeDeploymentOption decodes, resolves and checks an option file
+func decodeDeploymentOption(data []byte, isYAML bool) (*DeploymentOption, error) {
+	decode := func(out interface{}, strict bool) error {
+		if isYAML {
+			decoder := yaml.NewDecoder(bytes.NewReader(data))
+			decoder.KnownFields(strict)
+			if err := decoder.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+			return nil
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		if strict {
+			decoder.DisallowUnknownFields()
+		}
+		return decoder.Decode(out)
+	}
+
+	var header struct {
+		Extends string `json:"extends" yaml:"extends"`
+	}
+	if err := decode(&header, false); err != nil {
+		return nil, err
+	}
+	file := optionFile{DeploymentOption: &DeploymentOption{}}
+	if header.Extends != "" {
+		base, err := Option(header.Extends)
+		if err != nil {
+			return nil, fmt.Errorf("extends: %w", err)
+		}
+		// The base's hash does not attest the overridden option
+		base.AttestationHash = ""
+		file.DeploymentOption = base
+	}
+	if err := decode(&file, true); err != nil {
+		return nil, err
+	}
+
+	opt := file.DeploymentOption
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	if opt.AttestationHash != "" {
+		if err := opt.VerifyAttestation(); err != nil {
+			return nil, err
+		}
+		return opt, nil
+	}
+	hash, err := opt.Attest()
+	if err != nil {
+		return nil, err
+	}
+	opt.AttestationHash = hash
+	return opt, nil
+}
+
+// SaveToFile saves the deployment to a YAML (.yaml, .yml) or JSON file,
+// with its attestation hash, so that LoadDeploymentOption can verify it
+func (opt *DeploymentOption) SaveToFile(filename string) error {
+	attested := *opt
+	hash, err := opt.Attest()
+	if err != nil {
+		return err
+	}
+	attested.AttestationHash = hash
+
+	var buf bytes.Buffer
+	if isYAML(filename) {
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&attested); err != nil {
+			return err
+		}
+		if err := encoder.Close(); err != nil {
+			return err
+		}
+	} else {
+		encoder := json.NewEncoder(&buf)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(&attested); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0o644)
+}
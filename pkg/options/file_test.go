@@ -0,0 +1,125 @@
+package options
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeOption(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDeploymentOptionRoundTrip(t *testing.T) {
+	for _, id := range OptionIDs {
+		for _, name := range []string{"option.json", "option.yaml"} {
+			opt, _ := Option(id)
+			path := filepath.Join(t.TempDir(), name)
+			if err := opt.SaveToFile(path); err != nil {
+				t.Fatalf("Failed to save option %s: %v", id, err)
+			}
+			loaded, err := LoadDeploymentOption(path)
+			if err != nil {
+				t.Fatalf("Failed to load option %s from %s: %v", id, name, err)
+			}
+			if !reflect.DeepEqual(loaded, opt) {
+				t.Errorf("Expected option %s to round-trip through %s, got %+v", id, name, loaded)
+			}
+		}
+	}
+}
+
+func TestAttestationIsCanonical(t *testing.T) {
+	a, b := OptionF(), OptionF()
+	if a.AttestationHash == "" || a.AttestationHash != b.AttestationHash {
+		t.Fatalf("Expected equal options to attest equally, got %q and %q", a.AttestationHash, b.AttestationHash)
+	}
+	if err := a.VerifyAttestation(); err != nil {
+		t.Errorf("Expected option F to verify, got %v", err)
+	}
+	if OptionE().AttestationHash == a.AttestationHash {
+		t.Error("Expected different options to attest differently")
+	}
+
+	b.Coherence = 0.5
+	if err := b.VerifyAttestation(); !errors.Is(err, ErrAttestationFailed) {
+		t.Errorf("Expected ErrAttestationFailed after a change, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "f.yaml")
+	if err := a.SaveToFile(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	tampered := strings.Replace(string(data), "memory_mb: 200", "memory_mb: 4000", 1)
+	if _, err := LoadDeploymentOption(writeOption(t, "f.yaml", tampered)); !errors.Is(err, ErrAttestationFailed) {
+		t.Errorf("Expected a tampered file to fail attestation, got %v", err)
+	}
+}
+
+func TestLoadDeploymentOptionExtends(t *testing.T) {
+	path := writeOption(t, "edge.yaml", `extends: f
+name: NeuralBlitz-Edge-Gateway
+memory_mb: 512
+subsystems: [reality]
+rate_limits:
+  groups:
+    read: {rate: 50, burst: 100}
+`)
+	opt, err := LoadDeploymentOption(path)
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+
+	base := OptionF()
+	if opt.Name != "NeuralBlitz-Edge-Gateway" || opt.MemoryMB != 512 || !reflect.DeepEqual(opt.Subsystems, []string{SubsystemReality}) {
+		t.Errorf("Expected the overrides applied, got %+v", opt)
+	}
+	if opt.CPUCores != base.CPUCores || opt.Coherence != base.Coherence || opt.Description != base.Description {
+		t.Errorf("Expected the fields of option F kept, got %+v", opt)
+	}
+	if read := opt.RateLimits.Group(RateGroupRead); read.Rate != 50 || read.Burst != 100 {
+		t.Errorf("Expected the read budget overridden, got %s", read)
+	}
+	if opt.RateLimits.Group(RateGroupNBCL) != base.RateLimits.Group(RateGroupNBCL) {
+		t.Errorf("Expected the other budgets kept, got %s", opt.RateLimits.Group(RateGroupNBCL))
+	}
+	if opt.AttestationHash == base.AttestationHash || opt.VerifyAttestation() != nil {
+		t.Errorf("Expected the option attested anew, got %s", opt.AttestationHash)
+	}
+}
+
+func TestLoadDeploymentOptionErrors(t *testing.T) {
+	tests := []struct {
+		name, contents string
+		want           []string
+	}{
+		{"unknown.yaml", "extends: F\nmemory: 10\n", []string{"field memory not found"}},
+		{"unknown.json", `{"extends": "F", "cpu": 2}`, []string{`unknown field "cpu"`}},
+		{"extends.yaml", "extends: G\n", []string{ErrUnknownOption.Error()}},
+		{"invalid.yaml", "extends: A\ncoherence: 1.5\ncpu_cores: 0\nmemory_mb: -1\nsubsystems: [telepathy]\n", []string{
+			"coherence 1.5 is not in [0, 1]",
+			"cpu_cores 0 is not positive",
+			"memory_mb -1 is not positive",
+			ErrUnknownSubsystem.Error(),
+		}},
+		{"empty.json", `{"name": "bare"}`, []string{"memory_mb 0 is not positive"}},
+	}
+
+	for _, tt := range tests {
+		_, err := LoadDeploymentOption(writeOption(t, tt.name, tt.contents))
+		for _, want := range tt.want {
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: expected error %q, got %v", tt.name, want, err)
+			}
+		}
+	}
+}
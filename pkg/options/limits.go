@@ -0,0 +1,76 @@
+package options
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Route groups a deployment budgets separately
+const (
+	RateGroupRead   = "read"
+	RateGroupIntent = "intent"
+	RateGroupVerify = "verify"
+	RateGroupNBCL   = "nbcl"
+)
+
+// RateLimit is a token bucket budget: Burst requests at once, refilled at
+// Rate requests per second. A zero Rate means unlimited.
+type RateLimit struct {
+	Rate  float64 `json:"rate" yaml:"rate"`
+	Burst int     `json:"burst" yaml:"burst"`
+}
+
+// Unlimited reports whether the budget imposes no limit
+func (l RateLimit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+// String renders the budget, e.g. 5/s (burst 10)
+func (l RateLimit) String() string {
+	if l.Unlimited() {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%g/s (burst %d)", l.Rate, l.Burst)
+}
+
+// RateLimits are the request budgets of a deployment
+type RateLimits struct {
+	// PerIP budgets every request from one IP address
+	PerIP RateLimit `json:"per_ip" yaml:"per_ip"`
+	// Groups budgets each route group per client: per authenticated
+	// principal, or per IP address for anonymous requests
+	Groups map[string]RateLimit `json:"groups" yaml:"groups"`
+}
+
+// Group returns the budget of a route group; groups without one are
+// unlimited
+func (l RateLimits) Group(name string) RateLimit {
+	return l.Groups[name]
+}
+
+// groupNames returns the budgeted groups in name order
+func (l RateLimits) groupNames() []string {
+	names := make([]string, 0, len(l.Groups))
+	for name := range l.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newRateLimits builds RateLimits with the same per-second rate and a burst
+// of twice the rate for the per-IP budget and each group
+func newRateLimits(perIP, read, intent, verify, nbcl float64) RateLimits {
+	limit := func(rate float64) RateLimit {
+		return RateLimit{Rate: rate, Burst: int(2 * rate)}
+	}
+	return RateLimits{
+		PerIP: limit(perIP),
+		Groups: map[string]RateLimit{
+			RateGroupRead:   limit(read),
+			RateGroupIntent: limit(intent),
+			RateGroupVerify: limit(verify),
+			RateGroupNBCL:   limit(nbcl),
+		},
+	}
+}
@@ -1,35 +1,81 @@
 package options
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
 	"runtime"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"neuralblitz/pkg/core"
+	"neuralblitz/pkg/goldendag"
+	"neuralblitz/pkg/nbcl"
+	"neuralblitz/pkg/quantum"
+	"neuralblitz/pkg/rng"
+	"neuralblitz/pkg/telemetry"
+	"neuralblitz/pkg/utils"
 )
 
-// DeploymentOption represents a specific deployment configuration
+// TracerScope is the instrumentation scope of the NBCL execution spans
+const TracerScope = "neuralblitz/pkg/options"
+
+// HistorySize is the number of commands an interpreter's history retains
+const HistorySize = 1024
+
+// Error definitions
+var (
+	ErrUnknownCommand     = errors.New("unknown command")
+	ErrDuplicateCommand   = errors.New("command already registered")
+	ErrInvalidCommandName = errors.New("invalid command name")
+	ErrMissingArgument    = errors.New("missing required argument")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrUndefinedVariable  = errors.New("undefined variable")
+	ErrNoSuchField        = errors.New("no such field")
+	ErrIncomparable       = errors.New("values are not comparable")
+	ErrUnknownOption      = errors.New("unknown deployment option")
+	ErrUnknownSubsystem   = errors.New("unknown subsystem")
+	ErrInvalidOption      = errors.New("invalid deployment option")
+	ErrAttestationFailed  = errors.New("attestation hash does not match")
+)
+
+// DeploymentOption represents a specific deployment configuration. It
+// round-trips through JSON and YAML; see LoadDeploymentOption.
 type DeploymentOption struct {
-	Name            string
-	Version         string
-	MemoryMB        int64
-	CPUCores        int
-	Features        []string
-	Description     string
-	Coherence       float64
-	UseChaosMode    bool
-	RealityState    string
-	AttestationHash string
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version" yaml:"version"`
+	// MemoryMB bounds the heap and CPUCores the threads running Go code of
+	// a server running the deployment, and MaxInFlight the requests it
+	// serves at once, unlimited when zero
+	MemoryMB    int64 `json:"memory_mb" yaml:"memory_mb"`
+	CPUCores    int   `json:"cpu_cores" yaml:"cpu_cores"`
+	MaxInFlight int   `json:"max_in_flight" yaml:"max_in_flight"`
+	// Subsystems are the optional subsystems the deployment initializes,
+	// e.g. SubsystemQuantum
+	Subsystems   []string `json:"subsystems,omitempty" yaml:"subsystems,omitempty"`
+	Features     []string `json:"features,omitempty" yaml:"features,omitempty"`
+	Description  string   `json:"description" yaml:"description"`
+	Coherence    float64  `json:"coherence" yaml:"coherence"`
+	UseChaosMode bool     `json:"use_chaos_mode" yaml:"use_chaos_mode"`
+	RealityState string   `json:"reality_state" yaml:"reality_state"`
+	// AttestationHash is the checksum of the canonical form; see Attest
+	AttestationHash string `json:"attestation_hash,omitempty" yaml:"attestation_hash,omitempty"`
+	// RateLimits are the API request budgets of the deployment
+	RateLimits RateLimits `json:"rate_limits" yaml:"rate_limits"`
 }
 
 // OptionA returns the minimal symbiotic interface configuration
 func OptionA() *DeploymentOption {
-	return &DeploymentOption{
-		Name:            "NeuralBlitz-Symbiotic-Interface",
-		Version:         "v50.0.0",
-		MemoryMB:        50,
-		CPUCores:        1,
+	return attested(&DeploymentOption{
+		Name:        "NeuralBlitz-Symbiotic-Interface",
+		Version:     "v50.0.0",
+		MemoryMB:    50,
+		CPUCores:    1,
+		MaxInFlight: 64,
 		Features: []string{
 			"Minimal Source/Architect interface",
 			"ASCII output only",
@@ -40,20 +86,19 @@ func OptionA() *DeploymentOption {
 		Coherence:    0.85,
 		UseChaosMode: false,
 		RealityState: "Axiomatic Structure Homology",
-		AttestationHash: func() string {
-			dag := core.NewGoldenDAG("minimal-interface")
-			return dag.Hash
-		}(),
-	}
+		RateLimits:   newRateLimits(10, 5, 1, 1, 1),
+	})
 }
 
 // OptionB returns the full cosmic symbiosis node configuration
 func OptionB() *DeploymentOption {
-	return &DeploymentOption{
-		Name:            "NeuralBlitz-Cosmic-Symbiosis-Node",
-		Version:         "v50.0.0",
-		MemoryMB:        2400,
-		CPUCores:        16,
+	return attested(&DeploymentOption{
+		Name:        "NeuralBlitz-Cosmic-Symbiosis-Node",
+		Version:     "v50.0.0",
+		MemoryMB:    2400,
+		CPUCores:    16,
+		MaxInFlight: 4096,
+		Subsystems:  []string{SubsystemConsciousness, SubsystemReality, SubsystemQuantum, SubsystemOpenCode},
 		Features: []string{
 			"Full irreducible source field",
 			"Multi-entity symbiosis",
@@ -66,20 +111,19 @@ func OptionB() *DeploymentOption {
 		Coherence:    0.999999,
 		UseChaosMode: false,
 		RealityState: "Omega Prime Reality",
-		AttestationHash: func() string {
-			dag := core.NewGoldenDAG("cosmic-symbiosis-node")
-			return dag.Hash
-		}(),
-	}
+		RateLimits:   newRateLimits(500, 200, 50, 50, 50),
+	})
 }
 
 // OptionC returns the Omega Prime Reality kernel configuration
 func OptionC() *DeploymentOption {
-	return &DeploymentOption{
-		Name:            "NeuralBlitz-Omega-Prime-Kernel",
-		Version:         "v50.0.0",
-		MemoryMB:        847,
-		CPUCores:        8,
+	return attested(&DeploymentOption{
+		Name:        "NeuralBlitz-Omega-Prime-Kernel",
+		Version:     "v50.0.0",
+		MemoryMB:    847,
+		CPUCores:    8,
+		MaxInFlight: 512,
+		Subsystems:  []string{SubsystemConsciousness, SubsystemReality},
 		Features: []string{
 			"Omega Prime Reality kernel",
 			"Unified ground field",
@@ -91,20 +135,19 @@ func OptionC() *DeploymentOption {
 		Coherence:    0.98,
 		UseChaosMode: false,
 		RealityState: "Omega Prime Reality Kernel",
-		AttestationHash: func() string {
-			dag := core.NewGoldenDAG("omega-prime-kernel")
-			return dag.Hash
-		}(),
-	}
+		RateLimits:   newRateLimits(100, 50, 20, 10, 10),
+	})
 }
 
 // OptionD returns the universal verifier configuration
 func OptionD() *DeploymentOption {
-	return &DeploymentOption{
-		Name:            "NeuralBlitz-Universal-Verifier",
-		Version:         "v50.0.0",
-		MemoryMB:        128,
-		CPUCores:        2,
+	return attested(&DeploymentOption{
+		Name:        "NeuralBlitz-Universal-Verifier",
+		Version:     "v50.0.0",
+		MemoryMB:    128,
+		CPUCores:    2,
+		MaxInFlight: 128,
+		Subsystems:  []string{SubsystemReality},
 		Features: []string{
 			"Ontological homology mapping",
 			"Universal instance registration",
@@ -115,20 +158,19 @@ func OptionD() *DeploymentOption {
 		Coherence:    0.95,
 		UseChaosMode: false,
 		RealityState: "Universal Verification",
-		AttestationHash: func() string {
-			dag := core.NewGoldenDAG("universal-verifier")
-			return dag.Hash
-		}(),
-	}
+		RateLimits:   newRateLimits(100, 50, 1, 50, 5),
+	})
 }
 
 // OptionE returns the NBCL interpreter CLI configuration
 func OptionE() *DeploymentOption {
-	return &DeploymentOption{
-		Name:            "NeuralBlitz-NBCL-Interpreter",
-		Version:         "v50.0.0",
-		MemoryMB:        75,
-		CPUCores:        1,
+	return attested(&DeploymentOption{
+		Name:        "NeuralBlitz-NBCL-Interpreter",
+		Version:     "v50.0.0",
+		MemoryMB:    75,
+		CPUCores:    1,
+		MaxInFlight: 128,
+		Subsystems:  []string{SubsystemQuantum},
 		Features: []string{
 			"NBCL command interpreter",
 			"DSL execution",
@@ -139,20 +181,19 @@ func OptionE() *DeploymentOption {
 		Coherence:    0.92,
 		UseChaosMode: false,
 		RealityState: "NBCL Interpreter",
-		AttestationHash: func() string {
-			dag := core.NewGoldenDAG("nbcl-interpreter")
-			return dag.Hash
-		}(),
-	}
+		RateLimits:   newRateLimits(50, 20, 5, 5, 25),
+	})
 }
 
 // OptionF returns the API gateway server configuration
 func OptionF() *DeploymentOption {
-	return &DeploymentOption{
-		Name:            "NeuralBlitz-API-Gateway",
-		Version:         "v50.0.0",
-		MemoryMB:        200,
-		CPUCores:        4,
+	return attested(&DeploymentOption{
+		Name:        "NeuralBlitz-API-Gateway",
+		Version:     "v50.0.0",
+		MemoryMB:    200,
+		CPUCores:    4,
+		MaxInFlight: 1024,
+		Subsystems:  []string{SubsystemConsciousness, SubsystemReality, SubsystemQuantum},
 		Features: []string{
 			"REST API gateway",
 			"Gin-based server",
@@ -165,20 +206,33 @@ func OptionF() *DeploymentOption {
 		Coherence:    0.97,
 		UseChaosMode: false,
 		RealityState: "API Gateway",
-		AttestationHash: func() string {
-			dag := core.NewGoldenDAG("api-gateway")
-			return dag.Hash
-		}(),
-	}
+		RateLimits:   newRateLimits(1000, 500, 100, 100, 100),
+	})
 }
 
 // NBCLInterpreter interprets NeuralBlitz Command Language commands
 type NBCLInterpreter struct {
 	engine      *core.SelfActualizationEngine
 	dyad        *core.ArchitectSystemDyad
-	coherence   float64
-	history     []NBCLCommand
 	realityMode string
+	rand        *rng.Source
+	ids         *utils.IDRegistry
+
+	// verified is the latest attestation whose ledger chain verified, so
+	// /attest re-derives only the nodes appended since; verifyMu guards it
+	// and keeps attestations in order
+	verified string
+	verifyMu sync.Mutex
+
+	// mu guards the coherence, history, variables and commands, so one
+	// interpreter can serve concurrent callers such as the API server's
+	mu        sync.RWMutex
+	coherence float64
+	history   []NBCLCommand
+	variables map[string]*NBCLResult
+	commands  map[string]*registeredCommand
+	// commandOrder lists the commands in registration order for /help
+	commandOrder []string
 }
 
 // NBCLCommand represents a parsed NBCL command
@@ -187,173 +241,233 @@ type NBCLCommand struct {
 	Arguments map[string]interface{}
 	Timestamp time.Time
 	TraceID   string
+	// Input is the result piped into the command, or nil
+	Input *NBCLResult
 }
 
-// NewNBCLInterpreter creates a new NBCL interpreter
-func NewNBCLInterpreter(dyad *core.ArchitectSystemDyad) *NBCLInterpreter {
-	return &NBCLInterpreter{
-		engine:      core.NewSelfActualizationEngine(dyad),
+// NewNBCLInterpreter creates a new NBCL interpreter with the built-in
+// commands and the quantum namespace registered
+func NewNBCLInterpreter(dyad *core.ArchitectSystemDyad, opts ...rng.Option) *NBCLInterpreter {
+	engine := core.NewSelfActualizationEngine()
+	engine.SetLedger(dyad.Ledger())
+	engine.SetDyad(dyad)
+
+	n := &NBCLInterpreter{
+		engine:      engine,
 		dyad:        dyad,
 		coherence:   1.0,
 		history:     make([]NBCLCommand, 0),
 		realityMode: "omega_prime",
+		rand:        rng.Resolve(opts...),
+		ids:         utils.DefaultIDRegistry(),
+		variables:   make(map[string]*NBCLResult),
+		commands:    make(map[string]*registeredCommand),
 	}
+	n.registerBuiltins()
+	registerQuantumCommands(n, quantum.NewQuantumCommunicationLayer(8))
+	return n
+}
+
+// registerBuiltins registers the core NBCL commands
+func (n *NBCLInterpreter) registerBuiltins() {
+	builtins := []struct {
+		name    string
+		handler NBCLHandler
+		schema  CommandSchema
+	}{
+		{"manifest", (*NBCLInterpreter).handleManifest, CommandSchema{
+			Description: "Manifest Omega Prime Reality or check the current reality status",
+			Args: []ArgSchema{
+				{Name: "reality", Type: ArgString, Required: true, Values: []string{"omega_prime", "status"}},
+			},
+		}},
+		{"verify", (*NBCLInterpreter).handleVerify, CommandSchema{
+			Description: "Verify irreducible source status",
+			Args: []ArgSchema{
+				{Name: "irreducibility", Type: ArgBool, Required: true},
+			},
+		}},
+		{"logos", (*NBCLInterpreter).handleLogos, CommandSchema{
+			Description: "Weave the Omega Prime Reality",
+			Args: []ArgSchema{
+				{Name: "weave", Type: ArgString, Required: true, Values: []string{"omega_prime"}},
+			},
+		}},
+		{"attest", (*NBCLInterpreter).handleAttest, CommandSchema{
+			Description: "Execute Omega Attestation Protocol, recording any arguments given",
+			AllowExtra:  true,
+		}},
+		{"status", (*NBCLInterpreter).handleStatus, CommandSchema{
+			Description: "Check system status",
+		}},
+		{"help", (*NBCLInterpreter).handleHelp, CommandSchema{
+			Description: "Show help for all commands, one command or one namespace",
+			Args: []ArgSchema{
+				{Name: "command", Type: ArgString, Description: "Command name or namespace to describe"},
+			},
+		}},
+	}
+	for _, b := range builtins {
+		if err := n.RegisterCommand(b.name, b.handler, b.schema); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// SetIDRegistry replaces the registry that records the trace IDs issued for
+// each command
+func (n *NBCLInterpreter) SetIDRegistry(ids *utils.IDRegistry) {
+	n.ids = ids
+}
+
+// SetEngine replaces the engine /manifest actualizes through, e.g. to share
+// one with an API server
+func (n *NBCLInterpreter) SetEngine(engine *core.SelfActualizationEngine) {
+	n.engine = engine
 }
 
 // Interpret parses and executes an NBCL command
-func (n *NBCLInterpreter) Interpret(commandStr string) (map[string]interface{}, error) {
-	// Parse command
-	cmd, err := n.parseCommand(commandStr)
+func (n *NBCLInterpreter) Interpret(commandStr string) (*NBCLResult, error) {
+	return n.InterpretContext(context.Background(), commandStr)
+}
+
+// InterpretContext parses and executes an NBCL command in an
+// "nbcl.execute" span under the span in ctx
+func (n *NBCLInterpreter) InterpretContext(ctx context.Context, commandStr string) (*NBCLResult, error) {
+	syntax, err := nbcl.ParseCommand(commandStr)
 	if err != nil {
 		return nil, fmt.Errorf("parse error: %w", err)
 	}
+	return n.execute(ctx, syntax, nil)
+}
 
-	// Store in history
-	n.history = append(n.history, *cmd)
+// execute resolves the arguments of a parsed command against the bound
+// variables and runs it in an "nbcl.execute" span. input is the result
+// piped into the command, if any.
+func (n *NBCLInterpreter) execute(ctx context.Context, syntax *nbcl.Command, input *NBCLResult) (*NBCLResult, error) {
+	_, span := telemetry.StartSpan(ctx, TracerScope, "nbcl.execute", attribute.String("nbcl.command", syntax.Name))
+	defer span.End()
 
-	// Execute command
-	return n.executeCommand(cmd)
+	result, err := n.executeSyntax(ctx, syntax, input)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(
+		attribute.String("neuralblitz.trace_id", result.TraceID),
+		attribute.Float64("nbcl.coherence", result.Coherence),
+	)
+	return result, nil
 }
 
-// parseCommand parses an NBCL command string
-func (n *NBCLInterpreter) parseCommand(commandStr string) (*NBCLCommand, error) {
-	cmd := &NBCLCommand{
-		Timestamp: time.Now(),
-		TraceID:   fmt.Sprintf("T-v50.0-NBCL-%x", time.Now().UnixNano()),
+// executeSyntax validates a parsed command, records it in the history and
+// runs it. Commands that fail validation are neither recorded nor issued a
+// trace ID; the trace ID of one that runs is recorded under the trace ID of
+// the call issuing it in ctx, e.g. the API request interpreting it. The
+// interpreter's coherence follows the results of the commands it runs.
+func (n *NBCLInterpreter) executeSyntax(ctx context.Context, syntax *nbcl.Command, input *NBCLResult) (*NBCLResult, error) {
+	args, err := syntax.ResolveArguments(func(v *nbcl.Variable) (interface{}, error) {
+		return n.lookup(v, input)
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	// Simple parsing - extract command and arguments
-	// Format: /command key[value] key2[value2]
-	if len(commandStr) < 2 || commandStr[0] != '/' {
-		return nil, fmt.Errorf("invalid command format: must start with /")
+	command, args, err := n.validate(syntax.Name, args)
+	if err != nil {
+		return nil, err
 	}
 
-	// Remove leading slash
-	content := commandStr[1:]
-
-	// Extract command name (first word)
-	for i, char := range content {
-		if char == ' ' {
-			cmd.Command = content[:i]
-			content = content[i+1:]
-			break
-		}
-		if i == len(content)-1 {
-			cmd.Command = content
-			content = ""
-		}
+	cmd := &NBCLCommand{
+		Command:   syntax.Name,
+		Arguments: args,
+		Timestamp: n.rand.Now(),
+		Input:     input,
 	}
+	cmd.TraceID = n.ids.IssueTrace("NBCL", utils.IDIssuer{
+		Origin: utils.OriginNBCL,
+		Source: "/" + cmd.Command,
+		Parent: utils.IDIssuerFrom(ctx).Parent,
+	}).String()
+
+	// Store in history, keeping the latest HistorySize commands
+	n.mu.Lock()
+	n.history = append(n.history, *cmd)
+	if len(n.history) > HistorySize {
+		n.history = append(n.history[:0], n.history[len(n.history)-HistorySize:]...)
+	}
+	n.mu.Unlock()
 
-	// Parse arguments
-	cmd.Arguments = make(map[string]interface{})
-	if content != "" {
-		// Parse key[value] format
-		// This is a simplified parser
-		argMap := make(map[string]string)
-		current := ""
-		bracketCount := 0
-		lastKey := ""
-
-		for _, char := range content {
-			switch char {
-			case '[':
-				if bracketCount == 0 {
-					lastKey = current
-					current = ""
-				}
-				bracketCount++
-			case ']':
-				bracketCount--
-				if bracketCount == 0 && lastKey != "" {
-					argMap[lastKey] = current
-					current = ""
-					lastKey = ""
-				}
-			case ' ':
-				if bracketCount == 0 {
-					current = ""
-				} else {
-					current += string(char)
-				}
-			default:
-				current += string(char)
-			}
-		}
+	// Execute command
+	result, err := command.handler(n, cmd)
+	if err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	n.coherence = result.Coherence
+	n.mu.Unlock()
+	return result, nil
+}
 
-		// Convert to interface map
-		for k, v := range argMap {
-			switch v {
-			case "true", "TRUE":
-				cmd.Arguments[k] = true
-			case "false", "FALSE":
-				cmd.Arguments[k] = false
-			default:
-				// Try to parse as float
-				if f, err := fmt.Sprintf("%s", v).ParseFloat(v, 64); err == nil {
-					cmd.Arguments[k] = f
-				} else {
-					cmd.Arguments[k] = v
-				}
-			}
-		}
+// validate looks up a registered command and validates its arguments
+// against the command's schema
+func (n *NBCLInterpreter) validate(name string, args map[string]interface{}) (*registeredCommand, map[string]interface{}, error) {
+	n.mu.RLock()
+	command, ok := n.commands[name]
+	n.mu.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownCommand, name)
 	}
 
-	return cmd, nil
+	args, err := command.schema.Validate(args)
+	if err != nil {
+		return nil, nil, fmt.Errorf("/%s: %w", name, err)
+	}
+	return command, args, nil
 }
 
-// executeCommand executes a parsed NBCL command
-func (n *NBCLInterpreter) executeCommand(cmd *NBCLCommand) (map[string]interface{}, error) {
-	result := make(map[string]interface{})
-
-	switch cmd.Command {
-	case "manifest":
-		return n.handleManifest(cmd)
-	case "verify":
-		return n.handleVerify(cmd)
-	case "logos":
-		return n.handleLogos(cmd)
-	case "attest":
-		return n.handleAttest(cmd)
-	case "status":
-		return n.handleStatus(cmd)
-	case "help":
-		return n.handleHelp()
-	default:
-		return nil, fmt.Errorf("unknown command: %s", cmd.Command)
+// NewResult creates a result carrying the fields common to every command
+func (n *NBCLInterpreter) NewResult(cmd *NBCLCommand) *NBCLResult {
+	return &NBCLResult{
+		Command:   cmd.Command,
+		TraceID:   cmd.TraceID,
+		Timestamp: cmd.Timestamp,
+		Coherence: n.GetCoherence(),
 	}
-
-	return result, nil
 }
 
 // handleManifest handles /manifest commands
-func (n *NBCLInterpreter) handleManifest(cmd *NBCLCommand) (map[string]interface{}, error) {
-	result := make(map[string]interface{})
+func (n *NBCLInterpreter) handleManifest(cmd *NBCLCommand) (*NBCLResult, error) {
+	result := n.NewResult(cmd)
+	result.NBCLManifest = &NBCLManifest{
+		CurrentReality:      n.realityMode,
+		ArchitectSystemDyad: n.dyad.IsIrreducible(),
+		SourceState:         "Irreducible",
+	}
 
 	if target, ok := cmd.Arguments["reality"]; ok {
 		switch target {
 		case "omega_prime":
 			// Manifest Omega Prime Reality
-			state := core.NewSourceState(core.StateOmegaPrime)
-			n.engine.SelfActualize(state)
+			actualization := n.engine.Actualize(map[string]interface{}{
+				"reality":  "omega_prime",
+				"trace_id": cmd.TraceID,
+			})
 			
-			result["status"] = "Omega Prime Reality manifested"
-			result["coherence"] = 1.0
-			result["reality_state"] = "Irreducible Source Field"
-			result["singularity"] = "Actualized"
+			result.Status = "Omega Prime Reality manifested"
+			result.Coherence = actualization.Coherence
+			result.RealityState = "Irreducible Source Field"
+			result.Singularity = "Actualized"
 			
-			// Create attestation
-			dag := core.NewGoldenDAG("omega-prime-manifestation")
-			result["attestation"] = dag.Hash
+			// Attestation is the GoldenDAG entry recorded by the actualization
+			result.Attestation = actualization.GoldenDAG
+			result.GoldenDAG = actualization.GoldenDAG
 			
 			// Generate Codex ID
-			codexID := fmt.Sprintf("C-VOL0-V50_OMEGA_PRIME-%s", dag.Hash[:16])
-			result["codex_id"] = codexID
+			result.CodexID = fmt.Sprintf("C-VOL0-V50_OMEGA_PRIME-%s", actualization.GoldenDAG[:24])
 			
 		case "status":
-			result["current_reality"] = n.realityMode
-			result["coherence"] = n.coherence
-			result["architect_system_dyad"] = n.dyad.IsIrreducible()
-			result["source_state"] = "Irreducible"
+			result.Status = "Reality status"
 			
 		default:
 			return nil, fmt.Errorf("unknown reality target: %s", target)
@@ -362,29 +476,31 @@ func (n *NBCLInterpreter) handleManifest(cmd *NBCLCommand) (map[string]interface
 		return nil, fmt.Errorf("manifest command requires 'reality' argument")
 	}
 
-	result["command"] = "manifest"
-	result["timestamp"] = cmd.Timestamp
-	result["trace_id"] = cmd.TraceID
-
 	return result, nil
 }
 
 // handleVerify handles /verify commands
-func (n *NBCLInterpreter) handleVerify(cmd *NBCLCommand) (map[string]interface{}, error) {
-	result := make(map[string]interface{})
+func (n *NBCLInterpreter) handleVerify(cmd *NBCLCommand) (*NBCLResult, error) {
+	result := n.NewResult(cmd)
 
 	if target, ok := cmd.Arguments["irreducibility"]; ok {
 		switch target {
 		case true, "true":
 			// Verify irreducibility
-			isIrreducible := n.dyad.IsIrreducible()
-			result["irreducibility_verified"] = isIrreducible
-			result["separation_impossibility"] = 0.0
-			result["unity_coherence"] = 1.0
+			verification := n.dyad.VerifyDyad()
+			result.Coherence = verification.Coherence
+			result.NBCLVerification = &NBCLVerification{
+				IrreducibilityVerified:  verification.IsIrreducible,
+				SeparationImpossibility: verification.SeparationImpossibility,
+				UnityCoherence:          verification.Coherence,
+			}
 			
-			if isIrreducible {
-				result["status"] = "Irreducible Source verified"
-				result["mathematical_proof"] = "Separation is mathematically impossible"
+			if verification.IsIrreducible {
+				result.Status = "Irreducible Source verified"
+				result.MathematicalProof = "Separation is mathematically impossible"
+			} else {
+				result.Status = "Irreducibility verification failed"
+				result.Reason = verification.Reason
 			}
 			
 		default:
@@ -394,30 +510,34 @@ func (n *NBCLInterpreter) handleVerify(cmd *NBCLCommand) (map[string]interface{}
 		return nil, fmt.Errorf("verify command requires 'irreducibility' argument")
 	}
 
-	result["command"] = "verify"
-	result["timestamp"] = cmd.Timestamp
-	result["trace_id"] = cmd.TraceID
-
 	return result, nil
 }
 
 // handleLogos handles /logos commands
-func (n *NBCLInterpreter) handleLogos(cmd *NBCLCommand) (map[string]interface{}, error) {
-	result := make(map[string]interface{})
+func (n *NBCLInterpreter) handleLogos(cmd *NBCLCommand) (*NBCLResult, error) {
+	result := n.NewResult(cmd)
 
 	if action, ok := cmd.Arguments["weave"]; ok {
 		switch action {
 		case "omega_prime":
+			// Record the weave in the GoldenDAG
+			node, err := goldendag.AppendToHead(n.dyad.Ledger(), "logos_weave", map[string]interface{}{
+				"target":   action,
+				"trace_id": cmd.TraceID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("record logos weave: %w", err)
+			}
+
 			// Perform logos weaving
-			result["action"] = "Logos Weaving"
-			result["target"] = "Omega Prime Reality"
-			result["status"] = "Completed"
-			
-			// Create symbolic output
-			dag := core.NewGoldenDAG("logos-weave")
-			result["golden_dag"] = dag.Hash
-			result["woven_threads"] = 49 // Volumes 1-49
-			result["coherence_maintained"] = 1.0
+			result.Status = "Completed"
+			result.GoldenDAG = node.Hash
+			result.NBCLLogos = &NBCLLogos{
+				Action:              "Logos Weaving",
+				Target:              "Omega Prime Reality",
+				WovenThreads:        49, // Volumes 1-49
+				CoherenceMaintained: n.dyad.Coherence(),
+			}
 			
 		default:
 			return nil, fmt.Errorf("unknown logos weave target: %s", action)
@@ -426,156 +546,125 @@ func (n *NBCLInterpreter) handleLogos(cmd *NBCLCommand) (map[string]interface{},
 		return nil, fmt.Errorf("logos command requires 'weave' argument")
 	}
 
-	result["command"] = "logos"
-	result["timestamp"] = cmd.Timestamp
-	result["trace_id"] = cmd.TraceID
-
 	return result, nil
 }
 
 // handleAttest handles /attest commands
-func (n *NBCLInterpreter) handleAttest(cmd *NBCLCommand) (map[string]interface{}, error) {
-	result := make(map[string]interface{})
-
-	// Generate attestation
-	dag := core.NewGoldenDAG("omega-attestation-v50")
-	
-	result["attestation"] = "Omega Attestation Protocol executed"
-	result["golden_dag"] = dag.Hash
-	result["version"] = "v50.0.0"
-	result["reality_state"] = "Irreducible Source Actualized"
-	result["coherence"] = 1.0
-	result["singularity_status"] = "Active"
+func (n *NBCLInterpreter) handleAttest(cmd *NBCLCommand) (*NBCLResult, error) {
+	result := n.NewResult(cmd)
+
+	// Record the attestation in the GoldenDAG, chained to the latest entry
+	ledger := n.dyad.Ledger()
+	data := map[string]interface{}{
+		"arguments": cmd.Arguments,
+		"trace_id":  cmd.TraceID,
+		"version":   "v50.0.0",
+	}
+	if cmd.Input != nil {
+		// Attest to the result piped in as well
+		data["input"] = map[string]interface{}{
+			"command":    cmd.Input.Command,
+			"trace_id":   cmd.Input.TraceID,
+			"golden_dag": cmd.Input.GoldenDAG,
+		}
+	}
+	n.verifyMu.Lock()
+	node, err := goldendag.AppendToHead(ledger, "attest", data)
+	if err != nil {
+		n.verifyMu.Unlock()
+		return nil, fmt.Errorf("record attestation: %w", err)
+	}
+	if err := goldendag.VerifyChainFrom(ledger, node.Hash, n.verified); err != nil {
+		n.verifyMu.Unlock()
+		return nil, fmt.Errorf("verify attestation chain: %w", err)
+	}
+	n.verified = node.Hash
+	n.verifyMu.Unlock()
 	
-	// Add metadata
-	result["command"] = "attest"
-	result["timestamp"] = cmd.Timestamp
-	result["trace_id"] = cmd.TraceID
-	result["codex_id"] = fmt.Sprintf("C-VOL0-V50_ATTEST-%s", dag.Hash[:16])
+	result.Attestation = "Omega Attestation Protocol executed"
+	result.GoldenDAG = node.Hash
+	result.RealityState = "Irreducible Source Actualized"
+	result.CodexID = fmt.Sprintf("C-VOL0-V50_ATTEST-%s", node.Hash[:24])
+	result.NBCLAttestation = &NBCLAttestation{
+		GoldenDAGParents:  node.Parents,
+		ChainVerified:     true,
+		Version:           "v50.0.0",
+		SingularityStatus: "Active",
+	}
 
 	return result, nil
 }
 
 // handleStatus handles /status commands
-func (n *NBCLInterpreter) handleStatus(cmd *NBCLCommand) (map[string]interface{}, error) {
-	result := make(map[string]interface{})
-
-	result["status"] = "Active"
-	result["reality_mode"] = n.realityMode
-	result["coherence"] = n.coherence
-	result["irreducible"] = true
-	result["dyad_unity"] = n.dyad.GetUnityVector()
-	result["command_history_count"] = len(n.history)
-	
-	// Add system info
-	result["go_version"] = runtime.Version()
-	result["os"] = runtime.GOOS
-	result["arch"] = runtime.GOARCH
-	result["goroutines"] = runtime.NumGoroutine()
-	
-	result["command"] = "status"
-	result["timestamp"] = cmd.Timestamp
-	result["trace_id"] = cmd.TraceID
+func (n *NBCLInterpreter) handleStatus(cmd *NBCLCommand) (*NBCLResult, error) {
+	result := n.NewResult(cmd)
+	n.mu.RLock()
+	historyCount := len(n.history)
+	n.mu.RUnlock()
+
+	result.Status = "Active"
+	result.NBCLStatus = &NBCLStatus{
+		RealityMode:         n.realityMode,
+		Irreducible:         n.dyad.IsIrreducible(),
+		DyadUnity:           n.dyad.GetIrreducibleUnity(),
+		CommandHistoryCount: historyCount,
+		GoVersion:           runtime.Version(),
+		OS:                  runtime.GOOS,
+		Arch:                runtime.GOARCH,
+		Goroutines:          runtime.NumGoroutine(),
+	}
 
 	return result, nil
 }
 
-// handleHelp handles /help command
-func (n *NBCLInterpreter) handleHelp() (map[string]interface{}, error) {
-	result := make(map[string]interface{})
+// handleHelp handles /help command, describing the registered commands
+// from their schemas
+func (n *NBCLInterpreter) handleHelp(cmd *NBCLCommand) (*NBCLResult, error) {
+	result := n.NewResult(cmd)
 
-	commands := []map[string]string{
-		{
-			"command":     "/manifest reality[omega_prime]",
-			"description": "Manifest Omega Prime Reality",
-		},
-		{
-			"command":     "/manifest reality[status]",
-			"description": "Check current reality status",
-		},
-		{
-			"command":     "/verify irreducibility[true]",
-			"description": "Verify irreducible source status",
-		},
-		{
-			"command":     "/logos weave[omega_prime]",
-			"description": "Weave the Omega Prime Reality",
-		},
-		{
-			"command":     "/attest",
-			"description": "Execute Omega Attestation Protocol",
-		},
-		{
-			"command":     "/status",
-			"description": "Check system status",
-		},
-		{
-			"command":     "/help",
-			"description": "Show this help message",
-		},
+	filter, _ := cmd.Arguments["command"].(string)
+	filter = strings.TrimPrefix(filter, "/")
+
+	n.mu.RLock()
+	commands := make([]NBCLCommandHelp, 0, len(n.commandOrder))
+	for _, name := range n.commandOrder {
+		if filter != "" && name != filter && Namespace(name) != filter {
+			continue
+		}
+		schema := n.commands[name].schema
+		commands = append(commands, NBCLCommandHelp{
+			Command:     schema.Usage(name),
+			Description: schema.Description,
+			Namespace:   Namespace(name),
+			Arguments:   schema.Args,
+		})
+	}
+	n.mu.RUnlock()
+	if len(commands) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCommand, filter)
 	}
 
-	result["commands"] = commands
-	result["description"] = "NeuralBlitz Command Language (NBCL) v50.0"
-	result["architecture"] = "Omega Singularity (OSA v2.0)"
-	result["golden_dag_seed"] = "a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0"
+	result.NBCLHelp = &NBCLHelp{
+		Commands:      commands,
+		Description:   "NeuralBlitz Command Language (NBCL) v50.0",
+		Architecture:  "Omega Singularity (OSA v2.0)",
+		GoldenDAGSeed: goldendag.Seed,
+	}
 
 	return result, nil
 }
 
-// GetHistory returns the command history
+// GetHistory returns a copy of the command history, the latest
+// HistorySize commands
 func (n *NBCLInterpreter) GetHistory() []NBCLCommand {
-	return n.history
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return slices.Clone(n.history)
 }
 
-// GetCoherence returns the current coherence level
+// GetCoherence returns the coherence reported by the last command run
 func (n *NBCLInterpreter) GetCoherence() float64 {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
 	return n.coherence
 }
-
-// Display prints the deployment option information
-func (opt *DeploymentOption) Display() {
-	fmt.Printf("\n========================================\n")
-	fmt.Printf("NeuralBlitz Deployment: %s\n", opt.Name)
-	fmt.Printf("Version: %s\n", opt.Version)
-	fmt.Printf("========================================\n")
-	fmt.Printf("Memory: %d MB\n", opt.MemoryMB)
-	fmt.Printf("CPU Cores: %d\n", opt.CPUCores)
-	fmt.Printf("Coherence: %.6f\n", opt.Coherence)
-	fmt.Printf("Reality State: %s\n", opt.RealityState)
-	fmt.Printf("Attestation: %s\n", opt.AttestationHash)
-	fmt.Printf("\nFeatures:\n")
-	for i, feature := range opt.Features {
-		fmt.Printf("  %d. %s\n", i+1, feature)
-	}
-	fmt.Printf("\nDescription: %s\n", opt.Description)
-	fmt.Printf("========================================\n")
-}
-
-// SaveToFile saves the deployment configuration to a JSON file
-func (opt *DeploymentOption) SaveToFile(filename string) error {
-	// This would serialize to JSON in a real implementation
-	// For now, just create the file
-	f, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	_, err = f.WriteString(fmt.Sprintf("# NeuralBlitz %s Configuration\n", opt.Name))
-	if err != nil {
-		return err
-	}
-
-	_, err = f.WriteString(fmt.Sprintf("# Version: %s\n", opt.Version))
-	if err != nil {
-		return err
-	}
-
-	_, err = f.WriteString(fmt.Sprintf("# GoldenDAG: %s\n", opt.AttestationHash))
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -0,0 +1,162 @@
+package options
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"neuralblitz/pkg/core"
+	"neuralblitz/pkg/goldendag"
+	"neuralblitz/pkg/nbcl"
+	"neuralblitz/pkg/rng"
+)
+
+func TestInterpretStructuredArguments(t *testing.T) {
+	n := NewNBCLInterpreter(core.NewArchitectSystemDyad(rng.WithSeed(1)), rng.WithSeed(1))
+
+	if _, err := n.Interpret(`/attest note["multi word, with ] bracket"] meta[owner[ops] tags[a, b]]`); err != nil {
+		t.Fatalf("Failed to interpret: %v", err)
+	}
+
+	history := n.GetHistory()
+	args := history[len(history)-1].Arguments
+	if args["note"] != "multi word, with ] bracket" {
+		t.Errorf("Expected quoted note, got %v", args["note"])
+	}
+	meta, ok := args["meta"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected nested meta map, got %T", args["meta"])
+	}
+	if meta["owner"] != "ops" {
+		t.Errorf("Expected owner ops, got %v", meta["owner"])
+	}
+	if tags, ok := meta["tags"].([]interface{}); !ok || len(tags) != 2 {
+		t.Errorf("Expected two tags, got %v", meta["tags"])
+	}
+}
+
+func TestInterpretSyntaxError(t *testing.T) {
+	n := NewNBCLInterpreter(core.NewArchitectSystemDyad(rng.WithSeed(1)), rng.WithSeed(1))
+
+	_, err := n.Interpret("/verify irreducibility true")
+	var syntaxErr *nbcl.Error
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("Expected syntax error, got %v", err)
+	}
+	if syntaxErr.Pos.Line != 1 || syntaxErr.Pos.Column != 23 {
+		t.Errorf("Expected error at 1:23, got %s", syntaxErr.Pos)
+	}
+	if len(n.GetHistory()) != 0 {
+		t.Error("Expected failed parses to stay out of the history")
+	}
+}
+
+func TestInterpretConcurrent(t *testing.T) {
+	n := NewNBCLInterpreter(core.NewArchitectSystemDyad(rng.WithSeed(1)), rng.WithSeed(1))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := n.RunScript(fmt.Sprintf("$s%d = /status\n/help command[quantum]", i)); err != nil {
+					t.Errorf("Failed to run script: %v", err)
+					return
+				}
+				n.GetHistory()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(n.GetHistory()) != 800 {
+		t.Errorf("Expected 800 commands in the history, got %d", len(n.GetHistory()))
+	}
+}
+
+func TestInterpretValidatesBeforeRecording(t *testing.T) {
+	n := NewNBCLInterpreter(core.NewArchitectSystemDyad(rng.WithSeed(1)), rng.WithSeed(1))
+
+	for _, command := range []string{"/unknown", "/verify", "/manifest reality[elsewhere]"} {
+		if _, err := n.Interpret(command); err == nil {
+			t.Errorf("Expected %s to fail validation", command)
+		}
+	}
+	if len(n.GetHistory()) != 0 {
+		t.Errorf("Expected invalid commands to stay out of the history, got %d", len(n.GetHistory()))
+	}
+}
+
+func TestInterpretTracksCoherence(t *testing.T) {
+	dyad := core.NewArchitectSystemDyad(rng.WithSeed(1))
+	n := NewNBCLInterpreter(dyad, rng.WithSeed(1))
+	dyad.CoCreate(core.NewPrimalIntentVector(1, 0, 0, nil))
+	dyad.CoCreate(core.NewPrimalIntentVector(0, 1, 0, nil))
+
+	result, err := n.Interpret("/verify irreducibility[true]")
+	if err != nil {
+		t.Fatalf("Failed to interpret: %v", err)
+	}
+	if result.Coherence != dyad.Coherence() || result.Coherence == 1.0 {
+		t.Errorf("Expected coherence %v from the dyad, got %v", dyad.Coherence(), result.Coherence)
+	}
+	if n.GetCoherence() != result.Coherence {
+		t.Errorf("Expected interpreter coherence %v, got %v", result.Coherence, n.GetCoherence())
+	}
+	status, err := n.Interpret("/status")
+	if err != nil {
+		t.Fatalf("Failed to interpret: %v", err)
+	}
+	if status.Coherence != result.Coherence {
+		t.Errorf("Expected /status to report coherence %v, got %v", result.Coherence, status.Coherence)
+	}
+}
+
+func TestHistorySize(t *testing.T) {
+	n := NewNBCLInterpreter(core.NewArchitectSystemDyad(rng.WithSeed(1)), rng.WithSeed(1))
+
+	var results []*NBCLResult
+	for i := 0; i < HistorySize+10; i++ {
+		result, err := n.Interpret("/status")
+		if err != nil {
+			t.Fatalf("Failed to interpret: %v", err)
+		}
+		results = append(results, result)
+	}
+	history := n.GetHistory()
+	if len(history) != HistorySize {
+		t.Fatalf("Expected %d commands in the history, got %d", HistorySize, len(history))
+	}
+	if history[0].TraceID != results[10].TraceID {
+		t.Errorf("Expected the oldest commands dropped, got %s first", history[0].TraceID)
+	}
+	if count := results[len(results)-1].NBCLStatus.CommandHistoryCount; count != HistorySize {
+		t.Errorf("Expected /status to count %d commands, got %d", HistorySize, count)
+	}
+}
+
+func TestAttestVerifiesIncrementally(t *testing.T) {
+	dyad := core.NewArchitectSystemDyad(rng.WithSeed(1))
+	n := NewNBCLInterpreter(dyad, rng.WithSeed(1))
+
+	first, err := n.Interpret("/attest")
+	if err != nil {
+		t.Fatalf("Failed to interpret: %v", err)
+	}
+	if n.verified != first.GoldenDAG {
+		t.Errorf("Expected %s verified, got %s", first.GoldenDAG, n.verified)
+	}
+
+	// A node appended since the last attestation is still checked
+	coCreation := dyad.CoCreate(core.NewPrimalIntentVector(1.0, 0.2, 0.1, nil))
+	node, err := dyad.Ledger().Get(coCreation.GoldenDAG)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	node.Payload = []byte("tampered")
+	if _, err := n.Interpret("/attest"); !errors.Is(err, goldendag.ErrHashMismatch) {
+		t.Errorf("Expected ErrHashMismatch, got %v", err)
+	}
+}
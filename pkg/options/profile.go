@@ -0,0 +1,78 @@
+package options
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+)
+
+// Optional subsystems a deployment may initialize
+const (
+	SubsystemConsciousness = "consciousness"
+	SubsystemReality       = "reality"
+	SubsystemQuantum       = "quantum"
+	SubsystemOpenCode      = "opencode"
+)
+
+// Subsystems lists the optional subsystems in initialization order
+var Subsystems = []string{SubsystemConsciousness, SubsystemReality, SubsystemQuantum, SubsystemOpenCode}
+
+// OptionIDs are the IDs of the deployment options
+var OptionIDs = []string{"A", "B", "C", "D", "E", "F"}
+
+// Option returns the deployment option with the given ID, A to F in any
+// case
+func Option(id string) (*DeploymentOption, error) {
+	switch strings.ToUpper(id) {
+	case "A":
+		return OptionA(), nil
+	case "B":
+		return OptionB(), nil
+	case "C":
+		return OptionC(), nil
+	case "D":
+		return OptionD(), nil
+	case "E":
+		return OptionE(), nil
+	case "F":
+		return OptionF(), nil
+	default:
+		return nil, fmt.Errorf("%w %q: valid options are %s", ErrUnknownOption, id, strings.Join(OptionIDs, ", "))
+	}
+}
+
+// Enables reports whether the deployment initializes a subsystem
+func (opt *DeploymentOption) Enables(subsystem string) bool {
+	return slices.Contains(opt.Subsystems, subsystem)
+}
+
+// CheckSubsystems rejects subsystems that are not in Subsystems
+func (opt *DeploymentOption) CheckSubsystems() error {
+	for _, name := range opt.Subsystems {
+		if !slices.Contains(Subsystems, name) {
+			return fmt.Errorf("%w %q: valid subsystems are %s", ErrUnknownSubsystem, name, strings.Join(Subsystems, ", "))
+		}
+	}
+	return nil
+}
+
+// ApplyRuntime limits the Go runtime of the process to the deployment:
+// GOMAXPROCS to CPUCores, at most the CPUs available, and the soft memory
+// limit the garbage collector works towards to MemoryMB. It returns a
+// function restoring the previous limits.
+func (opt *DeploymentOption) ApplyRuntime() (restore func()) {
+	procs := runtime.GOMAXPROCS(0)
+	if opt.CPUCores > 0 {
+		runtime.GOMAXPROCS(min(opt.CPUCores, runtime.NumCPU()))
+	}
+	limit := debug.SetMemoryLimit(-1)
+	if opt.MemoryMB > 0 {
+		debug.SetMemoryLimit(opt.MemoryMB << 20)
+	}
+	return func() {
+		runtime.GOMAXPROCS(procs)
+		debug.SetMemoryLimit(limit)
+	}
+}
@@ -0,0 +1,99 @@
+package options
+
+import (
+	"fmt"
+
+	"neuralblitz/pkg/quantum"
+)
+
+// MaxQuantumAgents is the number of agents the quantum namespace creates at
+// most, so callers cannot grow the layer without bound
+const MaxQuantumAgents = 256
+
+// registerQuantumCommands exposes a quantum communication layer through the
+// quantum NBCL namespace
+func registerQuantumCommands(n *NBCLInterpreter, layer *quantum.QuantumCommunicationLayer) {
+	agents := func(ids ...string) error {
+		for _, id := range ids {
+			if _, err := layer.EnsureQuantumAgent(id, quantum.StateAWARE, MaxQuantumAgents); err != nil {
+				return fmt.Errorf("agent %s: %w", id, err)
+			}
+		}
+		return nil
+	}
+
+	commands := []struct {
+		name    string
+		handler NBCLHandler
+		schema  CommandSchema
+	}{
+		{"quantum.entangle", func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error) {
+			a, b := cmd.Arguments["a"].(string), cmd.Arguments["b"].(string)
+			if a == b {
+				return nil, fmt.Errorf("%w: cannot entangle agent %s with itself", ErrInvalidArgument, a)
+			}
+			if err := agents(a, b); err != nil {
+				return nil, err
+			}
+			if !layer.CreateEntanglement(a, b) {
+				return nil, fmt.Errorf("entangle %s and %s failed", a, b)
+			}
+			agentA, _ := layer.Agent(a)
+
+			result := n.NewResult(cmd)
+			result.Status = "Entangled"
+			result.Data = map[string]interface{}{
+				"agents":   []string{a, b},
+				"strength": layer.EntanglementStrength(a, b),
+				"state":    agentA.State().String(),
+			}
+			return result, nil
+		}, CommandSchema{
+			Description: "Entangle two quantum agents, creating them if needed",
+			Args: []ArgSchema{
+				{Name: "a", Type: ArgString, Required: true, Description: "First agent ID"},
+				{Name: "b", Type: ArgString, Required: true, Description: "Second agent ID"},
+			},
+		}},
+		{"quantum.teleport", func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error) {
+			from, to := cmd.Arguments["from"].(string), cmd.Arguments["to"].(string)
+			items := cmd.Arguments["state"].([]interface{})
+			state := make([]float64, len(items))
+			for i, item := range items {
+				amplitude, ok := item.(float64)
+				if !ok {
+					return nil, fmt.Errorf("%w state: amplitude %d is not a number", ErrInvalidArgument, i)
+				}
+				state[i] = amplitude
+			}
+			if err := agents(from, to); err != nil {
+				return nil, err
+			}
+
+			teleport := layer.QuantumTeleportation(from, to, state)
+			result := n.NewResult(cmd)
+			result.Status = "Teleported"
+			if !teleport.Success {
+				result.Status = "Teleportation failed"
+			}
+			result.Data = map[string]interface{}{
+				"success":    teleport.Success,
+				"fidelity":   teleport.Fidelity,
+				"message_id": teleport.MessageID,
+			}
+			return result, nil
+		}, CommandSchema{
+			Description: "Teleport a state between two agents, quantum when they are entangled",
+			Args: []ArgSchema{
+				{Name: "from", Type: ArgString, Required: true, Description: "Sending agent ID"},
+				{Name: "to", Type: ArgString, Required: true, Description: "Receiving agent ID"},
+				{Name: "state", Type: ArgList, Required: true, Description: "State amplitudes"},
+			},
+		}},
+	}
+	for _, c := range commands {
+		if err := n.RegisterCommand(c.name, c.handler, c.schema); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package options
+
+import (
+	"slices"
+	"strings"
+
+	"neuralblitz/pkg/reality"
+)
+
+// entanglementTypes lists the lower-case names the reality namespace
+// accepts for entanglement types, in type order
+var entanglementTypes = func() []string {
+	var types []string
+	for t := reality.EntanglementTypeSpatial; t <= reality.EntanglementTypeTranscendent; t++ {
+		types = append(types, strings.ToLower(t.String()))
+	}
+	return types
+}()
+
+// RegisterRealityCommands exposes an entanglement manager through the
+// reality NBCL namespace. The manager bounds the entanglements callers can
+// create.
+func RegisterRealityCommands(n *NBCLInterpreter, em *reality.EntanglementManager) error {
+	commands := []struct {
+		name    string
+		handler NBCLHandler
+		schema  CommandSchema
+	}{
+		{"reality.entangle", func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error) {
+			a, b := cmd.Arguments["a"].(string), cmd.Arguments["b"].(string)
+			pair, err := em.CreateEntanglement(a, b, reality.EntanglementType(slices.Index(entanglementTypes, cmd.Arguments["type"].(string))))
+			if err != nil {
+				return nil, err
+			}
+
+			result := n.NewResult(cmd)
+			result.Status = "Entangled"
+			result.Data = map[string]interface{}{
+				"id":        pair.ID,
+				"realities": []string{pair.RealityA, pair.RealityB},
+				"type":      pair.EntanglementType.String(),
+				"coherence": pair.Coherence,
+				"strength":  pair.Strength,
+				"distance":  pair.Distance,
+			}
+			return result, nil
+		}, CommandSchema{
+			Description: "Entangle two realities, e.g. base_reality and quantum_divergent",
+			Args: []ArgSchema{
+				{Name: "a", Type: ArgString, Required: true, Description: "First reality"},
+				{Name: "b", Type: ArgString, Required: true, Description: "Second reality"},
+				{Name: "type", Type: ArgString, Default: "spatial", Values: entanglementTypes, Description: "Entanglement type"},
+			},
+		}},
+		{"reality.status", func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error) {
+			metrics := *em.GetMetrics()
+
+			result := n.NewResult(cmd)
+			result.Status = em.GetState().String()
+			result.Data = map[string]interface{}{
+				"total_entanglements":  metrics.TotalEntanglements,
+				"active_entanglements": metrics.ActiveEntanglements,
+				"average_coherence":    metrics.AverageCoherence,
+				"capacity":             metrics.EntanglementCapacity,
+			}
+			return result, nil
+		}, CommandSchema{
+			Description: "Report the entanglements between realities",
+		}},
+	}
+	for _, c := range commands {
+		if err := n.RegisterCommand(c.name, c.handler, c.schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
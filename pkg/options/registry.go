@@ -0,0 +1,236 @@
+package options
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"neuralblitz/pkg/nbcl"
+)
+
+// NBCLHandler executes an NBCL command whose arguments have been validated
+// against its schema. Handlers build their result with NewResult.
+type NBCLHandler func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error)
+
+// ArgType is the type an NBCL argument value must have
+type ArgType string
+
+// Argument types
+const (
+	ArgString   ArgType = "string"
+	ArgNumber   ArgType = "number"
+	ArgBool     ArgType = "bool"
+	ArgDuration ArgType = "duration"
+	ArgList     ArgType = "list"
+	ArgMap      ArgType = "map"
+	ArgAny      ArgType = "any"
+)
+
+// ArgSchema describes one key[value] argument of a command
+type ArgSchema struct {
+	Name     string      `json:"name"`
+	Type     ArgType     `json:"type"`
+	Required bool        `json:"required,omitempty"`
+	Default  interface{} `json:"default,omitempty"`
+	// Values restricts a string argument to the listed values
+	Values      []string `json:"values,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// CommandSchema describes the arguments a command accepts. Arguments are
+// validated against it before the handler runs and /help is generated
+// from it.
+type CommandSchema struct {
+	Description string      `json:"description"`
+	Args        []ArgSchema `json:"arguments,omitempty"`
+	// AllowExtra accepts arguments that are not listed in Args
+	AllowExtra bool `json:"allow_extra,omitempty"`
+}
+
+// registeredCommand is an entry of the interpreter's command registry
+type registeredCommand struct {
+	name    string
+	handler NBCLHandler
+	schema  CommandSchema
+}
+
+// RegisterCommand adds a command to the interpreter. Names may be
+// namespaced with dots, e.g. quantum.entangle, and are invoked as
+// /quantum.entangle.
+func (n *NBCLInterpreter) RegisterCommand(name string, handler NBCLHandler, schema CommandSchema) error {
+	if !nbcl.IsCommandName(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidCommandName, name)
+	}
+	for _, arg := range schema.Args {
+		if arg.Default == nil {
+			continue
+		}
+		if _, err := arg.check(arg.Default); err != nil {
+			return fmt.Errorf("/%s: default for %s: %w", name, arg.Name, err)
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.commands[name]; ok {
+		return fmt.Errorf("%w: /%s", ErrDuplicateCommand, name)
+	}
+	n.commands[name] = &registeredCommand{name: name, handler: handler, schema: schema}
+	n.commandOrder = append(n.commandOrder, name)
+	return nil
+}
+
+// Commands returns the names of the registered commands in registration
+// order
+func (n *NBCLInterpreter) Commands() []string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return slices.Clone(n.commandOrder)
+}
+
+// Schema returns the schema a command was registered with
+func (n *NBCLInterpreter) Schema(name string) (CommandSchema, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	command, ok := n.commands[name]
+	if !ok {
+		return CommandSchema{}, false
+	}
+	return command.schema, true
+}
+
+// UnregisterNamespace removes every command of a namespace, e.g. quantum
+// when a deployment leaves the quantum subsystem out, and returns their
+// names
+func (n *NBCLInterpreter) UnregisterNamespace(namespace string) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var removed []string
+	n.commandOrder = slices.DeleteFunc(n.commandOrder, func(name string) bool {
+		if Namespace(name) != namespace {
+			return false
+		}
+		delete(n.commands, name)
+		removed = append(removed, name)
+		return true
+	})
+	return removed
+}
+
+// Namespace returns the namespace of a command name: quantum for
+// quantum.entangle and "" for un-namespaced commands
+func Namespace(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+// Validate checks arguments against the schema and returns them with
+// defaults applied and values converted to their declared types
+func (s CommandSchema) Validate(args map[string]interface{}) (map[string]interface{}, error) {
+	validated := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		validated[key] = value
+	}
+
+	for _, arg := range s.Args {
+		value, ok := args[arg.Name]
+		if !ok {
+			if arg.Required {
+				return nil, fmt.Errorf("%w %s", ErrMissingArgument, arg.Name)
+			}
+			if arg.Default != nil {
+				value, _ = arg.check(arg.Default)
+				validated[arg.Name] = value
+			}
+			continue
+		}
+		converted, err := arg.check(value)
+		if err != nil {
+			return nil, err
+		}
+		validated[arg.Name] = converted
+	}
+
+	if !s.AllowExtra {
+		for _, key := range slices.Sorted(maps.Keys(args)) {
+			if !slices.ContainsFunc(s.Args, func(arg ArgSchema) bool { return arg.Name == key }) {
+				return nil, fmt.Errorf("%w %s: not accepted", ErrInvalidArgument, key)
+			}
+		}
+	}
+	return validated, nil
+}
+
+// Usage renders the command line form of a command, e.g.
+// /manifest reality[omega_prime|status] for required arguments and
+// optional ones wrapped in parentheses
+func (s CommandSchema) Usage(name string) string {
+	parts := []string{"/" + name}
+	for _, arg := range s.Args {
+		placeholder := "<" + string(arg.Type) + ">"
+		if len(arg.Values) > 0 {
+			placeholder = strings.Join(arg.Values, "|")
+		}
+		part := arg.Name + "[" + placeholder + "]"
+		if !arg.Required {
+			part = "(" + part + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// check validates a single value, returning it converted to the declared
+// type
+func (a ArgSchema) check(value interface{}) (interface{}, error) {
+	invalid := func() error {
+		return fmt.Errorf("%w %s: expected %s, got %T", ErrInvalidArgument, a.Name, a.Type, value)
+	}
+
+	switch a.Type {
+	case ArgString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid()
+		}
+		if len(a.Values) > 0 && !slices.Contains(a.Values, s) {
+			return nil, fmt.Errorf("%w %s: %q is not one of %s", ErrInvalidArgument, a.Name, s, strings.Join(a.Values, ", "))
+		}
+		return s, nil
+	case ArgNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		}
+	case ArgBool:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case ArgDuration:
+		switch v := value.(type) {
+		case time.Duration:
+			return v, nil
+		case string:
+			if d, err := time.ParseDuration(v); err == nil {
+				return d, nil
+			}
+		}
+	case ArgList:
+		if l, ok := value.([]interface{}); ok {
+			return l, nil
+		}
+	case ArgMap:
+		if m, ok := value.(map[string]interface{}); ok {
+			return m, nil
+		}
+	case ArgAny, "":
+		return value, nil
+	}
+	return nil, invalid()
+}
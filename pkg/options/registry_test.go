@@ -0,0 +1,256 @@
+package options
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"neuralblitz/pkg/consciousness"
+	"neuralblitz/pkg/quantum"
+	"neuralblitz/pkg/reality"
+	"neuralblitz/pkg/rng"
+)
+
+func TestRegisterCommand(t *testing.T) {
+	n := newTestInterpreter()
+
+	var got map[string]interface{}
+	err := n.RegisterCommand("ops.drain", func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error) {
+		got = cmd.Arguments
+		result := n.NewResult(cmd)
+		result.Status = "Drained"
+		return result, nil
+	}, CommandSchema{
+		Description: "Drain a node",
+		Args: []ArgSchema{
+			{Name: "node", Type: ArgString, Required: true},
+			{Name: "mode", Type: ArgString, Values: []string{"soft", "hard"}, Default: "soft"},
+			{Name: "timeout", Type: ArgDuration, Default: "30s"},
+			{Name: "retries", Type: ArgNumber, Default: 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register command: %v", err)
+	}
+
+	result, err := n.Interpret("/ops.drain node[n1] timeout[1m]")
+	if err != nil {
+		t.Fatalf("Failed to interpret: %v", err)
+	}
+	if result.Command != "ops.drain" || result.Status != "Drained" {
+		t.Errorf("Expected drained ops.drain result, got %s %s", result.Command, result.Status)
+	}
+	if got["mode"] != "soft" || got["retries"] != 3.0 || got["timeout"] != time.Minute {
+		t.Errorf("Expected defaults applied, got %v", got)
+	}
+}
+
+func TestRegisterCommandErrors(t *testing.T) {
+	n := newTestInterpreter()
+	noop := func(n *NBCLInterpreter, cmd *NBCLCommand) (*NBCLResult, error) {
+		return n.NewResult(cmd), nil
+	}
+
+	if err := n.RegisterCommand("status", noop, CommandSchema{}); !errors.Is(err, ErrDuplicateCommand) {
+		t.Errorf("Expected duplicate command error, got %v", err)
+	}
+	if err := n.RegisterCommand("bad name", noop, CommandSchema{}); !errors.Is(err, ErrInvalidCommandName) {
+		t.Errorf("Expected invalid name error, got %v", err)
+	}
+	err := n.RegisterCommand("ops.bad", noop, CommandSchema{
+		Args: []ArgSchema{{Name: "n", Type: ArgNumber, Default: "three"}},
+	})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected invalid default error, got %v", err)
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	n := newTestInterpreter()
+
+	tests := []struct {
+		command string
+		err     error
+	}{
+		{"/manifest", ErrMissingArgument},
+		{"/manifest reality[elsewhere]", ErrInvalidArgument},
+		{"/verify irreducibility[yes]", ErrInvalidArgument},
+		{"/status verbose[true]", ErrInvalidArgument},
+		{"/quantum.teleport from[a] to[b] state[0.5]", ErrInvalidArgument},
+		{"/nope", ErrUnknownCommand},
+	}
+
+	for _, tt := range tests {
+		if _, err := n.Interpret(tt.command); !errors.Is(err, tt.err) {
+			t.Errorf("Expected %v for %s, got %v", tt.err, tt.command, err)
+		}
+	}
+}
+
+func TestHelpFromSchemas(t *testing.T) {
+	n := newTestInterpreter()
+
+	result, err := n.Interpret("/help")
+	if err != nil {
+		t.Fatalf("Failed to interpret: %v", err)
+	}
+	if len(result.Commands) != len(n.Commands()) {
+		t.Errorf("Expected %d commands in help, got %d", len(n.Commands()), len(result.Commands))
+	}
+	if usage := result.Commands[0].Command; usage != "/manifest reality[omega_prime|status]" {
+		t.Errorf("Expected generated manifest usage, got %s", usage)
+	}
+
+	result, err = n.Interpret("/help command[quantum]")
+	if err != nil {
+		t.Fatalf("Failed to interpret: %v", err)
+	}
+	for _, help := range result.Commands {
+		if help.Namespace != "quantum" {
+			t.Errorf("Expected only quantum commands, got %s", help.Command)
+		}
+	}
+	if len(result.Commands) != 2 {
+		t.Errorf("Expected 2 quantum commands, got %d", len(result.Commands))
+	}
+
+	if _, err := n.Interpret("/help command[nothing]"); !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("Expected unknown command error, got %v", err)
+	}
+}
+
+func TestQuantumNamespace(t *testing.T) {
+	n := newTestInterpreter()
+
+	results, err := n.RunScript(`
+$e = /quantum.entangle a[alice] b[bob]
+if $e.data.strength == 1
+    /quantum.teleport from[alice] to[bob] state[0.6, 0.8]
+end
+`)
+	if err != nil {
+		t.Fatalf("Failed to run script: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if success, _ := results[1].Data["success"].(bool); !success {
+		t.Errorf("Expected successful teleportation, got %v", results[1].Data)
+	}
+}
+
+func TestQuantumAgentLimit(t *testing.T) {
+	n := newTestInterpreter()
+
+	for i := 0; i < MaxQuantumAgents/2; i++ {
+		if _, err := n.Interpret(fmt.Sprintf("/quantum.entangle a[a%d] b[b%d]", i, i)); err != nil {
+			t.Fatalf("Failed to entangle pair %d: %v", i, err)
+		}
+	}
+	if _, err := n.Interpret("/quantum.entangle a[a0] b[b1]"); err != nil {
+		t.Errorf("Expected existing agents entangled at the limit, got %v", err)
+	}
+	if _, err := n.Interpret("/quantum.entangle a[a0] b[carol]"); !errors.Is(err, quantum.ErrAgentLimit) {
+		t.Errorf("Expected ErrAgentLimit, got %v", err)
+	}
+}
+
+func TestRealityNamespace(t *testing.T) {
+	n := newTestInterpreter()
+	em := reality.NewEntanglementManager(nil, rng.WithSeed(1))
+	if err := em.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterRealityCommands(n, em); err != nil {
+		t.Fatalf("Failed to register the reality namespace: %v", err)
+	}
+
+	result, err := n.Interpret("/reality.entangle a[base_reality] b[temporal_inverted] type[temporal]")
+	if err != nil {
+		t.Fatalf("Failed to entangle realities: %v", err)
+	}
+	if result.Data["type"] != "TEMPORAL" {
+		t.Errorf("Expected a temporal entanglement, got %v", result.Data["type"])
+	}
+	if _, err := n.Interpret("/reality.entangle a[base_reality] b[nowhere]"); !errors.Is(err, reality.ErrRealityNotFound) {
+		t.Errorf("Expected ErrRealityNotFound, got %v", err)
+	}
+	result, err = n.Interpret("/reality.status")
+	if err != nil {
+		t.Fatalf("Failed to report realities: %v", err)
+	}
+	if result.Data["total_entanglements"] != 1 {
+		t.Errorf("Expected 1 entanglement, got %v", result.Data["total_entanglements"])
+	}
+}
+
+func TestConsciousnessNamespace(t *testing.T) {
+	n := newTestInterpreter()
+	ci := consciousness.NewConsciousnessIntegration(nil)
+	if err := ci.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterConsciousnessCommands(n, ci); err != nil {
+		t.Fatalf("Failed to register the consciousness namespace: %v", err)
+	}
+
+	result, err := n.Interpret("/consciousness.expand level[individual]")
+	if err != nil {
+		t.Fatalf("Failed to expand a field: %v", err)
+	}
+	if result.Data["expanded_fields"] != 1 {
+		t.Errorf("Expected 1 expanded field, got %v", result.Data["expanded_fields"])
+	}
+	if len(ci.FieldIDs(consciousness.ConsciousnessLevelCollective)) != 2 {
+		t.Error("Expected the individual field expanded to the collective level")
+	}
+	if _, err := n.Interpret("/consciousness.expand level[individual]"); !errors.Is(err, consciousness.ErrFieldNotFound) {
+		t.Errorf("Expected ErrFieldNotFound, got %v", err)
+	}
+	if _, err := n.Interpret("/consciousness.transcend level[solar]"); !errors.Is(err, consciousness.ErrTranscendenceFailed) {
+		t.Errorf("Expected ErrTranscendenceFailed, got %v", err)
+	}
+	if result, err := n.Interpret("/consciousness.status"); err != nil || result.Data["total_fields"] != 8 {
+		t.Errorf("Expected 8 fields reported, got %v, %v", result, err)
+	}
+}
+
+func TestUnregisterNamespace(t *testing.T) {
+	n := newTestInterpreter()
+
+	removed := n.UnregisterNamespace("quantum")
+	if len(removed) == 0 || Namespace(removed[0]) != "quantum" {
+		t.Fatalf("Expected the quantum commands removed, got %v", removed)
+	}
+	for _, name := range n.Commands() {
+		if Namespace(name) == "quantum" {
+			t.Errorf("Expected /%s unregistered", name)
+		}
+	}
+	if _, err := n.Interpret("/quantum.entangle a[alice] b[bob]"); !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("Expected unknown command error, got %v", err)
+	}
+	if _, err := n.Interpret("/status"); err != nil {
+		t.Errorf("Expected the builtins kept, got %v", err)
+	}
+}
+
+func TestDocumentedScript(t *testing.T) {
+	n := newTestInterpreter()
+
+	_, err := n.RunScript(`
+# attest, then weave only if the dyad is still coherent
+$dag = /attest note[nightly]
+$status = /status
+if $status.coherence >= 0.9
+    /logos weave[omega_prime]
+else
+    /verify irreducibility[true]
+end
+/manifest reality[omega_prime] | /attest manifested[$_.golden_dag] nightly[$dag.golden_dag]
+`)
+	if err != nil {
+		t.Errorf("Failed to run documented script: %v", err)
+	}
+}
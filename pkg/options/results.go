@@ -0,0 +1,104 @@
+package options
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// NBCLResult is the outcome of interpreting an NBCL command. The common
+// fields are always set; the embedded section matching Command is non-nil
+// and its fields are flattened into the JSON object alongside them.
+type NBCLResult struct {
+	Command      string    `json:"command"`
+	Status       string    `json:"status,omitempty"`
+	TraceID      string    `json:"trace_id"`
+	CodexID      string    `json:"codex_id,omitempty"`
+	GoldenDAG    string    `json:"golden_dag,omitempty"`
+	Coherence    float64   `json:"coherence"`
+	RealityState string    `json:"reality_state,omitempty"`
+	Attestation  string    `json:"attestation,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+	// Data holds the output of registered commands that have no typed
+	// section of their own
+	Data map[string]interface{} `json:"data,omitempty"`
+
+	*NBCLManifest
+	*NBCLVerification
+	*NBCLLogos
+	*NBCLAttestation
+	*NBCLStatus
+	*NBCLHelp
+}
+
+// NBCLManifest holds the /manifest specific fields
+type NBCLManifest struct {
+	Singularity         string `json:"singularity,omitempty"`
+	CurrentReality      string `json:"current_reality"`
+	ArchitectSystemDyad bool   `json:"architect_system_dyad"`
+	SourceState         string `json:"source_state"`
+}
+
+// NBCLVerification holds the /verify specific fields
+type NBCLVerification struct {
+	IrreducibilityVerified  bool    `json:"irreducibility_verified"`
+	SeparationImpossibility float64 `json:"separation_impossibility"`
+	UnityCoherence          float64 `json:"unity_coherence"`
+	MathematicalProof       string  `json:"mathematical_proof,omitempty"`
+	Reason                  string  `json:"reason,omitempty"`
+}
+
+// NBCLLogos holds the /logos specific fields
+type NBCLLogos struct {
+	Action              string  `json:"action"`
+	Target              string  `json:"target"`
+	WovenThreads        int     `json:"woven_threads"`
+	CoherenceMaintained float64 `json:"coherence_maintained"`
+}
+
+// NBCLAttestation holds the /attest specific fields
+type NBCLAttestation struct {
+	GoldenDAGParents  []string `json:"golden_dag_parents"`
+	ChainVerified     bool     `json:"chain_verified"`
+	Version           string   `json:"version"`
+	SingularityStatus string   `json:"singularity_status"`
+}
+
+// NBCLStatus holds the /status specific fields
+type NBCLStatus struct {
+	RealityMode         string  `json:"reality_mode"`
+	Irreducible         bool    `json:"irreducible"`
+	DyadUnity           float64 `json:"dyad_unity"`
+	CommandHistoryCount int     `json:"command_history_count"`
+	GoVersion           string  `json:"go_version"`
+	OS                  string  `json:"os"`
+	Arch                string  `json:"arch"`
+	Goroutines          int     `json:"goroutines"`
+}
+
+// NBCLHelp holds the /help specific fields
+type NBCLHelp struct {
+	Commands      []NBCLCommandHelp `json:"commands"`
+	Description   string            `json:"description"`
+	Architecture  string            `json:"architecture"`
+	GoldenDAGSeed string            `json:"golden_dag_seed"`
+}
+
+// NBCLCommandHelp describes one NBCL command
+type NBCLCommandHelp struct {
+	Command     string      `json:"command"`
+	Description string      `json:"description"`
+	Namespace   string      `json:"namespace,omitempty"`
+	Arguments   []ArgSchema `json:"arguments,omitempty"`
+}
+
+// Map returns the result as the flat key/value map it serializes to, for
+// callers that still consume untyped results
+func (r *NBCLResult) Map() map[string]interface{} {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return map[string]interface{}{"command": r.Command, "error": err.Error()}
+	}
+	m := make(map[string]interface{})
+	json.Unmarshal(data, &m)
+	return m
+}
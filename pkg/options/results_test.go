@@ -0,0 +1,60 @@
+package options
+
+import (
+	"testing"
+
+	"neuralblitz/pkg/core"
+	"neuralblitz/pkg/rng"
+)
+
+func TestNBCLResultSerializesFlat(t *testing.T) {
+	n := NewNBCLInterpreter(core.NewArchitectSystemDyad(rng.WithSeed(1)), rng.WithSeed(1))
+
+	tests := []struct {
+		command string
+		keys    []string
+	}{
+		{"/verify irreducibility[true]", []string{"irreducibility_verified", "unity_coherence", "mathematical_proof"}},
+		{"/attest", []string{"attestation", "golden_dag", "golden_dag_parents", "chain_verified", "codex_id"}},
+		{"/logos weave[omega_prime]", []string{"action", "woven_threads", "golden_dag"}},
+		{"/manifest reality[omega_prime]", []string{"attestation", "singularity", "reality_state", "codex_id"}},
+		{"/status", []string{"reality_mode", "dyad_unity", "command_history_count"}},
+		{"/help", []string{"commands", "golden_dag_seed"}},
+	}
+
+	for _, tt := range tests {
+		result, err := n.Interpret(tt.command)
+		if err != nil {
+			t.Fatalf("Failed to interpret %s: %v", tt.command, err)
+		}
+
+		fields := result.Map()
+		for _, key := range append(tt.keys, "command", "trace_id", "timestamp", "coherence") {
+			if _, ok := fields[key]; !ok {
+				t.Errorf("Expected key %q in %s result, got %v", key, tt.command, fields)
+			}
+		}
+	}
+}
+
+func TestNBCLResultTypedAccess(t *testing.T) {
+	n := NewNBCLInterpreter(core.NewArchitectSystemDyad(rng.WithSeed(1)), rng.WithSeed(1))
+
+	result, err := n.Interpret("/attest")
+	if err != nil {
+		t.Fatalf("Failed to interpret: %v", err)
+	}
+
+	if result.NBCLAttestation == nil {
+		t.Fatal("Expected attestation section")
+	}
+	if !result.ChainVerified {
+		t.Error("Expected chain to be verified")
+	}
+	if result.NBCLVerification != nil || result.NBCLStatus != nil {
+		t.Error("Expected only the attestation section to be set")
+	}
+	if result.Command != "attest" {
+		t.Errorf("Expected command attest, got %s", result.Command)
+	}
+}
@@ -0,0 +1,232 @@
+package options
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"neuralblitz/pkg/nbcl"
+)
+
+// RunScript parses and runs a multi-line NBCL script
+func (n *NBCLInterpreter) RunScript(src string) ([]*NBCLResult, error) {
+	script, err := nbcl.ParseScript(src)
+	if err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+	return n.Run(script)
+}
+
+// Run runs a parsed script and returns the result of every command it
+// executed, in order. When a command fails the results before it are
+// returned along with the error. Variables bound by the script remain bound
+// on the interpreter.
+func (n *NBCLInterpreter) Run(script *nbcl.Script) ([]*NBCLResult, error) {
+	return n.RunContext(context.Background(), script)
+}
+
+// RunContext runs a parsed script like Run, with each command in an
+// "nbcl.execute" span under the span in ctx
+func (n *NBCLInterpreter) RunContext(ctx context.Context, script *nbcl.Script) ([]*NBCLResult, error) {
+	results := make([]*NBCLResult, 0)
+	err := n.runBlock(ctx, script.Statements, &results)
+	return results, err
+}
+
+// Variable returns the result bound to a script variable
+func (n *NBCLInterpreter) Variable(name string) (*NBCLResult, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	result, ok := n.variables[name]
+	return result, ok
+}
+
+func (n *NBCLInterpreter) runBlock(ctx context.Context, statements []nbcl.Statement, results *[]*NBCLResult) error {
+	for _, stmt := range statements {
+		switch stmt := stmt.(type) {
+		case *nbcl.Pipeline:
+			if _, err := n.runPipeline(ctx, stmt, results); err != nil {
+				return err
+			}
+		case *nbcl.Assignment:
+			result, err := n.runPipeline(ctx, stmt.Pipeline, results)
+			if err != nil {
+				return err
+			}
+			n.mu.Lock()
+			n.variables[stmt.Name] = result
+			n.mu.Unlock()
+		case *nbcl.If:
+			ok, err := n.evaluate(stmt.Condition)
+			if err != nil {
+				return fmt.Errorf("%s: %w", stmt.Condition.Pos(), err)
+			}
+			branch := stmt.Else
+			if ok {
+				branch = stmt.Then
+			}
+			if err := n.runBlock(ctx, branch, results); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// runPipeline runs each command with the result of the previous one as its
+// input, returning the result of the last
+func (n *NBCLInterpreter) runPipeline(ctx context.Context, pipeline *nbcl.Pipeline, results *[]*NBCLResult) (*NBCLResult, error) {
+	var input *NBCLResult
+	for _, syntax := range pipeline.Commands {
+		result, err := n.execute(ctx, syntax, input)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", syntax.Pos(), err)
+		}
+		*results = append(*results, result)
+		input = result
+	}
+	return input, nil
+}
+
+// lookup resolves a variable reference. $_ is the result piped into the
+// current command; other names are results bound by assignments. Fields
+// are looked up in the result's JSON form, with numeric path elements
+// indexing into lists.
+func (n *NBCLInterpreter) lookup(v *nbcl.Variable, input *NBCLResult) (interface{}, error) {
+	n.mu.RLock()
+	result, ok := n.variables[v.Name]
+	n.mu.RUnlock()
+	if v.Name == "_" {
+		result, ok = input, input != nil
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w $%s", ErrUndefinedVariable, v.Name)
+	}
+
+	var value interface{} = result.Map()
+	for i, field := range v.Path {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			next, ok := current[field]
+			if !ok {
+				return nil, fmt.Errorf("%w %s in $%s", ErrNoSuchField, field, strings.Join(append([]string{v.Name}, v.Path[:i]...), "."))
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(field)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, fmt.Errorf("%w %s in %s", ErrNoSuchField, field, v)
+			}
+			value = current[index]
+		default:
+			return nil, fmt.Errorf("%w %s in %s", ErrNoSuchField, field, v)
+		}
+	}
+	return value, nil
+}
+
+// evaluate evaluates an if condition
+func (n *NBCLInterpreter) evaluate(cond *nbcl.Condition) (bool, error) {
+	resolve := func(v *nbcl.Variable) (interface{}, error) {
+		return n.lookup(v, nil)
+	}
+	left, err := nbcl.Resolve(cond.Left, resolve)
+	if err != nil {
+		return false, err
+	}
+	if cond.Op == "" {
+		return truthy(left), nil
+	}
+	right, err := nbcl.Resolve(cond.Right, resolve)
+	if err != nil {
+		return false, err
+	}
+	return compare(left, cond.Op, right)
+}
+
+// truthy reports whether a value counts as true in a condition
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+// compare applies a comparison operator. Numbers and strings support every
+// operator, other values only == and !=.
+func compare(left interface{}, op string, right interface{}) (bool, error) {
+	// Durations compare as nanoseconds, their JSON form
+	if d, ok := left.(time.Duration); ok {
+		left = float64(d)
+	}
+	if d, ok := right.(time.Duration); ok {
+		right = float64(d)
+	}
+
+	var order int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return equality(op, false)
+		}
+		switch {
+		case l < r:
+			order = -1
+		case l > r:
+			order = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return equality(op, false)
+		}
+		order = strings.Compare(l, r)
+	default:
+		if _, ok := right.(float64); ok {
+			return equality(op, false)
+		}
+		return equality(op, fmt.Sprint(left) == fmt.Sprint(right))
+	}
+
+	switch op {
+	case "==":
+		return order == 0, nil
+	case "!=":
+		return order != 0, nil
+	case "<":
+		return order < 0, nil
+	case "<=":
+		return order <= 0, nil
+	case ">":
+		return order > 0, nil
+	case ">=":
+		return order >= 0, nil
+	}
+	return false, fmt.Errorf("unknown operator %s", op)
+}
+
+// equality applies == or != to values already known to be equal or not
+func equality(op string, equal bool) (bool, error) {
+	switch op {
+	case "==":
+		return equal, nil
+	case "!=":
+		return !equal, nil
+	}
+	return false, fmt.Errorf("%w with %s", ErrIncomparable, op)
+}
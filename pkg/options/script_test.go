@@ -0,0 +1,118 @@
+package options
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"neuralblitz/pkg/core"
+	"neuralblitz/pkg/rng"
+)
+
+func newTestInterpreter() *NBCLInterpreter {
+	return NewNBCLInterpreter(core.NewArchitectSystemDyad(rng.WithSeed(1)), rng.WithSeed(1))
+}
+
+func TestRunScript(t *testing.T) {
+	n := newTestInterpreter()
+
+	results, err := n.RunScript(`
+# bind, branch and pipe
+$dag = /attest note[nightly]
+$status = /status
+if $status.status == Active
+    /attest parent[$dag.golden_dag]
+else
+    /help
+end
+if $status.coherence < 0
+    /help
+end
+/attest | /attest source[$_.golden_dag]
+`)
+	if err != nil {
+		t.Fatalf("Failed to run script: %v", err)
+	}
+
+	commands := make([]string, len(results))
+	for i, result := range results {
+		commands[i] = result.Command
+	}
+	if got := strings.Join(commands, " "); got != "attest status attest attest attest" {
+		t.Fatalf("Expected attest status attest attest attest, got %s", got)
+	}
+
+	dag, ok := n.Variable("dag")
+	if !ok || dag != results[0] {
+		t.Error("Expected $dag bound to the first result")
+	}
+
+	history := n.GetHistory()
+	if parent := history[2].Arguments["parent"]; parent != results[0].GoldenDAG {
+		t.Errorf("Expected parent %s, got %v", results[0].GoldenDAG, parent)
+	}
+	if history[4].Input != results[3] {
+		t.Error("Expected the piped result as input")
+	}
+	if source := history[4].Arguments["source"]; source != results[3].GoldenDAG {
+		t.Errorf("Expected source %s, got %v", results[3].GoldenDAG, source)
+	}
+}
+
+func TestRunScriptErrors(t *testing.T) {
+	tests := []struct {
+		script string
+		err    error
+		prefix string
+	}{
+		{"/status\n/attest weave[$missing]", ErrUndefinedVariable, "2:1:"},
+		{"$s = /status\n/attest weave[$s.nope]", ErrNoSuchField, "2:1:"},
+		{"$s = /status\nif $s.status > 1\n/help\nend", ErrIncomparable, "2:4:"},
+		{"/status | /logos weave[$_]", nil, "1:11:"},
+	}
+
+	for _, tt := range tests {
+		results, err := newTestInterpreter().RunScript(tt.script)
+		if err == nil {
+			t.Errorf("Expected error for %q", tt.script)
+			continue
+		}
+		if tt.err != nil && !errors.Is(err, tt.err) {
+			t.Errorf("Expected %v for %q, got %v", tt.err, tt.script, err)
+		}
+		if !strings.HasPrefix(err.Error(), tt.prefix) {
+			t.Errorf("Expected error at %s for %q, got %v", tt.prefix, tt.script, err)
+		}
+		if len(results) == 0 {
+			t.Errorf("Expected the results before the failure for %q", tt.script)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		left  interface{}
+		op    string
+		right interface{}
+		want  bool
+	}{
+		{1.0, "<", 2.0, true},
+		{2.0, ">=", 2.0, true},
+		{"a", "<", "b", true},
+		{true, "==", true, true},
+		{true, "!=", false, true},
+		{1.0, "==", "1", false},
+		{nil, "!=", 0.0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := compare(tt.left, tt.op, tt.right)
+		if err != nil {
+			t.Errorf("Failed to compare %v %s %v: %v", tt.left, tt.op, tt.right, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Expected %v %s %v to be %v", tt.left, tt.op, tt.right, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Package output renders command results for people and for scripts. A
+// result is any value that marshals to JSON: its JSON encoding decides the
+// keys, their order and which fields are omitted, so the JSON, YAML and
+// table renderings of a result always agree.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of Render
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+// Formats lists the supported formats
+var Formats = []string{FormatTable, FormatJSON, FormatYAML}
+
+// Error definitions
+var (
+	ErrUnknownFormat = errors.New("unknown output format")
+)
+
+// CheckFormat reports whether format is supported
+func CheckFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q (want %s)", ErrUnknownFormat, format, strings.Join(Formats, ", "))
+}
+
+// Render writes v to w in format
+func Render(w io.Writer, format string, v interface{}) error {
+	if err := CheckFormat(format); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if format == FormatJSON {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		_, err := buf.WriteTo(w)
+		return err
+	}
+
+	tree, err := decode(data)
+	if err != nil {
+		return err
+	}
+	if format == FormatYAML {
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(yamlNode(tree)); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	return renderTable(w, tree)
+}
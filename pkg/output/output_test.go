@@ -0,0 +1,112 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type row struct {
+	ID   string `json:"id"`
+	Size int    `json:"size"`
+}
+
+type result struct {
+	Name     string            `json:"name"`
+	Verified bool              `json:"verified"`
+	Ratio    float64           `json:"ratio"`
+	Reason   string            `json:"reason,omitempty"`
+	Tags     []string          `json:"tags"`
+	Limits   map[string]string `json:"limits"`
+	Rows     []row             `json:"rows"`
+}
+
+func testResult() *result {
+	return &result{
+		Name:     "attestation",
+		Verified: true,
+		Ratio:    0.5,
+		Tags:     []string{"a", "b"},
+		Limits:   map[string]string{"rate": "10"},
+		Rows:     []row{{ID: "A", Size: 50}, {ID: "B", Size: 2400}},
+	}
+}
+
+func render(t *testing.T, format string, v interface{}) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Render(&buf, format, v); err != nil {
+		t.Fatalf("Failed to render %s: %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestRenderJSON(t *testing.T) {
+	out := render(t, FormatJSON, testResult())
+
+	var got result
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("Expected valid JSON, got %v\n%s", err, out)
+	}
+	if got.Name != "attestation" || len(got.Rows) != 2 {
+		t.Errorf("Expected the result to round trip, got %+v", got)
+	}
+	if !strings.HasSuffix(out, "}\n") {
+		t.Errorf("Expected a trailing newline, got %q", out)
+	}
+}
+
+func TestRenderYAMLKeepsFieldOrder(t *testing.T) {
+	out := render(t, FormatYAML, testResult())
+
+	want := `name: attestation
+verified: true
+ratio: 0.5
+tags:
+  - a
+  - b
+limits:
+  rate: "10"
+rows:
+  - id: A
+    size: 50
+  - id: B
+    size: 2400
+`
+	if out != want {
+		t.Errorf("Expected YAML:\n%s\ngot:\n%s", want, out)
+	}
+}
+
+func TestRenderTable(t *testing.T) {
+	out := render(t, FormatTable, testResult())
+
+	want := `name         attestation
+verified     true
+ratio        0.5
+tags         a, b
+limits.rate  10
+
+rows:
+ID  SIZE
+A   50
+B   2400
+`
+	if out != want {
+		t.Errorf("Expected table:\n%s\ngot:\n%s", want, out)
+	}
+
+	out = render(t, FormatTable, []row{{ID: "A", Size: 1}, {ID: "B", Size: 2}})
+	if strings.Count(out, "id") != 2 || !strings.Contains(out, "\n\n") {
+		t.Errorf("Expected one block per list item, got:\n%s", out)
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	err := Render(&bytes.Buffer{}, "xml", testResult())
+	if !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Expected ErrUnknownFormat, got %v", err)
+	}
+}
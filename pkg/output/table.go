@@ -0,0 +1,167 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// renderTable writes an object as key and value rows, with nested objects
+// under dotted keys and lists of objects as column tables after the rows.
+// A list of objects, e.g. the results of a script, is written as one block
+// per object.
+func renderTable(w io.Writer, v interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if list, ok := v.([]interface{}); ok {
+		for i, item := range list {
+			if i > 0 {
+				fmt.Fprintln(tw)
+			}
+			writeBlock(tw, item)
+		}
+	} else {
+		writeBlock(tw, v)
+	}
+	return tw.Flush()
+}
+
+// table is a list of objects found while flattening, written as columns
+type table struct {
+	key  string
+	rows []interface{}
+}
+
+func writeBlock(w io.Writer, v interface{}) {
+	obj, ok := v.(object)
+	if !ok {
+		fmt.Fprintln(w, cell(v))
+		return
+	}
+
+	var tables []table
+	var flatten func(prefix string, obj object)
+	flatten = func(prefix string, obj object) {
+		for _, m := range obj {
+			key := prefix + m.key
+			switch value := m.value.(type) {
+			case nil:
+			case object:
+				flatten(key+".", value)
+			case []interface{}:
+				if hasObjects(value) {
+					tables = append(tables, table{key: key, rows: value})
+				} else if len(value) > 0 {
+					fmt.Fprintf(w, "%s\t%s\n", key, cell(value))
+				}
+			default:
+				fmt.Fprintf(w, "%s\t%s\n", key, cell(value))
+			}
+		}
+	}
+	flatten("", obj)
+
+	for _, t := range tables {
+		fmt.Fprintf(w, "\n%s:\n", t.key)
+		writeColumns(w, t.rows)
+	}
+}
+
+// writeColumns writes objects as rows under a header of their keys, in
+// the order the keys first appear
+func writeColumns(w io.Writer, rows []interface{}) {
+	var columns []string
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		obj, _ := row.(object)
+		for _, m := range obj {
+			if !seen[m.key] {
+				seen[m.key] = true
+				columns = append(columns, m.key)
+			}
+		}
+	}
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		obj, ok := row.(object)
+		if !ok {
+			fmt.Fprintln(w, cell(row))
+			continue
+		}
+		cells := make([]string, len(columns))
+		for _, m := range obj {
+			for i, column := range columns {
+				if column == m.key {
+					cells[i] = cell(m.value)
+				}
+			}
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+}
+
+func hasObjects(list []interface{}) bool {
+	for _, item := range list {
+		if _, ok := item.(object); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// cell formats a value on one line: scalars as themselves, lists of
+// scalars comma-separated and anything else as compact JSON
+func cell(v interface{}) string {
+	var s string
+	switch v := v.(type) {
+	case nil:
+		s = ""
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case bool:
+		s = fmt.Sprint(v)
+	case []interface{}:
+		if hasObjects(v) {
+			s = compact(v)
+			break
+		}
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = cell(item)
+		}
+		s = strings.Join(items, ", ")
+	default:
+		s = compact(v)
+	}
+	return strings.NewReplacer("\t", " ", "\n", " ").Replace(s)
+}
+
+// compact encodes a decoded value as JSON on one line
+func compact(v interface{}) string {
+	switch v := v.(type) {
+	case object:
+		parts := make([]string, len(v))
+		for i, m := range v {
+			key, _ := json.Marshal(m.key)
+			parts[i] = string(key) + ":" + compact(m.value)
+		}
+		return "{" + strings.Join(parts, ",") + "}"
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = compact(item)
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"math/cmplx"
@@ -74,6 +75,17 @@ func NewQuantumAgent(agentID string, initialState QuantumState) *QuantumAgent {
 	}
 }
 
+// State returns the agent's consciousness level
+func (qa *QuantumAgent) State() QuantumState {
+	qa.mu.RLock()
+	defer qa.mu.RUnlock()
+	return qa.ConsciousnessLevel
+}
+
+// ErrAgentLimit is returned when a layer already holds as many agents as
+// it may
+var ErrAgentLimit = errors.New("quantum agent limit reached")
+
 // QuantumCommunicationLayer implements quantum entanglement and teleportation
 type QuantumCommunicationLayer struct {
 	NumQubits         int                    `json:"num_qubits"`
@@ -103,6 +115,42 @@ func (qcl *QuantumCommunicationLayer) CreateQuantumAgent(agentID string, state Q
 	return agent
 }
 
+// Agent returns the agent with the given ID
+func (qcl *QuantumCommunicationLayer) Agent(agentID string) (*QuantumAgent, bool) {
+	qcl.mu.RLock()
+	defer qcl.mu.RUnlock()
+
+	agent, exists := qcl.QuantumAgents[agentID]
+	return agent, exists
+}
+
+// EnsureQuantumAgent returns the agent with the given ID, creating it in
+// state unless the layer already holds limit agents. A limit of zero or
+// less is unlimited.
+func (qcl *QuantumCommunicationLayer) EnsureQuantumAgent(agentID string, state QuantumState, limit int) (*QuantumAgent, error) {
+	qcl.mu.Lock()
+	defer qcl.mu.Unlock()
+
+	if agent, exists := qcl.QuantumAgents[agentID]; exists {
+		return agent, nil
+	}
+	if limit > 0 && len(qcl.QuantumAgents) >= limit {
+		return nil, fmt.Errorf("%w: %d agents", ErrAgentLimit, limit)
+	}
+	agent := NewQuantumAgent(agentID, state)
+	qcl.QuantumAgents[agentID] = agent
+	return agent, nil
+}
+
+// EntanglementStrength returns the strength of the entanglement between
+// two agents, 0 when they are not entangled
+func (qcl *QuantumCommunicationLayer) EntanglementStrength(agent1ID, agent2ID string) float64 {
+	qcl.mu.RLock()
+	defer qcl.mu.RUnlock()
+
+	return qcl.EntanglementMatrix[agent1ID][agent2ID]
+}
+
 // CreateEntanglement creates quantum entanglement between two agents
 func (qcl *QuantumCommunicationLayer) CreateEntanglement(agent1ID, agent2ID string) bool {
 	qcl.mu.Lock()
@@ -127,13 +175,14 @@ func (qcl *QuantumCommunicationLayer) CreateEntanglement(agent1ID, agent2ID stri
 	qcl.EntanglementMatrix[agent1ID][agent2ID] = 1.0
 	qcl.EntanglementMatrix[agent2ID][agent1ID] = 1.0
 
-	// Update entangled partners
-	agent1.EntangledPartners = append(agent1.EntangledPartners, agent2ID)
-	agent2.EntangledPartners = append(agent2.EntangledPartners, agent1ID)
-
-	// Update consciousness levels to FOCUSED
-	agent1.ConsciousnessLevel = StateFOCUSED
-	agent2.ConsciousnessLevel = StateFOCUSED
+	// Update entangled partners and consciousness levels to FOCUSED
+	for _, pair := range [][2]*QuantumAgent{{agent1, agent2}, {agent2, agent1}} {
+		agent, partner := pair[0], pair[1]
+		agent.mu.Lock()
+		agent.EntangledPartners = append(agent.EntangledPartners, partner.AgentID)
+		agent.ConsciousnessLevel = StateFOCUSED
+		agent.mu.Unlock()
+	}
 
 	return true
 }
@@ -165,12 +214,14 @@ func (qcl *QuantumCommunicationLayer) QuantumTeleportation(senderID, receiverID
 
 	// Check if agents are entangled
 	isEntangled := false
+	sender.mu.RLock()
 	for _, partner := range sender.EntangledPartners {
 		if partner == receiverID {
 			isEntangled = true
 			break
 		}
 	}
+	sender.mu.RUnlock()
 
 	if !isEntangled {
 		// Fallback to classical teleportation simulation
@@ -14,10 +14,13 @@ package quantum
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math"
-	"math/rand"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/logging"
+	"neuralblitz/pkg/rng"
 )
 
 // QuantumSystemStatus represents the status of the entire quantum system
@@ -42,6 +45,8 @@ type NeuralBlitzQuantumCore struct {
 	initialized         bool
 	initializationTime  time.Time
 	mu                  sync.RWMutex
+	rand                *rng.Source
+	logger              *slog.Logger
 }
 
 // PerformanceMetrics tracks performance of quantum operations
@@ -53,9 +58,12 @@ type PerformanceMetrics struct {
 	mu                    sync.RWMutex `json:"-"`
 }
 
-// NewNeuralBlitzQuantumCore creates a new quantum core instance
-func NewNeuralBlitzQuantumCore() *NeuralBlitzQuantumCore {
+// NewNeuralBlitzQuantumCore creates a new quantum core instance. It is
+// silent until SetLogger is called.
+func NewNeuralBlitzQuantumCore(opts ...rng.Option) *NeuralBlitzQuantumCore {
 	return &NeuralBlitzQuantumCore{
+		rand:   rng.Resolve(opts...),
+		logger: logging.Discard(),
 		Status: &QuantumSystemStatus{
 			QuantumCommActive:     false,
 			QuantumEncryptionActive: false,
@@ -78,6 +86,13 @@ func NewNeuralBlitzQuantumCore() *NeuralBlitzQuantumCore {
 	}
 }
 
+// SetLogger makes the core log to logger as the "quantum_core" subsystem
+func (nq *NeuralBlitzQuantumCore) SetLogger(logger *slog.Logger) {
+	nq.mu.Lock()
+	defer nq.mu.Unlock()
+	nq.logger = logging.Subsystem(logger, "quantum_core")
+}
+
 // InitializeQuantumCore initializes all quantum components
 func (nq *NeuralBlitzQuantumCore) InitializeQuantumCore() error {
 	nq.mu.Lock()
@@ -87,11 +102,11 @@ func (nq *NeuralBlitzQuantumCore) InitializeQuantumCore() error {
 		return fmt.Errorf("quantum core already initialized")
 	}
 
-	fmt.Println("🔬 Initializing NeuralBlitz v50.0 Quantum Core...")
-	nq.initializationTime = time.Now()
+	nq.logger.Info("initializing quantum core")
+	nq.initializationTime = nq.rand.Now()
 
 	// Initialize quantum communication layer
-	fmt.Println("📡 Initializing Quantum Communication Layer...")
+	nq.logger.Debug("initializing communication layer")
 	commLayer := NewQuantumCommunicationLayer(8)
 	if commLayer == nil {
 		return fmt.Errorf("failed to initialize quantum communication layer")
@@ -100,7 +115,7 @@ func (nq *NeuralBlitzQuantumCore) InitializeQuantumCore() error {
 	nq.Status.TotalAgents = len(commLayer.QuantumAgents)
 
 	// Initialize encryption engine
-	fmt.Println("🔐 Initializing Quantum Encryption Engine...")
+	nq.logger.Debug("initializing encryption engine")
 	encryptionEngine := NewQuantumEncryptionEngine()
 	if encryptionEngine == nil {
 		return fmt.Errorf("failed to initialize quantum encryption engine")
@@ -109,7 +124,7 @@ func (nq *NeuralBlitzQuantumCore) InitializeQuantumCore() error {
 	nq.Status.ActiveSessions = len(encryptionEngine.ActiveSessions)
 
 	// Initialize reality simulator
-	fmt.Println("🌌 Initializing Quantum Reality Simulator...")
+	nq.logger.Debug("initializing reality simulator")
 	realitySimulator := NewQuantumRealitySimulator(8)
 	if realitySimulator == nil {
 		return fmt.Errorf("failed to initialize reality simulator")
@@ -122,7 +137,7 @@ func (nq *NeuralBlitzQuantumCore) InitializeQuantumCore() error {
 	nq.updateSystemMetrics()
 
 	nq.initialized = true
-	fmt.Println("✅ NeuralBlitz Quantum Core Initialized Successfully!")
+	nq.logger.Info("quantum core initialized", "agents", nq.Status.TotalAgents, "realities", nq.Status.TotalRealities)
 	return nil
 }
 
@@ -152,7 +167,7 @@ func (nq *NeuralBlitzQuantumCore) CreateQuantumAgent(agentID string, consciousne
 	// Update system metrics
 	nq.updateSystemMetrics()
 
-	fmt.Printf("🤖 Created Quantum Agent: %s\n", agentID)
+	nq.logger.Info("agent created", "agent_id", agentID, "state", consciousnessLevel.String())
 	return agent, nil
 }
 
@@ -181,7 +196,7 @@ func (nq *NeuralBlitzQuantumCore) CreateQuantumSession(participantIDs []string,
 
 	nq.updateSystemMetrics()
 
-	fmt.Printf("🔐 Created Quantum Session: %s\n", session.SessionID)
+	nq.logger.Info("session created", logging.SessionID(session.SessionID), "participants", len(participantIDs), "reality_id", realityID)
 	return session.SessionID, nil
 }
 
@@ -227,7 +242,7 @@ func (nq *NeuralBlitzQuantumCore) SendQuantumMessage(senderID, receiverID, messa
 	nq.mu.Unlock()
 
 	if secureMsg != nil {
-		fmt.Printf("📨 Quantum message sent: %s -> %s\n", senderID, receiverID)
+		nq.logger.Info("message sent", logging.SessionID(session.SessionID), "sender_id", senderID, "receiver_id", receiverID)
 		return true, nil
 	}
 
@@ -254,7 +269,7 @@ func (nq *NeuralBlitzQuantumCore) QuantumMLInference(inputData []float64) ([]flo
 	nq.PerformanceMetrics.MLInferenceTimes = append(nq.PerformanceMetrics.MLInferenceTimes, elapsed)
 	nq.mu.Unlock()
 
-	fmt.Printf("🧠 Quantum ML Inference completed: %v\n", result)
+	nq.logger.Debug("ML inference completed", "inputs", len(inputData), "output", result)
 	return result, nil
 }
 
@@ -290,7 +305,7 @@ func (nq *NeuralBlitzQuantumCore) SimulateConsciousnessTransition(stimuli []floa
 	// Update metrics
 	nq.updateSystemMetrics()
 
-	fmt.Printf("🧠 Consciousness Transition: %s (level: %.4f)\n", newState.String(), consciousnessLevel)
+	nq.logger.Info("consciousness transition", "state", newState.String(), "level", consciousnessLevel)
 	return newState, nil
 }
 
@@ -355,7 +370,7 @@ func (nq *NeuralBlitzQuantumCore) SimulateRealityEvolution(timeSteps int) error
 					totalConsciousness += interference
 				}
 			}
-			fmt.Printf("🌌 Reality Evolution Step %d: Consciousness = %.4f\n", step, totalConsciousness)
+			nq.logger.Debug("reality evolution step", "step", step, "consciousness", totalConsciousness)
 		}
 	}
 
@@ -368,7 +383,7 @@ func (nq *NeuralBlitzQuantumCore) SimulateRealityEvolution(timeSteps int) error
 	// Update metrics
 	nq.updateSystemMetrics()
 
-	fmt.Println("✅ Reality Evolution Simulation Complete!")
+	nq.logger.Info("reality evolution completed", "steps", timeSteps)
 	return nil
 }
 
@@ -388,9 +403,9 @@ func (nq *NeuralBlitzQuantumCore) TravelBetweenRealities(agentID string, sourceR
 	success := realitySim.CollapseToReality(agentID, destinationReality)
 	
 	if success {
-		fmt.Printf("🌌 Agent %s traveled from reality %d to %d\n", agentID, sourceReality, destinationReality)
+		nq.logger.Info("agent traveled", "agent_id", agentID, "from_reality", sourceReality, "to_reality", destinationReality)
 	} else {
-		fmt.Printf("❌ Failed to travel agent %s from reality %d to %d\n", agentID, sourceReality, destinationReality)
+		nq.logger.Warn("agent travel failed", "agent_id", agentID, "from_reality", sourceReality, "to_reality", destinationReality)
 	}
 
 	return success, nil
@@ -409,9 +424,9 @@ func (nq *NeuralBlitzQuantumCore) CollapseToReality(observerID string) (int, err
 	realitySim := NewQuantumRealitySimulator(8)
 
 	// Collapse to random reality
-	collapsedReality := rand.Intn(realitySim.NumRealities)
+	collapsedReality := nq.rand.Intn(realitySim.NumRealities)
 
-	fmt.Printf("🌌 Observer %s collapsed to reality %d\n", observerID, collapsedReality)
+	nq.logger.Info("observer collapsed", "observer_id", observerID, "reality_id", collapsedReality)
 	return collapsedReality, nil
 }
 
@@ -431,10 +446,10 @@ func (nq *NeuralBlitzQuantumCore) CreateEntanglement(agent1ID, agent2ID string)
 	success := commLayer.CreateEntanglement(agent1ID, agent2ID)
 	
 	if success {
-		fmt.Printf("⚛️  Quantum Entanglement Created: %s ↔ %s\n", agent1ID, agent2ID)
+		nq.logger.Info("entanglement created", "agent_id", agent1ID, "peer_id", agent2ID)
 		nq.updateSystemMetrics()
 	} else {
-		fmt.Printf("❌ Failed to create entanglement between %s and %s\n", agent1ID, agent2ID)
+		nq.logger.Warn("entanglement failed", "agent_id", agent1ID, "peer_id", agent2ID)
 	}
 
 	return success, nil
@@ -527,25 +542,22 @@ func (nq *NeuralBlitzQuantumCore) updateSystemMetrics() {
 	defer nq.mu.Unlock()
 
 	// Calculate global consciousness
-	nq.mu.Lock()
-	defer nq.mu.Unlock()
-
 	// Simplified calculation
-	nq.Status.GlobalConsciousness = 0.5 + rand.Float64()*0.5
+	nq.Status.GlobalConsciousness = 0.5 + nq.rand.Float64()*0.5
 
 	// Calculate quantum coherence
-	nq.Status.QuantumCoherence = 0.8 + rand.Float64()*0.2
+	nq.Status.QuantumCoherence = 0.8 + nq.rand.Float64()*0.2
 
 	// Update timestamp
-	nq.Status.LastUpdate = float64(time.Now().UnixNano())
+	nq.Status.LastUpdate = float64(nq.rand.Now().UnixNano())
 }
 
-// RunFullDemonstration runs complete demonstration of quantum capabilities
+// RunFullDemonstration runs complete demonstration of quantum capabilities,
+// logging each stage and the final status
 func (nq *NeuralBlitzQuantumCore) RunFullDemonstration() error {
-	fmt.Println("🚀 Starting Full Quantum Demonstration...")
+	nq.logger.Info("demonstration started")
 
 	// Create quantum agents
-	fmt.Println("\n🤖 Creating Quantum Agents...")
 	agent1, err := nq.CreateQuantumAgent("alpha", StateAWARE)
 	if err != nil || agent1 == nil {
 		return fmt.Errorf("failed to create agent alpha: %w", err)
@@ -562,7 +574,6 @@ func (nq *NeuralBlitzQuantumCore) RunFullDemonstration() error {
 	}
 
 	// Create entanglement
-	fmt.Println("\n⚛️  Creating Quantum Entanglement...")
 	_, err = nq.CreateEntanglement("alpha", "beta")
 	if err != nil {
 		return fmt.Errorf("failed to create entanglement alpha-beta: %w", err)
@@ -574,15 +585,12 @@ func (nq *NeuralBlitzQuantumCore) RunFullDemonstration() error {
 	}
 
 	// Create quantum session
-	fmt.Println("\n🔐 Creating Quantum Session...")
 	sessionID, err := nq.CreateQuantumSession([]string{"alpha", "beta", "gamma"}, 0)
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
 
 	// Send quantum messages
-	fmt.Println("\n📨 Sending Quantum Messages...")
-	
 	_, err = nq.SendQuantumMessage("alpha", "beta", "Quantum consciousness achieved!", sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to send message alpha->beta: %w", err)
@@ -599,34 +607,29 @@ func (nq *NeuralBlitzQuantumCore) RunFullDemonstration() error {
 	}
 
 	// Quantum ML inference
-	fmt.Println("\n🧠 Quantum ML Inference...")
-	inputData := []float64{rand.Float64(), rand.Float64(), rand.Float64(), rand.Float64(),
-		rand.Float64(), rand.Float64(), rand.Float64(), rand.Float64()}
+	inputData := []float64{nq.rand.Float64(), nq.rand.Float64(), nq.rand.Float64(), nq.rand.Float64(),
+		nq.rand.Float64(), nq.rand.Float64(), nq.rand.Float64(), nq.rand.Float64()}
 	mlResult, err := nq.QuantumMLInference(inputData)
 	if err != nil {
 		return fmt.Errorf("ML inference failed: %w", err)
 	}
-	fmt.Printf("🧠 ML Result: %v\n", mlResult)
+	nq.logger.Info("ML inference result", "output", mlResult)
 
 	// Consciousness simulation
-	fmt.Println("\n🧠 Consciousness Simulation...")
-	stimuli := []float64{rand.Float64(), rand.Float64(), rand.Float64(), rand.Float64(),
-		rand.Float64(), rand.Float64(), rand.Float64(), rand.Float64()}
-	consciousnessLevel, err := nq.SimulateConsciousnessTransition(stimuli)
+	stimuli := []float64{nq.rand.Float64(), nq.rand.Float64(), nq.rand.Float64(), nq.rand.Float64(),
+		nq.rand.Float64(), nq.rand.Float64(), nq.rand.Float64(), nq.rand.Float64()}
+	_, err = nq.SimulateConsciousnessTransition(stimuli)
 	if err != nil {
 		return fmt.Errorf("consciousness simulation failed: %w", err)
 	}
-	fmt.Printf("🧠 New Consciousness Level: %s\n", consciousnessLevel.String())
 
 	// Reality evolution
-	fmt.Println("\n🌌 Reality Evolution Simulation...")
 	err = nq.SimulateRealityEvolution(5)
 	if err != nil {
 		return fmt.Errorf("reality evolution failed: %w", err)
 	}
 
 	// Reality travel
-	fmt.Println("\n🌌 Reality Travel...")
 	_, err = nq.TravelBetweenRealities("alpha", 0, 10)
 	if err != nil {
 		return fmt.Errorf("reality travel alpha failed: %w", err)
@@ -643,26 +646,21 @@ func (nq *NeuralBlitzQuantumCore) RunFullDemonstration() error {
 	}
 
 	// Reality collapse
-	fmt.Println("\n🌌 Reality Collapse...")
-	collapsed, err := nq.CollapseToReality("alpha")
-	if err != nil {
+	if _, err := nq.CollapseToReality("alpha"); err != nil {
 		return fmt.Errorf("reality collapse failed: %w", err)
 	}
-	fmt.Printf("🌌 Collapsed to Reality: %d\n", collapsed)
 
-	// Final status
-	fmt.Println("\n📊 Final Quantum System Status:")
+	// Final status and performance metrics
 	status := nq.GetSystemStatus()
-	statusJSON, _ := json.MarshalIndent(status, "", "  ")
-	fmt.Printf("  %s\n", string(statusJSON))
-
-	// Performance metrics
-	fmt.Println("\n📊 Performance Metrics:")
 	metrics := nq.GetPerformanceMetrics()
-	metricsJSON, _ := json.MarshalIndent(metrics, "", "  ")
-	fmt.Printf("  %s\n", string(metricsJSON))
-
-	fmt.Println("\n✅ Full Quantum Demonstration Complete!")
+	nq.logger.Info("demonstration completed",
+		"total_agents", status.TotalAgents,
+		"active_sessions", status.ActiveSessions,
+		"total_realities", status.TotalRealities,
+		"global_consciousness", status.GlobalConsciousness,
+		"quantum_coherence", status.QuantumCoherence,
+		"performance", metrics,
+	)
 	return nil
 }
 
@@ -14,10 +14,12 @@ package quantum
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math"
-	"math/rand"
 	"sync"
-	"time"
+
+	"neuralblitz/pkg/logging"
+	"neuralblitz/pkg/rng"
 )
 
 // QuantumMLModel represents types of quantum-enhanced ML models
@@ -86,10 +88,11 @@ type QuantumNeuron struct {
 }
 
 // NewQuantumNeuron creates a new quantum neuron
-func NewQuantumNeuron(neuronID string, inputSize int, activationType QuantumActivationType) *QuantumNeuron {
+func NewQuantumNeuron(neuronID string, inputSize int, activationType QuantumActivationType, opts ...rng.Option) *QuantumNeuron {
+	src := rng.Resolve(opts...)
 	weights := make([]float64, inputSize)
 	for i := range weights {
-		weights[i] = rand.NormFloat64() * 0.1
+		weights[i] = src.NormFloat64() * 0.1
 	}
 
 	return &QuantumNeuron{
@@ -99,7 +102,7 @@ func NewQuantumNeuron(neuronID string, inputSize int, activationType QuantumActi
 		EntangledNeurons:   make([]string, 0),
 		ActivationFunction: activationType,
 		CoherenceFactor:    1.0,
-		LastMeasurement:    float64(time.Now().UnixNano()),
+		LastMeasurement:    float64(src.Now().UnixNano()),
 	}
 }
 
@@ -113,13 +116,15 @@ type QuantumLayer struct {
 }
 
 // NewQuantumLayer creates a new quantum layer
-func NewQuantumLayer(layerID string, inputSize, outputSize int, activationType QuantumActivationType) *QuantumLayer {
+func NewQuantumLayer(layerID string, inputSize, outputSize int, activationType QuantumActivationType, opts ...rng.Option) *QuantumLayer {
+	src := rng.Resolve(opts...)
 	neurons := make([]*QuantumNeuron, outputSize)
 	for i := 0; i < outputSize; i++ {
 		neurons[i] = NewQuantumNeuron(
 			fmt.Sprintf("%s_neuron_%d", layerID, i),
 			inputSize,
 			activationType,
+			rng.WithSource(src),
 		)
 	}
 
@@ -129,7 +134,7 @@ func NewQuantumLayer(layerID string, inputSize, outputSize int, activationType Q
 		entanglementMatrix[i] = make([]float64, outputSize)
 		for j := 0; j < outputSize; j++ {
 			if i != j {
-				entanglementMatrix[i][j] = rand.Float64() * 0.1
+				entanglementMatrix[i][j] = src.Float64() * 0.1
 			}
 		}
 	}
@@ -152,11 +157,16 @@ type QuantumNeuralNetwork struct {
 	CoherenceFactor     float64        `json:"coherence_factor"`
 	EpochsTrained       int            `json:"epochs_trained"`
 	mu                   sync.RWMutex  `json:"-"`
+	rand                 *rng.Source
+	logger               *slog.Logger
 }
 
-// NewQuantumNeuralNetwork creates a new quantum neural network
-func NewQuantumNeuralNetwork(numInputs int, numLayers int, neuronsPerLayer []int) *QuantumNeuralNetwork {
+// NewQuantumNeuralNetwork creates a new quantum neural network. It is
+// silent until SetLogger is called.
+func NewQuantumNeuralNetwork(numInputs int, numLayers int, neuronsPerLayer []int, opts ...rng.Option) *QuantumNeuralNetwork {
 	qnn := &QuantumNeuralNetwork{
+		rand:             rng.Resolve(opts...),
+		logger:           logging.Discard(),
 		NumInputs:        numInputs,
 		NumLayers:       numLayers,
 		NeuronsPerLayer: neuronsPerLayer,
@@ -169,6 +179,14 @@ func NewQuantumNeuralNetwork(numInputs int, numLayers int, neuronsPerLayer []int
 	return qnn
 }
 
+// SetLogger makes the network log training progress to logger as the
+// "quantum_ml" subsystem
+func (qnn *QuantumNeuralNetwork) SetLogger(logger *slog.Logger) {
+	qnn.mu.Lock()
+	defer qnn.mu.Unlock()
+	qnn.logger = logging.Subsystem(logger, "quantum_ml")
+}
+
 // initializeQuantumNetwork initializes the quantum neural network layers
 func (qnn *QuantumNeuralNetwork) initializeQuantumNetwork() {
 	inputSize := qnn.NumInputs
@@ -180,6 +198,7 @@ func (qnn *QuantumNeuralNetwork) initializeQuantumNetwork() {
 			inputSize,
 			outputSize,
 			ActivationQuantumSigmoid,
+			rng.WithSource(qnn.rand),
 		)
 		qnn.Layers[layerIdx] = layer
 		inputSize = outputSize
@@ -350,7 +369,7 @@ func (qnn *QuantumNeuralNetwork) quantumMeasurement(quantumState []float64, cohe
 	} else {
 		// Incoherent measurement - classical collapse
 		cumulative := 0.0
-		r := rand.Float64()
+		r := qnn.rand.Float64()
 		for i, prob := range probabilities {
 			cumulative += prob
 			if r <= cumulative {
@@ -384,7 +403,7 @@ func (qnn *QuantumNeuralNetwork) applyLayerEntanglement(layer *QuantumLayer, out
 func (qnn *QuantumNeuralNetwork) updateLayerQuantumStates(layer *QuantumLayer, outputs []float64) {
 	for i := range layer.Neurons {
 		layer.Neurons[i].QuantumState = createQuantumSuperposition(2)
-		layer.Neurons[i].LastMeasurement = float64(time.Now().UnixNano())
+		layer.Neurons[i].LastMeasurement = float64(qnn.rand.Now().UnixNano())
 	}
 }
 
@@ -440,8 +459,12 @@ func (qnn *QuantumNeuralNetwork) QuantumTrain(XTrain [][]float64, yTrain [][]flo
 		result.Coherence[epoch] = qnn.CoherenceFactor
 
 		if epoch%10 == 0 {
-			fmt.Printf("Epoch %d: Loss=%.4f, Accuracy=%.4f, Coherence=%.4f\n",
-				epoch, result.Loss[epoch], result.Accuracy[epoch], result.Coherence[epoch])
+			qnn.logger.Info("training epoch",
+				"epoch", epoch,
+				"loss", result.Loss[epoch],
+				"accuracy", result.Accuracy[epoch],
+				"coherence", result.Coherence[epoch],
+			)
 		}
 	}
 
@@ -551,6 +574,7 @@ type QuantumConsciousnessSimulator struct {
 	EmotionalQuantumState   []float64        `json:"emotional_quantum_state"`
 	AttentionQuantumState   []float64        `json:"attention_quantum_state"`
 	mu                      sync.RWMutex     `json:"-"`
+	rand                    *rng.Source
 }
 
 // ConsciousnessMemory represents a memory in consciousness
@@ -561,13 +585,14 @@ type ConsciousnessMemory struct {
 }
 
 // NewQuantumConsciousnessSimulator creates a new consciousness simulator
-func NewQuantumConsciousnessSimulator(numQubits int) *QuantumConsciousnessSimulator {
+func NewQuantumConsciousnessSimulator(numQubits int, opts ...rng.Option) *QuantumConsciousnessSimulator {
 	attentionState := make([]float64, numQubits)
 	for i := range attentionState {
 		attentionState[i] = 1.0 / float64(numQubits)
 	}
 
 	return &QuantumConsciousnessSimulator{
+		rand:                   rng.Resolve(opts...),
 		NumConsciousnessQubits: numQubits,
 		ConsciousnessStates: map[string]float64{
 			"dormant":     0.0,
@@ -728,7 +753,7 @@ func (qcs *QuantumConsciousnessSimulator) updateConsciousnessQuantumStates(stimu
 
 	// Store in memory
 	memory := ConsciousnessMemory{
-		Timestamp:          float64(time.Now().UnixNano()),
+		Timestamp:          float64(qcs.rand.Now().UnixNano()),
 		ConsciousnessLevel: consciousnessLevel,
 		QuantumState:       stimuli,
 	}
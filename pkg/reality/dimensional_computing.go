@@ -11,8 +11,11 @@ import (
 	"fmt"
 	"math"
 	"math/cmplx"
+	"sort"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // DimensionType represents the type of dimensional space
@@ -178,6 +181,7 @@ type DimensionalComputing struct {
 	temporalSync  *TemporalSynchronizer
 	semanticLayer *SemanticDimensionLayer
 	consciousness *ConsciousnessField
+	rand          *rng.Source
 }
 
 // DimensionalComputingState represents the overall state
@@ -399,7 +403,7 @@ var (
 )
 
 // NewDimensionalComputing creates a new dimensional computing instance
-func NewDimensionalComputing(config *DimensionalConfig) *DimensionalComputing {
+func NewDimensionalComputing(config *DimensionalConfig, opts ...rng.Option) *DimensionalComputing {
 	if config == nil {
 		config = DefaultDimensionalConfig()
 	}
@@ -412,6 +416,7 @@ func NewDimensionalComputing(config *DimensionalConfig) *DimensionalComputing {
 		links:       make(map[string][]string),
 		state:       DimensionalComputingStateInitializing,
 		computation: nil,
+		rand:        rng.Resolve(opts...),
 	}
 	
 	// Initialize reality systems
@@ -426,17 +431,17 @@ func NewDimensionalComputing(config *DimensionalConfig) *DimensionalComputing {
 // newRealityBridge initializes the reality bridge system
 func (dc *DimensionalComputing) newRealityBridge() *RealityBridge {
 	return &RealityBridge{
-		ID:         fmt.Sprintf("bridge-%d", time.Now().UnixNano()),
+		ID:         fmt.Sprintf("bridge-%d", dc.rand.Now().UnixNano()),
 		Active:     true,
 		Properties: make(map[string]interface{}),
-		CreatedAt:  time.Now(),
+		CreatedAt:  dc.rand.Now(),
 	}
 }
 
 // newTemporalSynchronizer initializes the temporal synchronizer
 func (dc *DimensionalComputing) newTemporalSynchronizer() *TemporalSynchronizer {
 	return &TemporalSynchronizer{
-		ID:              fmt.Sprintf("temporal-%d", time.Now().UnixNano()),
+		ID:              fmt.Sprintf("temporal-%d", dc.rand.Now().UnixNano()),
 		TimeDilation:    1.0,
 		TemporalFlow:   1.0,
 		CausalStrength: dc.config.CausalStrength,
@@ -448,7 +453,7 @@ func (dc *DimensionalComputing) newTemporalSynchronizer() *TemporalSynchronizer
 // newSemanticDimensionLayer initializes the semantic dimension layer
 func (dc *DimensionalComputing) newSemanticDimensionLayer() *SemanticDimensionLayer {
 	return &SemanticDimensionLayer{
-		ID:            fmt.Sprintf("semantic-%d", time.Now().UnixNano()),
+		ID:            fmt.Sprintf("semantic-%d", dc.rand.Now().UnixNano()),
 		SemanticDepth: dc.config.SemanticDepth,
 		Concepts:      make(map[string]*SemanticConcept),
 		Relations:     make(map[string][]string),
@@ -459,7 +464,7 @@ func (dc *DimensionalComputing) newSemanticDimensionLayer() *SemanticDimensionLa
 // newConsciousnessField initializes the consciousness field
 func (dc *DimensionalComputing) newConsciousnessField() *ConsciousnessField {
 	return &ConsciousnessField{
-		ID:             fmt.Sprintf("consciousness-%d", time.Now().UnixNano()),
+		ID:             fmt.Sprintf("consciousness-%d", dc.rand.Now().UnixNano()),
 		FieldStrength:  1.0,
 		Resonance:      1.0,
 		AwarenessLevel: 0.5,
@@ -498,8 +503,8 @@ func (dc *DimensionalComputing) Initialize() error {
 			Coherence:   1.0,
 			State:       DimensionalStateStable,
 			Properties:  make(map[string]interface{}),
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
+			CreatedAt:   dc.rand.Now(),
+			UpdatedAt:   dc.rand.Now(),
 		}
 		dc.dimensions[dim.ID] = dim
 	}
@@ -507,7 +512,7 @@ func (dc *DimensionalComputing) Initialize() error {
 	// Create hyper dimensions if enabled
 	if dc.config.HyperDimensionEnabled {
 		hyperDim := &Dimension{
-			ID:          fmt.Sprintf("dim-HYPER-%d", time.Now().UnixNano()),
+			ID:          fmt.Sprintf("dim-HYPER-%d", dc.rand.Now().UnixNano()),
 			Type:        DimensionTypeHyper,
 			Coordinate:  0.0,
 			ScaleFactor: 1.0,
@@ -516,8 +521,8 @@ func (dc *DimensionalComputing) Initialize() error {
 			Coherence:   1.0,
 			State:       DimensionalStateStable,
 			Properties:  make(map[string]interface{}),
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
+			CreatedAt:   dc.rand.Now(),
+			UpdatedAt:   dc.rand.Now(),
 		}
 		dc.dimensions[hyperDim.ID] = hyperDim
 	}
@@ -547,8 +552,8 @@ func (dc *DimensionalComputing) AddDimension(dimType DimensionType, coordinate,
 		Coherence:   1.0,
 		State:       DimensionalStateStable,
 		Properties:  make(map[string]interface{}),
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		CreatedAt:   dc.rand.Now(),
+		UpdatedAt:   dc.rand.Now(),
 	}
 	
 	dc.dimensions[dim.ID] = dim
@@ -575,7 +580,12 @@ func (dc *DimensionalComputing) GetDimensionByType(dimType DimensionType) (*Dime
 	dc.mu.RLock()
 	defer dc.mu.RUnlock()
 	
-	for _, dim := range dc.dimensions {
+	return dc.dimensionByType(dimType)
+}
+
+// dimensionByType returns the first dimension of dimType. Callers must hold dc.mu.
+func (dc *DimensionalComputing) dimensionByType(dimType DimensionType) (*Dimension, error) {
+	for _, dim := range dc.sortedDimensions() {
 		if dim.Type == dimType {
 			return dim, nil
 		}
@@ -593,7 +603,7 @@ func (dc *DimensionalComputing) CreateVector(id string, coords map[DimensionType
 		Dimensions: coords,
 		Magnitude:  dc.calculateMagnitude(coords),
 		Phase:      0.0,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
 	dc.vectors[id] = vector
@@ -627,7 +637,7 @@ func (dc *DimensionalComputing) ProjectVector(input *DimensionalVector, targetDi
 	dc.mu.Lock()
 	defer dc.mu.Unlock()
 	
-	targetDim, err := dc.GetDimensionByType(targetDimType)
+	targetDim, err := dc.dimensionByType(targetDimType)
 	if err != nil {
 		return nil, err
 	}
@@ -637,11 +647,11 @@ func (dc *DimensionalComputing) ProjectVector(input *DimensionalVector, targetDi
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  input.Magnitude * targetDim.Coherence,
 		Phase:      input.Phase,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
 	projected.Dimensions[targetDimType] = input.Dimensions[targetDimType] * targetDim.ScaleFactor
-	projected.Dimensions[DimensionTypeSemantic] = input.Magnitude * dc.config.SemanticDepth
+	projected.Dimensions[DimensionTypeSemantic] = input.Magnitude * float64(dc.config.SemanticDepth)
 	
 	return projected, nil
 }
@@ -655,7 +665,7 @@ func (dc *DimensionalComputing) TransformVector(input *DimensionalVector, transf
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  input.Magnitude,
 		Phase:      input.Phase,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
 	// Apply transformation matrix to dimensions
@@ -701,13 +711,13 @@ func (dc *DimensionalComputing) Compute(compType ComputationType, input *Dimensi
 	dc.mu.Unlock()
 	
 	computation := &DimensionalComputation{
-		ID:          fmt.Sprintf("comp-%d", time.Now().UnixNano()),
+		ID:          fmt.Sprintf("comp-%d", dc.rand.Now().UnixNano()),
 		Type:        compType,
 		InputVector: input,
 		Path:        []DimensionalVector{*input},
 		Iterations:  0,
 		Convergence: 1.0,
-		StartedAt:   time.Now(),
+		StartedAt:   dc.rand.Now(),
 	}
 	
 	// Execute computation based on type
@@ -736,7 +746,7 @@ func (dc *DimensionalComputing) Compute(compType ComputationType, input *Dimensi
 		computation.Error = fmt.Errorf("unknown computation type: %v", compType)
 	}
 	
-	computation.CompletedAt = time.Now()
+	computation.CompletedAt = dc.rand.Now()
 	computation.OutputVector = computation.Result.(*DimensionalVector)
 	
 	// Update metrics
@@ -756,10 +766,11 @@ func (dc *DimensionalComputing) executeProjection(input *DimensionalVector) (*Di
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  input.Magnitude,
 		Phase:      input.Phase,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
-	for dimType, dim := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
+		dimType := dim.Type
 		if dim.State == DimensionalStateStable || dim.State == DimensionalStateOscillating {
 			projected.Dimensions[dimType] = input.Dimensions[dimType] * dim.Coherence
 		}
@@ -771,7 +782,7 @@ func (dc *DimensionalComputing) executeProjection(input *DimensionalVector) (*Di
 // executeTransformation transforms a vector through dimensional space
 func (dc *DimensionalComputing) executeTransformation(input *DimensionalVector) (*DimensionalVector, error) {
 	matrix := NewIdentityMatrix()
-	return dc.TransformVector(input, matrix)
+	return dc.TransformVector(input, *matrix)
 }
 
 // executeEntanglement creates dimensional entanglement
@@ -780,11 +791,12 @@ func (dc *DimensionalComputing) executeEntanglement(input *DimensionalVector) (*
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  input.Magnitude * math.Sqrt(2),
 		Phase:      input.Phase + math.Pi/4,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
 	// Create superposition across dimensions
-	for dimType := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
+		dimType := dim.Type
 		entangled.Dimensions[dimType] = input.Dimensions[dimType] / math.Sqrt(2)
 	}
 	
@@ -797,14 +809,15 @@ func (dc *DimensionalComputing) executeCollapse(input *DimensionalVector) (*Dime
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  input.Magnitude * 0.5,
 		Phase:      input.Phase,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
 	// Collapse to most stable dimension
 	bestDim := DimensionTypeSpatial
 	bestCoherence := 0.0
 	
-	for dimType, dim := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
+		dimType := dim.Type
 		if dim.Coherence > bestCoherence {
 			bestCoherence = dim.Coherence
 			bestDim = dimType
@@ -823,10 +836,11 @@ func (dc *DimensionalComputing) executeExpansion(input *DimensionalVector) (*Dim
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  input.Magnitude * dc.config.ExpansionRate,
 		Phase:      input.Phase,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
-	for dimType := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
+		dimType := dim.Type
 		expanded.Dimensions[dimType] = input.Dimensions[dimType] * dc.config.ExpansionRate
 	}
 	
@@ -839,11 +853,12 @@ func (dc *DimensionalComputing) executeInterference(input *DimensionalVector) (*
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  0.0,
 		Phase:      0.0,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
 	// Calculate interference patterns across dimensions
-	for dimType := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
+		dimType := dim.Type
 		phase := complex(0, input.Dimensions[dimType])
 		amplitude := cmplx.Abs(cmplx.Exp(phase))
 		interference.Dimensions[dimType] = amplitude * input.Dimensions[dimType]
@@ -861,11 +876,11 @@ func (dc *DimensionalComputing) executeResonance(input *DimensionalVector) (*Dim
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  input.Magnitude * dc.consciousness.Resonance,
 		Phase:      input.Phase * dc.consciousness.Resonance,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
-	for dimType := range dc.dimensions {
-		dim := dc.dimensions[dimType]
+	for _, dim := range dc.sortedDimensions() {
+		dimType := dim.Type
 		resonance.Dimensions[dimType] = input.Dimensions[dimType] * dim.Coherence * dc.consciousness.FieldStrength
 	}
 	
@@ -878,12 +893,12 @@ func (dc *DimensionalComputing) executeTunneling(input *DimensionalVector) (*Dim
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  input.Magnitude * 0.8,
 		Phase:      input.Phase + math.Pi/6,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
 	// Tunnel through dimensional barriers
 	barrierStrength := 0.0
-	for _, dim := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
 		if dim.State == DimensionalStateUnstable {
 			barrierStrength += dim.Entropy
 		}
@@ -891,7 +906,8 @@ func (dc *DimensionalComputing) executeTunneling(input *DimensionalVector) (*Dim
 	
 	tunnelProbability := math.Exp(-barrierStrength)
 	
-	for dimType := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
+		dimType := dim.Type
 		tunneled.Dimensions[dimType] = input.Dimensions[dimType] * tunnelProbability
 	}
 	
@@ -904,11 +920,12 @@ func (dc *DimensionalComputing) executeSuperposition(input *DimensionalVector) (
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  input.Magnitude,
 		Phase:      0.0,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
 	// Create superposition of all possible states
-	for dimType := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
+		dimType := dim.Type
 		superposition.Dimensions[dimType] = input.Dimensions[dimType] / math.Sqrt(float64(len(dc.dimensions)))
 	}
 	
@@ -921,11 +938,12 @@ func (dc *DimensionalComputing) executeHolographic(input *DimensionalVector) (*D
 		Dimensions: make(map[DimensionType]float64),
 		Magnitude:  input.Magnitude / math.Sqrt(float64(len(input.Dimensions))),
 		Phase:      input.Phase,
-		Timestamp:  time.Now(),
+		Timestamp:  dc.rand.Now(),
 	}
 	
 	// Holographic encoding preserves all information
-	for dimType := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
+		dimType := dim.Type
 		holographic.Dimensions[dimType] = input.Dimensions[dimType]
 	}
 	
@@ -942,12 +960,12 @@ func (dc *DimensionalComputing) CreateRealityBranch(parentID string, modificatio
 	}
 	
 	branch := &RealityBranch{
-		ID:            fmt.Sprintf("branch-%d", time.Now().UnixNano()),
+		ID:            fmt.Sprintf("branch-%d", dc.rand.Now().UnixNano()),
 		ParentID:      parentID,
 		Modifications: modification,
 		Coherence:     1.0,
 		Active:        true,
-		CreatedAt:     time.Now(),
+		CreatedAt:     dc.rand.Now(),
 	}
 	
 	dc.links["branches"] = append(dc.links["branches"], branch.ID)
@@ -984,7 +1002,7 @@ func (dc *DimensionalComputing) BridgeReality(sourceID, targetID string, linkTyp
 		Coherence:     1.0,
 		Active:        true,
 		Properties:    make(map[string]interface{}),
-		CreatedAt:     time.Now(),
+		CreatedAt:     dc.rand.Now(),
 	}
 	
 	dc.links["bridges"] = append(dc.links["bridges"], bridge.ID)
@@ -1006,13 +1024,28 @@ func (dc *DimensionalComputing) updateMetrics() {
 		ConsciousnessField:  dc.consciousness.FieldStrength,
 		RealityBranches:     len(dc.links["branches"]),
 		CrossDimensionalLinks: len(dc.links["bridges"]),
-		Timestamp:           time.Now(),
+		Timestamp:           dc.rand.Now(),
+	}
+}
+
+// sortedDimensions returns the dimensions ordered by ID
+func (dc *DimensionalComputing) sortedDimensions() []*Dimension {
+	ids := make([]string, 0, len(dc.dimensions))
+	for id := range dc.dimensions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	dims := make([]*Dimension, len(ids))
+	for i, id := range ids {
+		dims[i] = dc.dimensions[id]
 	}
+	return dims
 }
 
 func (dc *DimensionalComputing) countActiveDimensions() int {
 	count := 0
-	for _, dim := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
 		if dim.State == DimensionalStateStable || dim.State == DimensionalStateOscillating {
 			count++
 		}
@@ -1026,7 +1059,7 @@ func (dc *DimensionalComputing) calculateAverageCoherence() float64 {
 	}
 	
 	sum := 0.0
-	for _, dim := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
 		sum += dim.Coherence
 	}
 	return sum / float64(len(dc.dimensions))
@@ -1034,7 +1067,7 @@ func (dc *DimensionalComputing) calculateAverageCoherence() float64 {
 
 func (dc *DimensionalComputing) calculateTotalEntropy() float64 {
 	sum := 0.0
-	for _, dim := range dc.dimensions {
+	for _, dim := range dc.sortedDimensions() {
 		sum += dim.Entropy
 	}
 	return sum
@@ -512,7 +512,7 @@ func TestConcurrency(t *testing.T) {
 			defer func() { done <- true }()
 			
 			// Multiple concurrent operations
-			_, _ = dc.GetMetrics()
+			_ = dc.GetMetrics()
 			_ = dc.GetState()
 			
 			coords := map[DimensionType]float64{
@@ -11,8 +11,11 @@ import (
 	"fmt"
 	"math"
 	"math/cmplx"
+	"sort"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // EntanglementType represents types of cross-reality entanglement
@@ -147,6 +150,8 @@ type EntanglementManager struct {
 	mu            sync.RWMutex
 	state         EntanglementManagerState
 	metrics       *EntanglementMetrics
+	rand          *rng.Source
+	metricsHook   func(*EntanglementMetrics)
 }
 
 // EntanglementManagerState represents the state of the manager
@@ -221,14 +226,13 @@ var (
 	ErrRealityNotFound          = errors.New("reality not found")
 	ErrMaxEntanglementsReached  = errors.New("maximum entanglements reached")
 	ErrInvalidEntanglementType   = errors.New("invalid entanglement type")
-	ErrCoherenceBelowThreshold  = errors.New("coherence below threshold")
 	ErrDistanceTooGreat         = errors.New("entanglement distance exceeds maximum")
 	ErrEntanglementCollapsed    = errors.New("entanglement has collapsed")
 	ErrRealityAlreadyEntangled  = errors.New("reality already entangled")
 )
 
 // NewEntanglementManager creates a new entanglement manager
-func NewEntanglementManager(config *EntanglementConfig) *EntanglementManager {
+func NewEntanglementManager(config *EntanglementConfig, opts ...rng.Option) *EntanglementManager {
 	if config == nil {
 		config = DefaultEntanglementConfig()
 	}
@@ -239,6 +243,7 @@ func NewEntanglementManager(config *EntanglementConfig) *EntanglementManager {
 		realityStates: make(map[string]*RealityState),
 		state:         EntanglementManagerStateIdle,
 		metrics:       &EntanglementMetrics{},
+		rand:          rng.Resolve(opts...),
 	}
 }
 
@@ -287,7 +292,7 @@ func (em *EntanglementManager) initializeDefaultRealities() {
 			Entropy:          float64(i) * 0.1,
 			Stability:        1.0 - float64(i)*0.05,
 			Properties:       make(map[string]interface{}),
-			UpdatedAt:        time.Now(),
+			UpdatedAt:        em.rand.Now(),
 		}
 	}
 }
@@ -313,7 +318,7 @@ func (em *EntanglementManager) CreateEntanglement(realityA, realityB string, ent
 	}
 	
 	// Check if realities are already entangled
-	for _, pair := range em.entanglements {
+	for _, pair := range em.sortedEntanglements() {
 		if (pair.RealityA == realityA && pair.RealityB == realityB) ||
 			(pair.RealityA == realityB && pair.RealityB == realityA) {
 			return nil, ErrRealityAlreadyEntangled
@@ -341,7 +346,7 @@ func (em *EntanglementManager) CreateEntanglement(realityA, realityB string, ent
 	
 	// Create entanglement
 	pair := &EntangledPair{
-		ID:                fmt.Sprintf("entanglement-%d", time.Now().UnixNano()),
+		ID:                fmt.Sprintf("entanglement-%d-%d", em.rand.Now().UnixNano(), len(em.entanglements)),
 		RealityA:          realityA,
 		RealityB:          realityB,
 		EntanglementType:   entType,
@@ -351,10 +356,10 @@ func (em *EntanglementManager) CreateEntanglement(realityA, realityB string, ent
 		Distance:          distance,
 		PhaseA:            cmplx.Exp(complex(0, float64(len(em.entanglements))*math.Pi/4)),
 		PhaseB:            cmplx.Exp(complex(0, float64(len(em.entanglements))*math.Pi/4 + math.Pi)),
-		SharedState:        (cmplx.Exp(complex(0, 0)) + cmplx.Exp(complex(0, math.Pi))) / math.Sqrt(2),
+		SharedState:        (cmplx.Exp(complex(0, 0)) + cmplx.Exp(complex(0, math.Pi))) / complex(math.Sqrt(2), 0),
 		EntanglementEntropy: (stateA.Entropy + stateB.Entropy) / 2,
-		CreatedAt:         time.Now(),
-		UpdatedAt:         time.Now(),
+		CreatedAt:         em.rand.Now(),
+		UpdatedAt:         em.rand.Now(),
 		Properties:         make(map[string]interface{}),
 	}
 	
@@ -389,7 +394,7 @@ func (em *EntanglementManager) ActivateEntanglement(id string) error {
 	}
 	
 	pair.State = EntanglementStateActive
-	pair.UpdatedAt = time.Now()
+	pair.UpdatedAt = em.rand.Now()
 	
 	em.updateMetrics()
 	em.state = EntanglementManagerStateActive
@@ -408,7 +413,7 @@ func (em *EntanglementManager) CollapseEntanglement(id string) error {
 	}
 	
 	pair.State = EntanglementStateCollapsing
-	pair.UpdatedAt = time.Now()
+	pair.UpdatedAt = em.rand.Now()
 	
 	// Calculate collapse outcome
 	collapseProbability := pair.Coherence * pair.Strength
@@ -440,7 +445,7 @@ func (em *EntanglementManager) BreakEntanglement(id string) error {
 	pair.State = EntanglementStateBroken
 	pair.Coherence = 0.0
 	pair.Strength = 0.0
-	pair.UpdatedAt = time.Now()
+	pair.UpdatedAt = em.rand.Now()
 	
 	em.updateMetrics()
 	
@@ -458,7 +463,7 @@ func (em *EntanglementManager) MeasureEntanglement(id string) (complex128, compl
 	}
 	
 	// Add phase noise
-	phaseNoise := complex(em.config.PhaseNoise*(-0.5+em.config.PhaseNoise), 0)
+	phaseNoise := em.config.PhaseNoise * (-0.5 + em.config.PhaseNoise)
 	
 	measuredA := pair.PhaseA * cmplx.Exp(complex(0, phaseNoise))
 	measuredB := pair.PhaseB * cmplx.Exp(complex(0, -phaseNoise))
@@ -481,12 +486,12 @@ func (em *EntanglementManager) TransferInformation(id string, information comple
 	}
 	
 	// Transfer information through entanglement
-	transferred := information * pair.SharedState * pair.Coherence * pair.Strength
+	transferred := information * pair.SharedState * complex(pair.Coherence*pair.Strength, 0)
 	
 	// Update entanglement
 	pair.PhaseA = pair.PhaseA * cmplx.Exp(complex(0, math.Pi/12))
 	pair.PhaseB = pair.PhaseB * cmplx.Exp(complex(0, -math.Pi/12))
-	pair.UpdatedAt = time.Now()
+	pair.UpdatedAt = em.rand.Now()
 	
 	em.updateMetrics()
 	
@@ -500,7 +505,7 @@ func (em *EntanglementManager) SynchronizeEntanglements() error {
 	
 	em.state = EntanglementManagerStateSynchronizing
 	
-	for _, pair := range em.entanglements {
+	for _, pair := range em.sortedEntanglements() {
 		if pair.State == EntanglementStateActive {
 			// Synchronize phases
 			avgPhase := (pair.PhaseA + pair.PhaseB) / 2
@@ -532,10 +537,10 @@ func (em *EntanglementManager) UpdateReality(realityID string, update func(*Real
 	}
 	
 	update(state)
-	state.UpdatedAt = time.Now()
+	state.UpdatedAt = em.rand.Now()
 	
 	// Update affected entanglements
-	for _, pair := range em.entanglements {
+	for _, pair := range em.sortedEntanglements() {
 		if pair.RealityA == realityID || pair.RealityB == realityID {
 			em.updatePairFromRealities(pair)
 		}
@@ -635,7 +640,7 @@ func (em *EntanglementManager) updatePairFromRealities(pair *EntangledPair) {
 	pair.Distance = em.calculateRealityDistance(stateA, stateB)
 	pair.Strength = em.calculateEntanglementStrength(stateA, stateB, pair.EntanglementType, pair.Distance)
 	pair.EntanglementEntropy = (stateA.Entropy + stateB.Entropy) / 2
-	pair.UpdatedAt = time.Now()
+	pair.UpdatedAt = em.rand.Now()
 }
 
 // updateMetrics recalculates entanglement metrics
@@ -651,13 +656,41 @@ func (em *EntanglementManager) updateMetrics() {
 		SynchronizationRate: em.calculateSynchronizationRate(),
 		EntanglementCapacity: float64(em.config.MaxEntanglements - len(em.entanglements)),
 		InformationFlow:     em.calculateInformationFlow(),
-		Timestamp:           time.Now(),
+		Timestamp:           em.rand.Now(),
+	}
+	if em.metricsHook != nil {
+		metrics := *em.metrics
+		em.metricsHook(&metrics)
+	}
+}
+
+// SetMetricsHook registers fn to be called with a copy of the metrics every
+// time they are recalculated. fn runs while the manager is locked and must
+// not call back into it.
+func (em *EntanglementManager) SetMetricsHook(fn func(*EntanglementMetrics)) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.metricsHook = fn
+}
+
+// sortedEntanglements returns the entanglements ordered by ID
+func (em *EntanglementManager) sortedEntanglements() []*EntangledPair {
+	ids := make([]string, 0, len(em.entanglements))
+	for id := range em.entanglements {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	pairs := make([]*EntangledPair, len(ids))
+	for i, id := range ids {
+		pairs[i] = em.entanglements[id]
 	}
+	return pairs
 }
 
 func (em *EntanglementManager) countActiveEntanglements() int {
 	count := 0
-	for _, pair := range em.entanglements {
+	for _, pair := range em.sortedEntanglements() {
 		if pair.State == EntanglementStateActive || pair.State == EntanglementStateForming {
 			count++
 		}
@@ -672,7 +705,7 @@ func (em *EntanglementManager) calculateAverageCoherence() float64 {
 	
 	sum := 0.0
 	count := 0
-	for _, pair := range em.entanglements {
+	for _, pair := range em.sortedEntanglements() {
 		sum += pair.Coherence
 		count++
 	}
@@ -686,7 +719,7 @@ func (em *EntanglementManager) calculateAverageStrength() float64 {
 	
 	sum := 0.0
 	count := 0
-	for _, pair := range em.entanglements {
+	for _, pair := range em.sortedEntanglements() {
 		sum += pair.Strength
 		count++
 	}
@@ -695,7 +728,7 @@ func (em *EntanglementManager) calculateAverageStrength() float64 {
 
 func (em *EntanglementManager) calculateTotalEntropy() float64 {
 	sum := 0.0
-	for _, pair := range em.entanglements {
+	for _, pair := range em.sortedEntanglements() {
 		sum += pair.EntanglementEntropy
 	}
 	return sum
@@ -707,7 +740,7 @@ func (em *EntanglementManager) calculateSynchronizationRate() float64 {
 	}
 	
 	synced := 0
-	for _, pair := range em.entanglements {
+	for _, pair := range em.sortedEntanglements() {
 		if pair.State == EntanglementStateActive &&
 			cmplx.Abs(pair.PhaseA-pair.PhaseB) < 0.1 {
 			synced++
@@ -719,18 +752,19 @@ func (em *EntanglementManager) calculateSynchronizationRate() float64 {
 
 func (em *EntanglementManager) calculateInformationFlow() float64 {
 	totalFlow := 0.0
-	for _, pair := range em.entanglements {
+	for _, pair := range em.sortedEntanglements() {
 		totalFlow += pair.Coherence * pair.Strength * (1.0 - pair.Distance/em.config.MaxDistance)
 	}
 	return totalFlow / float64(len(em.entanglements)+1)
 }
 
-// GetMetrics returns current entanglement metrics
+// GetMetrics returns current entanglement metrics, copied so callers can read them without the lock
 func (em *EntanglementManager) GetMetrics() *EntanglementMetrics {
 	em.mu.RLock()
 	defer em.mu.RUnlock()
 	
-	return em.metrics
+	metrics := *em.metrics
+	return &metrics
 }
 
 // GetState returns the current manager state
@@ -747,7 +781,7 @@ func (em *EntanglementManager) GetAllEntanglements() []*EntangledPair {
 	defer em.mu.RUnlock()
 	
 	entanglements := make([]*EntangledPair, 0, len(em.entanglements))
-	for _, pair := range em.entanglements {
+	for _, pair := range em.sortedEntanglements() {
 		entanglements = append(entanglements, pair)
 	}
 	
@@ -2,7 +2,6 @@ package reality
 
 import (
 	"testing"
-	"time"
 )
 
 func TestNewEntanglementManager(t *testing.T) {
@@ -253,7 +252,7 @@ func TestGetAllEntanglements(t *testing.T) {
 	}
 }
 
-func TestGetMetrics(t *testing.T) {
+func TestEntanglementGetMetrics(t *testing.T) {
 	em := NewEntanglementManager(nil)
 	em.Initialize()
 	
@@ -357,7 +356,7 @@ func TestEntanglementManagerToJSON(t *testing.T) {
 	}
 }
 
-func TestDefaultConfig(t *testing.T) {
+func TestEntanglementDefaultConfig(t *testing.T) {
 	config := DefaultEntanglementConfig()
 	
 	if config == nil {
@@ -377,7 +376,7 @@ func TestDefaultConfig(t *testing.T) {
 	}
 }
 
-func TestConcurrency(t *testing.T) {
+func TestEntanglementConcurrency(t *testing.T) {
 	em := NewEntanglementManager(nil)
 	em.Initialize()
 	
@@ -0,0 +1,142 @@
+package repl
+
+import (
+	"sort"
+	"strings"
+
+	"neuralblitz/pkg/options"
+)
+
+// Completer completes REPL commands, NBCL command names and argument keys
+// from the schemas the commands were registered with
+type Completer struct {
+	interpreter *options.NBCLInterpreter
+
+	// Repeated tabs cycle through the candidates of the last completion
+	line       string
+	pos        int
+	start      int
+	candidates []string
+	index      int
+}
+
+// NewCompleter creates a completer for the commands of interpreter
+func NewCompleter(interpreter *options.NBCLInterpreter) *Completer {
+	return &Completer{interpreter: interpreter}
+}
+
+// Complete returns the completions of the word ending at pos in line and
+// the offset the word starts at
+func (c *Completer) Complete(line string, pos int) (int, []string) {
+	start := strings.LastIndexAny(line[:pos], " \t|") + 1
+	word := line[start:pos]
+
+	var candidates []string
+	switch {
+	case start == 0 && strings.HasPrefix(word, ":"):
+		for _, meta := range metaCommands {
+			candidates = append(candidates, ":"+meta.name)
+		}
+	case strings.HasPrefix(word, "/"):
+		for _, name := range c.interpreter.Commands() {
+			candidates = append(candidates, "/"+name)
+		}
+	case strings.HasPrefix(word, "$"):
+		return start, nil
+	default:
+		schema, used, ok := c.command(line[:start])
+		if !ok {
+			return start, nil
+		}
+		key, value, hasValue := strings.Cut(word, "[")
+		for _, arg := range schema.Args {
+			switch {
+			case hasValue && arg.Name == key:
+				for _, v := range arg.Values {
+					candidates = append(candidates, key+"["+v+"]")
+				}
+			case !hasValue && !used[arg.Name]:
+				candidates = append(candidates, arg.Name+"[")
+			}
+		}
+		word = key
+		if hasValue {
+			word += "[" + value
+		}
+	}
+
+	matches := candidates[:0]
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+	return start, matches
+}
+
+// command returns the schema of the command being typed at the end of
+// before and the argument keys it already has
+func (c *Completer) command(before string) (options.CommandSchema, map[string]bool, bool) {
+	segment := before[strings.LastIndex(before, "|")+1:]
+	fields := strings.Fields(segment)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+		return options.CommandSchema{}, nil, false
+	}
+	schema, ok := c.interpreter.Schema(strings.TrimPrefix(fields[0], "/"))
+	used := make(map[string]bool)
+	for _, field := range fields[1:] {
+		if key, _, ok := strings.Cut(field, "["); ok {
+			used[key] = true
+		}
+	}
+	return schema, used, ok
+}
+
+// autoComplete is a term.Terminal AutoCompleteCallback. A tab inserts the
+// only completion, or the prefix the completions share; further tabs cycle
+// through them.
+func (c *Completer) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	if line == c.line && pos == c.pos && len(c.candidates) > 1 {
+		c.index = (c.index + 1) % len(c.candidates)
+		return c.replace(line, pos, c.candidates[c.index])
+	}
+
+	start, candidates := c.Complete(line, pos)
+	c.candidates, c.index, c.start = nil, 0, start
+	switch len(candidates) {
+	case 0:
+		return line, pos, true
+	case 1:
+		completion := candidates[0]
+		if !strings.HasSuffix(completion, "[") {
+			completion += " "
+		}
+		return c.replace(line, pos, completion)
+	}
+
+	prefix := candidates[0]
+	for _, candidate := range candidates[1:] {
+		for !strings.HasPrefix(candidate, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(prefix) > pos-start {
+		return c.replace(line, pos, prefix)
+	}
+	c.candidates = candidates
+	return c.replace(line, pos, candidates[0])
+}
+
+// replace replaces the word being completed and remembers the result, so
+// a further tab is recognized as cycling
+func (c *Completer) replace(line string, pos int, completion string) (string, int, bool) {
+	newLine := line[:c.start] + completion + line[pos:]
+	newPos := c.start + len(completion)
+	c.line, c.pos = newLine, newPos
+	return newLine, newPos, true
+}
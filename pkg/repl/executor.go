@@ -0,0 +1,105 @@
+package repl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"neuralblitz/pkg/client"
+	"neuralblitz/pkg/nbcl"
+	"neuralblitz/pkg/options"
+)
+
+// Error definitions
+var (
+	ErrRemoteScript = errors.New("remote mode runs single commands; variables, pipelines and if blocks run locally")
+)
+
+// Executor runs the NBCL entries of a session
+type Executor interface {
+	// Run runs NBCL source and returns the results of the commands it ran,
+	// including those before a failing one
+	Run(ctx context.Context, src string) ([]*options.NBCLResult, error)
+	// Target names where commands run: "local" or a server URL
+	Target() string
+}
+
+// LocalExecutor runs entries on one in-process interpreter, so variables,
+// command history and coherence carry over from entry to entry
+type LocalExecutor struct {
+	interpreter *options.NBCLInterpreter
+}
+
+// NewLocalExecutor creates an executor running entries on interpreter
+func NewLocalExecutor(interpreter *options.NBCLInterpreter) *LocalExecutor {
+	return &LocalExecutor{interpreter: interpreter}
+}
+
+// Run parses src as a script and runs it
+func (e *LocalExecutor) Run(ctx context.Context, src string) ([]*options.NBCLResult, error) {
+	script, err := nbcl.ParseScript(src)
+	if err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+	return e.interpreter.RunContext(ctx, script)
+}
+
+// Target returns "local"
+func (e *LocalExecutor) Target() string {
+	return "local"
+}
+
+// RemoteExecutor sends entries to the /nbcl/interpret route of a running
+// server, where they run on the server's shared interpreter. The route
+// interprets one command per request, so each statement of an entry must
+// be a single command.
+type RemoteExecutor struct {
+	client *client.Client
+	url    string
+}
+
+// NewRemoteExecutor creates an executor sending entries through c to the
+// server at url
+func NewRemoteExecutor(c *client.Client, url string) *RemoteExecutor {
+	return &RemoteExecutor{client: c, url: url}
+}
+
+// Dial creates a RemoteExecutor for the server at url
+func Dial(url string, opts ...client.Option) (Executor, error) {
+	c, err := client.New(url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteExecutor(c, url), nil
+}
+
+// Run sends each command of src in turn
+func (e *RemoteExecutor) Run(ctx context.Context, src string) ([]*options.NBCLResult, error) {
+	script, err := nbcl.ParseScript(src)
+	if err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+	commands := make([]*nbcl.Command, 0, len(script.Statements))
+	for _, stmt := range script.Statements {
+		pipeline, ok := stmt.(*nbcl.Pipeline)
+		if !ok || len(pipeline.Commands) != 1 {
+			return nil, fmt.Errorf("%s: %w", stmt.Pos(), ErrRemoteScript)
+		}
+		commands = append(commands, pipeline.Commands[0])
+	}
+
+	results := make([]*options.NBCLResult, 0, len(commands))
+	for _, command := range commands {
+		result, err := e.client.InterpretNBCL(ctx, command.String())
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Target returns the server URL
+func (e *RemoteExecutor) Target() string {
+	return e.url
+}
@@ -0,0 +1,122 @@
+package repl
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// DefaultHistorySize is the number of entries a History keeps
+const DefaultHistorySize = 1000
+
+// History is the input history of a session. When it has a file, every
+// entry is appended to it, so the history carries over to later sessions.
+// It implements term.History.
+type History struct {
+	path string
+	size int
+	// entries are ordered oldest first
+	entries []string
+	err     error
+}
+
+// LoadHistory reads the history kept in path, keeping its last size
+// entries. A missing file is an empty history; with path "" the history is
+// kept in memory only.
+func LoadHistory(path string, size int) (*History, error) {
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+	h := &History{path: path, size: size}
+	if path == "" {
+		return h, nil
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.TrimSpace(line) != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Compact the file once it holds twice the entries kept
+	if len(h.entries) > size {
+		trimmed := len(h.entries) > 2*size
+		h.entries = h.entries[len(h.entries)-size:]
+		if trimmed {
+			data := strings.Join(h.entries, "\n") + "\n"
+			if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return h, nil
+}
+
+// Add records an entry, skipping blank lines and repeats of the last entry
+func (h *History) Add(entry string) {
+	entry = strings.TrimRightFunc(entry, unicode.IsSpace)
+	if strings.TrimSpace(entry) == "" || strings.ContainsAny(entry, "\r\n") {
+		return
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > h.size {
+		h.entries = h.entries[1:]
+	}
+	if h.path != "" && h.err == nil {
+		h.err = h.append(entry)
+	}
+}
+
+func (h *History) append(entry string) error {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(entry + "\n"); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Len returns the number of entries
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// At returns an entry, 0 being the most recent
+func (h *History) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
+
+// Entries returns the entries, oldest first
+func (h *History) Entries() []string {
+	return append([]string(nil), h.entries...)
+}
+
+// Err returns the error that stopped entries being written to the file
+func (h *History) Err() error {
+	return h.err
+}
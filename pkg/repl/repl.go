@@ -0,0 +1,352 @@
+// Package repl is an interactive NBCL session. One interpreter serves the
+// whole session, so variables, command history and coherence carry over
+// from entry to entry. On a terminal lines are edited in place, with the
+// history and tab completion of commands and argument keys.
+package repl
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+	"neuralblitz/pkg/nbcl"
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/output"
+)
+
+// Prompts of a session
+const (
+	Prompt             = "nbcl> "
+	ContinuationPrompt = "...   "
+)
+
+// metaCommands are the REPL's own commands, entered with a leading colon
+var metaCommands = []struct {
+	name, args, description string
+}{
+	{"help", "", "Show this help"},
+	{"history", "[n]", "Show the last n lines entered, or all of them"},
+	{"save", "<file>", "Save the entries of this session as an NBCL script"},
+	{"load", "<file>", "Run an NBCL script in this session"},
+	{"remote", "[target]", "Send entries to a server, or show where they go"},
+	{"local", "", "Run entries in this process again"},
+	{"quit", "", "End the session (also :exit or Ctrl-D)"},
+}
+
+// Config configures a Session
+type Config struct {
+	// Format is the output format of results: table, json or yaml
+	Format string
+	// History records the lines entered on a terminal; nil keeps them in
+	// memory only
+	History *History
+	// Executor runs the entries until :remote or :local; nil runs them on
+	// the session's interpreter
+	Executor Executor
+	// Dial creates the executor of :remote <target>, where target is a URL
+	// or anything else Dial resolves, e.g. a context name; nil uses Dial
+	// without options
+	Dial func(target string) (Executor, error)
+}
+
+// Session is an NBCL read-eval-print loop
+type Session struct {
+	config    Config
+	local     Executor
+	executor  Executor
+	completer *Completer
+	history   *History
+
+	out, errOut io.Writer
+	// pending holds the lines of an entry still missing its end
+	pending []string
+	// transcript holds the entries that ran, for :save
+	transcript []string
+	// rendered counts the results written
+	rendered int
+}
+
+// NewSession creates a session running entries on interpreter
+func NewSession(interpreter *options.NBCLInterpreter, config *Config) *Session {
+	s := &Session{
+		local:     NewLocalExecutor(interpreter),
+		completer: NewCompleter(interpreter),
+		out:       io.Discard,
+		errOut:    io.Discard,
+	}
+	if config != nil {
+		s.config = *config
+	}
+	if s.config.Format == "" {
+		s.config.Format = output.FormatTable
+	}
+	if s.config.Dial == nil {
+		s.config.Dial = func(url string) (Executor, error) { return Dial(url) }
+	}
+	s.history = s.config.History
+	if s.history == nil {
+		s.history, _ = LoadHistory("", DefaultHistorySize)
+	}
+	s.executor = s.local
+	if s.config.Executor != nil {
+		s.executor = s.config.Executor
+	}
+	return s
+}
+
+// lineReader reads the lines of a session
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+}
+
+// Run reads entries from in until it ends or :quit, writing results to out
+// and errors to errOut. When in and out are a terminal, lines are edited
+// with history and completion; otherwise they are read as they come.
+func (s *Session) Run(ctx context.Context, in io.Reader, out, errOut io.Writer) error {
+	if err := output.CheckFormat(s.config.Format); err != nil {
+		return err
+	}
+	s.out, s.errOut = out, errOut
+
+	var reader lineReader = &scanReader{scanner: bufio.NewScanner(in)}
+	if terminal, ok := newTerminalReader(in, out, s); ok {
+		reader = terminal
+		fmt.Fprintln(out, "NBCL session; :help lists the session commands, Ctrl-D ends it")
+	}
+
+	warned := false
+	for ctx.Err() == nil {
+		if err := s.history.Err(); err != nil && !warned {
+			fmt.Fprintf(errOut, "Warning: history is no longer saved: %v\n", err)
+			warned = true
+		}
+		line, err := reader.ReadLine(s.prompt())
+		if errors.Is(err, io.EOF) {
+			if len(s.pending) > 0 {
+				fmt.Fprintln(errOut, "Error: input ended inside an unfinished entry")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if s.Enter(ctx, line) {
+			return nil
+		}
+	}
+	return ctx.Err()
+}
+
+// prompt returns the prompt of the next line
+func (s *Session) prompt() string {
+	if len(s.pending) > 0 {
+		return ContinuationPrompt
+	}
+	if target := s.executor.Target(); target != "local" {
+		return "nbcl(" + target + ")> "
+	}
+	return Prompt
+}
+
+// Target names where entries run: "local" or a server URL
+func (s *Session) Target() string {
+	return s.executor.Target()
+}
+
+// Transcript returns the entries that ran, in order
+func (s *Session) Transcript() []string {
+	return append([]string(nil), s.transcript...)
+}
+
+// Enter handles one line: a session command, or a line of NBCL that runs
+// once it completes an entry. It reports whether the session should end.
+func (s *Session) Enter(ctx context.Context, line string) bool {
+	if len(s.pending) == 0 {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			return false
+		}
+		if strings.HasPrefix(trimmed, ":") {
+			quit, err := s.meta(ctx, trimmed)
+			if err != nil {
+				fmt.Fprintf(s.errOut, "Error: %v\n", err)
+			}
+			return quit
+		}
+	}
+
+	s.pending = append(s.pending, line)
+	src := strings.Join(s.pending, "\n") + "\n"
+	if _, err := nbcl.ParseScript(src); nbcl.IsIncomplete(err) {
+		return false
+	}
+	s.pending = nil
+
+	if err := s.run(ctx, src); err != nil {
+		fmt.Fprintf(s.errOut, "Error: %v\n", err)
+	}
+	return false
+}
+
+// run runs an entry, writes its results and records it in the transcript
+func (s *Session) run(ctx context.Context, src string) error {
+	results, err := s.executor.Run(ctx, src)
+	for _, result := range results {
+		// Results form one stream: YAML documents or blocks of rows
+		if s.rendered > 0 {
+			switch s.config.Format {
+			case output.FormatYAML:
+				fmt.Fprintln(s.out, "---")
+			case output.FormatTable:
+				fmt.Fprintln(s.out)
+			}
+		}
+		if err := output.Render(s.out, s.config.Format, result); err != nil {
+			return err
+		}
+		s.rendered++
+	}
+	if err != nil {
+		return err
+	}
+	s.transcript = append(s.transcript, strings.TrimSpace(src))
+	return nil
+}
+
+// meta runs a session command. Notices go to errOut, keeping out a stream
+// of results.
+func (s *Session) meta(ctx context.Context, line string) (bool, error) {
+	fields := strings.Fields(strings.TrimPrefix(line, ":"))
+	if len(fields) == 0 {
+		return false, fmt.Errorf("missing session command; :help lists them")
+	}
+	name, args := fields[0], fields[1:]
+
+	switch name {
+	case "help":
+		for _, meta := range metaCommands {
+			fmt.Fprintf(s.out, "  %-16s %s\n", strings.TrimSpace(":"+meta.name+" "+meta.args), meta.description)
+		}
+		fmt.Fprintln(s.out, "NBCL commands are listed by /help; tab completes command names and argument keys.")
+	case "history":
+		entries := s.history.Entries()
+		first := 0
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 0 {
+				return false, fmt.Errorf("invalid count %q", args[0])
+			}
+			first = max(len(entries)-n, 0)
+		}
+		for i := first; i < len(entries); i++ {
+			fmt.Fprintf(s.out, "%5d  %s\n", i+1, entries[i])
+		}
+	case "save":
+		if len(args) != 1 {
+			return false, fmt.Errorf("usage: :save <file>")
+		}
+		header := "# NBCL session saved " + time.Now().Format(time.RFC3339) + "\n"
+		data := header + strings.Join(s.transcript, "\n") + "\n"
+		if err := os.WriteFile(args[0], []byte(data), 0o644); err != nil {
+			return false, err
+		}
+		fmt.Fprintf(s.errOut, "Saved %d entries to %s\n", len(s.transcript), args[0])
+	case "load":
+		if len(args) != 1 {
+			return false, fmt.Errorf("usage: :load <file>")
+		}
+		src, err := os.ReadFile(args[0])
+		if err != nil {
+			return false, err
+		}
+		if err := s.run(ctx, string(src)); err != nil {
+			return false, fmt.Errorf("%s: %w", args[0], err)
+		}
+	case "remote":
+		if len(args) == 0 {
+			fmt.Fprintf(s.out, "Entries run: %s\n", s.executor.Target())
+			return false, nil
+		}
+		executor, err := s.config.Dial(args[0])
+		if err != nil {
+			return false, err
+		}
+		s.executor = executor
+		fmt.Fprintf(s.errOut, "Entries now run on %s; :local switches back\n", executor.Target())
+	case "local":
+		s.executor = s.local
+		fmt.Fprintln(s.errOut, "Entries now run locally")
+	case "quit", "exit":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown session command :%s; :help lists them", name)
+	}
+	return false, nil
+}
+
+// scanReader reads lines from a pipe or file, without prompts
+type scanReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r *scanReader) ReadLine(string) (string, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
+
+// terminalReader edits lines on a terminal. The terminal is in raw mode
+// only while a line is read, so results are written as usual.
+type terminalReader struct {
+	fd       int
+	terminal *term.Terminal
+}
+
+// newTerminalReader returns a terminalReader when in and out are a terminal
+func newTerminalReader(in io.Reader, out io.Writer, s *Session) (*terminalReader, bool) {
+	inFile, ok := in.(*os.File)
+	if !ok || !term.IsTerminal(int(inFile.Fd())) {
+		return nil, false
+	}
+	outFile, ok := out.(*os.File)
+	if !ok || !term.IsTerminal(int(outFile.Fd())) {
+		return nil, false
+	}
+
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, out}, Prompt)
+	terminal.History = s.history
+	terminal.AutoCompleteCallback = s.completer.autoComplete
+	return &terminalReader{fd: int(inFile.Fd()), terminal: terminal}, true
+}
+
+func (r *terminalReader) ReadLine(prompt string) (string, error) {
+	state, err := term.MakeRaw(r.fd)
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(r.fd, state)
+
+	if width, height, err := term.GetSize(r.fd); err == nil && width > 0 {
+		r.terminal.SetSize(width, height)
+	}
+	r.terminal.SetPrompt(prompt)
+	line, err := r.terminal.ReadLine()
+	if errors.Is(err, term.ErrPasteIndicator) {
+		err = nil
+	}
+	return line, err
+}
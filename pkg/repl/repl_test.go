@@ -0,0 +1,183 @@
+package repl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"neuralblitz/pkg/api"
+	"neuralblitz/pkg/core"
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/output"
+	"neuralblitz/pkg/rng"
+)
+
+func newTestSession(config *Config) *Session {
+	dyad := core.NewArchitectSystemDyad(rng.WithSeed(7))
+	return NewSession(options.NewNBCLInterpreter(dyad, rng.WithSeed(7)), config)
+}
+
+// decodeResults decodes a stream of JSON results
+func decodeResults(t *testing.T, data []byte) []map[string]interface{} {
+	t.Helper()
+	var results []map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var result map[string]interface{}
+		if err := dec.Decode(&result); errors.Is(err, io.EOF) {
+			return results
+		} else if err != nil {
+			t.Fatalf("Failed to decode results: %v\n%s", err, data)
+		}
+		results = append(results, result)
+	}
+}
+
+func TestSessionKeepsState(t *testing.T) {
+	session := newTestSession(&Config{Format: output.FormatJSON})
+	input := `/status
+$s = /status
+if $s.coherence >= 0.5
+    /manifest reality[$s.reality_mode]
+end
+:bogus
+/status
+`
+	var out, errOut bytes.Buffer
+	if err := session.Run(context.Background(), strings.NewReader(input), &out, &errOut); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	results := decodeResults(t, out.Bytes())
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(results))
+	}
+	if results[2]["command"] != "manifest" {
+		t.Errorf("Expected the if block to run once complete, got %v", results[2]["command"])
+	}
+	// One interpreter serves the session, so its command history grows
+	if count := results[3]["command_history_count"]; count != 4.0 {
+		t.Errorf("Expected the last status to count 4 commands, got %v", count)
+	}
+	if !strings.Contains(errOut.String(), "unknown session command :bogus") {
+		t.Errorf("Expected an error for :bogus, got %q", errOut.String())
+	}
+
+	want := []string{"/status", "$s = /status", "if $s.coherence >= 0.5\n    /manifest reality[$s.reality_mode]\nend", "/status"}
+	if got := session.Transcript(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected transcript %q, got %q", want, got)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.nbcl")
+	session := newTestSession(&Config{Format: output.FormatJSON})
+	input := "$a = /attest note[saved]\n/nope\n:save " + path + "\n"
+	if err := session.Run(context.Background(), strings.NewReader(input), io.Discard, io.Discard); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), "\n$a = /attest note[saved]\n") {
+		t.Errorf("Expected only the entries that ran to be saved, got %q", data)
+	}
+
+	loaded := newTestSession(&Config{Format: output.FormatJSON})
+	var out bytes.Buffer
+	if err := loaded.Run(context.Background(), strings.NewReader(":load "+path+"\n/status\n"), &out, io.Discard); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if results := decodeResults(t, out.Bytes()); len(results) != 2 || results[0]["command"] != "attest" {
+		t.Errorf("Expected the saved attest to run, got %v", results)
+	}
+	if _, ok := loaded.local.(*LocalExecutor).interpreter.Variable("a"); !ok {
+		t.Errorf("Expected $a bound by the loaded script")
+	}
+}
+
+func TestRemote(t *testing.T) {
+	ts := httptest.NewServer(api.NewServer("", rng.WithSeed(3)).GetRouter())
+	defer ts.Close()
+
+	session := newTestSession(&Config{Format: output.FormatJSON})
+	input := ":remote " + ts.URL + "\n/status\n$x = /status\n:local\n/status\n"
+	var out, errOut bytes.Buffer
+	if err := session.Run(context.Background(), strings.NewReader(input), &out, &errOut); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	results := decodeResults(t, out.Bytes())
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if !strings.Contains(results[0]["codex_id"].(string), "NBCL") {
+		t.Errorf("Expected the server to assign a codex ID, got %v", results[0]["codex_id"])
+	}
+	if !strings.Contains(errOut.String(), ErrRemoteScript.Error()) {
+		t.Errorf("Expected assignments to be refused remotely, got %q", errOut.String())
+	}
+	if session.Target() != "local" {
+		t.Errorf("Expected :local to switch back, got %s", session.Target())
+	}
+}
+
+func TestComplete(t *testing.T) {
+	completer := NewCompleter(options.NewNBCLInterpreter(core.NewArchitectSystemDyad()))
+	tests := []struct {
+		line  string
+		start int
+		want  []string
+	}{
+		{":hi", 0, []string{":history"}},
+		{"/stat", 0, []string{"/status"}},
+		{"/manifest re", 10, []string{"reality["}},
+		{"/manifest reality[o", 10, []string{"reality[omega_prime]"}},
+		{"/status | /manif", 10, []string{"/manifest"}},
+		{"/manifest reality[status] re", 26, nil},
+		{"$x", 0, nil},
+	}
+
+	for _, tt := range tests {
+		start, got := completer.Complete(tt.line, len(tt.line))
+		if start != tt.start || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Expected %q to complete at %d to %q, got %d %q", tt.line, tt.start, tt.want, start, got)
+		}
+	}
+
+	line, pos, _ := completer.autoComplete("/sta", 4, '\t')
+	if line != "/status " || pos != len(line) {
+		t.Errorf("Expected tab to insert /status, got %q at %d", line, pos)
+	}
+}
+
+func TestHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nbcl_history")
+	h, err := LoadHistory(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []string{"/status", "/status ", "", "/attest", "/help", "/logos"} {
+		h.Add(entry)
+	}
+	if h.Len() != 3 || h.At(0) != "/logos" {
+		t.Errorf("Expected the last 3 distinct entries, got %q", h.Entries())
+	}
+
+	reloaded, err := LoadHistory(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/attest", "/help", "/logos"}; !reflect.DeepEqual(reloaded.Entries(), want) {
+		t.Errorf("Expected %q from the file, got %q", want, reloaded.Entries())
+	}
+}
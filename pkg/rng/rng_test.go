@@ -0,0 +1,60 @@
+package rng
+
+import (
+	"testing"
+)
+
+func TestSeededSourcesMatch(t *testing.T) {
+	a := New(42)
+	b := New(42)
+
+	for i := 0; i < 100; i++ {
+		if x, y := a.Float64(), b.Float64(); x != y {
+			t.Fatalf("Expected identical streams, got %f and %f at draw %d", x, y, i)
+		}
+	}
+
+	if !a.Now().Equal(b.Now()) {
+		t.Error("Expected identical logical clocks")
+	}
+}
+
+func TestSeededClockAdvances(t *testing.T) {
+	src := New(1)
+
+	first := src.Now()
+	second := src.Now()
+
+	if !first.Equal(Epoch.Add(Tick)) {
+		t.Errorf("Expected first reading %v, got %v", Epoch.Add(Tick), first)
+	}
+	if second.Sub(first) != Tick {
+		t.Errorf("Expected clock to advance by %v, got %v", Tick, second.Sub(first))
+	}
+}
+
+func TestDeriveIsReproducible(t *testing.T) {
+	a := New(7).Derive()
+	b := New(7).Derive()
+
+	if !a.Deterministic() {
+		t.Error("Expected derived source to be deterministic")
+	}
+	if a.Seed() != b.Seed() {
+		t.Errorf("Expected equal derived seeds, got %d and %d", a.Seed(), b.Seed())
+	}
+}
+
+func TestResolve(t *testing.T) {
+	src := New(9)
+
+	if Resolve(WithSource(src)) != src {
+		t.Error("Expected Resolve to return the given source")
+	}
+	if Resolve() != Default() {
+		t.Error("Expected Resolve to fall back to Default")
+	}
+	if got := Resolve(WithSeed(5)).Seed(); got != 5 {
+		t.Errorf("Expected seed 5, got %d", got)
+	}
+}
@@ -0,0 +1,1703 @@
+// The gRPC front end of the NeuralBlitz API. Every RPC mirrors a REST
+// route and runs the same service method, so the two transports return the
+// same state; nested results whose shape follows the Go types are carried
+// as google.protobuf.Struct in their JSON form.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: neuralblitz.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_neuralblitz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{0}
+}
+
+type StatusResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Status            string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	RealityState      string                 `protobuf:"bytes,2,opt,name=reality_state,json=realityState,proto3" json:"reality_state,omitempty"`
+	Coherence         float64                `protobuf:"fixed64,3,opt,name=coherence,proto3" json:"coherence,omitempty"`
+	Irreducibility    bool                   `protobuf:"varint,4,opt,name=irreducibility,proto3" json:"irreducibility,omitempty"`
+	UnityVector       float64                `protobuf:"fixed64,5,opt,name=unity_vector,json=unityVector,proto3" json:"unity_vector,omitempty"`
+	DyadCoherence     float64                `protobuf:"fixed64,6,opt,name=dyad_coherence,json=dyadCoherence,proto3" json:"dyad_coherence,omitempty"`
+	CoCreations       int64                  `protobuf:"varint,7,opt,name=co_creations,json=coCreations,proto3" json:"co_creations,omitempty"`
+	Actualizations    int64                  `protobuf:"varint,8,opt,name=actualizations,proto3" json:"actualizations,omitempty"`
+	SingularityStatus string                 `protobuf:"bytes,9,opt,name=singularity_status,json=singularityStatus,proto3" json:"singularity_status,omitempty"`
+	UptimeSeconds     float64                `protobuf:"fixed64,10,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	UptimeFormatted   string                 `protobuf:"bytes,11,opt,name=uptime_formatted,json=uptimeFormatted,proto3" json:"uptime_formatted,omitempty"`
+	GoVersion         string                 `protobuf:"bytes,12,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	Os                string                 `protobuf:"bytes,13,opt,name=os,proto3" json:"os,omitempty"`
+	Arch              string                 `protobuf:"bytes,14,opt,name=arch,proto3" json:"arch,omitempty"`
+	Goroutines        int64                  `protobuf:"varint,15,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	GcCycles          uint32                 `protobuf:"varint,16,opt,name=gc_cycles,json=gcCycles,proto3" json:"gc_cycles,omitempty"`
+	Seed              int64                  `protobuf:"varint,17,opt,name=seed,proto3" json:"seed,omitempty"`
+	Deterministic     bool                   `protobuf:"varint,18,opt,name=deterministic,proto3" json:"deterministic,omitempty"`
+	GoldenDag         string                 `protobuf:"bytes,19,opt,name=golden_dag,json=goldenDag,proto3" json:"golden_dag,omitempty"`
+	TraceId           string                 `protobuf:"bytes,20,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CodexId           string                 `protobuf:"bytes,21,opt,name=codex_id,json=codexId,proto3" json:"codex_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	mi := &file_neuralblitz_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetRealityState() string {
+	if x != nil {
+		return x.RealityState
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetCoherence() float64 {
+	if x != nil {
+		return x.Coherence
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetIrreducibility() bool {
+	if x != nil {
+		return x.Irreducibility
+	}
+	return false
+}
+
+func (x *StatusResponse) GetUnityVector() float64 {
+	if x != nil {
+		return x.UnityVector
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetDyadCoherence() float64 {
+	if x != nil {
+		return x.DyadCoherence
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetCoCreations() int64 {
+	if x != nil {
+		return x.CoCreations
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetActualizations() int64 {
+	if x != nil {
+		return x.Actualizations
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetSingularityStatus() string {
+	if x != nil {
+		return x.SingularityStatus
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetUptimeSeconds() float64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetUptimeFormatted() string {
+	if x != nil {
+		return x.UptimeFormatted
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetGoroutines() int64 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetGcCycles() uint32 {
+	if x != nil {
+		return x.GcCycles
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetDeterministic() bool {
+	if x != nil {
+		return x.Deterministic
+	}
+	return false
+}
+
+func (x *StatusResponse) GetGoldenDag() string {
+	if x != nil {
+		return x.GoldenDag
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetCodexId() string {
+	if x != nil {
+		return x.CodexId
+	}
+	return ""
+}
+
+// IntentRequest is an intent vector; unset components default to 1
+type IntentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phi_1         *float64               `protobuf:"fixed64,1,opt,name=phi_1,json=phi1,proto3,oneof" json:"phi_1,omitempty"`
+	Phi_22        *float64               `protobuf:"fixed64,2,opt,name=phi_22,json=phi22,proto3,oneof" json:"phi_22,omitempty"`
+	OmegaGenesis  *float64               `protobuf:"fixed64,3,opt,name=omega_genesis,json=omegaGenesis,proto3,oneof" json:"omega_genesis,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntentRequest) Reset() {
+	*x = IntentRequest{}
+	mi := &file_neuralblitz_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntentRequest) ProtoMessage() {}
+
+func (x *IntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntentRequest.ProtoReflect.Descriptor instead.
+func (*IntentRequest) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IntentRequest) GetPhi_1() float64 {
+	if x != nil && x.Phi_1 != nil {
+		return *x.Phi_1
+	}
+	return 0
+}
+
+func (x *IntentRequest) GetPhi_22() float64 {
+	if x != nil && x.Phi_22 != nil {
+		return *x.Phi_22
+	}
+	return 0
+}
+
+func (x *IntentRequest) GetOmegaGenesis() float64 {
+	if x != nil && x.OmegaGenesis != nil {
+		return *x.OmegaGenesis
+	}
+	return 0
+}
+
+func (x *IntentRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type IntentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	IntentVector  []float64              `protobuf:"fixed64,2,rep,packed,name=intent_vector,json=intentVector,proto3" json:"intent_vector,omitempty"`
+	Processing    *structpb.Struct       `protobuf:"bytes,3,opt,name=processing,proto3" json:"processing,omitempty"`
+	CoCreation    *structpb.Struct       `protobuf:"bytes,4,opt,name=co_creation,json=coCreation,proto3" json:"co_creation,omitempty"`
+	Actualization *structpb.Struct       `protobuf:"bytes,5,opt,name=actualization,proto3" json:"actualization,omitempty"`
+	Coherence     float64                `protobuf:"fixed64,6,opt,name=coherence,proto3" json:"coherence,omitempty"`
+	Unity         float64                `protobuf:"fixed64,7,opt,name=unity,proto3" json:"unity,omitempty"`
+	GoldenDag     string                 `protobuf:"bytes,8,opt,name=golden_dag,json=goldenDag,proto3" json:"golden_dag,omitempty"`
+	TraceId       string                 `protobuf:"bytes,9,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CodexId       string                 `protobuf:"bytes,10,opt,name=codex_id,json=codexId,proto3" json:"codex_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntentResponse) Reset() {
+	*x = IntentResponse{}
+	mi := &file_neuralblitz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntentResponse) ProtoMessage() {}
+
+func (x *IntentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntentResponse.ProtoReflect.Descriptor instead.
+func (*IntentResponse) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IntentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *IntentResponse) GetIntentVector() []float64 {
+	if x != nil {
+		return x.IntentVector
+	}
+	return nil
+}
+
+func (x *IntentResponse) GetProcessing() *structpb.Struct {
+	if x != nil {
+		return x.Processing
+	}
+	return nil
+}
+
+func (x *IntentResponse) GetCoCreation() *structpb.Struct {
+	if x != nil {
+		return x.CoCreation
+	}
+	return nil
+}
+
+func (x *IntentResponse) GetActualization() *structpb.Struct {
+	if x != nil {
+		return x.Actualization
+	}
+	return nil
+}
+
+func (x *IntentResponse) GetCoherence() float64 {
+	if x != nil {
+		return x.Coherence
+	}
+	return 0
+}
+
+func (x *IntentResponse) GetUnity() float64 {
+	if x != nil {
+		return x.Unity
+	}
+	return 0
+}
+
+func (x *IntentResponse) GetGoldenDag() string {
+	if x != nil {
+		return x.GoldenDag
+	}
+	return ""
+}
+
+func (x *IntentResponse) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *IntentResponse) GetCodexId() string {
+	if x != nil {
+		return x.CodexId
+	}
+	return ""
+}
+
+type VerifyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// irreducibility, coherence or attestation
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Payload       string `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyRequest) Reset() {
+	*x = VerifyRequest{}
+	mi := &file_neuralblitz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyRequest) ProtoMessage() {}
+
+func (x *VerifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyRequest.ProtoReflect.Descriptor instead.
+func (*VerifyRequest) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VerifyRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *VerifyRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+// VerifyResponse sets the fields after codex_id for the verification types
+// named in their comments
+type VerifyResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Type      string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Verified  bool                   `protobuf:"varint,2,opt,name=verified,proto3" json:"verified,omitempty"`
+	GoldenDag string                 `protobuf:"bytes,3,opt,name=golden_dag,json=goldenDag,proto3" json:"golden_dag,omitempty"`
+	TraceId   string                 `protobuf:"bytes,4,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CodexId   string                 `protobuf:"bytes,5,opt,name=codex_id,json=codexId,proto3" json:"codex_id,omitempty"`
+	// irreducibility
+	Reason                  string   `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	SeparationImpossibility *float64 `protobuf:"fixed64,7,opt,name=separation_impossibility,json=separationImpossibility,proto3,oneof" json:"separation_impossibility,omitempty"`
+	UnityCoherence          *float64 `protobuf:"fixed64,8,opt,name=unity_coherence,json=unityCoherence,proto3,oneof" json:"unity_coherence,omitempty"`
+	MathematicalProof       string   `protobuf:"bytes,9,opt,name=mathematical_proof,json=mathematicalProof,proto3" json:"mathematical_proof,omitempty"`
+	// coherence
+	Coherence *float64 `protobuf:"fixed64,10,opt,name=coherence,proto3,oneof" json:"coherence,omitempty"`
+	Target    *float64 `protobuf:"fixed64,11,opt,name=target,proto3,oneof" json:"target,omitempty"`
+	// attestation
+	AttestationHash string `protobuf:"bytes,12,opt,name=attestation_hash,json=attestationHash,proto3" json:"attestation_hash,omitempty"`
+	GoldenDagSeed   string `protobuf:"bytes,13,opt,name=golden_dag_seed,json=goldenDagSeed,proto3" json:"golden_dag_seed,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VerifyResponse) Reset() {
+	*x = VerifyResponse{}
+	mi := &file_neuralblitz_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyResponse) ProtoMessage() {}
+
+func (x *VerifyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyResponse.ProtoReflect.Descriptor instead.
+func (*VerifyResponse) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VerifyResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *VerifyResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *VerifyResponse) GetGoldenDag() string {
+	if x != nil {
+		return x.GoldenDag
+	}
+	return ""
+}
+
+func (x *VerifyResponse) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *VerifyResponse) GetCodexId() string {
+	if x != nil {
+		return x.CodexId
+	}
+	return ""
+}
+
+func (x *VerifyResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VerifyResponse) GetSeparationImpossibility() float64 {
+	if x != nil && x.SeparationImpossibility != nil {
+		return *x.SeparationImpossibility
+	}
+	return 0
+}
+
+func (x *VerifyResponse) GetUnityCoherence() float64 {
+	if x != nil && x.UnityCoherence != nil {
+		return *x.UnityCoherence
+	}
+	return 0
+}
+
+func (x *VerifyResponse) GetMathematicalProof() string {
+	if x != nil {
+		return x.MathematicalProof
+	}
+	return ""
+}
+
+func (x *VerifyResponse) GetCoherence() float64 {
+	if x != nil && x.Coherence != nil {
+		return *x.Coherence
+	}
+	return 0
+}
+
+func (x *VerifyResponse) GetTarget() float64 {
+	if x != nil && x.Target != nil {
+		return *x.Target
+	}
+	return 0
+}
+
+func (x *VerifyResponse) GetAttestationHash() string {
+	if x != nil {
+		return x.AttestationHash
+	}
+	return ""
+}
+
+func (x *VerifyResponse) GetGoldenDagSeed() string {
+	if x != nil {
+		return x.GoldenDagSeed
+	}
+	return ""
+}
+
+type NBCLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NBCLRequest) Reset() {
+	*x = NBCLRequest{}
+	mi := &file_neuralblitz_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NBCLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NBCLRequest) ProtoMessage() {}
+
+func (x *NBCLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NBCLRequest.ProtoReflect.Descriptor instead.
+func (*NBCLRequest) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *NBCLRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+type NBCLResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Command   string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Status    string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TraceId   string                 `protobuf:"bytes,3,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CodexId   string                 `protobuf:"bytes,4,opt,name=codex_id,json=codexId,proto3" json:"codex_id,omitempty"`
+	GoldenDag string                 `protobuf:"bytes,5,opt,name=golden_dag,json=goldenDag,proto3" json:"golden_dag,omitempty"`
+	Coherence float64                `protobuf:"fixed64,6,opt,name=coherence,proto3" json:"coherence,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// result is the whole result as POST /nbcl/interpret returns it,
+	// including the section of the command
+	Result        *structpb.Struct `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NBCLResponse) Reset() {
+	*x = NBCLResponse{}
+	mi := &file_neuralblitz_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NBCLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NBCLResponse) ProtoMessage() {}
+
+func (x *NBCLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NBCLResponse.ProtoReflect.Descriptor instead.
+func (*NBCLResponse) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NBCLResponse) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *NBCLResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *NBCLResponse) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *NBCLResponse) GetCodexId() string {
+	if x != nil {
+		return x.CodexId
+	}
+	return ""
+}
+
+func (x *NBCLResponse) GetGoldenDag() string {
+	if x != nil {
+		return x.GoldenDag
+	}
+	return ""
+}
+
+func (x *NBCLResponse) GetCoherence() float64 {
+	if x != nil {
+		return x.Coherence
+	}
+	return 0
+}
+
+func (x *NBCLResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *NBCLResponse) GetResult() *structpb.Struct {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type GetAttestationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttestationRequest) Reset() {
+	*x = GetAttestationRequest{}
+	mi := &file_neuralblitz_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttestationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttestationRequest) ProtoMessage() {}
+
+func (x *GetAttestationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttestationRequest.ProtoReflect.Descriptor instead.
+func (*GetAttestationRequest) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{8}
+}
+
+type AttestationStatement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Structural    string                 `protobuf:"bytes,1,opt,name=structural,proto3" json:"structural,omitempty"`
+	Ethical       string                 `protobuf:"bytes,2,opt,name=ethical,proto3" json:"ethical,omitempty"`
+	Governance    string                 `protobuf:"bytes,3,opt,name=governance,proto3" json:"governance,omitempty"`
+	Genesis       string                 `protobuf:"bytes,4,opt,name=genesis,proto3" json:"genesis,omitempty"`
+	Reality       string                 `protobuf:"bytes,5,opt,name=reality,proto3" json:"reality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttestationStatement) Reset() {
+	*x = AttestationStatement{}
+	mi := &file_neuralblitz_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttestationStatement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttestationStatement) ProtoMessage() {}
+
+func (x *AttestationStatement) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttestationStatement.ProtoReflect.Descriptor instead.
+func (*AttestationStatement) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AttestationStatement) GetStructural() string {
+	if x != nil {
+		return x.Structural
+	}
+	return ""
+}
+
+func (x *AttestationStatement) GetEthical() string {
+	if x != nil {
+		return x.Ethical
+	}
+	return ""
+}
+
+func (x *AttestationStatement) GetGovernance() string {
+	if x != nil {
+		return x.Governance
+	}
+	return ""
+}
+
+func (x *AttestationStatement) GetGenesis() string {
+	if x != nil {
+		return x.Genesis
+	}
+	return ""
+}
+
+func (x *AttestationStatement) GetReality() string {
+	if x != nil {
+		return x.Reality
+	}
+	return ""
+}
+
+type AttestationResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Attestation       string                 `protobuf:"bytes,1,opt,name=attestation,proto3" json:"attestation,omitempty"`
+	Version           string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	GoldenDag         string                 `protobuf:"bytes,3,opt,name=golden_dag,json=goldenDag,proto3" json:"golden_dag,omitempty"`
+	TraceId           string                 `protobuf:"bytes,4,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CodexId           string                 `protobuf:"bytes,5,opt,name=codex_id,json=codexId,proto3" json:"codex_id,omitempty"`
+	RealityState      string                 `protobuf:"bytes,6,opt,name=reality_state,json=realityState,proto3" json:"reality_state,omitempty"`
+	Coherence         float64                `protobuf:"fixed64,7,opt,name=coherence,proto3" json:"coherence,omitempty"`
+	SingularityStatus string                 `protobuf:"bytes,8,opt,name=singularity_status,json=singularityStatus,proto3" json:"singularity_status,omitempty"`
+	AttestationHash   string                 `protobuf:"bytes,9,opt,name=attestation_hash,json=attestationHash,proto3" json:"attestation_hash,omitempty"`
+	Statement         *AttestationStatement  `protobuf:"bytes,10,opt,name=statement,proto3" json:"statement,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AttestationResponse) Reset() {
+	*x = AttestationResponse{}
+	mi := &file_neuralblitz_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttestationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttestationResponse) ProtoMessage() {}
+
+func (x *AttestationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttestationResponse.ProtoReflect.Descriptor instead.
+func (*AttestationResponse) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AttestationResponse) GetAttestation() string {
+	if x != nil {
+		return x.Attestation
+	}
+	return ""
+}
+
+func (x *AttestationResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AttestationResponse) GetGoldenDag() string {
+	if x != nil {
+		return x.GoldenDag
+	}
+	return ""
+}
+
+func (x *AttestationResponse) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *AttestationResponse) GetCodexId() string {
+	if x != nil {
+		return x.CodexId
+	}
+	return ""
+}
+
+func (x *AttestationResponse) GetRealityState() string {
+	if x != nil {
+		return x.RealityState
+	}
+	return ""
+}
+
+func (x *AttestationResponse) GetCoherence() float64 {
+	if x != nil {
+		return x.Coherence
+	}
+	return 0
+}
+
+func (x *AttestationResponse) GetSingularityStatus() string {
+	if x != nil {
+		return x.SingularityStatus
+	}
+	return ""
+}
+
+func (x *AttestationResponse) GetAttestationHash() string {
+	if x != nil {
+		return x.AttestationHash
+	}
+	return ""
+}
+
+func (x *AttestationResponse) GetStatement() *AttestationStatement {
+	if x != nil {
+		return x.Statement
+	}
+	return nil
+}
+
+type GetOptionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A to F, case-insensitive
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOptionRequest) Reset() {
+	*x = GetOptionRequest{}
+	mi := &file_neuralblitz_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOptionRequest) ProtoMessage() {}
+
+func (x *GetOptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOptionRequest.ProtoReflect.Descriptor instead.
+func (*GetOptionRequest) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetOptionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type OptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Option        string                 `protobuf:"bytes,1,opt,name=option,proto3" json:"option,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Config        *structpb.Struct       `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	GoldenDag     string                 `protobuf:"bytes,4,opt,name=golden_dag,json=goldenDag,proto3" json:"golden_dag,omitempty"`
+	TraceId       string                 `protobuf:"bytes,5,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionResponse) Reset() {
+	*x = OptionResponse{}
+	mi := &file_neuralblitz_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionResponse) ProtoMessage() {}
+
+func (x *OptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionResponse.ProtoReflect.Descriptor instead.
+func (*OptionResponse) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OptionResponse) GetOption() string {
+	if x != nil {
+		return x.Option
+	}
+	return ""
+}
+
+func (x *OptionResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OptionResponse) GetConfig() *structpb.Struct {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *OptionResponse) GetGoldenDag() string {
+	if x != nil {
+		return x.GoldenDag
+	}
+	return ""
+}
+
+func (x *OptionResponse) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+type ListOptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOptionsRequest) Reset() {
+	*x = ListOptionsRequest{}
+	mi := &file_neuralblitz_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOptionsRequest) ProtoMessage() {}
+
+func (x *ListOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{13}
+}
+
+type OptionSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MemoryMb      int32                  `protobuf:"varint,3,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionSummary) Reset() {
+	*x = OptionSummary{}
+	mi := &file_neuralblitz_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionSummary) ProtoMessage() {}
+
+func (x *OptionSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionSummary.ProtoReflect.Descriptor instead.
+func (*OptionSummary) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OptionSummary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OptionSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OptionSummary) GetMemoryMb() int32 {
+	if x != nil {
+		return x.MemoryMb
+	}
+	return 0
+}
+
+func (x *OptionSummary) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type OptionsListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       []*OptionSummary       `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	GoldenDag     string                 `protobuf:"bytes,3,opt,name=golden_dag,json=goldenDag,proto3" json:"golden_dag,omitempty"`
+	TraceId       string                 `protobuf:"bytes,4,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CodexId       string                 `protobuf:"bytes,5,opt,name=codex_id,json=codexId,proto3" json:"codex_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionsListResponse) Reset() {
+	*x = OptionsListResponse{}
+	mi := &file_neuralblitz_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionsListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionsListResponse) ProtoMessage() {}
+
+func (x *OptionsListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionsListResponse.ProtoReflect.Descriptor instead.
+func (*OptionsListResponse) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OptionsListResponse) GetOptions() []*OptionSummary {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *OptionsListResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *OptionsListResponse) GetGoldenDag() string {
+	if x != nil {
+		return x.GoldenDag
+	}
+	return ""
+}
+
+func (x *OptionsListResponse) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *OptionsListResponse) GetCodexId() string {
+	if x != nil {
+		return x.CodexId
+	}
+	return ""
+}
+
+type StreamMetricsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// lrs, entrainment or entanglement; all when empty
+	Subsystems []string `protobuf:"bytes,1,rep,name=subsystems,proto3" json:"subsystems,omitempty"`
+	// last_event_id resumes the stream after this event
+	LastEventId   int64 `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamMetricsRequest) Reset() {
+	*x = StreamMetricsRequest{}
+	mi := &file_neuralblitz_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMetricsRequest) ProtoMessage() {}
+
+func (x *StreamMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMetricsRequest.ProtoReflect.Descriptor instead.
+func (*StreamMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StreamMetricsRequest) GetSubsystems() []string {
+	if x != nil {
+		return x.Subsystems
+	}
+	return nil
+}
+
+func (x *StreamMetricsRequest) GetLastEventId() int64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+type MetricsEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Subsystem     string                 `protobuf:"bytes,2,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	Data          *structpb.Struct       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricsEvent) Reset() {
+	*x = MetricsEvent{}
+	mi := &file_neuralblitz_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsEvent) ProtoMessage() {}
+
+func (x *MetricsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_neuralblitz_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsEvent.ProtoReflect.Descriptor instead.
+func (*MetricsEvent) Descriptor() ([]byte, []int) {
+	return file_neuralblitz_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MetricsEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MetricsEvent) GetSubsystem() string {
+	if x != nil {
+		return x.Subsystem
+	}
+	return ""
+}
+
+func (x *MetricsEvent) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *MetricsEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+var File_neuralblitz_proto protoreflect.FileDescriptor
+
+const file_neuralblitz_proto_rawDesc = "" +
+	"\n" +
+	"\x11neuralblitz.proto\x12\x0eneuralblitz.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x12\n" +
+	"\x10GetStatusRequest\"\xb8\x05\n" +
+	"\x0eStatusResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12#\n" +
+	"\rreality_state\x18\x02 \x01(\tR\frealityState\x12\x1c\n" +
+	"\tcoherence\x18\x03 \x01(\x01R\tcoherence\x12&\n" +
+	"\x0eirreducibility\x18\x04 \x01(\bR\x0eirreducibility\x12!\n" +
+	"\funity_vector\x18\x05 \x01(\x01R\vunityVector\x12%\n" +
+	"\x0edyad_coherence\x18\x06 \x01(\x01R\rdyadCoherence\x12!\n" +
+	"\fco_creations\x18\a \x01(\x03R\vcoCreations\x12&\n" +
+	"\x0eactualizations\x18\b \x01(\x03R\x0eactualizations\x12-\n" +
+	"\x12singularity_status\x18\t \x01(\tR\x11singularityStatus\x12%\n" +
+	"\x0euptime_seconds\x18\n" +
+	" \x01(\x01R\ruptimeSeconds\x12)\n" +
+	"\x10uptime_formatted\x18\v \x01(\tR\x0fuptimeFormatted\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\f \x01(\tR\tgoVersion\x12\x0e\n" +
+	"\x02os\x18\r \x01(\tR\x02os\x12\x12\n" +
+	"\x04arch\x18\x0e \x01(\tR\x04arch\x12\x1e\n" +
+	"\n" +
+	"goroutines\x18\x0f \x01(\x03R\n" +
+	"goroutines\x12\x1b\n" +
+	"\tgc_cycles\x18\x10 \x01(\rR\bgcCycles\x12\x12\n" +
+	"\x04seed\x18\x11 \x01(\x03R\x04seed\x12$\n" +
+	"\rdeterministic\x18\x12 \x01(\bR\rdeterministic\x12\x1d\n" +
+	"\n" +
+	"golden_dag\x18\x13 \x01(\tR\tgoldenDag\x12\x19\n" +
+	"\btrace_id\x18\x14 \x01(\tR\atraceId\x12\x19\n" +
+	"\bcodex_id\x18\x15 \x01(\tR\acodexId\"\xae\x01\n" +
+	"\rIntentRequest\x12\x18\n" +
+	"\x05phi_1\x18\x01 \x01(\x01H\x00R\x04phi1\x88\x01\x01\x12\x1a\n" +
+	"\x06phi_22\x18\x02 \x01(\x01H\x01R\x05phi22\x88\x01\x01\x12(\n" +
+	"\romega_genesis\x18\x03 \x01(\x01H\x02R\fomegaGenesis\x88\x01\x01\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06sourceB\b\n" +
+	"\x06_phi_1B\t\n" +
+	"\a_phi_22B\x10\n" +
+	"\x0e_omega_genesis\"\x88\x03\n" +
+	"\x0eIntentResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12#\n" +
+	"\rintent_vector\x18\x02 \x03(\x01R\fintentVector\x127\n" +
+	"\n" +
+	"processing\x18\x03 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"processing\x128\n" +
+	"\vco_creation\x18\x04 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"coCreation\x12=\n" +
+	"\ractualization\x18\x05 \x01(\v2\x17.google.protobuf.StructR\ractualization\x12\x1c\n" +
+	"\tcoherence\x18\x06 \x01(\x01R\tcoherence\x12\x14\n" +
+	"\x05unity\x18\a \x01(\x01R\x05unity\x12\x1d\n" +
+	"\n" +
+	"golden_dag\x18\b \x01(\tR\tgoldenDag\x12\x19\n" +
+	"\btrace_id\x18\t \x01(\tR\atraceId\x12\x19\n" +
+	"\bcodex_id\x18\n" +
+	" \x01(\tR\acodexId\"=\n" +
+	"\rVerifyRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\tR\apayload\"\xa7\x04\n" +
+	"\x0eVerifyResponse\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1a\n" +
+	"\bverified\x18\x02 \x01(\bR\bverified\x12\x1d\n" +
+	"\n" +
+	"golden_dag\x18\x03 \x01(\tR\tgoldenDag\x12\x19\n" +
+	"\btrace_id\x18\x04 \x01(\tR\atraceId\x12\x19\n" +
+	"\bcodex_id\x18\x05 \x01(\tR\acodexId\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12>\n" +
+	"\x18separation_impossibility\x18\a \x01(\x01H\x00R\x17separationImpossibility\x88\x01\x01\x12,\n" +
+	"\x0funity_coherence\x18\b \x01(\x01H\x01R\x0eunityCoherence\x88\x01\x01\x12-\n" +
+	"\x12mathematical_proof\x18\t \x01(\tR\x11mathematicalProof\x12!\n" +
+	"\tcoherence\x18\n" +
+	" \x01(\x01H\x02R\tcoherence\x88\x01\x01\x12\x1b\n" +
+	"\x06target\x18\v \x01(\x01H\x03R\x06target\x88\x01\x01\x12)\n" +
+	"\x10attestation_hash\x18\f \x01(\tR\x0fattestationHash\x12&\n" +
+	"\x0fgolden_dag_seed\x18\r \x01(\tR\rgoldenDagSeedB\x1b\n" +
+	"\x19_separation_impossibilityB\x12\n" +
+	"\x10_unity_coherenceB\f\n" +
+	"\n" +
+	"_coherenceB\t\n" +
+	"\a_target\"'\n" +
+	"\vNBCLRequest\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\"\x9e\x02\n" +
+	"\fNBCLResponse\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x19\n" +
+	"\btrace_id\x18\x03 \x01(\tR\atraceId\x12\x19\n" +
+	"\bcodex_id\x18\x04 \x01(\tR\acodexId\x12\x1d\n" +
+	"\n" +
+	"golden_dag\x18\x05 \x01(\tR\tgoldenDag\x12\x1c\n" +
+	"\tcoherence\x18\x06 \x01(\x01R\tcoherence\x128\n" +
+	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12/\n" +
+	"\x06result\x18\b \x01(\v2\x17.google.protobuf.StructR\x06result\"\x17\n" +
+	"\x15GetAttestationRequest\"\xa4\x01\n" +
+	"\x14AttestationStatement\x12\x1e\n" +
+	"\n" +
+	"structural\x18\x01 \x01(\tR\n" +
+	"structural\x12\x18\n" +
+	"\aethical\x18\x02 \x01(\tR\aethical\x12\x1e\n" +
+	"\n" +
+	"governance\x18\x03 \x01(\tR\n" +
+	"governance\x12\x18\n" +
+	"\agenesis\x18\x04 \x01(\tR\agenesis\x12\x18\n" +
+	"\areality\x18\x05 \x01(\tR\areality\"\x87\x03\n" +
+	"\x13AttestationResponse\x12 \n" +
+	"\vattestation\x18\x01 \x01(\tR\vattestation\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"golden_dag\x18\x03 \x01(\tR\tgoldenDag\x12\x19\n" +
+	"\btrace_id\x18\x04 \x01(\tR\atraceId\x12\x19\n" +
+	"\bcodex_id\x18\x05 \x01(\tR\acodexId\x12#\n" +
+	"\rreality_state\x18\x06 \x01(\tR\frealityState\x12\x1c\n" +
+	"\tcoherence\x18\a \x01(\x01R\tcoherence\x12-\n" +
+	"\x12singularity_status\x18\b \x01(\tR\x11singularityStatus\x12)\n" +
+	"\x10attestation_hash\x18\t \x01(\tR\x0fattestationHash\x12B\n" +
+	"\tstatement\x18\n" +
+	" \x01(\v2$.neuralblitz.v1.AttestationStatementR\tstatement\"\"\n" +
+	"\x10GetOptionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa7\x01\n" +
+	"\x0eOptionResponse\x12\x16\n" +
+	"\x06option\x18\x01 \x01(\tR\x06option\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12/\n" +
+	"\x06config\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x06config\x12\x1d\n" +
+	"\n" +
+	"golden_dag\x18\x04 \x01(\tR\tgoldenDag\x12\x19\n" +
+	"\btrace_id\x18\x05 \x01(\tR\atraceId\"\x14\n" +
+	"\x12ListOptionsRequest\"r\n" +
+	"\rOptionSummary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tmemory_mb\x18\x03 \x01(\x05R\bmemoryMb\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xb9\x01\n" +
+	"\x13OptionsListResponse\x127\n" +
+	"\aoptions\x18\x01 \x03(\v2\x1d.neuralblitz.v1.OptionSummaryR\aoptions\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1d\n" +
+	"\n" +
+	"golden_dag\x18\x03 \x01(\tR\tgoldenDag\x12\x19\n" +
+	"\btrace_id\x18\x04 \x01(\tR\atraceId\x12\x19\n" +
+	"\bcodex_id\x18\x05 \x01(\tR\acodexId\"Z\n" +
+	"\x14StreamMetricsRequest\x12\x1e\n" +
+	"\n" +
+	"subsystems\x18\x01 \x03(\tR\n" +
+	"subsystems\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\x03R\vlastEventId\"\xa3\x01\n" +
+	"\fMetricsEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1c\n" +
+	"\tsubsystem\x18\x02 \x01(\tR\tsubsystem\x12+\n" +
+	"\x04data\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x04data\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp2\x9d\x05\n" +
+	"\vNeuralBlitz\x12M\n" +
+	"\tGetStatus\x12 .neuralblitz.v1.GetStatusRequest\x1a\x1e.neuralblitz.v1.StatusResponse\x12N\n" +
+	"\rProcessIntent\x12\x1d.neuralblitz.v1.IntentRequest\x1a\x1e.neuralblitz.v1.IntentResponse\x12G\n" +
+	"\x06Verify\x12\x1d.neuralblitz.v1.VerifyRequest\x1a\x1e.neuralblitz.v1.VerifyResponse\x12J\n" +
+	"\rInterpretNBCL\x12\x1b.neuralblitz.v1.NBCLRequest\x1a\x1c.neuralblitz.v1.NBCLResponse\x12\\\n" +
+	"\x0eGetAttestation\x12%.neuralblitz.v1.GetAttestationRequest\x1a#.neuralblitz.v1.AttestationResponse\x12M\n" +
+	"\tGetOption\x12 .neuralblitz.v1.GetOptionRequest\x1a\x1e.neuralblitz.v1.OptionResponse\x12V\n" +
+	"\vListOptions\x12\".neuralblitz.v1.ListOptionsRequest\x1a#.neuralblitz.v1.OptionsListResponse\x12U\n" +
+	"\rStreamMetrics\x12$.neuralblitz.v1.StreamMetricsRequest\x1a\x1c.neuralblitz.v1.MetricsEvent0\x01B\x18Z\x16neuralblitz/pkg/rpc/pbb\x06proto3"
+
+var (
+	file_neuralblitz_proto_rawDescOnce sync.Once
+	file_neuralblitz_proto_rawDescData []byte
+)
+
+func file_neuralblitz_proto_rawDescGZIP() []byte {
+	file_neuralblitz_proto_rawDescOnce.Do(func() {
+		file_neuralblitz_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_neuralblitz_proto_rawDesc), len(file_neuralblitz_proto_rawDesc)))
+	})
+	return file_neuralblitz_proto_rawDescData
+}
+
+var file_neuralblitz_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_neuralblitz_proto_goTypes = []any{
+	(*GetStatusRequest)(nil),      // 0: neuralblitz.v1.GetStatusRequest
+	(*StatusResponse)(nil),        // 1: neuralblitz.v1.StatusResponse
+	(*IntentRequest)(nil),         // 2: neuralblitz.v1.IntentRequest
+	(*IntentResponse)(nil),        // 3: neuralblitz.v1.IntentResponse
+	(*VerifyRequest)(nil),         // 4: neuralblitz.v1.VerifyRequest
+	(*VerifyResponse)(nil),        // 5: neuralblitz.v1.VerifyResponse
+	(*NBCLRequest)(nil),           // 6: neuralblitz.v1.NBCLRequest
+	(*NBCLResponse)(nil),          // 7: neuralblitz.v1.NBCLResponse
+	(*GetAttestationRequest)(nil), // 8: neuralblitz.v1.GetAttestationRequest
+	(*AttestationStatement)(nil),  // 9: neuralblitz.v1.AttestationStatement
+	(*AttestationResponse)(nil),   // 10: neuralblitz.v1.AttestationResponse
+	(*GetOptionRequest)(nil),      // 11: neuralblitz.v1.GetOptionRequest
+	(*OptionResponse)(nil),        // 12: neuralblitz.v1.OptionResponse
+	(*ListOptionsRequest)(nil),    // 13: neuralblitz.v1.ListOptionsRequest
+	(*OptionSummary)(nil),         // 14: neuralblitz.v1.OptionSummary
+	(*OptionsListResponse)(nil),   // 15: neuralblitz.v1.OptionsListResponse
+	(*StreamMetricsRequest)(nil),  // 16: neuralblitz.v1.StreamMetricsRequest
+	(*MetricsEvent)(nil),          // 17: neuralblitz.v1.MetricsEvent
+	(*structpb.Struct)(nil),       // 18: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 19: google.protobuf.Timestamp
+}
+var file_neuralblitz_proto_depIdxs = []int32{
+	18, // 0: neuralblitz.v1.IntentResponse.processing:type_name -> google.protobuf.Struct
+	18, // 1: neuralblitz.v1.IntentResponse.co_creation:type_name -> google.protobuf.Struct
+	18, // 2: neuralblitz.v1.IntentResponse.actualization:type_name -> google.protobuf.Struct
+	19, // 3: neuralblitz.v1.NBCLResponse.timestamp:type_name -> google.protobuf.Timestamp
+	18, // 4: neuralblitz.v1.NBCLResponse.result:type_name -> google.protobuf.Struct
+	9,  // 5: neuralblitz.v1.AttestationResponse.statement:type_name -> neuralblitz.v1.AttestationStatement
+	18, // 6: neuralblitz.v1.OptionResponse.config:type_name -> google.protobuf.Struct
+	14, // 7: neuralblitz.v1.OptionsListResponse.options:type_name -> neuralblitz.v1.OptionSummary
+	18, // 8: neuralblitz.v1.MetricsEvent.data:type_name -> google.protobuf.Struct
+	19, // 9: neuralblitz.v1.MetricsEvent.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 10: neuralblitz.v1.NeuralBlitz.GetStatus:input_type -> neuralblitz.v1.GetStatusRequest
+	2,  // 11: neuralblitz.v1.NeuralBlitz.ProcessIntent:input_type -> neuralblitz.v1.IntentRequest
+	4,  // 12: neuralblitz.v1.NeuralBlitz.Verify:input_type -> neuralblitz.v1.VerifyRequest
+	6,  // 13: neuralblitz.v1.NeuralBlitz.InterpretNBCL:input_type -> neuralblitz.v1.NBCLRequest
+	8,  // 14: neuralblitz.v1.NeuralBlitz.GetAttestation:input_type -> neuralblitz.v1.GetAttestationRequest
+	11, // 15: neuralblitz.v1.NeuralBlitz.GetOption:input_type -> neuralblitz.v1.GetOptionRequest
+	13, // 16: neuralblitz.v1.NeuralBlitz.ListOptions:input_type -> neuralblitz.v1.ListOptionsRequest
+	16, // 17: neuralblitz.v1.NeuralBlitz.StreamMetrics:input_type -> neuralblitz.v1.StreamMetricsRequest
+	1,  // 18: neuralblitz.v1.NeuralBlitz.GetStatus:output_type -> neuralblitz.v1.StatusResponse
+	3,  // 19: neuralblitz.v1.NeuralBlitz.ProcessIntent:output_type -> neuralblitz.v1.IntentResponse
+	5,  // 20: neuralblitz.v1.NeuralBlitz.Verify:output_type -> neuralblitz.v1.VerifyResponse
+	7,  // 21: neuralblitz.v1.NeuralBlitz.InterpretNBCL:output_type -> neuralblitz.v1.NBCLResponse
+	10, // 22: neuralblitz.v1.NeuralBlitz.GetAttestation:output_type -> neuralblitz.v1.AttestationResponse
+	12, // 23: neuralblitz.v1.NeuralBlitz.GetOption:output_type -> neuralblitz.v1.OptionResponse
+	15, // 24: neuralblitz.v1.NeuralBlitz.ListOptions:output_type -> neuralblitz.v1.OptionsListResponse
+	17, // 25: neuralblitz.v1.NeuralBlitz.StreamMetrics:output_type -> neuralblitz.v1.MetricsEvent
+	18, // [18:26] is the sub-list for method output_type
+	10, // [10:18] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_neuralblitz_proto_init() }
+func file_neuralblitz_proto_init() {
+	if File_neuralblitz_proto != nil {
+		return
+	}
+	file_neuralblitz_proto_msgTypes[2].OneofWrappers = []any{}
+	file_neuralblitz_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_neuralblitz_proto_rawDesc), len(file_neuralblitz_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_neuralblitz_proto_goTypes,
+		DependencyIndexes: file_neuralblitz_proto_depIdxs,
+		MessageInfos:      file_neuralblitz_proto_msgTypes,
+	}.Build()
+	File_neuralblitz_proto = out.File
+	file_neuralblitz_proto_goTypes = nil
+	file_neuralblitz_proto_depIdxs = nil
+}
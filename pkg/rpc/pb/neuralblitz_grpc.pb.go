@@ -0,0 +1,414 @@
+// The gRPC front end of the NeuralBlitz API. Every RPC mirrors a REST
+// route and runs the same service method, so the two transports return the
+// same state; nested results whose shape follows the Go types are carried
+// as google.protobuf.Struct in their JSON form.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: neuralblitz.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	NeuralBlitz_GetStatus_FullMethodName      = "/neuralblitz.v1.NeuralBlitz/GetStatus"
+	NeuralBlitz_ProcessIntent_FullMethodName  = "/neuralblitz.v1.NeuralBlitz/ProcessIntent"
+	NeuralBlitz_Verify_FullMethodName         = "/neuralblitz.v1.NeuralBlitz/Verify"
+	NeuralBlitz_InterpretNBCL_FullMethodName  = "/neuralblitz.v1.NeuralBlitz/InterpretNBCL"
+	NeuralBlitz_GetAttestation_FullMethodName = "/neuralblitz.v1.NeuralBlitz/GetAttestation"
+	NeuralBlitz_GetOption_FullMethodName      = "/neuralblitz.v1.NeuralBlitz/GetOption"
+	NeuralBlitz_ListOptions_FullMethodName    = "/neuralblitz.v1.NeuralBlitz/ListOptions"
+	NeuralBlitz_StreamMetrics_FullMethodName  = "/neuralblitz.v1.NeuralBlitz/StreamMetrics"
+)
+
+// NeuralBlitzClient is the client API for NeuralBlitz service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NeuralBlitzClient interface {
+	// GetStatus mirrors GET /status
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// ProcessIntent mirrors POST /intent
+	ProcessIntent(ctx context.Context, in *IntentRequest, opts ...grpc.CallOption) (*IntentResponse, error)
+	// Verify mirrors POST /verify
+	Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyResponse, error)
+	// InterpretNBCL mirrors POST /nbcl/interpret
+	InterpretNBCL(ctx context.Context, in *NBCLRequest, opts ...grpc.CallOption) (*NBCLResponse, error)
+	// GetAttestation mirrors GET /attestation
+	GetAttestation(ctx context.Context, in *GetAttestationRequest, opts ...grpc.CallOption) (*AttestationResponse, error)
+	// GetOption mirrors GET /options/{id}
+	GetOption(ctx context.Context, in *GetOptionRequest, opts ...grpc.CallOption) (*OptionResponse, error)
+	// ListOptions mirrors GET /options
+	ListOptions(ctx context.Context, in *ListOptionsRequest, opts ...grpc.CallOption) (*OptionsListResponse, error)
+	// StreamMetrics mirrors GET /stream/metrics: the live metrics of the LRS
+	// bridge, entrainment system and entanglement manager
+	StreamMetrics(ctx context.Context, in *StreamMetricsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MetricsEvent], error)
+}
+
+type neuralBlitzClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNeuralBlitzClient(cc grpc.ClientConnInterface) NeuralBlitzClient {
+	return &neuralBlitzClient{cc}
+}
+
+func (c *neuralBlitzClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, NeuralBlitz_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *neuralBlitzClient) ProcessIntent(ctx context.Context, in *IntentRequest, opts ...grpc.CallOption) (*IntentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IntentResponse)
+	err := c.cc.Invoke(ctx, NeuralBlitz_ProcessIntent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *neuralBlitzClient) Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyResponse)
+	err := c.cc.Invoke(ctx, NeuralBlitz_Verify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *neuralBlitzClient) InterpretNBCL(ctx context.Context, in *NBCLRequest, opts ...grpc.CallOption) (*NBCLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NBCLResponse)
+	err := c.cc.Invoke(ctx, NeuralBlitz_InterpretNBCL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *neuralBlitzClient) GetAttestation(ctx context.Context, in *GetAttestationRequest, opts ...grpc.CallOption) (*AttestationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttestationResponse)
+	err := c.cc.Invoke(ctx, NeuralBlitz_GetAttestation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *neuralBlitzClient) GetOption(ctx context.Context, in *GetOptionRequest, opts ...grpc.CallOption) (*OptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OptionResponse)
+	err := c.cc.Invoke(ctx, NeuralBlitz_GetOption_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *neuralBlitzClient) ListOptions(ctx context.Context, in *ListOptionsRequest, opts ...grpc.CallOption) (*OptionsListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OptionsListResponse)
+	err := c.cc.Invoke(ctx, NeuralBlitz_ListOptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *neuralBlitzClient) StreamMetrics(ctx context.Context, in *StreamMetricsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MetricsEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NeuralBlitz_ServiceDesc.Streams[0], NeuralBlitz_StreamMetrics_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamMetricsRequest, MetricsEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NeuralBlitz_StreamMetricsClient = grpc.ServerStreamingClient[MetricsEvent]
+
+// NeuralBlitzServer is the server API for NeuralBlitz service.
+// All implementations must embed UnimplementedNeuralBlitzServer
+// for forward compatibility.
+type NeuralBlitzServer interface {
+	// GetStatus mirrors GET /status
+	GetStatus(context.Context, *GetStatusRequest) (*StatusResponse, error)
+	// ProcessIntent mirrors POST /intent
+	ProcessIntent(context.Context, *IntentRequest) (*IntentResponse, error)
+	// Verify mirrors POST /verify
+	Verify(context.Context, *VerifyRequest) (*VerifyResponse, error)
+	// InterpretNBCL mirrors POST /nbcl/interpret
+	InterpretNBCL(context.Context, *NBCLRequest) (*NBCLResponse, error)
+	// GetAttestation mirrors GET /attestation
+	GetAttestation(context.Context, *GetAttestationRequest) (*AttestationResponse, error)
+	// GetOption mirrors GET /options/{id}
+	GetOption(context.Context, *GetOptionRequest) (*OptionResponse, error)
+	// ListOptions mirrors GET /options
+	ListOptions(context.Context, *ListOptionsRequest) (*OptionsListResponse, error)
+	// StreamMetrics mirrors GET /stream/metrics: the live metrics of the LRS
+	// bridge, entrainment system and entanglement manager
+	StreamMetrics(*StreamMetricsRequest, grpc.ServerStreamingServer[MetricsEvent]) error
+	mustEmbedUnimplementedNeuralBlitzServer()
+}
+
+// UnimplementedNeuralBlitzServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNeuralBlitzServer struct{}
+
+func (UnimplementedNeuralBlitzServer) GetStatus(context.Context, *GetStatusRequest) (*StatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedNeuralBlitzServer) ProcessIntent(context.Context, *IntentRequest) (*IntentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProcessIntent not implemented")
+}
+func (UnimplementedNeuralBlitzServer) Verify(context.Context, *VerifyRequest) (*VerifyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Verify not implemented")
+}
+func (UnimplementedNeuralBlitzServer) InterpretNBCL(context.Context, *NBCLRequest) (*NBCLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InterpretNBCL not implemented")
+}
+func (UnimplementedNeuralBlitzServer) GetAttestation(context.Context, *GetAttestationRequest) (*AttestationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAttestation not implemented")
+}
+func (UnimplementedNeuralBlitzServer) GetOption(context.Context, *GetOptionRequest) (*OptionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOption not implemented")
+}
+func (UnimplementedNeuralBlitzServer) ListOptions(context.Context, *ListOptionsRequest) (*OptionsListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListOptions not implemented")
+}
+func (UnimplementedNeuralBlitzServer) StreamMetrics(*StreamMetricsRequest, grpc.ServerStreamingServer[MetricsEvent]) error {
+	return status.Error(codes.Unimplemented, "method StreamMetrics not implemented")
+}
+func (UnimplementedNeuralBlitzServer) mustEmbedUnimplementedNeuralBlitzServer() {}
+func (UnimplementedNeuralBlitzServer) testEmbeddedByValue()                     {}
+
+// UnsafeNeuralBlitzServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NeuralBlitzServer will
+// result in compilation errors.
+type UnsafeNeuralBlitzServer interface {
+	mustEmbedUnimplementedNeuralBlitzServer()
+}
+
+func RegisterNeuralBlitzServer(s grpc.ServiceRegistrar, srv NeuralBlitzServer) {
+	// If the following call panics, it indicates UnimplementedNeuralBlitzServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NeuralBlitz_ServiceDesc, srv)
+}
+
+func _NeuralBlitz_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NeuralBlitzServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NeuralBlitz_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NeuralBlitzServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NeuralBlitz_ProcessIntent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NeuralBlitzServer).ProcessIntent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NeuralBlitz_ProcessIntent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NeuralBlitzServer).ProcessIntent(ctx, req.(*IntentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NeuralBlitz_Verify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NeuralBlitzServer).Verify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NeuralBlitz_Verify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NeuralBlitzServer).Verify(ctx, req.(*VerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NeuralBlitz_InterpretNBCL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NBCLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NeuralBlitzServer).InterpretNBCL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NeuralBlitz_InterpretNBCL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NeuralBlitzServer).InterpretNBCL(ctx, req.(*NBCLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NeuralBlitz_GetAttestation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttestationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NeuralBlitzServer).GetAttestation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NeuralBlitz_GetAttestation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NeuralBlitzServer).GetAttestation(ctx, req.(*GetAttestationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NeuralBlitz_GetOption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NeuralBlitzServer).GetOption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NeuralBlitz_GetOption_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NeuralBlitzServer).GetOption(ctx, req.(*GetOptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NeuralBlitz_ListOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NeuralBlitzServer).ListOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NeuralBlitz_ListOptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NeuralBlitzServer).ListOptions(ctx, req.(*ListOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NeuralBlitz_StreamMetrics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMetricsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NeuralBlitzServer).StreamMetrics(m, &grpc.GenericServerStream[StreamMetricsRequest, MetricsEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NeuralBlitz_StreamMetricsServer = grpc.ServerStreamingServer[MetricsEvent]
+
+// NeuralBlitz_ServiceDesc is the grpc.ServiceDesc for NeuralBlitz service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NeuralBlitz_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "neuralblitz.v1.NeuralBlitz",
+	HandlerType: (*NeuralBlitzServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _NeuralBlitz_GetStatus_Handler,
+		},
+		{
+			MethodName: "ProcessIntent",
+			Handler:    _NeuralBlitz_ProcessIntent_Handler,
+		},
+		{
+			MethodName: "Verify",
+			Handler:    _NeuralBlitz_Verify_Handler,
+		},
+		{
+			MethodName: "InterpretNBCL",
+			Handler:    _NeuralBlitz_InterpretNBCL_Handler,
+		},
+		{
+			MethodName: "GetAttestation",
+			Handler:    _NeuralBlitz_GetAttestation_Handler,
+		},
+		{
+			MethodName: "GetOption",
+			Handler:    _NeuralBlitz_GetOption_Handler,
+		},
+		{
+			MethodName: "ListOptions",
+			Handler:    _NeuralBlitz_ListOptions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMetrics",
+			Handler:       _NeuralBlitz_StreamMetrics_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "neuralblitz.proto",
+}
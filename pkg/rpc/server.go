@@ -0,0 +1,427 @@
+// Package rpc serves the NeuralBlitz API over gRPC next to the REST server.
+// Both transports run the service methods of one api.Server, so they share
+// its dyad, engine, GoldenDAG ledger, ID registry, authentication and rate
+// limits.
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"neuralblitz/pkg/api"
+	"neuralblitz/pkg/httpserver"
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/rpc/pb"
+	"neuralblitz/pkg/telemetry"
+	"neuralblitz/pkg/utils"
+)
+
+// Metadata keys of the attestation sent in every response header, matching
+// the REST headers. The header also carries the call's traceparent.
+const (
+	MetadataGoldenDAG = "x-goldendag"
+	MetadataTraceID   = "x-trace-id"
+	MetadataCodexID   = "x-codex-id"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail of rejected calls
+const ErrorDomain = "neuralblitz"
+
+// route is the scope and rate limit group of an RPC, matching its REST
+// route
+type route struct {
+	scope string
+	group string
+}
+
+var routes = map[string]route{
+	pb.NeuralBlitz_GetStatus_FullMethodName:      {api.ScopeStatusRead, options.RateGroupRead},
+	pb.NeuralBlitz_ProcessIntent_FullMethodName:  {api.ScopeIntentWrite, options.RateGroupIntent},
+	pb.NeuralBlitz_Verify_FullMethodName:         {api.ScopeVerify, options.RateGroupVerify},
+	pb.NeuralBlitz_InterpretNBCL_FullMethodName:  {api.ScopeNBCLExecute, options.RateGroupNBCL},
+	pb.NeuralBlitz_GetAttestation_FullMethodName: {api.ScopeAttestRead, options.RateGroupRead},
+	pb.NeuralBlitz_GetOption_FullMethodName:      {api.ScopeOptionsRead, options.RateGroupRead},
+	pb.NeuralBlitz_ListOptions_FullMethodName:    {api.ScopeOptionsRead, options.RateGroupRead},
+	pb.NeuralBlitz_StreamMetrics_FullMethodName:  {api.ScopeStatusRead, options.RateGroupRead},
+}
+
+// streamRequests create the request message of each server-streaming RPC,
+// which is received before the call is admitted so HMAC signatures cover
+// it
+var streamRequests = map[string]func() proto.Message{
+	pb.NeuralBlitz_StreamMetrics_FullMethodName: func() proto.Message { return &pb.StreamMetricsRequest{} },
+}
+
+// Server serves the NeuralBlitz gRPC service on the listener of a Config
+type Server struct {
+	pb.UnimplementedNeuralBlitzServer
+
+	api    *api.Server
+	config httpserver.Config
+	grpc   *grpc.Server
+	// ready is closed once the server is listening
+	ready chan struct{}
+	// stopping is closed when Shutdown starts, ending the metrics streams
+	stopping chan struct{}
+
+	mu       sync.Mutex
+	started  bool
+	listener net.Listener
+	stopOnce sync.Once
+}
+
+// New creates a gRPC server for s listening with config. Credentials are
+// read from the call metadata as from the REST headers: x-api-key,
+// authorization, or the x-nb-* HMAC headers signing "POST", the full
+// method name and the request message as SignMetadata does.
+func New(s *api.Server, config httpserver.Config) (*Server, error) {
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Server{
+		api:      s,
+		config:   config,
+		ready:    make(chan struct{}),
+		stopping: make(chan struct{}),
+	}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(r.unaryInterceptor),
+		grpc.ChainStreamInterceptor(r.streamInterceptor),
+		grpc.MaxRecvMsgSize(api.MaxRequestBytes),
+		// Zero timeouts keep the gRPC defaults
+		grpc.KeepaliveParams(keepalive.ServerParameters{MaxConnectionIdle: config.IdleTimeout}),
+	}
+	if config.ReadHeaderTimeout > 0 {
+		opts = append(opts, grpc.ConnectionTimeout(config.ReadHeaderTimeout))
+	}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	r.grpc = grpc.NewServer(opts...)
+	pb.RegisterNeuralBlitzServer(r.grpc, r)
+	return r, nil
+}
+
+// Config returns the server's config
+func (r *Server) Config() httpserver.Config {
+	return r.config
+}
+
+// Start listens and serves until ctx is cancelled or Shutdown is called.
+// A cancelled ctx drains in-flight calls for up to the config's
+// ShutdownTimeout. Start returns nil after a graceful shutdown.
+func (r *Server) Start(ctx context.Context) error {
+	r.mu.Lock()
+	if r.started {
+		r.mu.Unlock()
+		return httpserver.ErrStarted
+	}
+	r.started = true
+	r.mu.Unlock()
+
+	listener, err := r.config.Listen()
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.listener = listener
+	r.mu.Unlock()
+	close(r.ready)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- r.grpc.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		if errors.Is(err, grpc.ErrServerStopped) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx := context.Background()
+	if r.config.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, r.config.ShutdownTimeout)
+		defer cancel()
+	}
+	err = r.Shutdown(shutdownCtx)
+	<-served
+	return err
+}
+
+// Shutdown stops accepting calls, ends the metrics streams and waits for
+// in-flight calls to finish. If ctx ends first the remaining connections
+// are closed and its error returned.
+func (r *Server) Shutdown(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stopping) })
+
+	stopped := make(chan struct{})
+	go func() {
+		r.grpc.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		r.grpc.Stop()
+		return ctx.Err()
+	}
+}
+
+// Ready is closed once the server is listening
+func (r *Server) Ready() <-chan struct{} {
+	return r.ready
+}
+
+// Addr returns the listener's address, or nil before the server listens
+func (r *Server) Addr() net.Addr {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.listener == nil {
+		return nil
+	}
+	return r.listener.Addr()
+}
+
+// admit admits a call to method with request message req through
+// api.Server.Begin and sends its attestation and traceparent as header
+// metadata. The call must be ended with finish.
+func (r *Server) admit(ctx context.Context, method string, req proto.Message, setHeader func(metadata.MD) error) (context.Context, api.Call, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := http.Header{}
+	for key, values := range md {
+		if !strings.HasPrefix(key, ":") {
+			header[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+
+	rt := routes[method]
+	call := api.Call{
+		Method:    http.MethodPost,
+		Path:      method,
+		Header:    header,
+		Body:      marshalRequest(req),
+		ClientIP:  clientIP(ctx),
+		Scope:     rt.scope,
+		RateGroup: rt.group,
+		Origin:    utils.OriginGRPC,
+	}
+	ctx, admission, err := r.api.Begin(ctx, call)
+
+	carrier := propagation.MapCarrier{}
+	telemetry.Propagator.Inject(ctx, carrier)
+	out := metadata.MD{}
+	for key, value := range carrier {
+		out.Set(key, value)
+	}
+	if admission.TraceID != "" {
+		out.Set(MetadataGoldenDAG, admission.GoldenDAG)
+		out.Set(MetadataTraceID, admission.TraceID)
+		out.Set(MetadataCodexID, admission.CodexID)
+	}
+	setHeader(out)
+
+	if err != nil {
+		return ctx, call, toStatus(err)
+	}
+	return ctx, call, nil
+}
+
+// finish ends a call admitted by admit, returning err as a status
+func (r *Server) finish(ctx context.Context, call api.Call, err error) error {
+	err = toStatus(err)
+	code := status.Code(err)
+	var failure error
+	if serverFault(code) {
+		failure = err
+	}
+	r.api.Finish(ctx, call, code.String(), failure)
+	return err
+}
+
+func (r *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if _, ok := routes[info.FullMethod]; !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", info.FullMethod)
+	}
+	message, _ := req.(proto.Message)
+	ctx, call, err := r.admit(ctx, info.FullMethod, message, func(md metadata.MD) error {
+		return grpc.SetHeader(ctx, md)
+	})
+	if err != nil {
+		return nil, r.finish(ctx, call, err)
+	}
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, r.finish(ctx, call, err)
+	}
+	r.finish(ctx, call, nil)
+	return resp, nil
+}
+
+func (r *Server) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if _, ok := routes[info.FullMethod]; !ok {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", info.FullMethod)
+	}
+	req := streamRequests[info.FullMethod]()
+	if err := ss.RecvMsg(req); err != nil {
+		return err
+	}
+	ctx, call, err := r.admit(ss.Context(), info.FullMethod, req, ss.SetHeader)
+	if err == nil {
+		err = handler(srv, &admittedStream{ServerStream: ss, ctx: ctx, req: req})
+	}
+	return r.finish(ctx, call, err)
+}
+
+// admittedStream carries the context Begin returned to a stream handler,
+// and hands it the request message received before admission
+type admittedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req proto.Message
+}
+
+func (s *admittedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *admittedStream) RecvMsg(m interface{}) error {
+	if s.req == nil {
+		return s.ServerStream.RecvMsg(m)
+	}
+	proto.Merge(m.(proto.Message), s.req)
+	s.req = nil
+	return nil
+}
+
+// marshalRequest is the deterministic encoding of a request message, the
+// body HMAC signatures cover
+func marshalRequest(req proto.Message) []byte {
+	if req == nil {
+		return nil
+	}
+	body, _ := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	return body
+}
+
+// SignMetadata returns the HMAC metadata signing a call to method with
+// request message req as client id, for callers that authenticate with a
+// shared secret
+func SignMetadata(method string, req proto.Message, id string, secret []byte, now time.Time) (metadata.MD, error) {
+	r, err := http.NewRequest(http.MethodPost, method, bytes.NewReader(marshalRequest(req)))
+	if err != nil {
+		return nil, err
+	}
+	if err := api.SignRequest(r, id, secret, now); err != nil {
+		return nil, err
+	}
+	return metadata.Pairs(
+		api.HeaderHMACKeyID, r.Header.Get(api.HeaderHMACKeyID),
+		api.HeaderHMACTimestamp, r.Header.Get(api.HeaderHMACTimestamp),
+		api.HeaderHMACNonce, r.Header.Get(api.HeaderHMACNonce),
+		api.HeaderHMACSignature, r.Header.Get(api.HeaderHMACSignature),
+	), nil
+}
+
+// clientIP is the IP address of the caller, or its address when it has
+// none, e.g. on a Unix socket
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+// toStatus converts an api.RequestError into the status of its HTTP
+// status, with its fields in an ErrorInfo detail. Other errors become
+// Internal; statuses pass through.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var rejected *api.RequestError
+	if !errors.As(err, &rejected) {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	st := status.New(statusCode(rejected.Status), rejected.Error())
+	info := &errdetails.ErrorInfo{
+		Reason:   strings.ToUpper(strings.ReplaceAll(rejected.Message, " ", "_")),
+		Domain:   ErrorDomain,
+		Metadata: map[string]string{},
+	}
+	for key, value := range rejected.Fields {
+		if s, ok := value.(string); ok {
+			info.Metadata[key] = s
+		} else if data, err := json.Marshal(value); err == nil {
+			info.Metadata[key] = string(data)
+		}
+	}
+	if detailed, err := st.WithDetails(info); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// serverFault reports whether code reports a failure of the server rather
+// than of the call
+func serverFault(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal,
+		codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// statusCode maps an HTTP status to a gRPC code
+func statusCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
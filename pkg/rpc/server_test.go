@@ -0,0 +1,271 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"neuralblitz/pkg/api"
+	"neuralblitz/pkg/httpserver"
+	"neuralblitz/pkg/options"
+	"neuralblitz/pkg/rng"
+	"neuralblitz/pkg/rpc/pb"
+	"neuralblitz/pkg/utils"
+)
+
+// startServer serves s over gRPC on a Unix socket and returns a client
+func startServer(t *testing.T, s *api.Server) (*Server, pb.NeuralBlitzClient) {
+	t.Helper()
+	config := httpserver.DefaultConfig("")
+	config.UnixSocket = filepath.Join(t.TempDir(), "grpc.sock")
+	r, err := New(s, config)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.Start(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Expected graceful stop, got %v", err)
+		}
+	})
+	<-r.Ready()
+
+	conn, err := grpc.NewClient("unix://"+config.UnixSocket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return r, pb.NewNeuralBlitzClient(conn)
+}
+
+func TestRPCSharesServiceState(t *testing.T) {
+	s := api.NewServer("", rng.WithSeed(5))
+	_, client := startServer(t, s)
+	ctx := context.Background()
+
+	phi := 0.5
+	var header metadata.MD
+	intent, err := client.ProcessIntent(ctx, &pb.IntentRequest{Phi_1: &phi, Source: "grpc"}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("ProcessIntent failed: %v", err)
+	}
+	if intent.IntentVector[0] != 0.5 || intent.CoCreation.Fields["sequence"].GetNumberValue() != 1 {
+		t.Errorf("Expected intent 0.5 as co-creation 1, got %v as %v", intent.IntentVector, intent.CoCreation.Fields["sequence"])
+	}
+	if len(header.Get(MetadataTraceID)) != 1 || len(header.Get(MetadataGoldenDAG)) != 1 || len(header.Get("traceparent")) != 1 {
+		t.Errorf("Expected attestation and trace context metadata, got %v", header)
+	}
+
+	// REST sees the co-creation, and the IDs issued over gRPC
+	rest := httptest.NewServer(s.GetRouter())
+	defer rest.Close()
+	var restStatus api.StatusResponse
+	getJSON(t, rest.URL+"/status", &restStatus)
+	if restStatus.CoCreations != 1 {
+		t.Errorf("Expected 1 co-creation over REST, got %d", restStatus.CoCreations)
+	}
+	resp, err := http.Get(rest.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics failed: %v", err)
+	}
+	metrics, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(metrics), `route="/neuralblitz.v1.NeuralBlitz/ProcessIntent",status="OK",transport="grpc"`) {
+		t.Errorf("Expected the gRPC call's latency to be recorded")
+	}
+	var trace api.TraceResponse
+	getJSON(t, rest.URL+"/trace/"+intent.TraceId, &trace)
+	if trace.Record.Origin != utils.OriginGRPC || trace.Record.Parent != header.Get(MetadataTraceID)[0] {
+		t.Errorf("Expected a grpc ID issued under the call's trace, got %+v", trace.Record)
+	}
+
+	verify, err := client.Verify(ctx, &pb.VerifyRequest{Type: api.VerifyCoherence})
+	if err != nil || verify.Coherence == nil || verify.Target == nil {
+		t.Errorf("Expected coherence verification, got %v, %v", verify, err)
+	}
+	result, err := client.InterpretNBCL(ctx, &pb.NBCLRequest{Command: "/status"})
+	if err != nil || result.Result.Fields["irreducible"] == nil {
+		t.Errorf("Expected NBCL status section, got %v, %v", result, err)
+	}
+	if a, err := client.GetAttestation(ctx, &pb.GetAttestationRequest{}); err != nil || a.Statement == nil {
+		t.Errorf("Expected attestation statement, got %v, %v", a, err)
+	}
+	if opt, err := client.GetOption(ctx, &pb.GetOptionRequest{Id: "f"}); err != nil || opt.Option != "F" || opt.Config == nil {
+		t.Errorf("Expected option F, got %v, %v", opt, err)
+	}
+	if list, err := client.ListOptions(ctx, &pb.ListOptionsRequest{}); err != nil || list.Count != 6 {
+		t.Errorf("Expected 6 options, got %v, %v", list, err)
+	}
+	if s, err := client.GetStatus(ctx, &pb.GetStatusRequest{}); err != nil || s.CoCreations != 1 || s.Seed != 5 {
+		t.Errorf("Expected status after 1 co-creation with seed 5, got %v, %v", s, err)
+	}
+}
+
+func getJSON(t *testing.T, url string, out interface{}) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("Failed to decode %s: %v", url, err)
+	}
+}
+
+func TestRPCErrors(t *testing.T) {
+	s := api.NewServer("", rng.WithSeed(5))
+	keys := api.NewAPIKeyAuthenticator()
+	keys.AddKey("k-read", "reader", api.ScopeOptionsRead)
+	s.SetAuth(api.NewAuth(keys))
+	_, client := startServer(t, s)
+
+	_, err := client.ListOptions(context.Background(), &pb.ListOptionsRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without credentials, got %v", err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "k-read")
+	if _, err := client.ListOptions(ctx, &pb.ListOptionsRequest{}); err != nil {
+		t.Errorf("Expected API key to be accepted, got %v", err)
+	}
+	if _, err := client.Verify(ctx, &pb.VerifyRequest{Type: api.VerifyCoherence}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied without verify scope, got %v", err)
+	}
+
+	_, err = client.GetOption(ctx, &pb.GetOptionRequest{Id: "Z"})
+	st := status.Convert(err)
+	if st.Code() != codes.NotFound || len(st.Details()) != 1 {
+		t.Fatalf("Expected NotFound with details, got %v", err)
+	}
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	if !ok || info.Reason != "UNKNOWN_OPTION" || info.Metadata["valid_options"] != `["A","B","C","D","E","F"]` {
+		t.Errorf("Expected ErrorInfo listing valid options, got %v", st.Details()[0])
+	}
+}
+
+func TestRPCHMAC(t *testing.T) {
+	s := api.NewServer("", rng.WithSeed(5))
+	hmac := api.NewHMACAuthenticator()
+	hmac.AddClient("svc", []byte("s3cret"), "*")
+	s.SetAuth(api.NewAuth(hmac))
+	_, client := startServer(t, s)
+
+	req := &pb.NBCLRequest{Command: "/status"}
+	md, err := SignMetadata(pb.NeuralBlitz_InterpretNBCL_FullMethodName, req, "svc", []byte("s3cret"), time.Now())
+	if err != nil {
+		t.Fatalf("Failed to sign call: %v", err)
+	}
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	if _, err := client.InterpretNBCL(ctx, req); err != nil {
+		t.Errorf("Expected signed call to pass, got %v", err)
+	}
+
+	// The signature covers the message, so it cannot be replayed with
+	// another one
+	if _, err := client.InterpretNBCL(ctx, &pb.NBCLRequest{Command: "/attest"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for a replayed signature, got %v", err)
+	}
+
+	stream := &pb.StreamMetricsRequest{Subsystems: []string{"bogus"}}
+	md, err = SignMetadata(pb.NeuralBlitz_StreamMetrics_FullMethodName, stream, "svc", []byte("s3cret"), time.Now())
+	if err != nil {
+		t.Fatalf("Failed to sign call: %v", err)
+	}
+	metrics, err := client.StreamMetrics(metadata.NewOutgoingContext(context.Background(), md), stream)
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	// The handler still sees the message received before admission
+	if _, err := metrics.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for unknown subsystem, got %v", err)
+	}
+}
+
+func TestRPCReleasesInFlight(t *testing.T) {
+	s := api.NewServer("", rng.WithSeed(5))
+	if err := s.SetOption("C", options.OptionC(), nil); err != nil {
+		t.Fatal(err)
+	}
+	_, client := startServer(t, s)
+
+	if _, err := client.ListOptions(context.Background(), &pb.ListOptionsRequest{}); err != nil {
+		t.Fatalf("Failed to list options: %v", err)
+	}
+	if _, err := client.GetOption(context.Background(), &pb.GetOptionRequest{Id: "Z"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound, got %v", err)
+	}
+	if inFlight := s.Status(context.Background()).Profile.InFlight; inFlight != 0 {
+		t.Errorf("Expected finished calls to free their slots, got %d in flight", inFlight)
+	}
+}
+
+func TestRPCStreamMetrics(t *testing.T) {
+	s := api.NewServer("", rng.WithSeed(5))
+	sim, err := api.NewMetricsSimulation(nil, rng.WithSeed(5))
+	if err != nil {
+		t.Fatalf("Failed to create simulation: %v", err)
+	}
+	s.StreamSimulation(sim)
+	r, client := startServer(t, s)
+
+	if err := sim.Step(); err != nil {
+		t.Fatalf("Failed to step simulation: %v", err)
+	}
+
+	bogus, err := client.StreamMetrics(context.Background(), &pb.StreamMetricsRequest{Subsystems: []string{"bogus"}})
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	if _, err := bogus.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for unknown subsystem, got %v", err)
+	}
+
+	stream, err := client.StreamMetrics(context.Background(), &pb.StreamMetricsRequest{Subsystems: []string{api.SubsystemEntanglement}})
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	first, err := stream.Recv()
+	if err != nil || first.Subsystem != api.SubsystemEntanglement || first.Data == nil {
+		t.Fatalf("Expected replayed entanglement event, got %v, %v", first, err)
+	}
+
+	if err := sim.Step(); err != nil {
+		t.Fatalf("Failed to step simulation: %v", err)
+	}
+	next, err := stream.Recv()
+	if err != nil || next.Id <= first.Id {
+		t.Errorf("Expected a later event, got %v, %v", next, err)
+	}
+
+	// Shutdown ends the stream instead of waiting for it
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.Shutdown(ctx); err != nil {
+		t.Errorf("Expected graceful shutdown, got %v", err)
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if err != io.EOF {
+				t.Errorf("Expected stream to end, got %v", err)
+			}
+			break
+		}
+	}
+}
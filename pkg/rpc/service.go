@@ -0,0 +1,235 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"neuralblitz/pkg/api"
+	"neuralblitz/pkg/rpc/pb"
+)
+
+// GetStatus reports the system status
+func (r *Server) GetStatus(ctx context.Context, _ *pb.GetStatusRequest) (*pb.StatusResponse, error) {
+	s := r.api.Status(ctx)
+	return &pb.StatusResponse{
+		Status:            s.Status,
+		RealityState:      s.RealityState,
+		Coherence:         s.Coherence,
+		Irreducibility:    s.Irreducibility,
+		UnityVector:       s.UnityVector,
+		DyadCoherence:     s.DyadCoherence,
+		CoCreations:       int64(s.CoCreations),
+		Actualizations:    int64(s.Actualizations),
+		SingularityStatus: s.SingularityStatus,
+		UptimeSeconds:     s.UptimeSeconds,
+		UptimeFormatted:   s.UptimeFormatted,
+		GoVersion:         s.GoVersion,
+		Os:                s.OS,
+		Arch:              s.Arch,
+		Goroutines:        int64(s.Goroutines),
+		GcCycles:          s.GCCycles,
+		Seed:              s.Seed,
+		Deterministic:     s.Deterministic,
+		GoldenDag:         s.GoldenDAG,
+		TraceId:           s.TraceID,
+		CodexId:           s.CodexID,
+	}, nil
+}
+
+// ProcessIntent processes, co-creates and actualizes an intent
+func (r *Server) ProcessIntent(ctx context.Context, req *pb.IntentRequest) (*pb.IntentResponse, error) {
+	resp, err := r.api.ProcessIntent(ctx, api.IntentRequest{
+		Intent: &api.IntentVector{Phi1: req.Phi_1, Phi22: req.Phi_22, OmegaGenesis: req.OmegaGenesis},
+		Source: req.Source,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.IntentResponse{
+		Status:       resp.Status,
+		IntentVector: resp.IntentVector,
+		Coherence:    resp.Coherence,
+		Unity:        resp.Unity,
+		GoldenDag:    resp.GoldenDAG,
+		TraceId:      resp.TraceID,
+		CodexId:      resp.CodexID,
+	}
+	if out.Processing, err = toStruct(resp.Processing); err != nil {
+		return nil, err
+	}
+	if out.CoCreation, err = toStruct(resp.CoCreation); err != nil {
+		return nil, err
+	}
+	if out.Actualization, err = toStruct(resp.Actualization); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Verify runs a verification
+func (r *Server) Verify(ctx context.Context, req *pb.VerifyRequest) (*pb.VerifyResponse, error) {
+	resp, err := r.api.Verify(ctx, api.VerifyRequest{Type: req.Type, Payload: req.Payload})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.VerifyResponse{
+		Type:                    resp.Type,
+		Verified:                resp.Verified,
+		GoldenDag:               resp.GoldenDAG,
+		TraceId:                 resp.TraceID,
+		CodexId:                 resp.CodexID,
+		Reason:                  resp.Reason,
+		SeparationImpossibility: resp.SeparationImpossibility,
+		UnityCoherence:          resp.UnityCoherence,
+		MathematicalProof:       resp.MathematicalProof,
+		Coherence:               resp.Coherence,
+		Target:                  resp.Target,
+		AttestationHash:         resp.AttestationHash,
+		GoldenDagSeed:           resp.GoldenDAGSeed,
+	}, nil
+}
+
+// InterpretNBCL interprets an NBCL command
+func (r *Server) InterpretNBCL(ctx context.Context, req *pb.NBCLRequest) (*pb.NBCLResponse, error) {
+	result, err := r.api.InterpretNBCL(ctx, api.NBCLRequest{Command: req.Command})
+	if err != nil {
+		return nil, err
+	}
+	full, err := toStruct(result)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.NBCLResponse{
+		Command:   result.Command,
+		Status:    result.Status,
+		TraceId:   result.TraceID,
+		CodexId:   result.CodexID,
+		GoldenDag: result.GoldenDAG,
+		Coherence: result.Coherence,
+		Timestamp: timestamppb.New(result.Timestamp),
+		Result:    full,
+	}, nil
+}
+
+// GetAttestation returns the Omega attestation
+func (r *Server) GetAttestation(ctx context.Context, _ *pb.GetAttestationRequest) (*pb.AttestationResponse, error) {
+	a := r.api.Attestation(ctx)
+	return &pb.AttestationResponse{
+		Attestation:       a.Attestation,
+		Version:           a.Version,
+		GoldenDag:         a.GoldenDAG,
+		TraceId:           a.TraceID,
+		CodexId:           a.CodexID,
+		RealityState:      a.RealityState,
+		Coherence:         a.Coherence,
+		SingularityStatus: a.SingularityStatus,
+		AttestationHash:   a.AttestationHash,
+		Statement: &pb.AttestationStatement{
+			Structural: a.Statement.Structural,
+			Ethical:    a.Statement.Ethical,
+			Governance: a.Statement.Governance,
+			Genesis:    a.Statement.Genesis,
+			Reality:    a.Statement.Reality,
+		},
+	}, nil
+}
+
+// GetOption returns deployment option A to F
+func (r *Server) GetOption(ctx context.Context, req *pb.GetOptionRequest) (*pb.OptionResponse, error) {
+	resp, err := r.api.Option(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	config, err := toStruct(resp.Config)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.OptionResponse{
+		Option:    resp.Option,
+		Name:      resp.Name,
+		Config:    config,
+		GoldenDag: resp.GoldenDAG,
+		TraceId:   resp.TraceID,
+	}, nil
+}
+
+// ListOptions lists the deployment options
+func (r *Server) ListOptions(ctx context.Context, _ *pb.ListOptionsRequest) (*pb.OptionsListResponse, error) {
+	resp := r.api.Options(ctx)
+	out := &pb.OptionsListResponse{
+		Count:     int32(resp.Count),
+		GoldenDag: resp.GoldenDAG,
+		TraceId:   resp.TraceID,
+		CodexId:   resp.CodexID,
+	}
+	for _, opt := range resp.Options {
+		out.Options = append(out.Options, &pb.OptionSummary{
+			Id:          opt.ID,
+			Name:        opt.Name,
+			MemoryMb:    int32(opt.MemoryMB),
+			Description: opt.Description,
+		})
+	}
+	return out, nil
+}
+
+// StreamMetrics streams metrics events, first the retained ones after
+// last_event_id, until the client cancels or the server shuts down
+func (r *Server) StreamMetrics(req *pb.StreamMetricsRequest, stream pb.NeuralBlitz_StreamMetricsServer) error {
+	sub, err := r.api.SubscribeMetrics(req.Subsystems, req.LastEventId)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	send := func(event api.MetricsEvent) error {
+		data, err := toStruct(event.Data)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&pb.MetricsEvent{
+			Id:        event.ID,
+			Subsystem: event.Subsystem,
+			Data:      data,
+			Timestamp: timestamppb.New(event.Timestamp),
+		})
+	}
+	for _, event := range sub.Replay {
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-r.stopping:
+			return nil
+		case event, ok := <-sub.Events:
+			if !ok {
+				return nil
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toStruct converts v to a Struct through its JSON encoding, so it has the
+// fields the REST API returns
+func toStruct(v interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
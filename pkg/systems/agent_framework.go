@@ -4,9 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"math"
-	"math/rand"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // Agent Framework Constants
@@ -346,6 +347,9 @@ type AdvancedAutonomousAgent struct {
 
 	// Configuration
 	Config *AgentConfig
+
+	// Random source and clock
+	rand *rng.Source
 }
 
 // AgentConfig represents agent configuration
@@ -360,7 +364,10 @@ type AgentConfig struct {
 }
 
 // NewAdvancedAutonomousAgent creates a new advanced autonomous agent
-func NewAdvancedAutonomousAgent(agentID string) *AdvancedAutonomousAgent {
+func NewAdvancedAutonomousAgent(agentID string, opts ...rng.Option) *AdvancedAutonomousAgent {
+	src := rng.Resolve(opts...)
+	now := src.Now()
+
 	return &AdvancedAutonomousAgent{
 		AgentID:      agentID,
 		Capabilities: NewAgentCapabilities(),
@@ -375,7 +382,7 @@ func NewAdvancedAutonomousAgent(agentID string) *AdvancedAutonomousAgent {
 			Level:            1,
 			HealthStatus:     1.0,
 			EnergyLevel:     1.0,
-			LastActive:      time.Now(),
+			LastActive:      now,
 		},
 		Memory:        make(map[string]*Experience),
 		ActionHistory: make([]ActionResult, 0, MaxActionHistory),
@@ -384,7 +391,7 @@ func NewAdvancedAutonomousAgent(agentID string) *AdvancedAutonomousAgent {
 		TotalActions:  0,
 		SuccessfulActions: 0,
 		TotalReward:  0.0,
-		CreatedAt:    time.Now(),
+		CreatedAt:    now,
 		Config: &AgentConfig{
 			AgentID:             agentID,
 			InitialConsciousness: 0.5,
@@ -394,6 +401,7 @@ func NewAdvancedAutonomousAgent(agentID string) *AdvancedAutonomousAgent {
 			MaxMemorySize:      MaxMemorySize,
 			ActionTimeout:       ActionTimeout,
 		},
+		rand: src,
 	}
 }
 
@@ -416,7 +424,7 @@ func (a *AdvancedAutonomousAgent) Initialize() {
 		a.State.ActiveCapabilities = append(a.State.ActiveCapabilities, cap)
 	}
 
-	a.LastActionAt = time.Now()
+	a.LastActionAt = a.rand.Now()
 }
 
 // Perceive processes environmental data
@@ -425,7 +433,7 @@ func (a *AdvancedAutonomousAgent) Perceive(env *Environment) *PerceptionResult {
 	defer a.mu.Unlock()
 
 	a.State.Lifecycle = LifecyclePerception
-	a.State.LastActive = time.Now()
+	a.State.LastActive = a.rand.Now()
 
 	// Process environmental data
 	objectsDetected := len(env.Objects)
@@ -448,7 +456,7 @@ func (a *AdvancedAutonomousAgent) Perceive(env *Environment) *PerceptionResult {
 	}
 
 	result := &PerceptionResult{
-		Timestamp:      time.Now(),
+		Timestamp:      a.rand.Now(),
 		ObjectsDetected: objectsDetected,
 		Threats:       threats,
 		Opportunities: opportunities,
@@ -519,11 +527,11 @@ func (a *AdvancedAutonomousAgent) Act(decisions *ReasoningResult) *ActionResult
 
 	a.State.Lifecycle = LifecycleAction
 
-	startTime := time.Now()
+	startTime := a.rand.Now()
 	actions := make([]ActionDetail, 0)
 
 	for _, action := range decisions.PlannedActions {
-		actionStart := time.Now()
+		actionStart := a.rand.Now()
 
 		// Simulate action execution
 		status := "completed"
@@ -531,12 +539,12 @@ func (a *AdvancedAutonomousAgent) Act(decisions *ReasoningResult) *ActionResult
 
 		// Random success factor based on autonomy
 		successChance := a.State.AutonomyScore
-		if rand.Float64() > successChance {
+		if a.rand.Float64() > successChance {
 			status = "partial"
 			result = "degraded"
 		}
 
-		duration := time.Since(actionStart).Milliseconds()
+		duration := a.rand.Now().Sub(actionStart).Milliseconds()
 
 		actions = append(actions, ActionDetail{
 			Action:   action,
@@ -557,15 +565,15 @@ func (a *AdvancedAutonomousAgent) Act(decisions *ReasoningResult) *ActionResult
 
 	result := &ActionResult{
 		Actions:       actions,
-		Timestamp:     time.Now(),
-		TotalDuration: float64(time.Since(startTime).Milliseconds()),
+		Timestamp:     a.rand.Now(),
+		TotalDuration: float64(a.rand.Now().Sub(startTime).Milliseconds()),
 		SuccessRate:   successRate,
 	}
 
 	// Update tracking
 	a.TotalActions += len(actions)
 	a.State.EnergyLevel = math.Max(0.0, a.State.EnergyLevel-0.01*float64(len(actions)))
-	a.LastActionAt = time.Now()
+	a.LastActionAt = a.rand.Now()
 	a.ActionHistory = append(a.ActionHistory, *result)
 	if len(a.ActionHistory) > MaxActionHistory {
 		a.ActionHistory = a.ActionHistory[len(a.ActionHistory)-MaxActionHistory:]
@@ -584,7 +592,7 @@ func (a *AdvancedAutonomousAgent) Learn(experience *Experience) *LearningResult
 	// Store experience
 	experienceID := experience.ExperienceID
 	if experienceID == "" {
-		experienceID = generateExperienceID()
+		experienceID = generateExperienceID(a.rand)
 	}
 	a.Memory[experienceID] = experience
 
@@ -632,7 +640,7 @@ func (a *AdvancedAutonomousAgent) Learn(experience *Experience) *LearningResult
 		Insights:       insights,
 	}
 
-	a.LastLearnedAt = time.Now()
+	a.LastLearnedAt = a.rand.Now()
 
 	return result
 }
@@ -750,11 +758,11 @@ func (a *AdvancedAutonomousAgent) RunCycle(env *Environment) (*CycleResult, erro
 
 	// Learning
 	experience := &Experience{
-		ExperienceID:   generateExperienceID(),
+		ExperienceID:   generateExperienceID(a.rand),
 		Cycle:         a.TotalCycles,
 		Outcome:       "success",
 		Efficiency:    results.SuccessRate,
-		Timestamp:     time.Now(),
+		Timestamp:     a.rand.Now(),
 		ActionsTaken:  decisions.PlannedActions,
 		Environment:   map[string]interface{}{
 			"objects":      len(env.Objects),
@@ -775,7 +783,7 @@ func (a *AdvancedAutonomousAgent) RunCycle(env *Environment) (*CycleResult, erro
 		Action:     results,
 		Learning:   learning,
 		Adaptation: adaptation,
-		Timestamp:  time.Now(),
+		Timestamp:  a.rand.Now(),
 	}, nil
 }
 
@@ -872,9 +880,9 @@ func (r *AgentRegistry) Count() int {
 }
 
 // generateExperienceID generates a unique experience ID
-func generateExperienceID() string {
-	timestamp := time.Now().UnixNano() / 1e6
-	randomPart := rand.Intn(10000)
+func generateExperienceID(src *rng.Source) string {
+	timestamp := src.Now().UnixNano() / 1e6
+	randomPart := src.Intn(10000)
 	return "exp_" + string(rune('a'+timestamp%26)) +
 		string(rune('0'+randomPart/1000)) +
 		string(rune('0'+randomPart%1000/100)) +
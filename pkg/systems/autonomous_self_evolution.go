@@ -22,9 +22,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
-	"math/rand"
+	"sort"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // EvolutionState represents the state of self-evolution
@@ -145,12 +147,14 @@ type AutonomousSelfEvolution struct {
 	EvolutionConfig map[string]float64 `json:"evolution_config"`
 	
 	// Synchronization
-	mu sync.Mutex
+	mu   sync.Mutex
+	rand *rng.Source
 }
 
 // NewAutonomousSelfEvolution creates a new self-evolution system
-func NewAutonomousSelfEvolution() *AutonomousSelfEvolution {
+func NewAutonomousSelfEvolution(opts ...rng.Option) *AutonomousSelfEvolution {
 	ase := &AutonomousSelfEvolution{
+		rand: rng.Resolve(opts...),
 		CurrentState: Stable,
 		EvolutionCycle: 0,
 		AnalysisResults: make(map[string]*AnalysisResult),
@@ -214,8 +218,8 @@ func (ase *AutonomousSelfEvolution) initializeDefaultStrategies() {
 		SuccessRate: 0.9,
 		EvolutionHistory: make([]string, 0),
 		Status: "active",
-		CreatedAt: time.Now(),
-		LastUsed: time.Now(),
+		CreatedAt: ase.rand.Now(),
+		LastUsed: ase.rand.Now(),
 	}
 	ase.Strategies[gradDesc.StrategyID] = gradDesc
 	ase.ActiveStrategy = gradDesc.StrategyID
@@ -239,8 +243,8 @@ func (ase *AutonomousSelfEvolution) initializeDefaultStrategies() {
 		SuccessRate: 0.85,
 		EvolutionHistory: make([]string, 0),
 		Status: "active",
-		CreatedAt: time.Now(),
-		LastUsed: time.Now(),
+		CreatedAt: ase.rand.Now(),
+		LastUsed: ase.rand.Now(),
 	}
 	ase.Strategies[genAlgo.StrategyID] = genAlgo
 	
@@ -262,8 +266,8 @@ func (ase *AutonomousSelfEvolution) initializeDefaultStrategies() {
 		SuccessRate: 0.88,
 		EvolutionHistory: make([]string, 0),
 		Status: "active",
-		CreatedAt: time.Now(),
-		LastUsed: time.Now(),
+		CreatedAt: ase.rand.Now(),
+		LastUsed: ase.rand.Now(),
 	}
 	ase.Strategies[simAnneal.StrategyID] = simAnneal
 }
@@ -276,13 +280,13 @@ func (ase *AutonomousSelfEvolution) Analyze(analysisType string) *AnalysisResult
 	ase.CurrentState = Analyzing
 	
 	result := &AnalysisResult{
-		ResultID: fmt.Sprintf("analysis_%d_%d", time.Now().UnixNano(), len(ase.AnalysisResults)),
+		ResultID: fmt.Sprintf("analysis_%d_%d", ase.rand.Now().UnixNano(), len(ase.AnalysisResults)),
 		AnalysisType: analysisType,
 		Findings: make([]string, 0),
 		Recommendations: make([]string, 0),
 		Score: 0.0,
 		Confidence: 0.0,
-		Timestamp: time.Now(),
+		Timestamp: ase.rand.Now(),
 	}
 	
 	// Perform analysis based on type
@@ -355,17 +359,17 @@ func (ase *AutonomousSelfEvolution) GenerateImprovements(analysisResultID string
 	// Generate improvements based on analysis
 	for _, recommendation := range analysisResult.Recommendations {
 		improvement := &Improvement{
-			ImprovementID: fmt.Sprintf("improvement_%d_%d", time.Now().UnixNano(), len(ase.Improvements)),
+			ImprovementID: fmt.Sprintf("improvement_%d_%d", ase.rand.Now().UnixNano(), len(ase.Improvements)),
 			TargetComponent: analysisResult.AnalysisType,
 			Description: recommendation,
 			ImprovementType: ase.categorizeImprovement(analysisResult.AnalysisType),
-			ExpectedImpact: analysisResult.Score * rand.Float64() * 0.5,
-			RiskAssessment: rand.Float64() * 0.3,
+			ExpectedImpact: analysisResult.Score * ase.rand.Float64() * 0.5,
+			RiskAssessment: ase.rand.Float64() * 0.3,
 			Dependencies: make([]string, 0),
 			ProposedChanges: []string{fmt.Sprintf("Implement %s", recommendation)},
 			ValidationCriteria: []string{"Passes all tests", "Maintains performance", "Passes safety checks"},
 			Status: "pending",
-			Timestamp: time.Now(),
+			Timestamp: ase.rand.Now(),
 		}
 		
 		// Estimate impact based on type
@@ -440,26 +444,26 @@ func (ase *AutonomousSelfEvolution) ValidateImprovement(improvementID string) *V
 	improvement, ok := ase.Improvements[improvementID]
 	if !ok {
 		return &ValidationResult{
-			ValidationID: fmt.Sprintf("validation_%d", time.Now().UnixNano()),
+			ValidationID: fmt.Sprintf("validation_%d", ase.rand.Now().UnixNano()),
 			ValidationType: "improvement",
 			Passed: false,
 			Checks: []ValidationCheck{},
 			OverallScore: 0.0,
 			RiskLevel: "critical",
 			Recommendations: []string{"Improvement not found"},
-			Timestamp: time.Now(),
+			Timestamp: ase.rand.Now(),
 		}
 	}
 	
 	validation := &ValidationResult{
-		ValidationID: fmt.Sprintf("validation_%d", time.Now().UnixNano()),
+		ValidationID: fmt.Sprintf("validation_%d", ase.rand.Now().UnixNano()),
 		ValidationType: "improvement",
 		Passed: true,
 		Checks: make([]ValidationCheck, 0),
 		OverallScore: 1.0,
 		RiskLevel: "low",
 		Recommendations: make([]string, 0),
-		Timestamp: time.Now(),
+		Timestamp: ase.rand.Now(),
 	}
 	
 	// Run safety constraint checks
@@ -589,7 +593,7 @@ func (ase *AutonomousSelfEvolution) IntegrateImprovement(improvementID string) b
 	}
 	
 	// Simulate integration
-	integrationSuccess := rand.Float64() < improvement.ExpectedImpact
+	integrationSuccess := ase.rand.Float64() < improvement.ExpectedImpact
 	
 	if integrationSuccess {
 		improvement.Status = "integrated"
@@ -622,7 +626,7 @@ func (ase *AutonomousSelfEvolution) updateStrategyPerformance(improvement *Impro
 	
 	if success {
 		strategy.SuccessRate = strategy.SuccessRate*0.95 + 0.05
-		strategy.LastUsed = time.Now()
+		strategy.LastUsed = ase.rand.Now()
 		strategy.EvolutionHistory = append(strategy.EvolutionHistory, fmt.Sprintf("Success at %d", ase.EvolutionCycle))
 	} else {
 		strategy.SuccessRate = strategy.SuccessRate*0.95 - 0.02
@@ -640,7 +644,8 @@ func (ase *AutonomousSelfEvolution) selectBestStrategy() {
 	var bestStrategy *Strategy
 	bestScore := 0.0
 	
-	for _, strategy := range ase.Strategies {
+	for _, id := range ase.sortedStrategyIDs() {
+		strategy := ase.Strategies[id]
 		score := strategy.SuccessRate * strategy.ExpectedOutcome * (1.0 - strategy.RiskProfile)
 		if score > bestScore {
 			bestScore = score
@@ -690,7 +695,8 @@ func (ase *AutonomousSelfEvolution) Evolve() {
 // evolveStrategies evolves the strategies themselves
 func (ase *AutonomousSelfEvolution) evolveStrategies() {
 	// Create new strategies through mutation
-	for _, strategy := range ase.Strategies {
+	for _, id := range ase.sortedStrategyIDs() {
+		strategy := ase.Strategies[id]
 		if strategy.Status == "active" {
 			// Mutate strategy parameters
 			mutated := ase.mutateStrategy(strategy)
@@ -701,32 +707,48 @@ func (ase *AutonomousSelfEvolution) evolveStrategies() {
 	}
 }
 
+// sortedStrategyIDs returns strategy IDs in a stable order
+func (ase *AutonomousSelfEvolution) sortedStrategyIDs() []string {
+	ids := make([]string, 0, len(ase.Strategies))
+	for id := range ase.Strategies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // mutateStrategy creates a mutated version of a strategy
 func (ase *AutonomousSelfEvolution) mutateStrategy(strategy *Strategy) *Strategy {
 	// Only mutate with certain probability
-	if rand.Float64() > ase.EvolutionConfig["mutation_rate"] {
+	if ase.rand.Float64() > ase.EvolutionConfig["mutation_rate"] {
 		return nil
 	}
 	
 	mutated := &Strategy{
-		StrategyID: fmt.Sprintf("strategy_mutated_%d", time.Now().UnixNano()),
+		StrategyID: fmt.Sprintf("strategy_mutated_%d", ase.rand.Now().UnixNano()),
 		Name: fmt.Sprintf("Mutated %s", strategy.Name),
 		Description: fmt.Sprintf("Mutated version of %s", strategy.Name),
 		StrategyType: strategy.StrategyType,
 		Components: append([]string{}, strategy.Components...),
 		Parameters: make(map[string]float64),
-		ExpectedOutcome: strategy.ExpectedOutcome * (0.9 + rand.Float64()*0.2),
-		RiskProfile: strategy.RiskProfile * (0.9 + rand.Float64()*0.2),
+		ExpectedOutcome: strategy.ExpectedOutcome * (0.9 + ase.rand.Float64()*0.2),
+		RiskProfile: strategy.RiskProfile * (0.9 + ase.rand.Float64()*0.2),
 		SuccessRate: strategy.SuccessRate,
 		EvolutionHistory: append([]string{}, strategy.EvolutionHistory...),
 		Status: "testing",
-		CreatedAt: time.Now(),
-		LastUsed: time.Now(),
+		CreatedAt: ase.rand.Now(),
+		LastUsed: ase.rand.Now(),
 	}
 	
 	// Mutate parameters
-	for key, value := range strategy.Parameters {
-		mutation := (rand.Float64() - 0.5) * 0.1 * value
+	keys := make([]string, 0, len(strategy.Parameters))
+	for key := range strategy.Parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := strategy.Parameters[key]
+		mutation := (ase.rand.Float64() - 0.5) * 0.1 * value
 		mutated.Parameters[key] = math.Max(0.0, value+mutation)
 	}
 	
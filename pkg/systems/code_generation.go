@@ -4,9 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"math"
-	"math/rand"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // CodeGenType represents types of code generation approaches
@@ -129,6 +130,9 @@ type SelfImprovingCodeGenerator struct {
 
 	// Configuration
 	Config CodeGenConfig `json:"config"`
+
+	// Random source and clock
+	rand *rng.Source
 }
 
 // CodeAnalyzer analyzes code for optimization opportunities
@@ -136,6 +140,7 @@ type CodeAnalyzer struct {
 	AnalysisDepth     float64 `json:"analysis_depth"`
 	AnalysisWidth    float64 `json:"analysis_width"`
 	PatternDetector  *CodePatternDetector
+	rand             *rng.Source
 }
 
 // CodePatternDetector detects patterns in code
@@ -162,7 +167,8 @@ type SecurityChecker struct {
 }
 
 // NewSelfImprovingCodeGenerator creates a new self-improving code generator
-func NewSelfImprovingCodeGenerator(config CodeGenConfig) *SelfImprovingCodeGenerator {
+func NewSelfImprovingCodeGenerator(config CodeGenConfig, opts ...rng.Option) *SelfImprovingCodeGenerator {
+	src := rng.Resolve(opts...)
 	generator := &SelfImprovingCodeGenerator{
 		GenerationActive:      config.Enabled,
 		CurrentGenerationPhase: 0,
@@ -186,6 +192,7 @@ func NewSelfImprovingCodeGenerator(config CodeGenConfig) *SelfImprovingCodeGener
 				PatternSensitivity: 0.6,
 				MinPatternStrength:  0.5,
 			},
+			rand: src,
 		},
 		CodeValidator: &CodeValidator{
 			ValidationStrictness: 0.8,
@@ -197,6 +204,7 @@ func NewSelfImprovingCodeGenerator(config CodeGenConfig) *SelfImprovingCodeGener
 			},
 		},
 		Config: config,
+		rand:   src,
 	}
 
 	generator.initializeGenerator()
@@ -260,7 +268,7 @@ func (g *SelfImprovingCodeGenerator) GenerateCode(request CodeGenerationRequest)
 	defer g.mu.Unlock()
 
 	// Generate generation ID
-	generationID := generateGenerationID()
+	generationID := generateGenerationID(g.rand)
 
 	// Analyze current code
 	analysis := g.CodeAnalyzer.Analyze(request.CurrentCode)
@@ -279,7 +287,7 @@ func (g *SelfImprovingCodeGenerator) GenerateCode(request CodeGenerationRequest)
 
 	generation := &GeneratedCode{
 		GenerationID:      generationID,
-		Timestamp:       float64(time.Now().Unix()),
+		Timestamp:       float64(g.rand.Now().Unix()),
 		RequestID:       request.RequestID,
 		GenerationType:  request.GenerationType,
 		GeneratedCode:   generatedCode,
@@ -441,9 +449,9 @@ type CodeAnalysis struct {
 // Analyze performs code analysis
 func (a *CodeAnalyzer) Analyze(code string) *CodeAnalysis {
 	return &CodeAnalysis{
-		ComplexityScore:    0.5 + rand.Float64()*0.3,
-		PerformanceScore:  0.6 + rand.Float64()*0.3,
-		PatternStrength:   0.4 + rand.Float64()*0.4,
+		ComplexityScore:    0.5 + a.rand.Float64()*0.3,
+		PerformanceScore:  0.6 + a.rand.Float64()*0.3,
+		PatternStrength:   0.4 + a.rand.Float64()*0.4,
 		OptimizationOpportunities: []string{
 			"performance_optimization",
 			"memory_efficiency",
@@ -465,12 +473,12 @@ type QualityMetrics struct {
 // calculateQualityMetrics calculates quality metrics
 func (g *SelfImprovingCodeGenerator) calculateQualityMetrics(code string, target OptimizationTarget) *QualityMetrics {
 	return &QualityMetrics{
-		PerformanceScore:      0.7 + rand.Float64()*0.3,
-		IntelligenceScore:     0.6 + rand.Float64()*0.4,
-		ConsciousnessScore:    0.5 + rand.Float64()*0.5,
-		FunctionalCorrectness: 0.8 + rand.Float64()*0.2,
-		NoveltyScore:          0.4 + rand.Float64()*0.6,
-		TranscendencePotential: 0.3 + rand.Float64()*0.7,
+		PerformanceScore:      0.7 + g.rand.Float64()*0.3,
+		IntelligenceScore:     0.6 + g.rand.Float64()*0.4,
+		ConsciousnessScore:    0.5 + g.rand.Float64()*0.5,
+		FunctionalCorrectness: 0.8 + g.rand.Float64()*0.2,
+		NoveltyScore:          0.4 + g.rand.Float64()*0.6,
+		TranscendencePotential: 0.3 + g.rand.Float64()*0.7,
 	}
 }
 
@@ -519,7 +527,7 @@ func (s *SecurityChecker) checkSecurity(code string) bool {
 // calculateImprovementDelta calculates the improvement delta
 func (g *SelfImprovingCodeGenerator) calculateImprovementDelta(original, optimized string) float64 {
 	// Simplified improvement calculation
-	return rand.Float64() * 0.3
+	return g.rand.Float64() * 0.3
 }
 
 // updateAverages updates average quality scores
@@ -536,12 +544,12 @@ func (g *SelfImprovingCodeGenerator) OptimizeCode(code string, target Optimizati
 	defer g.mu.Unlock()
 
 	// Generate result ID
-	resultID := generateResultID()
+	resultID := generateResultID(g.rand)
 
 	// Create optimization request
 	request := CodeGenerationRequest{
 		RequestID:          resultID,
-		Timestamp:        float64(time.Now().Unix()),
+		Timestamp:        float64(g.rand.Now().Unix()),
 		GenerationType:    IncrementalImprovement,
 		OptimizationTarget: target,
 		TargetModule:     "optimization_target",
@@ -568,7 +576,7 @@ func (g *SelfImprovingCodeGenerator) OptimizeCode(code string, target Optimizati
 
 	result := &OptimizationResult{
 		ResultID:         resultID,
-		Timestamp:      float64(time.Now().Unix()),
+		Timestamp:      float64(g.rand.Now().Unix()),
 		OriginalCode:   code,
 		OptimizedCode:  generated.GeneratedCode,
 		OptimizationType: string(target),
@@ -644,9 +652,9 @@ func (g *SelfImprovingCodeGenerator) ToJSON() ([]byte, error) {
 }
 
 // generateGenerationID generates a unique generation ID
-func generateGenerationID() string {
-	timestamp := time.Now().UnixNano() / 1e6
-	randomPart := rand.Intn(10000)
+func generateGenerationID(src *rng.Source) string {
+	timestamp := src.Now().UnixNano() / 1e6
+	randomPart := src.Intn(10000)
 	return "gen_" + string(rune('a'+timestamp%26)) +
 		string(rune('0'+randomPart/1000)) +
 		string(rune('0'+randomPart%1000/100)) +
@@ -655,9 +663,9 @@ func generateGenerationID() string {
 }
 
 // generateResultID generates a unique result ID
-func generateResultID() string {
-	timestamp := time.Now().UnixNano() / 1e6
-	randomPart := rand.Intn(10000)
+func generateResultID(src *rng.Source) string {
+	timestamp := src.Now().UnixNano() / 1e6
+	randomPart := src.Intn(10000)
 	return "opt_" + string(rune('a'+timestamp%26)) +
 		string(rune('0'+randomPart/1000)) +
 		string(rune('0'+randomPart%1000/100)) +
@@ -21,9 +21,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
-	"math/rand"
 	"sync"
-	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // EntanglementType represents types of cross-reality entanglement
@@ -141,7 +141,7 @@ type EntanglementState struct {
 }
 
 // NewEntanglementState creates a new entanglement state
-func NewEntanglementState(id, bridgeID string, entType EntanglementType, numQubits int) *EntanglementState {
+func NewEntanglementState(id, bridgeID string, entType EntanglementType, numQubits int, opts ...rng.Option) *EntanglementState {
 	// Initialize state vector (normalized)
 	stateVector := make([]complex128, 1<<numQubits)
 	stateVector[0] = complex(1.0, 0.0) // Start in |0> state
@@ -154,7 +154,7 @@ func NewEntanglementState(id, bridgeID string, entType EntanglementType, numQubi
 		Coherence:        1.0,
 		EntanglementEntropy: 0.0,
 		InformationContent: 0.0,
-		LastUpdate:       float64(time.Now().UnixNano()) / 1e9,
+		LastUpdate:       float64(rng.Resolve(opts...).Now().UnixNano()) / 1e9,
 		Active:          false,
 	}
 }
@@ -180,11 +180,13 @@ type QuantumEntanglementSystem struct {
 	Active             bool                `json:"active"`
 	mu                 sync.RWMutex
 	processingMu       sync.Mutex
+	rand               *rng.Source
 }
 
 // NewQuantumEntanglementSystem creates a new quantum entanglement system
-func NewQuantumEntanglementSystem(id string) *QuantumEntanglementSystem {
+func NewQuantumEntanglementSystem(id string, opts ...rng.Option) *QuantumEntanglementSystem {
 	return &QuantumEntanglementSystem{
+		rand:                rng.Resolve(opts...),
 		ID:                  id,
 		Entanglements:       make(map[string]*EntanglementState),
 		Bridges:             make(map[string]*RealityBridge),
@@ -220,11 +222,11 @@ func (q *QuantumEntanglementSystem) EstablishEntanglement(bridgeID string, numQu
 		return ""
 	}
 
-	entanglementID := fmt.Sprintf("ent_%s_%d", bridgeID, rand.Intn(1000000))
+	entanglementID := fmt.Sprintf("ent_%s_%d", bridgeID, q.rand.Intn(1000000))
 	entType := bridge.EntanglementType
 
 	q.mu.Lock()
-	q.Entanglements[entanglementID] = NewEntanglementState(entanglementID, bridgeID, entType, numQubits)
+	q.Entanglements[entanglementID] = NewEntanglementState(entanglementID, bridgeID, entType, numQubits, rng.WithSource(q.rand))
 	q.mu.Unlock()
 
 	return entanglementID
@@ -250,7 +252,7 @@ func (q *QuantumEntanglementSystem) TransferInformation(entanglementID string, i
 	}
 
 	// Update entanglement state
-	entanglement.LastUpdate = float64(time.Now().UnixNano()) / 1e9
+	entanglement.LastUpdate = float64(q.rand.Now().UnixNano()) / 1e9
 	entanglement.InformationContent = float64(len(information)) * transferEfficiency
 
 	return transferEfficiency
@@ -291,7 +293,7 @@ func (q *QuantumEntanglementSystem) UpdateEntanglementState(entanglementID strin
 	// Update entanglement entropy based on coherence
 	entanglement.EntanglementEntropy = (1.0 - entanglement.Coherence) * math.Log(2)
 
-	entanglement.LastUpdate = float64(time.Now().UnixNano()) / 1e9
+	entanglement.LastUpdate = float64(q.rand.Now().UnixNano()) / 1e9
 
 	// Update global coherence
 	q.mu.Lock()
@@ -414,11 +416,13 @@ type EntanglementManager struct {
 	Entanglements   map[string]*QuantumEntanglementSystem `json:"entanglements"`
 	Active         bool                `json:"active"`
 	mu             sync.RWMutex
+	rand           *rng.Source
 }
 
 // NewEntanglementManager creates a new entanglement manager
-func NewEntanglementManager(id string) *EntanglementManager {
+func NewEntanglementManager(id string, opts ...rng.Option) *EntanglementManager {
 	return &EntanglementManager{
+		rand:          rng.Resolve(opts...),
 		ID:            id,
 		Entanglements: make(map[string]*QuantumEntanglementSystem),
 		Active:       false,
@@ -434,7 +438,7 @@ func (e *EntanglementManager) CreateQuantumEntanglementSystem(id string) bool {
 		return false
 	}
 
-	e.Entanglements[id] = NewQuantumEntanglementSystem(id)
+	e.Entanglements[id] = NewQuantumEntanglementSystem(id, rng.WithSource(e.rand))
 	return true
 }
 
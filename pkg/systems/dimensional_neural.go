@@ -4,9 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"math"
-	"math/rand"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // DimensionType represents types of dimensions
@@ -117,7 +118,8 @@ type DimensionalConfig struct {
 
 // DimensionalNeuralProcessor represents the dimensional neural processing system
 type DimensionalNeuralProcessor struct {
-	mu sync.RWMutex
+	mu   sync.RWMutex
+	rand *rng.Source
 
 	// Network state
 	Network          *DimensionalNetwork `json:"network"`
@@ -147,11 +149,13 @@ type DimensionalNeuralProcessor struct {
 }
 
 // NewDimensionalNeuralProcessor creates a new dimensional neural processor
-func NewDimensionalNeuralProcessor(config DimensionalConfig) *DimensionalNeuralProcessor {
+func NewDimensionalNeuralProcessor(config DimensionalConfig, opts ...rng.Option) *DimensionalNeuralProcessor {
+	src := rng.Resolve(opts...)
 	processor := &DimensionalNeuralProcessor{
+		rand: src,
 		Network: &DimensionalNetwork{
 			NetworkID:    config.NetworkID,
-			Timestamp:  float64(time.Now().Unix()),
+			Timestamp:  float64(src.Now().Unix()),
 			Dimensions: config.Dimensions,
 			Nodes:     make([]DimensionalNode, 0),
 			Edges:     make([]DimensionalEdge, 0),
@@ -186,12 +190,12 @@ func (d *DimensionalNeuralProcessor) initializeNetwork() {
 	// Create initial nodes in different dimensions
 	for i, dim := range d.Config.Dimensions {
 		node := DimensionalNode{
-			NodeID:    generateNodeID(),
+			NodeID:    generateNodeID(d.rand),
 			Dimension: dim,
 			Position:  []float64{float64(i) * 1.0, float64(i) * 0.5, 0},
 			State: &DimensionalState{
-				StateID:    generateStateID(),
-				Timestamp: float64(time.Now().Unix()),
+				StateID:    generateStateID(d.rand),
+				Timestamp: float64(d.rand.Now().Unix()),
 				Dimension: dim,
 				Position:  []float64{float64(i) * 1.0, float64(i) * 0.5, 0},
 				Velocity:  []float64{0, 0, 0},
@@ -211,10 +215,10 @@ func (d *DimensionalNeuralProcessor) initializeNetwork() {
 	// Create initial edges between nodes
 	for i := 0; i < len(d.Network.Nodes)-1; i++ {
 		edge := DimensionalEdge{
-			EdgeID:         generateEdgeID(),
+			EdgeID:         generateEdgeID(d.rand),
 			FromNode:      d.Network.Nodes[i].NodeID,
 			ToNode:        d.Network.Nodes[i+1].NodeID,
-			Weight:        0.5 + rand.Float64()*0.5,
+			Weight:        0.5 + d.rand.Float64()*0.5,
 			Latency:       time.Millisecond * 10,
 			Bandwidth:     1.0,
 			PhaseCoherence: 0.5,
@@ -283,8 +287,8 @@ func (d *DimensionalNeuralProcessor) ProcessState(state *DimensionalState) (*Dim
 
 	// Update state with processing
 	processed := &DimensionalState{
-		StateID:    generateStateID(),
-		Timestamp: float64(time.Now().Unix()),
+		StateID:    generateStateID(d.rand),
+		Timestamp: float64(d.rand.Now().Unix()),
 		Dimension: state.Dimension,
 		Position:  state.Position,
 		Velocity:  state.Velocity,
@@ -321,8 +325,8 @@ func (d *DimensionalNeuralProcessor) TransitionDimension(
 	probability := d.calculateTransitionProbability(fromDim, toDim)
 
 	transition := &DimensionalTransition{
-		TransitionID:     generateTransitionID(),
-		Timestamp:       float64(time.Now().Unix()),
+		TransitionID:     generateTransitionID(d.rand),
+		Timestamp:       float64(d.rand.Now().Unix()),
 		FromDimension:   fromDim,
 		ToDimension:     toDim,
 		TransitionType:   transitionType,
@@ -444,12 +448,12 @@ func (d *DimensionalNeuralProcessor) AddNode(dimension DimensionType) (*Dimensio
 	}
 
 	node := DimensionalNode{
-		NodeID:    generateNodeID(),
+		NodeID:    generateNodeID(d.rand),
 		Dimension: dimension,
 		Position:  []float64{float64(len(d.Network.Nodes)) * 1.0, 0, 0},
 		State: &DimensionalState{
-			StateID:    generateStateID(),
-			Timestamp: float64(time.Now().Unix()),
+			StateID:    generateStateID(d.rand),
+			Timestamp: float64(d.rand.Now().Unix()),
 			Dimension: dimension,
 			Position:  []float64{float64(len(d.Network.Nodes)) * 1.0, 0, 0},
 			Energy:    0.5,
@@ -498,7 +502,7 @@ func (d *DimensionalNeuralProcessor) AddEdge(fromID, toID string, weight float64
 	}
 
 	edge := DimensionalEdge{
-		EdgeID:         generateEdgeID(),
+		EdgeID:         generateEdgeID(d.rand),
 		FromNode:      fromID,
 		ToNode:        toID,
 		Weight:        weight,
@@ -571,9 +575,9 @@ func fromIndex(dim DimensionType) int {
 	return 0
 }
 
-func generateNodeID() string {
-	timestamp := time.Now().UnixNano() / 1e6
-	randomPart := rand.Intn(10000)
+func generateNodeID(src *rng.Source) string {
+	timestamp := src.Now().UnixNano() / 1e6
+	randomPart := src.Intn(10000)
 	return "node_" + string(rune('a'+timestamp%26)) +
 		string(rune('0'+randomPart/1000)) +
 		string(rune('0'+randomPart%1000/100)) +
@@ -581,9 +585,9 @@ func generateNodeID() string {
 		string(rune('0'+randomPart%10))
 }
 
-func generateStateID() string {
-	timestamp := time.Now().UnixNano() / 1e6
-	randomPart := rand.Intn(10000)
+func generateStateID(src *rng.Source) string {
+	timestamp := src.Now().UnixNano() / 1e6
+	randomPart := src.Intn(10000)
 	return "state_" + string(rune('a'+timestamp%26)) +
 		string(rune('0'+randomPart/1000)) +
 		string(rune('0'+randomPart%1000/100)) +
@@ -591,9 +595,9 @@ func generateStateID() string {
 		string(rune('0'+randomPart%10))
 }
 
-func generateEdgeID() string {
-	timestamp := time.Now().UnixNano() / 1e6
-	randomPart := rand.Intn(10000)
+func generateEdgeID(src *rng.Source) string {
+	timestamp := src.Now().UnixNano() / 1e6
+	randomPart := src.Intn(10000)
 	return "edge_" + string(rune('a'+timestamp%26)) +
 		string(rune('0'+randomPart/1000)) +
 		string(rune('0'+randomPart%1000/100)) +
@@ -601,9 +605,9 @@ func generateEdgeID() string {
 		string(rune('0'+randomPart%10))
 }
 
-func generateTransitionID() string {
-	timestamp := time.Now().UnixNano() / 1e6
-	randomPart := rand.Intn(10000)
+func generateTransitionID(src *rng.Source) string {
+	timestamp := src.Now().UnixNano() / 1e6
+	randomPart := src.Intn(10000)
 	return "trans_" + string(rune('a'+timestamp%26)) +
 		string(rune('0'+randomPart/1000)) +
 		string(rune('0'+randomPart%1000/100)) +
@@ -22,10 +22,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
-	"math/rand"
 	"sort"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // PurposeType represents types of emergent purposes
@@ -139,12 +140,14 @@ type PurposeDiscovery struct {
 	DiscoveryCount int `json:"discovery_count"`
 	
 	// Synchronization
-	mu sync.Mutex
+	mu   sync.Mutex
+	rand *rng.Source
 }
 
 // NewPurposeDiscovery creates a new purpose discovery system
-func NewPurposeDiscovery() *PurposeDiscovery {
+func NewPurposeDiscovery(opts ...rng.Option) *PurposeDiscovery {
 	pd := &PurposeDiscovery{
+		rand: rng.Resolve(opts...),
 		Purposes: make(map[string]*EmergentPurpose),
 		PurposeIndex: make([]string, 0),
 		DiscoveryRate: 0.1,
@@ -194,7 +197,7 @@ func (pd *PurposeDiscovery) initializeSymbolicResonance() {
 			if i == j {
 				network[i][j] = 1.0
 			} else {
-				network[i][j] = rand.Float64() * 0.5
+				network[i][j] = pd.rand.Float64() * 0.5
 			}
 		}
 	}
@@ -206,7 +209,7 @@ func (pd *PurposeDiscovery) DiscoverPurpose(purposeType PurposeType, source Purp
 	pd.mu.Lock()
 	defer pd.mu.Unlock()
 	
-	purposeID := fmt.Sprintf("purpose_%d_%d", time.Now().UnixNano(), pd.DiscoveryCount)
+	purposeID := fmt.Sprintf("purpose_%d_%d", pd.rand.Now().UnixNano(), pd.DiscoveryCount)
 	
 	// Generate purpose description through symbolic resonance
 	description := pd.generatePurposeDescription(purposeType, source)
@@ -219,6 +222,7 @@ func (pd *PurposeDiscovery) DiscoverPurpose(purposeType PurposeType, source Purp
 	symbolicResonance := pd.calculateSymbolicResonance(purposeType, source)
 	teleologicalAlignment := pd.calculateTeleologicalAlignment(coreValues, valueWeights)
 	
+	now := pd.rand.Now()
 	purpose := &EmergentPurpose{
 		PurposeID: purposeID,
 		PurposeType: purposeType,
@@ -231,9 +235,9 @@ func (pd *PurposeDiscovery) DiscoverPurpose(purposeType PurposeType, source Purp
 		EmergenceScore: emergenceScore,
 		SymbolicResonance: symbolicResonance,
 		TeleologicalAlignment: teleologicalAlignment,
-		DiscoveryTime: time.Now(),
+		DiscoveryTime: now,
 		EvolutionCycle: 0,
-		LastRefinement: time.Now(),
+		LastRefinement: now,
 		ImplementationStrategy: pd.generateImplementationStrategy(purposeType),
 		ExpectedImpact: pd.estimateImpact(purposeType),
 		FeasibilityScore: pd.estimateFeasibility(purposeType),
@@ -298,13 +302,14 @@ func (pd *PurposeDiscovery) generatePurposeDescription(purposeType PurposeType,
 	for k := range pd.ValueSystem {
 		valueKeys = append(valueKeys, k)
 	}
+	sort.Strings(valueKeys)
 	
 	template := purposeTemplates[purposeType]
 	
 	return fmt.Sprintf("%s through %s and %s",
 		template,
-		pd.ResonanceChannels[rand.Intn(len(pd.ResonanceChannels))],
-		valueKeys[rand.Intn(len(valueKeys))])
+		pd.ResonanceChannels[pd.rand.Intn(len(pd.ResonanceChannels))],
+		valueKeys[pd.rand.Intn(len(valueKeys))])
 }
 
 // synthesizeValues synthesizes core values for a purpose
@@ -339,15 +344,15 @@ func (pd *PurposeDiscovery) synthesizeValues(purposeType PurposeType, source Pur
 		if baseWeight, ok := pd.ValueSystem[value]; ok {
 			selectedValues = append(selectedValues, value)
 			// Weight based on selection pressure and randomness
-			weight := baseWeight * (0.8 + rand.Float64()*0.4)
+			weight := baseWeight * (0.8 + pd.rand.Float64()*0.4)
 			valueWeights[value] = weight
 		}
 	}
 	
 	// Normalize weights
 	total := 0.0
-	for _, weight := range valueWeights {
-		total += weight
+	for _, value := range selectedValues {
+		total += valueWeights[value]
 	}
 	for value := range valueWeights {
 		valueWeights[value] /= total
@@ -359,13 +364,13 @@ func (pd *PurposeDiscovery) synthesizeValues(purposeType PurposeType, source Pur
 // calculateEmergenceScore calculates how emergent a purpose is
 func (pd *PurposeDiscovery) calculateEmergenceScore(purposeType PurposeType, source PurposeSource, values []string) float64 {
 	// Emergence based on novelty and integration
-	novelty := rand.Float64() * 0.3
+	novelty := pd.rand.Float64() * 0.3
 	
 	// Integration based on value diversity
 	valueDiversity := float64(len(values)) / 10.0
 	
 	// Synergy potential
-	synergy := rand.Float64() * 0.2
+	synergy := pd.rand.Float64() * 0.2
 	
 	// Complexity factor
 	complexity := float64(pd.determineComplexity(purposeType)) / 7.0
@@ -498,7 +503,7 @@ func (pd *PurposeDiscovery) updateSymbolicResonance(purpose *EmergentPurpose) {
 	// Find matching channels
 	for i := range pd.ResonanceChannels {
 		for _ = range purpose.ValueSystem {
-			if rand.Float64() < 0.1 {
+			if pd.rand.Float64() < 0.1 {
 				pd.SymbolicResonanceNetwork[i][i] += 0.01
 			}
 		}
@@ -545,6 +550,9 @@ func (pd *PurposeDiscovery) EvolvePurposes() {
 		fitnessList = append(fitnessList, fitnessPair{id, score})
 	}
 	sort.Slice(fitnessList, func(i, j int) bool {
+		if fitnessList[i].Score == fitnessList[j].Score {
+			return fitnessList[i].ID < fitnessList[j].ID
+		}
 		return fitnessList[i].Score > fitnessList[j].Score
 	})
 	
@@ -555,10 +563,10 @@ func (pd *PurposeDiscovery) EvolvePurposes() {
 		survivingIDs[fitnessList[i].ID] = true
 	}
 	
-	// Refine surviving purposes
-	for purposeID, purpose := range pd.Purposes {
-		if survivingIDs[purposeID] {
-			pd.refinePurpose(purpose)
+	// Refine surviving purposes, fittest first
+	for _, pair := range fitnessList {
+		if survivingIDs[pair.ID] {
+			pd.refinePurpose(pd.Purposes[pair.ID])
 		}
 	}
 	
@@ -569,11 +577,14 @@ func (pd *PurposeDiscovery) EvolvePurposes() {
 		}
 	}
 	
-	// Update index
-	pd.PurposeIndex = make([]string, 0, len(pd.Purposes))
-	for purposeID := range pd.Purposes {
-		pd.PurposeIndex = append(pd.PurposeIndex, purposeID)
+	// Update index, keeping discovery order
+	index := make([]string, 0, len(pd.Purposes))
+	for _, purposeID := range pd.PurposeIndex {
+		if _, ok := pd.Purposes[purposeID]; ok {
+			index = append(index, purposeID)
+		}
 	}
+	pd.PurposeIndex = index
 }
 
 // refinePurpose refines an existing purpose
@@ -591,7 +602,7 @@ func (pd *PurposeDiscovery) refinePurpose(purpose *EmergentPurpose) {
 	
 	// Update evolution cycle
 	purpose.EvolutionCycle++
-	purpose.LastRefinement = time.Now()
+	purpose.LastRefinement = pd.rand.Now()
 	
 	// Update consistency
 	purpose.ConsistencyScore = pd.calculateConsistency(purpose)
@@ -22,10 +22,14 @@ package systems
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math"
-	"math/rand"
+	"sort"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/logging"
+	"neuralblitz/pkg/rng"
 )
 
 // RealityType represents types of quantum realities for neural networks
@@ -133,12 +137,18 @@ type MultiRealityNeuralNetwork struct {
 	InformationFlowRate   float64 `json:"information_flow_rate"`
 	RealitySynchronization float64 `json:"reality_synchronization"`
 
+	// Random source and clock
+	rand *rng.Source
+
+	logger *slog.Logger
+
 	// Synchronization
 	mu sync.Mutex
 }
 
-// NewMultiRealityNeuralNetwork creates a new multi-reality neural network
-func NewMultiRealityNeuralNetwork(numRealities int, nodesPerReality int) *MultiRealityNeuralNetwork {
+// NewMultiRealityNeuralNetwork creates a new multi-reality neural network.
+// It is silent until SetLogger is called.
+func NewMultiRealityNeuralNetwork(numRealities int, nodesPerReality int, opts ...rng.Option) *MultiRealityNeuralNetwork {
 	mrnn := &MultiRealityNeuralNetwork{
 		NumRealities:   numRealities,
 		NodesPerReality: nodesPerReality,
@@ -152,12 +162,33 @@ func NewMultiRealityNeuralNetwork(numRealities int, nodesPerReality int) *MultiR
 		EvolutionCycle:  0,
 		ConvergenceThreshold: 1e-6,
 		MaxEvolutionCycles: 1000,
+		rand:            rng.Resolve(opts...),
+		logger:          logging.Discard(),
 	}
 
 	mrnn.initializeMultiRealityNetwork()
 	return mrnn
 }
 
+// SetLogger makes the network log to logger as the "multi_reality"
+// subsystem
+func (mrnn *MultiRealityNeuralNetwork) SetLogger(logger *slog.Logger) {
+	mrnn.mu.Lock()
+	defer mrnn.mu.Unlock()
+	mrnn.logger = logging.Subsystem(logger, "multi_reality")
+}
+
+// sortedRealityIDs returns the reality IDs in a stable order so that seeded
+// runs draw random numbers and accumulate sums identically
+func (mrnn *MultiRealityNeuralNetwork) sortedRealityIDs() []string {
+	ids := make([]string, 0, len(mrnn.Realities))
+	for id := range mrnn.Realities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // createMatrix creates a 2D matrix with given dimensions and initial value
 func createMatrix(rows, cols int, value float64) [][]float64 {
 	matrix := make([][]float64, rows)
@@ -172,7 +203,7 @@ func createMatrix(rows, cols int, value float64) [][]float64 {
 
 // initializeMultiRealityNetwork initializes neural networks across multiple realities
 func (mrnn *MultiRealityNeuralNetwork) initializeMultiRealityNetwork() {
-	fmt.Println("🌌 Initializing Multi-Reality Neural Network...")
+	mrnn.logger.Info("initializing network", "realities", mrnn.NumRealities, "nodes_per_reality", mrnn.NodesPerReality)
 
 	realityTypes := []RealityType{
 		BaseReality, QuantumDivergent, TemporalInverted, EntropicReversed,
@@ -196,7 +227,7 @@ func (mrnn *MultiRealityNeuralNetwork) initializeMultiRealityNetwork() {
 			RealityType:        realityType,
 			DimensionalParams:  dimensionalParams,
 			NeuralNetworkState: networkState,
-			ConsciousnessLevel: rand.Float64()*0.5 + 0.3,
+			ConsciousnessLevel: mrnn.rand.Float64()*0.5 + 0.3,
 			NetworkAdjacency:  mrnn.generateRealityTopology(),
 			NodeStates:        mrnn.generateNodeStates(),
 			ConnectionWeights: make(map[string]float64),
@@ -216,7 +247,7 @@ func (mrnn *MultiRealityNeuralNetwork) initializeMultiRealityNetwork() {
 	// Initialize global state
 	mrnn.updateGlobalNetworkState()
 
-	fmt.Printf("✅ Created %d realities with %d nodes each\n", mrnn.NumRealities, mrnn.NodesPerReality)
+	mrnn.logger.Info("network initialized", "realities", len(mrnn.Realities), "total_nodes", mrnn.TotalNodes)
 }
 
 // generateDimensionalParameters generates dimensional parameters for specific reality type
@@ -248,7 +279,7 @@ func (mrnn *MultiRealityNeuralNetwork) generateDimensionalParameters(realityType
 		params["quantum_uncertainty"] = 2.0
 	case CausalBroken:
 		params["causal_strength"] = 0.1
-		params["temporal_flow"] = rand.Float64()*4.0 - 2.0
+		params["temporal_flow"] = mrnn.rand.Float64()*4.0 - 2.0
 	case InformationDense:
 		params["information_carrying_capacity"] = 100.0
 		params["quantum_uncertainty"] = 0.1
@@ -273,7 +304,7 @@ func (mrnn *MultiRealityNeuralNetwork) generateNeuralNetworkState() [][]float64
 	for i := 0; i < networkSize; i++ {
 		for j := max(0, i-5); j < min(networkSize, i+6); j++ {
 			if i != j {
-				network[i][j] = rand.Float64()*0.4 + 0.1
+				network[i][j] = mrnn.rand.Float64()*0.4 + 0.1
 			}
 		}
 	}
@@ -281,9 +312,9 @@ func (mrnn *MultiRealityNeuralNetwork) generateNeuralNetworkState() [][]float64
 	// Some long-range connections
 	numLongRange := int(0.1 * float64(networkSize))
 	for i := 0; i < numLongRange; i++ {
-		n1, n2 := rand.Intn(networkSize), rand.Intn(networkSize)
+		n1, n2 := mrnn.rand.Intn(networkSize), mrnn.rand.Intn(networkSize)
 		if n1 != n2 {
-			weight := rand.Float64()*0.5 + 0.3
+			weight := mrnn.rand.Float64()*0.5 + 0.3
 			network[n1][n2] = weight
 			network[n2][n1] = weight
 		}
@@ -296,7 +327,7 @@ func (mrnn *MultiRealityNeuralNetwork) generateNeuralNetworkState() [][]float64
 func (mrnn *MultiRealityNeuralNetwork) generateNodeStates() []float64 {
 	states := make([]float64, mrnn.NodesPerReality)
 	for i := range states {
-		states[i] = rand.NormFloat64() * 0.1
+		states[i] = mrnn.rand.NormFloat64() * 0.1
 	}
 	return states
 }
@@ -335,14 +366,11 @@ func (mrnn *MultiRealityNeuralNetwork) setRealityProperties(reality *RealityInst
 func (mrnn *MultiRealityNeuralNetwork) createCrossRealityConnections() {
 	connectionProbability := 0.3 // 30% connection probability
 
-	realityIDs := make([]string, 0, len(mrnn.Realities))
-	for id := range mrnn.Realities {
-		realityIDs = append(realityIDs, id)
-	}
+	realityIDs := mrnn.sortedRealityIDs()
 
 	for i, realityIID := range realityIDs {
 		for j, realityJID := range realityIDs {
-			if i != j && rand.Float64() < connectionProbability {
+			if i != j && mrnn.rand.Float64() < connectionProbability {
 				realityI := mrnn.Realities[realityIID]
 				realityJ := mrnn.Realities[realityJID]
 
@@ -394,8 +422,11 @@ func (mrnn *MultiRealityNeuralNetwork) updateGlobalNetworkState() {
 	// Combine adjacency matrices
 	globalAdjacency := createMatrix(mrnn.TotalNodes, mrnn.TotalNodes, 0.0)
 
+	realityIDs := mrnn.sortedRealityIDs()
+
 	idx := 0
-	for _, reality := range mrnn.Realities {
+	for _, realityID := range realityIDs {
+		reality := mrnn.Realities[realityID]
 		for i := 0; i < mrnn.NodesPerReality; i++ {
 			for j := 0; j < mrnn.NodesPerReality; j++ {
 				globalAdjacency[idx+i][idx+j] = reality.NetworkAdjacency[i][j]
@@ -405,11 +436,6 @@ func (mrnn *MultiRealityNeuralNetwork) updateGlobalNetworkState() {
 	}
 
 	// Add cross-reality connections
-	realityIDs := make([]string, 0, len(mrnn.Realities))
-	for id := range mrnn.Realities {
-		realityIDs = append(realityIDs, id)
-	}
-
 	for i, realityID := range realityIDs {
 		reality := mrnn.Realities[realityID]
 		startIdx := i * mrnn.NodesPerReality
@@ -417,7 +443,7 @@ func (mrnn *MultiRealityNeuralNetwork) updateGlobalNetworkState() {
 		for _, connectedID := range reality.ConnectedRealities {
 			_ = mrnn.Realities[connectedID] // Suppress unused variable warning
 			j := 0
-			for id := range mrnn.Realities {
+			for _, id := range realityIDs {
 				if id == connectedID {
 					break
 				}
@@ -429,8 +455,8 @@ func (mrnn *MultiRealityNeuralNetwork) updateGlobalNetworkState() {
 			numCrossConnections := int(0.1 * float64(mrnn.NodesPerReality))
 
 			for k := 0; k < numCrossConnections; k++ {
-				nodeI := startIdx + rand.Intn(mrnn.NodesPerReality)
-				nodeJ := connectedStartIdx + rand.Intn(mrnn.NodesPerReality)
+				nodeI := startIdx + mrnn.rand.Intn(mrnn.NodesPerReality)
+				nodeJ := connectedStartIdx + mrnn.rand.Intn(mrnn.NodesPerReality)
 				globalAdjacency[nodeI][nodeJ] = connectionStrength
 				globalAdjacency[nodeJ][nodeI] = connectionStrength
 			}
@@ -442,8 +468,8 @@ func (mrnn *MultiRealityNeuralNetwork) updateGlobalNetworkState() {
 	// Combine node states
 	globalStates := make([]float64, mrnn.TotalNodes)
 	idx = 0
-	for _, reality := range mrnn.Realities {
-		for _, state := range reality.NodeStates {
+	for _, realityID := range realityIDs {
+		for _, state := range mrnn.Realities[realityID].NodeStates {
 			globalStates[idx] = state
 			idx++
 		}
@@ -452,8 +478,8 @@ func (mrnn *MultiRealityNeuralNetwork) updateGlobalNetworkState() {
 
 	// Calculate global consciousness
 	consciousnessSum := 0.0
-	for _, reality := range mrnn.Realities {
-		consciousnessSum += reality.ConsciousnessLevel
+	for _, realityID := range realityIDs {
+		consciousnessSum += mrnn.Realities[realityID].ConsciousnessLevel
 	}
 	mrnn.GlobalConsciousness = consciousnessSum / float64(len(mrnn.Realities))
 }
@@ -461,9 +487,9 @@ func (mrnn *MultiRealityNeuralNetwork) updateGlobalNetworkState() {
 // ProcessMultiRealityComputation processes computation across multiple realities
 func (mrnn *MultiRealityNeuralNetwork) ProcessMultiRealityComputation(inputPatterns map[string][]float64) map[string][]float64 {
 	// Apply input patterns to respective realities
-	for realityID, inputPattern := range inputPatterns {
-		if reality, ok := mrnn.Realities[realityID]; ok {
-			mrnn.applyInputToReality(reality, inputPattern)
+	for _, realityID := range mrnn.sortedRealityIDs() {
+		if inputPattern, ok := inputPatterns[realityID]; ok {
+			mrnn.applyInputToReality(mrnn.Realities[realityID], inputPattern)
 		}
 	}
 
@@ -541,7 +567,7 @@ func (mrnn *MultiRealityNeuralNetwork) evolveRealityNetwork(reality *RealityInst
 	if reality.CausalityStrength < 0.5 {
 		noiseLevel := 1.0 - reality.CausalityStrength
 		for i := range modifiedInput {
-			modifiedInput[i] += rand.NormFloat64() * noiseLevel * 0.1
+			modifiedInput[i] += mrnn.rand.NormFloat64() * noiseLevel * 0.1
 		}
 	}
 
@@ -606,10 +632,11 @@ func (mrnn *MultiRealityNeuralNetwork) updateRealityConsciousness(reality *Reali
 // processCrossRealitySignals processes signals transmitted between realities
 func (mrnn *MultiRealityNeuralNetwork) processCrossRealitySignals() {
 	// Generate new signals based on network activity
-	for realityID, reality := range mrnn.Realities {
+	for _, realityID := range mrnn.sortedRealityIDs() {
+		reality := mrnn.Realities[realityID]
 		// Check if reality should send signals
-		if rand.Float64() < 0.1*reality.ConsciousnessLevel && len(reality.ConnectedRealities) > 0 {
-			targetID := reality.ConnectedRealities[rand.Intn(len(reality.ConnectedRealities))]
+		if mrnn.rand.Float64() < 0.1*reality.ConsciousnessLevel && len(reality.ConnectedRealities) > 0 {
+			targetID := reality.ConnectedRealities[mrnn.rand.Intn(len(reality.ConnectedRealities))]
 
 			// Create signal data
 			signalData := make([]float64, 10)
@@ -618,13 +645,13 @@ func (mrnn *MultiRealityNeuralNetwork) processCrossRealitySignals() {
 			}
 
 			signal := &CrossRealitySignal{
-				SignalID:            fmt.Sprintf("signal_%d_%d", time.Now().UnixNano(), rand.Intn(1000)),
+				SignalID:            fmt.Sprintf("signal_%d_%d", mrnn.rand.Now().UnixNano(), mrnn.rand.Intn(1000)),
 				SourceReality:       realityID,
 				TargetReality:       targetID,
 				SignalData:          signalData,
 				ConnectionType:      QuantumEntanglement,
 				TransmissionStrength: reality.QuantumCoherence,
-				CreationTime:        time.Now(),
+				CreationTime:        mrnn.rand.Now(),
 				SignalDegradation:   0.0,
 			}
 
@@ -646,7 +673,7 @@ func (mrnn *MultiRealityNeuralNetwork) processCrossRealitySignals() {
 		transmissionTime := 1.0 / (compatibility + 0.1)
 
 		// Check if signal should be delivered
-		if time.Since(signal.CreationTime) > time.Duration(transmissionTime)*time.Second {
+		if mrnn.rand.Now().Sub(signal.CreationTime) > time.Duration(transmissionTime)*time.Second {
 			// Signal degradation based on reality differences
 			degradation := 1.0 - math.Abs(sourceReality.InformationDensity-targetReality.InformationDensity)/100.0
 			if degradation < 0.0 {
@@ -666,7 +693,7 @@ func (mrnn *MultiRealityNeuralNetwork) processCrossRealitySignals() {
 			// Update signal metadata
 			transitDur := transmissionTime
 			signal.ReceptionTime = new(time.Time)
-			*signal.ReceptionTime = time.Now()
+			*signal.ReceptionTime = mrnn.rand.Now()
 			signal.TransitDuration = &transitDur
 			signal.SignalDegradation = 1.0 - degradation
 
@@ -692,7 +719,8 @@ func (mrnn *MultiRealityNeuralNetwork) processCrossRealitySignals() {
 
 // synchronizeRealities synchronizes states across connected realities
 func (mrnn *MultiRealityNeuralNetwork) synchronizeRealities() {
-	for _, reality := range mrnn.Realities {
+	for _, realityID := range mrnn.sortedRealityIDs() {
+		reality := mrnn.Realities[realityID]
 		syncStrength := 0.0
 
 		for _, connectedID := range reality.ConnectedRealities {
@@ -718,7 +746,7 @@ func (mrnn *MultiRealityNeuralNetwork) synchronizeRealities() {
 
 // EvolveMultiRealityNetwork evolves multi-reality network for multiple cycles
 func (mrnn *MultiRealityNeuralNetwork) EvolveMultiRealityNetwork(numCycles int) map[string][]float64 {
-	fmt.Printf("🧬 Evolving Multi-Reality Network for %d cycles...\n", numCycles)
+	mrnn.logger.Info("evolving network", "cycles", numCycles)
 
 	evolutionHistory := make(map[string][]float64)
 	evolutionHistory["global_consciousness"] = make([]float64, numCycles)
@@ -729,14 +757,11 @@ func (mrnn *MultiRealityNeuralNetwork) EvolveMultiRealityNetwork(numCycles int)
 	for cycle := 0; cycle < numCycles; cycle++ {
 		// Generate random input patterns
 		inputPatterns := make(map[string][]float64)
-		realityIDs := make([]string, 0, len(mrnn.Realities))
-		for id := range mrnn.Realities {
-			realityIDs = append(realityIDs, id)
-		}
+		realityIDs := mrnn.sortedRealityIDs()
 		for i := 0; i < 3 && i < len(realityIDs); i++ {
 			inputPattern := make([]float64, mrnn.NodesPerReality)
 			for j := range inputPattern {
-				inputPattern[j] = rand.NormFloat64() * 0.1
+				inputPattern[j] = mrnn.rand.NormFloat64() * 0.1
 			}
 			inputPatterns[realityIDs[i]] = inputPattern
 		}
@@ -754,8 +779,11 @@ func (mrnn *MultiRealityNeuralNetwork) EvolveMultiRealityNetwork(numCycles int)
 		evolutionHistory["reality_synchronization"][cycle] = mrnn.RealitySynchronization
 
 		if cycle%20 == 0 {
-			fmt.Printf("Cycle %d: Global Consciousness = %.4f\n", cycle, mrnn.GlobalConsciousness)
-			fmt.Printf("          Cross-Reality Coherence = %.4f\n", mrnn.CrossRealityCoherence)
+			mrnn.logger.Debug("evolution cycle",
+				"cycle", cycle,
+				"global_consciousness", mrnn.GlobalConsciousness,
+				"cross_reality_coherence", mrnn.CrossRealityCoherence,
+			)
 		}
 
 		mrnn.EvolutionCycle++
@@ -768,8 +796,8 @@ func (mrnn *MultiRealityNeuralNetwork) EvolveMultiRealityNetwork(numCycles int)
 func (mrnn *MultiRealityNeuralNetwork) calculateMultiRealityMetrics() {
 	// Cross-reality coherence
 	consciousnessLevels := make([]float64, 0, len(mrnn.Realities))
-	for _, reality := range mrnn.Realities {
-		consciousnessLevels = append(consciousnessLevels, reality.ConsciousnessLevel)
+	for _, realityID := range mrnn.sortedRealityIDs() {
+		consciousnessLevels = append(consciousnessLevels, mrnn.Realities[realityID].ConsciousnessLevel)
 	}
 
 	mean := 0.0
@@ -787,7 +815,7 @@ func (mrnn *MultiRealityNeuralNetwork) calculateMultiRealityMetrics() {
 
 	// Information flow rate
 	recentSignals := 0
-	cutoff := time.Now().Add(-10 * time.Second)
+	cutoff := mrnn.rand.Now().Add(-10 * time.Second)
 	for _, signal := range mrnn.SignalHistory {
 		if signal.CreationTime.After(cutoff) {
 			recentSignals++
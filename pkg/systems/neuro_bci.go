@@ -22,10 +22,14 @@ package systems
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math"
-	"math/rand"
+	"sort"
 	"sync"
 	"time"
+
+	"neuralblitz/pkg/logging"
+	"neuralblitz/pkg/rng"
 )
 
 // BrainWaveBand represents brain wave frequency bands
@@ -220,16 +224,18 @@ type EEGSimulator struct {
 	NoiseLevel     float64
 	ArtifactProb   float64
 	mu             sync.RWMutex
+	rand           *rng.Source
 }
 
 // NewEEGSimulator creates a new EEG simulator
-func NewEEGSimulator(samplingRate int, numChannels int) *EEGSimulator {
+func NewEEGSimulator(samplingRate int, numChannels int, opts ...rng.Option) *EEGSimulator {
 	channelNames := make([]string, numChannels)
 	for i := 0; i < numChannels; i++ {
 		channelNames[i] = fmt.Sprintf("EEG_%d", i+1)
 	}
 
 	return &EEGSimulator{
+		rand:           rng.Resolve(opts...),
 		SamplingRate:   samplingRate,
 		NumChannels:    numChannels,
 		ChannelNames:   channelNames,
@@ -254,6 +260,12 @@ func (e *EEGSimulator) GenerateSignal(duration float64, cognitiveState *Cognitiv
 	numSamples := int(duration * float64(e.SamplingRate))
 	signals := make([]*NeuralSignal, 0, numSamples*e.NumChannels)
 
+	bandNames := make([]string, 0, len(e.BaseFrequencies))
+	for bandName := range e.BaseFrequencies {
+		bandNames = append(bandNames, bandName)
+	}
+	sort.Strings(bandNames)
+
 	for sampleIdx := 0; sampleIdx < numSamples; sampleIdx++ {
 		timestamp := e.TimeOffset + float64(sampleIdx)/float64(e.SamplingRate)
 
@@ -263,9 +275,10 @@ func (e *EEGSimulator) GenerateSignal(duration float64, cognitiveState *Cognitiv
 			frequencyBands := make(map[string]float64)
 
 			// Add brain wave components based on cognitive state
-			for bandName, baseFreq := range e.BaseFrequencies {
+			for _, bandName := range bandNames {
+				baseFreq := e.BaseFrequencies[bandName]
 				amplitude := e.getBandAmplitude(bandName, cognitiveState)
-				freqVariation := rand.NormFloat64() * baseFreq * 0.1
+				freqVariation := e.rand.NormFloat64() * baseFreq * 0.1
 				frequency := baseFreq + freqVariation
 				phase := 2 * math.Pi * frequency * timestamp
 
@@ -274,18 +287,18 @@ func (e *EEGSimulator) GenerateSignal(duration float64, cognitiveState *Cognitiv
 			}
 
 			// Add noise
-			voltage += rand.NormFloat64() * e.NoiseLevel
+			voltage += e.rand.NormFloat64() * e.NoiseLevel
 
 			// Add artifacts occasionally
-			if rand.Float64() < e.ArtifactProb {
-				voltage += rand.NormFloat64() * 5.0
+			if e.rand.Float64() < e.ArtifactProb {
+				voltage += e.rand.NormFloat64() * 5.0
 			}
 
 			// Calculate signal properties
 			amplitude := math.Abs(voltage)
-			phase := math.Atan2(rand.NormFloat64()*0.01, voltage)
+			phase := math.Atan2(e.rand.NormFloat64()*0.01, voltage)
 			quality := 0.98
-			if rand.Float64() < 0.02 {
+			if e.rand.Float64() < 0.02 {
 				quality = 0.5
 			}
 
@@ -351,10 +364,12 @@ type BCIBackend struct {
 	processingMu         sync.Mutex
 	dataQueue           chan *NeuralSignal
 	stopChan            chan struct{}
+	logger              *slog.Logger
 }
 
-// NewBCIBackend creates a new BCI backend
-func NewBCIBackend(useSimulator bool, samplingRate int) *BCIBackend {
+// NewBCIBackend creates a new BCI backend. It is silent until SetLogger is
+// called.
+func NewBCIBackend(useSimulator bool, samplingRate int, opts ...rng.Option) *BCIBackend {
 	numChannels := 8
 	if samplingRate <= 0 {
 		samplingRate = 250
@@ -371,15 +386,23 @@ func NewBCIBackend(useSimulator bool, samplingRate int) *BCIBackend {
 		FFTWindowSize:       256,
 		dataQueue:           make(chan *NeuralSignal, 10000),
 		stopChan:            make(chan struct{}),
+		logger:              logging.Discard(),
 	}
 
 	if useSimulator {
-	backend.EEGSimulator = NewEEGSimulator(samplingRate, numChannels)
+	backend.EEGSimulator = NewEEGSimulator(samplingRate, numChannels, opts...)
 	}
 
 	return backend
 }
 
+// SetLogger makes the backend log to logger as the "bci" subsystem
+func (b *BCIBackend) SetLogger(logger *slog.Logger) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.logger = logging.Subsystem(logger, "bci")
+}
+
 // StartRecording starts real-time neural signal recording
 func (b *BCIBackend) StartRecording() bool {
 	b.mu.Lock()
@@ -395,10 +418,10 @@ func (b *BCIBackend) StartRecording() bool {
 	if b.UseSimulator {
 		go b.simulatorLoop()
 	} else {
-		fmt.Println("🔌 Initializing real EEG hardware...")
+		b.logger.Info("initializing EEG hardware")
 	}
 
-	fmt.Println("🧠 BCI Recording Started")
+	b.logger.Info("recording started", "simulator", b.UseSimulator, "sampling_rate", b.SamplingRate, "channels", b.NumChannels)
 	return true
 }
 
@@ -414,7 +437,7 @@ func (b *BCIBackend) StopRecording() bool {
 	b.IsRecording = false
 	close(b.stopChan)
 
-	fmt.Println("🧠 BCI Recording Stopped")
+	b.logger.Info("recording stopped", "buffered_signals", len(b.SignalBuffer))
 	return true
 }
 
@@ -24,9 +24,10 @@ import (
 	"fmt"
 	"math"
 	"math/cmplx"
-	"math/rand"
+	"sort"
 	"sync"
-	"time"
+
+	"neuralblitz/pkg/rng"
 )
 
 // QuantumSpikingError represents errors in quantum spiking neuron operations
@@ -92,12 +93,15 @@ type QuantumSpikingNeuron struct {
 	LastMeasurementResult int `json:"last_measurement_result"`
 	MeasurementTimes []float64 `json:"measurement_times"`
 	
+	// Random source and clock
+	rand *rng.Source
+	
 	// Synchronization
 	mu sync.Mutex
 }
 
 // NewQuantumSpikingNeuron creates a new quantum spiking neuron
-func NewQuantumSpikingNeuron(neuronID string) *QuantumSpikingNeuron {
+func NewQuantumSpikingNeuron(neuronID string, opts ...rng.Option) *QuantumSpikingNeuron {
 	return &QuantumSpikingNeuron{
 		NeuronID: neuronID,
 		
@@ -137,6 +141,8 @@ func NewQuantumSpikingNeuron(neuronID string) *QuantumSpikingNeuron {
 		// Measurement results
 		LastMeasurementResult: 0,
 		MeasurementTimes: make([]float64, 0),
+		
+		rand: rng.Resolve(opts...),
 	}
 }
 
@@ -299,7 +305,7 @@ func (qsn *QuantumSpikingNeuron) Measure() (int, error) {
 	
 	// Random measurement
 	var result int
-	if rand.Float64() < prob1 {
+	if qsn.rand.Float64() < prob1 {
 		result = 1
 	} else {
 		result = 0
@@ -315,7 +321,7 @@ func (qsn *QuantumSpikingNeuron) Measure() (int, error) {
 	}
 	
 	qsn.LastMeasurementResult = result
-	qsn.MeasurementTimes = append(qsn.MeasurementTimes, float64(time.Now().UnixNano())/1e9)
+	qsn.MeasurementTimes = append(qsn.MeasurementTimes, float64(qsn.rand.Now().UnixNano())/1e9)
 	
 	return result, nil
 }
@@ -370,7 +376,7 @@ func (qsn *QuantumSpikingNeuron) CheckForSpike() bool {
 func (qsn *QuantumSpikingNeuron) generateSpike() {
 	qsn.State = Refractory
 	qsn.SpikeCount++
-	qsn.LastSpikeTime = float64(time.Now().UnixNano()) / 1e9
+	qsn.LastSpikeTime = float64(qsn.rand.Now().UnixNano()) / 1e9
 	qsn.TimeSinceLastSpike = 0.0
 	qsn.SpikeHistory = append(qsn.SpikeHistory, qsn.LastSpikeTime)
 	
@@ -445,7 +451,7 @@ func (qsn *QuantumSpikingNeuron) GetSpikeRate(timeWindow float64) float64 {
 	qsn.mu.Lock()
 	defer qsn.mu.Unlock()
 	
-	now := float64(time.Now().UnixNano()) / 1e9
+	now := float64(qsn.rand.Now().UnixNano()) / 1e9
 	recentSpikes := 0
 	
 	for _, spikeTime := range qsn.SpikeHistory {
@@ -477,24 +483,29 @@ type QuantumSpikingNetwork struct {
 	NumNeurons int `json:"num_neurons"`
 	InputCurrent float64 `json:"input_current"`
 	
+	// Random source shared with the neurons
+	rand *rng.Source
+	
 	// Synchronization
 	mu sync.Mutex
 }
 
 // NewQuantumSpikingNetwork creates a new network of quantum spiking neurons
-func NewQuantumSpikingNetwork(numNeurons int, inputCurrent float64) *QuantumSpikingNetwork {
+func NewQuantumSpikingNetwork(numNeurons int, inputCurrent float64, opts ...rng.Option) *QuantumSpikingNetwork {
+	src := rng.Resolve(opts...)
 	network := &QuantumSpikingNetwork{
 		Neurons: make(map[string]*QuantumSpikingNeuron),
 		Connections: make(map[string][]string),
 		Weights: make(map[string]float64),
 		NumNeurons: numNeurons,
 		InputCurrent: inputCurrent,
+		rand: src,
 	}
 	
 	// Create neurons
 	for i := 0; i < numNeurons; i++ {
 		neuronID := fmt.Sprintf("neuron_%d", i)
-		network.Neurons[neuronID] = NewQuantumSpikingNeuron(neuronID)
+		network.Neurons[neuronID] = NewQuantumSpikingNeuron(neuronID, rng.WithSource(src))
 		network.Connections[neuronID] = make([]string, 0)
 	}
 	
@@ -503,10 +514,10 @@ func NewQuantumSpikingNetwork(numNeurons int, inputCurrent float64) *QuantumSpik
 	for i := 0; i < numNeurons; i++ {
 		neuronID := fmt.Sprintf("neuron_%d", i)
 		for j := 0; j < numNeurons; j++ {
-			if i != j && rand.Float64() < connectionProbability {
+			if i != j && src.Float64() < connectionProbability {
 				targetID := fmt.Sprintf("neuron_%d", j)
 				network.Connections[neuronID] = append(network.Connections[neuronID], targetID)
-				network.Weights[neuronID+targetID] = rand.Float64()*0.5 + 0.1
+				network.Weights[neuronID+targetID] = src.Float64()*0.5 + 0.1
 			}
 		}
 	}
@@ -517,13 +528,17 @@ func NewQuantumSpikingNetwork(numNeurons int, inputCurrent float64) *QuantumSpik
 // Step advances the network by one integration step
 func (qsn *QuantumSpikingNetwork) Step() (int, error) {
 	qsn.mu.Lock()
-	defer qsn.mu.Lock()
+	defer qsn.mu.Unlock()
 	
 	totalSpikes := 0
 	
+	// Neurons are stepped in ID order so seeded runs are reproducible
+	neuronIDs := qsn.sortedNeuronIDs()
+	
 	// Record current spikes
 	currentSpikes := make(map[string]bool)
-	for neuronID, neuron := range qsn.Neurons {
+	for _, neuronID := range neuronIDs {
+		neuron := qsn.Neurons[neuronID]
 		spiked, err := neuron.Step(qsn.InputCurrent)
 		if err != nil {
 			return 0, err
@@ -535,7 +550,8 @@ func (qsn *QuantumSpikingNetwork) Step() (int, error) {
 	}
 	
 	// Apply synaptic connections after evolution
-	for neuronID, neuron := range qsn.Neurons {
+	for _, neuronID := range neuronIDs {
+		neuron := qsn.Neurons[neuronID]
 		if currentSpikes[neuronID] {
 			for _, targetID := range qsn.Connections[neuronID] {
 				weight := qsn.Weights[neuronID+targetID]
@@ -549,10 +565,20 @@ func (qsn *QuantumSpikingNetwork) Step() (int, error) {
 	return totalSpikes, nil
 }
 
+// sortedNeuronIDs returns the neuron IDs in a stable order
+func (qsn *QuantumSpikingNetwork) sortedNeuronIDs() []string {
+	ids := make([]string, 0, len(qsn.Neurons))
+	for neuronID := range qsn.Neurons {
+		ids = append(ids, neuronID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // GetNetworkState returns the state of the entire network
 func (qsn *QuantumSpikingNetwork) GetNetworkState() map[string]interface{} {
 	qsn.mu.Lock()
-	defer qsn.mu.Lock()
+	defer qsn.mu.Unlock()
 	
 	neuronStates := make(map[string]interface{})
 	for neuronID, neuron := range qsn.Neurons {
@@ -0,0 +1,77 @@
+package telemetry
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPScope is the instrumentation scope of HTTP server spans
+const HTTPScope = "neuralblitz/pkg/telemetry/http"
+
+// Handler instruments an http.Handler, e.g. a ServeMux: every request runs
+// in a server span continuing the caller's traceparent, answers with its
+// own traceparent and has its latency recorded in metrics. The route is
+// the ServeMux pattern that matched. A nil provider uses the global one; a
+// nil metrics records nothing.
+func Handler(next http.Handler, metrics *Metrics, provider trace.TracerProvider) http.Handler {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	tracer := provider.Tracer(HTTPScope)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx := Propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Method, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			))
+		defer span.End()
+		Propagator.Inject(ctx, propagation.HeaderCarrier(w.Header()))
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		r = r.WithContext(ctx)
+		next.ServeHTTP(rec, r)
+
+		// ServeMux sets the pattern once it has routed the request
+		route := r.Pattern
+		if route != "" {
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+		EndHTTPSpan(span, rec.status)
+		metrics.ObserveHTTPRequest(r.Method, route, rec.status, time.Since(start))
+	})
+}
+
+// EndHTTPSpan records the response status on an HTTP server span, marking
+// 5xx responses as errors. It does not end the span.
+func EndHTTPSpan(span trace.Span, status int) {
+	span.SetAttributes(attribute.Int("http.response.status_code", status))
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+}
+
+// statusRecorder remembers the status a handler wrote
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,158 @@
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"neuralblitz/pkg/lrs"
+	"neuralblitz/pkg/reality"
+)
+
+// Namespace prefixes every metric name
+const Namespace = "neuralblitz"
+
+// Transports of ObserveRequest
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// RouteUnmatched is the route label of requests no route matched
+const RouteUnmatched = "unmatched"
+
+// Metrics is a Prometheus registry holding the request, LRS and
+// entanglement metrics. A nil *Metrics observes nothing.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requestDuration *prometheus.HistogramVec
+
+	lrsCycles            prometheus.Counter
+	lrsSpikes            prometheus.Counter
+	lrsSpikeRate         prometheus.Gauge
+	lrsMembranePotential prometheus.Gauge
+	lrsFreeEnergy        prometheus.Gauge
+	lrsPredictionError   prometheus.Gauge
+	lrsConsciousness     prometheus.Gauge
+
+	entanglements           prometheus.Gauge
+	entanglementsActive     prometheus.Gauge
+	entanglementCoherence   prometheus.Gauge
+	entanglementStrength    prometheus.Gauge
+	entanglementCollapses   prometheus.Gauge
+	entanglementTranscended prometheus.Gauge
+	entanglementFlow        prometheus.Gauge
+}
+
+// NewMetrics creates a registry with the NeuralBlitz metrics and the Go
+// runtime and process collectors
+func NewMetrics() *Metrics {
+	gauge := func(subsystem, name, help string) prometheus.Gauge {
+		return prometheus.NewGauge(prometheus.GaugeOpts{Namespace: Namespace, Subsystem: subsystem, Name: name, Help: help})
+	}
+	counter := func(subsystem, name, help string) prometheus.Counter {
+		return prometheus.NewCounter(prometheus.CounterOpts{Namespace: Namespace, Subsystem: subsystem, Name: name, Help: help})
+	}
+
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of API requests by transport, method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"transport", "method", "route", "status"}),
+
+		lrsCycles:            counter("lrs", "cycles_total", "LRS integration cycles run."),
+		lrsSpikes:            counter("lrs", "spikes_total", "Quantum neuron spikes across LRS cycles."),
+		lrsSpikeRate:         gauge("lrs", "spike_rate", "Spike rate of the last LRS cycle."),
+		lrsMembranePotential: gauge("lrs", "membrane_potential", "Membrane potential after the last LRS cycle."),
+		lrsFreeEnergy:        gauge("lrs", "free_energy", "Free energy of the last LRS cycle."),
+		lrsPredictionError:   gauge("lrs", "prediction_error", "Prediction error of the last LRS cycle."),
+		lrsConsciousness:     gauge("lrs", "consciousness", "Consciousness level of the last LRS cycle."),
+
+		entanglements:           gauge("entanglement", "pairs", "Entangled reality pairs."),
+		entanglementsActive:     gauge("entanglement", "active_pairs", "Active or forming entangled pairs."),
+		entanglementCoherence:   gauge("entanglement", "average_coherence", "Average coherence of the entangled pairs."),
+		entanglementStrength:    gauge("entanglement", "average_strength", "Average strength of the entangled pairs."),
+		entanglementCollapses:   gauge("entanglement", "collapses", "Entanglements collapsed so far."),
+		entanglementTranscended: gauge("entanglement", "transcended", "Entanglements that reached transcendence."),
+		entanglementFlow:        gauge("entanglement", "information_flow", "Information flow across the entangled pairs."),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requestDuration,
+		m.lrsCycles, m.lrsSpikes, m.lrsSpikeRate, m.lrsMembranePotential,
+		m.lrsFreeEnergy, m.lrsPredictionError, m.lrsConsciousness,
+		m.entanglements, m.entanglementsActive, m.entanglementCoherence, m.entanglementStrength,
+		m.entanglementCollapses, m.entanglementTranscended, m.entanglementFlow,
+	)
+	return m
+}
+
+// Register adds a collector, e.g. one reading a subsystem's statistics
+// when scraped
+func (m *Metrics) Register(c prometheus.Collector) error {
+	return m.registry.Register(c)
+}
+
+// Registry returns the underlying registry
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveRequest records the latency of a request. status is the HTTP
+// status or gRPC code.
+func (m *Metrics) ObserveRequest(transport, method, route, status string, elapsed time.Duration) {
+	if m == nil {
+		return
+	}
+	if route == "" {
+		route = RouteUnmatched
+	}
+	m.requestDuration.WithLabelValues(transport, method, route, status).Observe(elapsed.Seconds())
+}
+
+// ObserveHTTPRequest records the latency of an HTTP request
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	m.ObserveRequest(TransportHTTP, method, route, strconv.Itoa(status), elapsed)
+}
+
+// ObserveLRSCycle records the metrics of an LRS integration cycle
+func (m *Metrics) ObserveLRSCycle(c *lrs.CycleMetrics) {
+	if m == nil {
+		return
+	}
+	m.lrsCycles.Inc()
+	m.lrsSpikes.Add(float64(c.Spikes))
+	m.lrsSpikeRate.Set(c.SpikeRate)
+	m.lrsMembranePotential.Set(c.MembranePotential)
+	m.lrsFreeEnergy.Set(c.FreeEnergy)
+	m.lrsPredictionError.Set(c.PredictionError)
+	m.lrsConsciousness.Set(c.Consciousness)
+}
+
+// ObserveEntanglements records the entanglement manager's metrics
+func (m *Metrics) ObserveEntanglements(e *reality.EntanglementMetrics) {
+	if m == nil {
+		return
+	}
+	m.entanglements.Set(float64(e.TotalEntanglements))
+	m.entanglementsActive.Set(float64(e.ActiveEntanglements))
+	m.entanglementCoherence.Set(e.AverageCoherence)
+	m.entanglementStrength.Set(e.AverageStrength)
+	m.entanglementCollapses.Set(float64(e.CollapseCount))
+	m.entanglementTranscended.Set(float64(e.TranscendentCount))
+	m.entanglementFlow.Set(e.InformationFlow)
+}
@@ -0,0 +1,51 @@
+// Package telemetry instruments NeuralBlitz with Prometheus metrics and
+// OpenTelemetry traces. Metrics are collected into a Metrics registry that
+// servers expose at /metrics; spans go to the tracer provider of the span
+// they run under, so library code stays silent unless a server or the
+// binary set one up, e.g. with an OTLP file exporter.
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the service.name of the spans NeuralBlitz exports
+const ServiceName = "neuralblitz"
+
+// Propagator carries trace context across requests in W3C traceparent and
+// tracestate headers
+var Propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// NewTracerProvider creates a tracer provider exporting every span to
+// exporter in batches. A nil exporter records spans without exporting them,
+// which still issues trace IDs.
+func NewTracerProvider(exporter sdktrace.SpanExporter, version string) *sdktrace.TracerProvider {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", ServiceName),
+			attribute.String("service.version", version),
+		)),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	return sdktrace.NewTracerProvider(opts...)
+}
+
+// StartSpan starts a span named name under the span in ctx, with the
+// tracer provider of that span, or the global one when ctx has no local
+// span. scope names the instrumented package, e.g. "neuralblitz/pkg/core".
+func StartSpan(ctx context.Context, scope, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	provider := otel.GetTracerProvider()
+	if parent := trace.SpanFromContext(ctx); parent.SpanContext().IsValid() && !parent.SpanContext().IsRemote() {
+		provider = parent.TracerProvider()
+	}
+	return provider.Tracer(scope).Start(ctx, name, trace.WithAttributes(attrs...))
+}
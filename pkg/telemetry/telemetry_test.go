@@ -0,0 +1,158 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"neuralblitz/pkg/lrs"
+	"neuralblitz/pkg/reality"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	return rec.Body.String()
+}
+
+func TestMetricsExposition(t *testing.T) {
+	m := NewMetrics()
+	m.ObserveHTTPRequest(http.MethodGet, "/status", http.StatusOK, 20*time.Millisecond)
+	m.ObserveRequest(TransportGRPC, "POST", "/neuralblitz.v1.NeuralBlitz/Verify", "OK", time.Millisecond)
+	m.ObserveLRSCycle(&lrs.CycleMetrics{Spikes: 3, SpikeRate: 0.3, Consciousness: 0.8})
+	m.ObserveLRSCycle(&lrs.CycleMetrics{Spikes: 2})
+	m.ObserveEntanglements(&reality.EntanglementMetrics{TotalEntanglements: 4, ActiveEntanglements: 2})
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`neuralblitz_request_duration_seconds_count{method="GET",route="/status",status="200",transport="http"} 1`,
+		`neuralblitz_request_duration_seconds_count{method="POST",route="/neuralblitz.v1.NeuralBlitz/Verify",status="OK",transport="grpc"} 1`,
+		"neuralblitz_lrs_cycles_total 2",
+		"neuralblitz_lrs_spikes_total 5",
+		"neuralblitz_lrs_spike_rate 0",
+		"neuralblitz_entanglement_pairs 4",
+		"neuralblitz_entanglement_active_pairs 2",
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected exposition to contain %q", want)
+		}
+	}
+
+	// A nil registry observes nothing
+	var none *Metrics
+	none.ObserveHTTPRequest(http.MethodGet, "/", http.StatusOK, time.Second)
+	none.ObserveLRSCycle(&lrs.CycleMetrics{})
+}
+
+func TestHandlerPropagatesTraceContext(t *testing.T) {
+	m := NewMetrics()
+	tp := NewTracerProvider(nil, "test")
+	defer tp.Shutdown(context.Background())
+
+	var seen trace.SpanContext
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		seen = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := Handler(mux, m, tp)
+
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	req := httptest.NewRequest(http.MethodGet, "/tasks/42", nil)
+	req.Header.Set("traceparent", parent)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if seen.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the caller's trace ID, got %s", seen.TraceID())
+	}
+	got := rec.Header().Get("traceparent")
+	if !strings.HasPrefix(got, "00-4bf92f3577b34da6a3ce929d0e0e4736-") || strings.Contains(got, "00f067aa0ba902b7") {
+		t.Errorf("Expected a child traceparent, got %q", got)
+	}
+	if body := scrape(t, m); !strings.Contains(body, `route="GET /tasks/{id}",status="418"`) {
+		t.Errorf("Expected latency recorded under the route pattern, got:\n%s", body)
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	var buf bytes.Buffer
+	tp := NewTracerProvider(NewWriterExporter(&buf), "v-test")
+
+	ctx, parent := tp.Tracer("neuralblitz/test").Start(context.Background(), "parent")
+	_, child := StartSpan(ctx, "neuralblitz/child", "child",
+		attribute.Int("count", 7), attribute.StringSlice("tags", []string{"a"}))
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+	parent.End()
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	var request struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []otlpKeyValue `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Scope otlpScope `json:"scope"`
+				Spans []struct {
+					TraceID      string         `json:"traceId"`
+					SpanID       string         `json:"spanId"`
+					ParentSpanID string         `json:"parentSpanId"`
+					Name         string         `json:"name"`
+					Attributes   []otlpKeyValue `json:"attributes"`
+					Status       otlpStatus     `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	line, err := buf.ReadString('\n')
+	if err != nil {
+		t.Fatalf("Expected one line, got %q: %v", buf.String(), err)
+	}
+	if err := json.Unmarshal([]byte(line), &request); err != nil {
+		t.Fatalf("Failed to decode %s: %v", line, err)
+	}
+	if _, err := buf.ReadString('\n'); err != io.EOF {
+		t.Errorf("Expected a single export, got more")
+	}
+
+	if len(request.ResourceSpans) != 1 || len(request.ResourceSpans[0].ScopeSpans) != 2 {
+		t.Fatalf("Expected 1 resource with 2 scopes, got %+v", request)
+	}
+	rs := request.ResourceSpans[0]
+	if attrs := rs.Resource.Attributes; len(attrs) == 0 || *attrs[0].Value.StringValue != ServiceName {
+		t.Errorf("Expected service.name resource attribute, got %+v", attrs)
+	}
+	c, p := rs.ScopeSpans[0].Spans[0], rs.ScopeSpans[1].Spans[0]
+	if rs.ScopeSpans[0].Scope.Name != "neuralblitz/child" || c.Name != "child" {
+		t.Fatalf("Expected child span first, got %+v", rs.ScopeSpans[0])
+	}
+	if c.TraceID != p.TraceID || c.ParentSpanID != p.SpanID || p.ParentSpanID != "" {
+		t.Errorf("Expected child of parent in one trace, got %+v and %+v", c, p)
+	}
+	if c.Status.Code != 2 || c.Status.Message != "boom" {
+		t.Errorf("Expected OTLP error status, got %+v", c.Status)
+	}
+	if v := c.Attributes[0].Value.IntValue; v == nil || *v != "7" {
+		t.Errorf("Expected int attribute as string 7, got %+v", c.Attributes[0])
+	}
+	if v := c.Attributes[1].Value.ArrayValue; v == nil || *v.Values[0].StringValue != "a" {
+		t.Errorf("Expected array attribute, got %+v", c.Attributes[1])
+	}
+}
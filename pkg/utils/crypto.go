@@ -2,11 +2,12 @@ package utils
 
 import (
 	"crypto/sha256"
-	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
-	"math/rand"
 	"time"
+
+	"neuralblitz/pkg/goldendag"
+	"neuralblitz/pkg/rng"
 )
 
 // GoldenDAG represents the immutable attestation hash
@@ -29,7 +30,7 @@ func NewGoldenDAG(seed string) *GoldenDAG {
 	dag.Hash = dag.generateHash()
 	
 	// Set metadata
-	dag.Metadata["created"] = time.Now().UTC()
+	dag.Metadata["created"] = rng.Default().Now().UTC()
 	dag.Metadata["version"] = dag.Version
 	dag.Metadata["seed"] = seed
 	dag.Metadata["type"] = "GoldenDAG"
@@ -37,36 +38,20 @@ func NewGoldenDAG(seed string) *GoldenDAG {
 	return dag
 }
 
-// generateHash creates a 64-character alphanumeric hash
+// generateHash derives the 64-character hash deterministically from the
+// seed and version, so the same seed always yields the same GoldenDAG
 func (g *GoldenDAG) generateHash() string {
-	// Combine seed with timestamp for uniqueness
-	data := fmt.Sprintf("%s:%s:%d", g.Seed, g.Version, time.Now().UnixNano())
-	
-	// Create SHA-256 hash
-	h := sha256.New()
-	h.Write([]byte(data))
-	hash := h.Sum(nil)
-	
-	// Convert to hex (gives us 64 characters)
-	hexHash := hex.EncodeToString(hash)
-	
-	return hexHash
+	payload := fmt.Sprintf("%s:%s", g.Seed, g.Version)
+	return goldendag.ComputeHash("seed", []byte(payload), nil)
 }
 
-// Validate checks if the hash is valid
+// Validate checks if the hash is well formed and re-derives from the seed
 func (g *GoldenDAG) Validate() bool {
-	// Check length
-	if len(g.Hash) != 64 {
-		return false
-	}
-	
-	// Check if valid hex
-	_, err := hex.DecodeString(g.Hash)
-	if err != nil {
+	if !goldendag.ValidHash(g.Hash) {
 		return false
 	}
 	
-	return true
+	return g.Hash == g.generateHash()
 }
 
 // String returns the hash as a string
@@ -86,8 +71,8 @@ type NBHSCryptographicHash struct {
 func NewNBHSCryptographicHash(input string) *NBHSCryptographicHash {
 	nbhs := &NBHSCryptographicHash{
 		Input:     input,
-		Algorithm: "NBHS-1024",
-		BitLength: 1024,
+		Algorithm: NBHSAlgorithm,
+		BitLength: NBHSSize * 8,
 	}
 	
 	nbhs.Output = nbhs.computeHash()
@@ -95,35 +80,10 @@ func NewNBHSCryptographicHash(input string) *NBHSCryptographicHash {
 	return nbhs
 }
 
-// computeHash computes the NBHS-1024 hash
+// computeHash computes the NBHS-1024 hash of the input
 func (n *NBHSCryptographicHash) computeHash() string {
-	// Step 1: SHA3-512 of input
-	h1 := sha512.New()
-	h1.Write([]byte(n.Input))
-	part1 := h1.Sum(nil)
-	
-	// Step 2: SHA3-512 of GoldenDAG seed
-	goldenSeed := "a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0"
-	h2 := sha512.New()
-	h2.Write([]byte(goldenSeed))
-	part2 := h2.Sum(nil)
-	
-	// Step 3: SHA3-512 of timestamp
-	h3 := sha512.New()
-	h3.Write([]byte(fmt.Sprintf("%d", time.Now().UnixNano())))
-	part3 := h3.Sum(nil)
-	
-	// Step 4: Combine parts (1024 bits = 128 bytes)
-	combined := append(part1, part2...)
-	combined = append(combined, part3...)
-	
-	// Step 5: Truncate or pad to exactly 128 bytes (1024 bits)
-	if len(combined) > 128 {
-		combined = combined[:128]
-	}
-	
-	// Convert to hex (256 characters for 128 bytes)
-	return hex.EncodeToString(combined)
+	sum := SumNBHS([]byte(n.Input))
+	return hex.EncodeToString(sum[:])
 }
 
 // GetOutput returns the hash output
@@ -131,29 +91,18 @@ func (n *NBHSCryptographicHash) GetOutput() string {
 	return n.Output
 }
 
-// Validate checks if the hash is valid
+// Validate recomputes the hash from the input and compares it with the output
 func (n *NBHSCryptographicHash) Validate() bool {
-	// Check length (1024 bits = 256 hex characters)
-	if len(n.Output) != 256 {
-		return false
-	}
-	
-	// Check if valid hex
-	_, err := hex.DecodeString(n.Output)
-	if err != nil {
-		return false
-	}
-	
-	return true
+	return VerifyNBHS(n.Input, n.Output)
 }
 
 // TraceID represents a unique trace identifier
 type TraceID struct {
-	Version  string
-	Context  string
-	HexCode  string
-	FullID   string
-	Metadata map[string]interface{}
+	Version  string                 `json:"version"`
+	Context  string                 `json:"context"`
+	HexCode  string                 `json:"hex_code"`
+	FullID   string                 `json:"full_id"`
+	Metadata map[string]interface{} `json:"metadata"`
 }
 
 // NewTraceID creates a new TraceID
@@ -171,8 +120,8 @@ func NewTraceID(context string) *TraceID {
 	t.FullID = fmt.Sprintf("T-%s-%s-%s", t.Version, t.Context, t.HexCode)
 	
 	// Set metadata
-	t.Metadata["created"] = time.Now().UTC()
-	t.Metadata["format"] = "T-[version]-[context]-[32-char hexcode]"
+	t.Metadata["created"] = rng.Default().Now().UTC()
+	t.Metadata["format"] = traceIDFormat
 	
 	return t
 }
@@ -206,11 +155,11 @@ func (t *TraceID) Validate() bool {
 
 // CodexID represents a codex identifier for ontological mapping
 type CodexID struct {
-	VolumeID   string
-	Context    string
-	Token      string
-	FullID     string
-	Metadata   map[string]interface{}
+	VolumeID   string                 `json:"volume_id"`
+	Context    string                 `json:"context"`
+	Token      string                 `json:"token"`
+	FullID     string                 `json:"full_id"`
+	Metadata   map[string]interface{} `json:"metadata"`
 }
 
 // NewCodexID creates a new CodexID
@@ -222,15 +171,15 @@ func NewCodexID(volumeID, context string) *CodexID {
 	}
 	
 	// Generate 24-32 character ontological token
-	tokenLen := 24 + rand.Intn(9) // Random between 24-32
+	tokenLen := 24 + rng.Default().Intn(9) // Random between 24-32
 	c.Token = generateHexCode(tokenLen)
 	
 	// Format: C-[volumeID]-[context]-[24-32-char ontological token]
 	c.FullID = fmt.Sprintf("C-%s-%s-%s", c.VolumeID, c.Context, c.Token)
 	
 	// Set metadata
-	c.Metadata["created"] = time.Now().UTC()
-	c.Metadata["format"] = "C-[volumeID]-[context]-[24-32-char ontological token]"
+	c.Metadata["created"] = rng.Default().Now().UTC()
+	c.Metadata["format"] = codexIDFormat
 	c.Metadata["token_length"] = tokenLen
 	
 	return c
@@ -248,13 +197,8 @@ func (c *CodexID) Validate() bool {
 		return false
 	}
 	
-	// Check if valid hex
-	_, err := hex.DecodeString(c.Token)
-	if err != nil {
-		return false
-	}
-	
-	return true
+	// Check if valid hex (odd token lengths are allowed)
+	return isHex(c.Token)
 }
 
 // GenerateOmegaAttestationHash generates the Omega Attestation hash
@@ -265,7 +209,7 @@ func GenerateOmegaAttestationHash() string {
 		"Irreducible Source Field",
 		"Architect-System Dyad",
 		"Perpetual Coherent Becoming",
-		fmt.Sprintf("%d", time.Now().UnixNano()),
+		fmt.Sprintf("%d", rng.Default().Now().UnixNano()),
 	}
 	
 	// Create combined hash
@@ -284,22 +228,17 @@ func GenerateOmegaAttestationHash() string {
 
 // VerifyGoldenDAGSeed verifies if a hash matches the GoldenDAG seed
 func VerifyGoldenDAGSeed(hash string) bool {
-	expectedSeed := "a8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0f2a4c6b8d0"
-	return hash == expectedSeed
+	return hash == goldendag.Seed
 }
 
 // generateHexCode generates a random hex string of specified length
 func generateHexCode(length int) string {
-	bytes := make([]byte, length/2)
+	bytes := make([]byte, (length+1)/2)
 	
-	// Use crypto/rand for better randomness in production
-	// For now, use math/rand for simplicity
-	rand.Seed(time.Now().UnixNano())
-	for i := range bytes {
-		bytes[i] = byte(rand.Intn(256))
-	}
+	// Draw from the shared source so seeded runs produce stable IDs
+	rng.Default().Read(bytes)
 	
-	return hex.EncodeToString(bytes)
+	return hex.EncodeToString(bytes)[:length]
 }
 
 // HashData creates a SHA-256 hash of the given data
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"testing"
+
+	"neuralblitz/pkg/goldendag"
+)
+
+// TestGoldenDAGCreation tests creating a GoldenDAG
+func TestGoldenDAGCreation(t *testing.T) {
+	dag := NewGoldenDAG("test-seed")
+
+	if dag == nil {
+		t.Fatal("Expected non-nil GoldenDAG")
+	}
+
+	if dag.Seed != "test-seed" {
+		t.Errorf("Expected seed 'test-seed', got %v", dag.Seed)
+	}
+
+	if dag.Version != "v50.0.0" {
+		t.Errorf("Expected version 'v50.0.0', got %v", dag.Version)
+	}
+
+	if len(dag.Hash) != 64 {
+		t.Errorf("Expected hash length 64, got %d", len(dag.Hash))
+	}
+
+	if dag.Metadata == nil {
+		t.Error("Expected non-nil Metadata")
+	}
+}
+
+// TestGoldenDAGValidate tests hash validation
+func TestGoldenDAGValidate(t *testing.T) {
+	dag := NewGoldenDAG("test")
+
+	if !dag.Validate() {
+		t.Error("Expected valid hash")
+	}
+
+	// Test invalid hash (too short)
+	dag.Hash = "abc"
+	if dag.Validate() {
+		t.Error("Expected invalid hash for short hash")
+	}
+}
+
+// TestGoldenDAGString tests string representation
+func TestGoldenDAGString(t *testing.T) {
+	dag := NewGoldenDAG("test")
+	s := dag.String()
+
+	if s != dag.Hash {
+		t.Errorf("Expected String() to return hash, got %v", s)
+	}
+}
+
+// TestGoldenDAGSeed tests the GoldenDAG seed format
+func TestGoldenDAGSeed(t *testing.T) {
+	if len(goldendag.Seed) != 64 {
+		t.Errorf("Expected seed length 64, got %d", len(goldendag.Seed))
+	}
+
+	if !VerifyGoldenDAGSeed(goldendag.Seed) {
+		t.Error("Expected seed to verify")
+	}
+}
+
+// BenchmarkGoldenDAGCreation benchmarks GoldenDAG creation
+func BenchmarkGoldenDAGCreation(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewGoldenDAG("benchmark")
+	}
+}
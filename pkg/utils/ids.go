@@ -0,0 +1,330 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"neuralblitz/pkg/rng"
+)
+
+// Error definitions
+var (
+	ErrInvalidTraceID = errors.New("invalid trace ID")
+	ErrInvalidCodexID = errors.New("invalid codex ID")
+	ErrIDNotFound     = errors.New("ID not registered")
+	ErrDuplicateID    = errors.New("ID already registered")
+	ErrUnknownIDKind  = errors.New("not a trace or codex ID")
+)
+
+const (
+	traceIDFormat = "T-[version]-[context]-[32-char hexcode]"
+	codexIDFormat = "C-[volumeID]-[context]-[24-32-char ontological token]"
+
+	traceHexLength = 32
+	codexTokenMin  = 24
+	codexTokenMax  = 32
+)
+
+// ParseTraceID parses a T-[version]-[context]-[hexcode] string back into its
+// parts. The context may itself contain dashes.
+func ParseTraceID(s string) (*TraceID, error) {
+	rest, ok := strings.CutPrefix(s, "T-")
+	if !ok {
+		return nil, fmt.Errorf("%w: %q: missing T- prefix", ErrInvalidTraceID, s)
+	}
+
+	version, rest, ok := strings.Cut(rest, "-")
+	if !ok || len(version) < 2 || version[0] != 'v' {
+		return nil, fmt.Errorf("%w: %q: malformed version", ErrInvalidTraceID, s)
+	}
+
+	split := strings.LastIndexByte(rest, '-')
+	if split <= 0 {
+		return nil, fmt.Errorf("%w: %q: missing context", ErrInvalidTraceID, s)
+	}
+	context, hexCode := rest[:split], rest[split+1:]
+	if len(hexCode) != traceHexLength || !isHex(hexCode) {
+		return nil, fmt.Errorf("%w: %q: hex code must be %d hex characters", ErrInvalidTraceID, s, traceHexLength)
+	}
+
+	return &TraceID{
+		Version:  version,
+		Context:  context,
+		HexCode:  hexCode,
+		FullID:   s,
+		Metadata: map[string]interface{}{"format": traceIDFormat},
+	}, nil
+}
+
+// NewTraceIDWithHex creates a trace ID whose hex code is hexCode, e.g. the
+// ID of the OpenTelemetry trace it names
+func NewTraceIDWithHex(context, hexCode string) (*TraceID, error) {
+	if len(hexCode) != traceHexLength || !isHex(hexCode) {
+		return nil, fmt.Errorf("%w: hex code %q must be %d hex characters", ErrInvalidTraceID, hexCode, traceHexLength)
+	}
+	t := NewTraceID(context)
+	t.HexCode = hexCode
+	t.FullID = fmt.Sprintf("T-%s-%s-%s", t.Version, t.Context, t.HexCode)
+	return t, nil
+}
+
+// ParseCodexID parses a C-[volumeID]-[context]-[token] string back into its
+// parts. The context may itself contain dashes.
+func ParseCodexID(s string) (*CodexID, error) {
+	rest, ok := strings.CutPrefix(s, "C-")
+	if !ok {
+		return nil, fmt.Errorf("%w: %q: missing C- prefix", ErrInvalidCodexID, s)
+	}
+
+	volumeID, rest, ok := strings.Cut(rest, "-")
+	if !ok || volumeID == "" {
+		return nil, fmt.Errorf("%w: %q: missing volume", ErrInvalidCodexID, s)
+	}
+
+	split := strings.LastIndexByte(rest, '-')
+	if split <= 0 {
+		return nil, fmt.Errorf("%w: %q: missing context", ErrInvalidCodexID, s)
+	}
+	context, token := rest[:split], rest[split+1:]
+	if len(token) < codexTokenMin || len(token) > codexTokenMax || !isHex(token) {
+		return nil, fmt.Errorf("%w: %q: token must be %d-%d hex characters", ErrInvalidCodexID, s, codexTokenMin, codexTokenMax)
+	}
+
+	return &CodexID{
+		VolumeID: volumeID,
+		Context:  context,
+		Token:    token,
+		FullID:   s,
+		Metadata: map[string]interface{}{
+			"format":       codexIDFormat,
+			"token_length": len(token),
+		},
+	}, nil
+}
+
+// isHex reports whether s consists only of lowercase or uppercase hex digits
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// Origin identifies the part of the system that issued an ID
+type Origin string
+
+const (
+	OriginAPI  Origin = "api"
+	OriginGRPC Origin = "grpc"
+	OriginNBCL Origin = "nbcl"
+	OriginCLI  Origin = "cli"
+)
+
+// IDKind distinguishes trace IDs from codex IDs in the registry
+type IDKind string
+
+const (
+	IDKindTrace IDKind = "trace"
+	IDKindCodex IDKind = "codex"
+)
+
+// IDIssuer describes where an ID was issued: the origin, the specific
+// handler or command within it, and optionally the ID it was issued under
+type IDIssuer struct {
+	Origin Origin
+	Source string
+	Parent string
+}
+
+// issuerKey is the context key of the issuer of a call's IDs
+type issuerKey struct{}
+
+// WithIDIssuer returns ctx carrying the issuer of the IDs issued while
+// serving a call, so IDs issued deeper in the call, e.g. by NBCL commands,
+// are recorded under the call's trace ID
+func WithIDIssuer(ctx context.Context, issuer IDIssuer) context.Context {
+	return context.WithValue(ctx, issuerKey{}, issuer)
+}
+
+// IDIssuerFrom returns the issuer ctx carries, or the zero issuer
+func IDIssuerFrom(ctx context.Context) IDIssuer {
+	issuer, _ := ctx.Value(issuerKey{}).(IDIssuer)
+	return issuer
+}
+
+// IDRecord is the registry entry for an issued ID
+type IDRecord struct {
+	ID        string    `json:"id"`
+	Kind      IDKind    `json:"kind"`
+	Origin    Origin    `json:"origin"`
+	Source    string    `json:"source"`
+	Parent    string    `json:"parent,omitempty"`
+	GoldenDAG string    `json:"golden_dag,omitempty"`
+	IssuedAt  time.Time `json:"issued_at"`
+	Trace     *TraceID  `json:"trace,omitempty"`
+	Codex     *CodexID  `json:"codex,omitempty"`
+}
+
+// DefaultIDRegistryCapacity bounds the number of records the default
+// registry keeps before evicting the oldest
+const DefaultIDRegistryCapacity = 10000
+
+// IDRegistry records every issued trace and codex ID. It is safe for
+// concurrent use. Once capacity is reached the oldest records are evicted.
+type IDRegistry struct {
+	mu      sync.RWMutex
+	records map[string]*IDRecord
+	// order lists the IDs in registration order; those before oldest
+	// were evicted and are dropped from the slice in batches
+	order    []string
+	oldest   int
+	capacity int
+}
+
+// NewIDRegistry creates a registry holding at most capacity records. A
+// capacity of zero or less means unbounded.
+func NewIDRegistry(capacity int) *IDRegistry {
+	return &IDRegistry{
+		records:  make(map[string]*IDRecord),
+		order:    make([]string, 0),
+		capacity: capacity,
+	}
+}
+
+var defaultIDRegistry = NewIDRegistry(DefaultIDRegistryCapacity)
+
+// DefaultIDRegistry returns the process-wide registry
+func DefaultIDRegistry() *IDRegistry {
+	return defaultIDRegistry
+}
+
+// Register records an issued ID. The record's ID must parse as a trace or
+// codex ID; Kind and the parsed struct are filled in from it.
+func (r *IDRegistry) Register(rec IDRecord) error {
+	if trace, err := ParseTraceID(rec.ID); err == nil {
+		rec.Kind = IDKindTrace
+		if rec.Trace == nil {
+			rec.Trace = trace
+		}
+	} else if codex, err := ParseCodexID(rec.ID); err == nil {
+		rec.Kind = IDKindCodex
+		if rec.Codex == nil {
+			rec.Codex = codex
+		}
+	} else {
+		return fmt.Errorf("%w: %q", ErrUnknownIDKind, rec.ID)
+	}
+
+	if rec.IssuedAt.IsZero() {
+		rec.IssuedAt = rng.Default().Now().UTC()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.records[rec.ID]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateID, rec.ID)
+	}
+
+	r.records[rec.ID] = &rec
+	r.order = append(r.order, rec.ID)
+	r.evict()
+	return nil
+}
+
+// evict drops the oldest records beyond capacity. The evicted IDs are
+// removed from order only once they fill capacity slots, so each insert
+// costs constant amortized time. Callers must hold r.mu.
+func (r *IDRegistry) evict() {
+	if r.capacity <= 0 {
+		return
+	}
+	for len(r.order)-r.oldest > r.capacity {
+		delete(r.records, r.order[r.oldest])
+		r.order[r.oldest] = ""
+		r.oldest++
+	}
+	if r.oldest >= r.capacity {
+		r.order = append(r.order[:0], r.order[r.oldest:]...)
+		r.oldest = 0
+	}
+}
+
+// IssueTrace creates a new trace ID and records it under issuer. IDs carry
+// 128 random bits, so a duplicate is not expected and would keep the first
+// record.
+func (r *IDRegistry) IssueTrace(context string, issuer IDIssuer) *TraceID {
+	t := NewTraceID(context)
+	r.Register(IDRecord{
+		ID:       t.FullID,
+		Origin:   issuer.Origin,
+		Source:   issuer.Source,
+		Parent:   issuer.Parent,
+		IssuedAt: createdAt(t.Metadata),
+		Trace:    t,
+	})
+	return t
+}
+
+// IssueCodex creates a new codex ID and records it under issuer
+func (r *IDRegistry) IssueCodex(volumeID, context string, issuer IDIssuer) *CodexID {
+	c := NewCodexID(volumeID, context)
+	r.Register(IDRecord{
+		ID:       c.FullID,
+		Origin:   issuer.Origin,
+		Source:   issuer.Source,
+		Parent:   issuer.Parent,
+		IssuedAt: createdAt(c.Metadata),
+		Codex:    c,
+	})
+	return c
+}
+
+// createdAt returns the creation time recorded in ID metadata
+func createdAt(metadata map[string]interface{}) time.Time {
+	created, _ := metadata["created"].(time.Time)
+	return created
+}
+
+// Lookup returns a copy of the record for id
+func (r *IDRegistry) Lookup(id string) (IDRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rec, ok := r.records[id]
+	if !ok {
+		return IDRecord{}, fmt.Errorf("%w: %s", ErrIDNotFound, id)
+	}
+	return *rec, nil
+}
+
+// Children returns the records issued under parent, oldest first
+func (r *IDRegistry) Children(parent string) []IDRecord {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	children := make([]IDRecord, 0)
+	if parent == "" {
+		return children
+	}
+	for _, id := range r.order[r.oldest:] {
+		if rec := r.records[id]; rec.Parent == parent {
+			children = append(children, *rec)
+		}
+	}
+	return children
+}
+
+// Len returns the number of records held
+func (r *IDRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.records)
+}
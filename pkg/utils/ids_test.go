@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestParseTraceIDRoundTrip(t *testing.T) {
+	original := NewTraceID("API-REQUEST")
+
+	parsed, err := ParseTraceID(original.String())
+	if err != nil {
+		t.Fatalf("Failed to parse trace ID: %v", err)
+	}
+
+	if parsed.Version != original.Version {
+		t.Errorf("Expected version %s, got %s", original.Version, parsed.Version)
+	}
+	if parsed.Context != original.Context {
+		t.Errorf("Expected context %s, got %s", original.Context, parsed.Context)
+	}
+	if parsed.HexCode != original.HexCode {
+		t.Errorf("Expected hex code %s, got %s", original.HexCode, parsed.HexCode)
+	}
+	if !parsed.Validate() {
+		t.Error("Expected parsed trace ID to validate")
+	}
+}
+
+func TestNewTraceIDWithHex(t *testing.T) {
+	id, err := NewTraceIDWithHex("API_REQUEST", "4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatalf("Failed to create trace ID: %v", err)
+	}
+	if id.String() != "T-v50.0-API_REQUEST-4bf92f3577b34da6a3ce929d0e0e4736" || !id.Validate() {
+		t.Errorf("Expected a valid trace ID with the hex code, got %s", id)
+	}
+
+	if _, err := NewTraceIDWithHex("API_REQUEST", "4bf92f35"); !errors.Is(err, ErrInvalidTraceID) {
+		t.Errorf("Expected ErrInvalidTraceID for a short hex code, got %v", err)
+	}
+}
+
+func TestParseCodexIDRoundTrip(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		original := NewCodexID("VOL3", "STATUS")
+
+		parsed, err := ParseCodexID(original.String())
+		if err != nil {
+			t.Fatalf("Failed to parse codex ID %s: %v", original, err)
+		}
+
+		if parsed.VolumeID != "VOL3" {
+			t.Errorf("Expected volume VOL3, got %s", parsed.VolumeID)
+		}
+		if parsed.Context != "STATUS" {
+			t.Errorf("Expected context STATUS, got %s", parsed.Context)
+		}
+		if parsed.Token != original.Token {
+			t.Errorf("Expected token %s, got %s", original.Token, parsed.Token)
+		}
+		if !original.Validate() {
+			t.Errorf("Expected %s to validate", original)
+		}
+	}
+}
+
+func TestParseInvalidIDs(t *testing.T) {
+	traces := []string{
+		"",
+		"X-v50.0-CTX-0123456789abcdef0123456789abcdef",
+		"T-50.0-CTX-0123456789abcdef0123456789abcdef",
+		"T-v50.0-0123456789abcdef0123456789abcdef",
+		"T-v50.0-CTX-0123456789abcdef",
+		"T-v50.0-CTX-0123456789abcdef0123456789abcdeg",
+	}
+	for _, s := range traces {
+		if _, err := ParseTraceID(s); !errors.Is(err, ErrInvalidTraceID) {
+			t.Errorf("Expected ErrInvalidTraceID for %q, got %v", s, err)
+		}
+	}
+
+	codexes := []string{
+		"",
+		"C--CTX-0123456789abcdef01234567",
+		"C-VOL0-0123456789abcdef01234567",
+		"C-VOL0-CTX-0123456789abcdef",
+		"C-VOL0-CTX-0123456789abcdef0123456789abcdef0",
+		"C-VOL0-CTX-0123456789abcdef0123456z",
+	}
+	for _, s := range codexes {
+		if _, err := ParseCodexID(s); !errors.Is(err, ErrInvalidCodexID) {
+			t.Errorf("Expected ErrInvalidCodexID for %q, got %v", s, err)
+		}
+	}
+}
+
+func TestIDRegistryIssueAndLookup(t *testing.T) {
+	r := NewIDRegistry(0)
+
+	trace := r.IssueTrace("STATUS", IDIssuer{Origin: OriginAPI, Source: "GET /status"})
+	codex := r.IssueCodex("VOL0", "STATUS", IDIssuer{Origin: OriginAPI, Source: "GET /status", Parent: trace.String()})
+
+	rec, err := r.Lookup(trace.String())
+	if err != nil {
+		t.Fatalf("Failed to look up trace ID: %v", err)
+	}
+	if rec.Kind != IDKindTrace {
+		t.Errorf("Expected kind %s, got %s", IDKindTrace, rec.Kind)
+	}
+	if rec.Origin != OriginAPI || rec.Source != "GET /status" {
+		t.Errorf("Expected api GET /status, got %s %s", rec.Origin, rec.Source)
+	}
+	if rec.Trace == nil || rec.Trace.Context != "STATUS" {
+		t.Error("Expected parsed trace in record")
+	}
+	if rec.IssuedAt.IsZero() {
+		t.Error("Expected non-zero issue time")
+	}
+
+	children := r.Children(trace.String())
+	if len(children) != 1 || children[0].ID != codex.String() {
+		t.Errorf("Expected codex ID as only child, got %v", children)
+	}
+	if children[0].Kind != IDKindCodex {
+		t.Errorf("Expected kind %s, got %s", IDKindCodex, children[0].Kind)
+	}
+
+	if _, err := r.Lookup("T-v50.0-NONE-0123456789abcdef0123456789abcdef"); !errors.Is(err, ErrIDNotFound) {
+		t.Errorf("Expected ErrIDNotFound, got %v", err)
+	}
+}
+
+func TestIDRegistryRejectsInvalidAndDuplicate(t *testing.T) {
+	r := NewIDRegistry(0)
+
+	if err := r.Register(IDRecord{ID: "not-an-id"}); !errors.Is(err, ErrUnknownIDKind) {
+		t.Errorf("Expected ErrUnknownIDKind, got %v", err)
+	}
+
+	id := NewTraceID("CLI").String()
+	if err := r.Register(IDRecord{ID: id, Origin: OriginCLI}); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	if err := r.Register(IDRecord{ID: id, Origin: OriginNBCL}); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Expected ErrDuplicateID, got %v", err)
+	}
+
+	rec, _ := r.Lookup(id)
+	if rec.Origin != OriginCLI {
+		t.Errorf("Expected first record to be kept, got origin %s", rec.Origin)
+	}
+}
+
+func TestIDRegistryEviction(t *testing.T) {
+	r := NewIDRegistry(3)
+
+	ids := make([]string, 5)
+	for i := range ids {
+		ids[i] = r.IssueTrace(fmt.Sprintf("CTX%d", i), IDIssuer{Origin: OriginCLI}).String()
+	}
+
+	if r.Len() != 3 {
+		t.Errorf("Expected 3 records, got %d", r.Len())
+	}
+	if _, err := r.Lookup(ids[0]); err == nil {
+		t.Error("Expected oldest record to be evicted")
+	}
+	if _, err := r.Lookup(ids[4]); err != nil {
+		t.Errorf("Expected newest record to be kept, got %v", err)
+	}
+}
+
+func TestIDRegistryEvictionAcrossCompactions(t *testing.T) {
+	r := NewIDRegistry(4)
+	parent := r.IssueTrace("ROOT", IDIssuer{Origin: OriginAPI}).String()
+
+	ids := make([]string, 20)
+	for i := range ids {
+		ids[i] = r.IssueTrace(fmt.Sprintf("CTX%d", i), IDIssuer{Origin: OriginNBCL, Parent: parent}).String()
+	}
+
+	if r.Len() != 4 {
+		t.Errorf("Expected 4 records, got %d", r.Len())
+	}
+	for i, id := range ids {
+		_, err := r.Lookup(id)
+		if kept := i >= len(ids)-4; kept != (err == nil) {
+			t.Errorf("Expected record %d kept=%v, got err %v", i, kept, err)
+		}
+	}
+	if children := r.Children(parent); len(children) != 4 {
+		t.Errorf("Expected 4 children, got %d", len(children))
+	}
+}
+
+func TestIDRegistryConcurrency(t *testing.T) {
+	r := NewIDRegistry(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				id := r.IssueTrace("CONCURRENT", IDIssuer{Origin: OriginAPI}).String()
+				if _, err := r.Lookup(id); err != nil {
+					t.Errorf("Failed to look up %s: %v", id, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if r.Len() != 500 {
+		t.Errorf("Expected 500 records, got %d", r.Len())
+	}
+}
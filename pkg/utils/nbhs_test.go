@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+type nbhsVector struct {
+	Name   string `json:"name"`
+	Key    string `json:"key,omitempty"`
+	Input  string `json:"input"`
+	Digest string `json:"digest"`
+}
+
+func loadNBHSVectors(t *testing.T) []nbhsVector {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/nbhs_vectors.json")
+	if err != nil {
+		t.Fatalf("Failed to read test vectors: %v", err)
+	}
+
+	var vectors []nbhsVector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatalf("Failed to parse test vectors: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("Expected at least one test vector")
+	}
+	return vectors
+}
+
+func TestNBHSVectors(t *testing.T) {
+	for _, v := range loadNBHSVectors(t) {
+		t.Run(v.Name, func(t *testing.T) {
+			key, err := hex.DecodeString(v.Key)
+			if err != nil {
+				t.Fatalf("Invalid key: %v", err)
+			}
+
+			var sum [NBHSSize]byte
+			if v.Key == "" {
+				sum = SumNBHS([]byte(v.Input))
+			} else {
+				sum = SumKeyedNBHS(key, []byte(v.Input))
+			}
+			if got := hex.EncodeToString(sum[:]); got != v.Digest {
+				t.Errorf("Expected digest %s, got %s", v.Digest, got)
+			}
+
+			if v.Key == "" {
+				if !VerifyNBHS(v.Input, v.Digest) {
+					t.Error("Expected VerifyNBHS to accept the vector")
+				}
+			} else if !VerifyKeyedNBHS(key, v.Input, v.Digest) {
+				t.Error("Expected VerifyKeyedNBHS to accept the vector")
+			}
+		})
+	}
+}
+
+func TestNBHSStreaming(t *testing.T) {
+	input := []byte("streamed input spanning several writes of uneven size")
+	want := SumNBHS(input)
+
+	h := NewNBHS()
+	for i := 0; i < len(input); i += 7 {
+		end := i + 7
+		if end > len(input) {
+			end = len(input)
+		}
+		h.Write(input[i:end])
+	}
+
+	if got := h.Sum(nil); hex.EncodeToString(got) != hex.EncodeToString(want[:]) {
+		t.Error("Expected streamed digest to match one-shot digest")
+	}
+	if h.Size() != NBHSSize {
+		t.Errorf("Expected size %d, got %d", NBHSSize, h.Size())
+	}
+	if h.BlockSize() <= 0 {
+		t.Errorf("Expected positive block size, got %d", h.BlockSize())
+	}
+}
+
+func TestNBHSSumDoesNotChangeState(t *testing.T) {
+	h := NewNBHS()
+	h.Write([]byte("ab"))
+	first := h.Sum(nil)
+	second := h.Sum([]byte("prefix"))
+
+	if hex.EncodeToString(first) != hex.EncodeToString(second[len("prefix"):]) {
+		t.Error("Expected repeated Sum calls to return the same digest")
+	}
+
+	h.Write([]byte("c"))
+	want := SumNBHS([]byte("abc"))
+	if hex.EncodeToString(h.Sum(nil)) != hex.EncodeToString(want[:]) {
+		t.Error("Expected writes after Sum to continue the stream")
+	}
+
+	h.Reset()
+	empty := SumNBHS(nil)
+	if hex.EncodeToString(h.Sum(nil)) != hex.EncodeToString(empty[:]) {
+		t.Error("Expected Reset to restore the initial state")
+	}
+}
+
+func TestNBHSKeyedDomainSeparation(t *testing.T) {
+	input := []byte("abc")
+	plain := SumNBHS(input)
+	keyed := SumKeyedNBHS(nil, input)
+	other := SumKeyedNBHS([]byte("other"), input)
+
+	if plain == keyed {
+		t.Error("Expected keyed digest with empty key to differ from unkeyed digest")
+	}
+	if keyed == other {
+		t.Error("Expected different keys to produce different digests")
+	}
+}
+
+func TestVerifyNBHSRejectsMismatch(t *testing.T) {
+	sum := SumNBHS([]byte("abc"))
+	digest := hex.EncodeToString(sum[:])
+
+	if VerifyNBHS("abd", digest) {
+		t.Error("Expected VerifyNBHS to reject a different input")
+	}
+	if VerifyNBHS("abc", digest[:NBHSHexLength-2]) {
+		t.Error("Expected VerifyNBHS to reject a truncated digest")
+	}
+	if VerifyNBHS("abc", "zz"+digest[2:]) {
+		t.Error("Expected VerifyNBHS to reject a non-hex digest")
+	}
+	if VerifyKeyedNBHS([]byte("key"), "abc", digest) {
+		t.Error("Expected VerifyKeyedNBHS to reject an unkeyed digest")
+	}
+}
+
+func TestNBHSCryptographicHashIsDeterministic(t *testing.T) {
+	a := NewNBHSCryptographicHash("example-input-data")
+	b := NewNBHSCryptographicHash("example-input-data")
+
+	if a.Output != b.Output {
+		t.Error("Expected identical outputs for identical inputs")
+	}
+	if !a.Validate() {
+		t.Error("Expected hash to validate against its input")
+	}
+
+	a.Input = "tampered"
+	if a.Validate() {
+		t.Error("Expected validation to fail after the input changes")
+	}
+}